@@ -0,0 +1,11 @@
+#!/bin/bash
+# SessionEnd hook for the felt plugin.
+#
+# Thin shim: the binary owns the logic. `felt hook session-end` reads the
+# SessionEnd payload from stdin and, when hook.digest: true is set in
+# .felt/config.yaml, appends what the session opened, closed, and touched to
+# the day's log fiber (log/<date>). Without the setting it does nothing. See
+# `felt hook session-end --help`.
+
+set -e
+exec felt hook session-end
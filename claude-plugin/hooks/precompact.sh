@@ -0,0 +1,10 @@
+#!/bin/bash
+# PreCompact hook for the felt plugin.
+#
+# Thin shim: the binary owns the logic. `felt hook precompact` reads the
+# PreCompact payload from stdin and emits the active fibers, with the latest
+# note on each, as additionalContext so the compaction summary keeps them.
+# See `felt hook precompact --help`.
+
+set -e
+exec felt hook precompact
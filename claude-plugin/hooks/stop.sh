@@ -0,0 +1,10 @@
+#!/bin/bash
+# Stop hook for the felt plugin.
+#
+# Thin shim: the binary owns the logic. `felt hook stop` reads the Stop
+# payload from stdin and reminds the agent of active fibers — or, with
+# hook.stop: block, exits 2 so it records their outcomes first.
+# See `felt hook stop --help`.
+
+set -e
+exec felt hook stop
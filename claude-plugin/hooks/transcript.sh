@@ -0,0 +1,11 @@
+#!/bin/bash
+# SessionEnd hook for the felt plugin: transcript mining.
+#
+# Thin shim: the binary owns the logic. `felt hook transcript` reads the
+# SessionEnd payload from stdin and, when hook.transcript.mine: true is set
+# in .felt/config.yaml, files the session's decisions and open questions as
+# fibers. Without the setting it does nothing. See
+# `felt hook transcript --help`.
+
+set -e
+exec felt hook transcript
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	acceptCriteriaAdd  []string
+	acceptCriteriaUndo bool
+)
+
+var acceptCriteriaCmd = &cobra.Command{
+	Use:   "accept <id> [n...]",
+	Short: "Tick a fiber's acceptance criteria",
+	Long: `Ticks acceptance criteria, the checklist in a fiber's acceptance:
+frontmatter that says when it is done. Criteria are numbered from 1 in the
+order felt show lists them; --undo unticks them instead. --add appends new,
+unticked criteria first, so a checklist can be written and worked without
+editing frontmatter by hand. Written by hand, each criterion is a quoted
+string — - "[ ] text" — since an unquoted [ ] is not valid YAML.
+
+With no numbers and nothing to add, accept lists the criteria. Closing a
+fiber with criteria still unmet is allowed, but felt edit warns about it.`,
+	Example: `  felt accept mocks --add "Covariance matches DES Y3" --add "Figure 4 regenerated"
+  felt accept mocks 1
+  felt accept mocks 2 --undo`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		var indexes []int
+		for _, arg := range args[1:] {
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("invalid criterion number %q", arg)
+			}
+			indexes = append(indexes, n)
+		}
+
+		storage := felt.NewStorage(root)
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		f, err := storage.Read(target.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(indexes) == 0 && len(acceptCriteriaAdd) == 0 {
+			fmt.Print(formatAcceptance(f.Acceptance()))
+			return nil
+		}
+
+		criteria := f.Acceptance()
+		if criteria == nil && f.ExtraFields[felt.AcceptanceKey] != nil {
+			return fmt.Errorf("%s's acceptance: is not a list of criteria; fix it by hand first", f.ID)
+		}
+		for _, text := range acceptCriteriaAdd {
+			if text = strings.TrimSpace(text); text != "" {
+				criteria = append(criteria, felt.Criterion{Text: text})
+			}
+		}
+		if err := f.SetAcceptance(criteria); err != nil {
+			return err
+		}
+		for _, n := range indexes {
+			if err := f.MarkAcceptance(n, !acceptCriteriaUndo); err != nil {
+				return err
+			}
+		}
+		f.Touch(time.Now())
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+
+		fmt.Printf("Updated %s\n", f.ID)
+		fmt.Print(formatAcceptance(f.Acceptance()))
+		return nil
+	},
+}
+
+// formatAcceptance renders criteria as a numbered checklist under a
+// met/total count.
+func formatAcceptance(criteria []felt.Criterion) string {
+	if len(criteria) == 0 {
+		return "No acceptance criteria\n"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Acceptance: %d/%d met\n", felt.AcceptanceMet(criteria), len(criteria))
+	for i, c := range criteria {
+		fmt.Fprintf(&sb, "  %d. %s\n", i+1, c)
+	}
+	return sb.String()
+}
+
+func init() {
+	rootCmd.AddCommand(acceptCriteriaCmd)
+	acceptCriteriaCmd.Flags().StringArrayVar(&acceptCriteriaAdd, "add", nil, "Append an unticked criterion (repeatable)")
+	acceptCriteriaCmd.Flags().BoolVar(&acceptCriteriaUndo, "undo", false, "Untick the numbered criteria instead")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/pflag"
+)
+
+// resetAcceptFlags clears felt accept's flags, which persist across runs of
+// the shared root command.
+func resetAcceptFlags() {
+	acceptCriteriaAdd, acceptCriteriaUndo = nil, false
+	acceptCriteriaCmd.Flags().VisitAll(func(f *pflag.Flag) { f.Changed = false })
+}
+
+func TestAcceptAddsTicksAndShowRendersCriteria(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "mocks", Name: "Mocks", Status: felt.StatusActive, CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}); err != nil {
+		t.Fatal(err)
+	}
+	defer resetAcceptFlags()
+
+	resetAcceptFlags()
+	if out, err := runCommand(t, dir, "accept", "mocks", "--add", "Covariance matches", "--add", "Figure regenerated"); err != nil {
+		t.Fatalf("accept --add: %v\n%s", err, out)
+	}
+	resetAcceptFlags()
+	out, err := runCommand(t, dir, "accept", "mocks", "2")
+	if err != nil {
+		t.Fatalf("accept 2: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Acceptance: 1/2 met\n  1. [ ] Covariance matches\n  2. [x] Figure regenerated\n") {
+		t.Fatalf("accept output:\n%s", out)
+	}
+
+	resetAcceptFlags()
+	if _, err := runCommand(t, dir, "accept", "mocks", "3"); err == nil || !strings.Contains(err.Error(), "there is no 3") {
+		t.Fatalf("accept 3: err = %v", err)
+	}
+
+	out, err = runCommand(t, dir, "show", "mocks")
+	if err != nil {
+		t.Fatalf("show: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Acceptance: 1/2 met\n  1. [ ] Covariance matches\n") {
+		t.Fatalf("show missing the checklist:\n%s", out)
+	}
+
+	resetAcceptFlags()
+	if out, err := runCommand(t, dir, "accept", "mocks", "2", "--undo"); err != nil {
+		t.Fatalf("accept --undo: %v\n%s", err, out)
+	}
+	f, err := storage.Read("mocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if met := felt.AcceptanceMet(f.Acceptance()); met != 0 || len(f.Acceptance()) != 2 {
+		t.Fatalf("after --undo: %+v", f.Acceptance())
+	}
+}
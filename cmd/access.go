@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+// accessSessionEnv names the session recorded with each read; agent hooks
+// and wrappers export it so the log can say who revisited a fiber.
+const accessSessionEnv = "FELT_SESSION_ID"
+
+// recordAccess appends ids to the access log when `access.log` is enabled in
+// config.yaml. It is best-effort: a read never fails because it could not
+// be logged, so problems surface as warnings on stderr.
+func recordAccess(storage *felt.Storage, ids ...string) {
+	cfg, err := storage.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return
+	}
+	if !cfg.Access.Log {
+		return
+	}
+	if err := storage.RecordAccess(ids, os.Getenv(accessSessionEnv), time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
@@ -11,11 +11,15 @@ import (
 
 var (
 	addBody     string
+	addBodyFile string
 	addStatus   string
 	addDue      string
 	addTags     []string
 	addOutcome  string
 	addTopLevel bool
+	addUID      string
+	addOwner    string
+	addImpact   string
 )
 
 var addCmd = &cobra.Command{
@@ -33,9 +37,26 @@ skip resolution and create at the root even when nested matches exist;
 ambiguous matches (the leading segment appears in multiple subtrees) abort
 with the candidates listed.
 
-Examples:
-  felt add mocks-unbiased "Are the mocks unbiased?"
-  felt add pure_eb/covariance "Covariance method"`,
+The frontmatter id is minted as a ULID unless --id assigns one, for
+migration tools and cross-repo references that must keep an existing
+identifier. An assigned id must be unique in the store; only ULID-shaped
+ids resolve as command arguments (felt show <id>).
+
+--owner records who is responsible for the fiber. Without it, a fiber
+given a status gets the default owner: $FELT_OWNER, else owner.default in
+.felt/config.yaml.
+
+--impact records how much a bug-like fiber hurts: low, medium, high, or
+critical. It is shown as a badge in listings and filtered with
+felt ls --impact.
+
+A slug longer than 200 bytes is shortened at a hyphen, keeping any hex
+suffix, so the fiber's filename stays within filesystem limits; add warns
+when it does.`,
+	Example: `  felt add mocks-unbiased "Are the mocks unbiased?"
+  felt add pure_eb/covariance "Covariance method"
+  cat notes.md | felt add findings "Findings" --body-file -
+  felt add imported/spec "Imported spec" --id 01KTC9C1G1CBJ84H6WB92J8A13`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := resolveProjectRoot()
@@ -45,13 +66,33 @@ Examples:
 
 		storage := felt.NewStorage(root)
 
+		if addBodyFile != "" {
+			if addBody != "" {
+				return fmt.Errorf("--body and --body-file are mutually exclusive")
+			}
+			addBody, err = readTextInput(cmd, "--body-file", addBodyFile)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Pull [bracketed] tags out of the slug so `felt add "[tag]name"` works
 		extractedTags, cleanSlug := felt.ExtractTags(args[0])
+		cfg, err := storage.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.Slug.Transliterate {
+			cleanSlug = felt.TransliteratePath(cleanSlug)
+		}
 
 		f, err := felt.New(cleanSlug, args[1])
 		if err != nil {
 			return err
 		}
+		if f.ID != felt.SlugifyPath(cleanSlug) {
+			fmt.Fprintf(os.Stderr, "warning: slug shortened to %s (filenames are limited to %d bytes)\n", f.ID, felt.MaxSlugBytes)
+		}
 		if !addTopLevel {
 			felts, err := storage.ListMetadata()
 			if err != nil {
@@ -73,6 +114,17 @@ Examples:
 		if err := storage.CheckAvailableID(f.ID); err != nil {
 			return err
 		}
+		if addUID != "" {
+			if err := felt.ValidateUID(addUID); err != nil {
+				return err
+			}
+			if err := storage.CheckAvailableUID(addUID); err != nil {
+				return err
+			}
+			f.UID = addUID
+		} else if err := storage.EnsureAvailableUID(f); err != nil {
+			return err
+		}
 
 		// Add extracted tags
 		for _, tag := range extractedTags {
@@ -83,7 +135,11 @@ Examples:
 			f.Body = addBody
 		}
 		if addStatus != "" {
+			if err := cfg.CheckStatusTransition("", addStatus); err != nil {
+				return err
+			}
 			f.Status = addStatus
+			f.NoteStatusChange("", f.CreatedAt)
 		}
 		if len(addTags) > 0 {
 			for _, raw := range addTags {
@@ -93,15 +149,29 @@ Examples:
 			}
 		}
 		if addDue != "" {
-			due, err := time.Parse("2006-01-02", addDue)
+			due, err := felt.ParseDateExpr(addDue, time.Now())
 			if err != nil {
-				return fmt.Errorf("invalid due date (use YYYY-MM-DD): %w", err)
+				return fmt.Errorf("invalid due date: %w", err)
 			}
 			f.Due = &due
 		}
 		if addOutcome != "" {
 			f.Outcome = addOutcome
 		}
+		owner := ""
+		if cmd.Flags().Changed("owner") {
+			if owner, err = resolveOwnerFlag(storage, addOwner); err != nil {
+				return err
+			}
+		} else if f.HasStatus() {
+			owner = cfg.DefaultOwner()
+		}
+		if err := f.SetOwner(owner); err != nil {
+			return err
+		}
+		if err := f.SetImpact(addImpact); err != nil {
+			return err
+		}
 
 		// Seed the durable recency anchor at creation time, so a fresh clone
 		// orders a never-edited fiber by when it was born, not file mtime.
@@ -125,9 +195,15 @@ Examples:
 func init() {
 	rootCmd.AddCommand(addCmd)
 	addCmd.Flags().StringVarP(&addBody, "body", "b", "", "Body text")
+	addCmd.Flags().StringVar(&addBodyFile, "body-file", "", "Read body text from a file (- for stdin)")
 	addCmd.Flags().StringVarP(&addStatus, "status", "s", "", "Status (open, active, closed)")
-	addCmd.Flags().StringVarP(&addDue, "due", "D", "", "Due date (YYYY-MM-DD)")
+	addCmd.Flags().StringVarP(&addDue, "due", "D", "", "Due date (YYYY-MM-DD, friday, next week, in 3 days, ...)")
 	addCmd.Flags().StringArrayVarP(&addTags, "tag", "t", nil, "Tag (repeatable)")
+	registerTagCompletion(addCmd, "tag")
 	addCmd.Flags().StringVarP(&addOutcome, "outcome", "o", "", "Outcome (the conclusion)")
+	addCmd.Flags().StringVar(&addOwner, "owner", "", "Who is responsible (me for the default owner; tracked fibers default to it)")
+	addCmd.Flags().StringVar(&addImpact, "impact", "", "How much it hurts: low, medium, high, critical")
+	registerImpactCompletion(addCmd)
 	addCmd.Flags().BoolVar(&addTopLevel, "top-level", false, "Create at the top level; don't resolve <slug> against existing fibers")
+	addCmd.Flags().StringVar(&addUID, "id", "", "Assign the frontmatter id instead of minting a ULID (must be unique)")
 }
@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cailmdaley/felt/internal/felt"
 )
@@ -59,6 +62,113 @@ func TestAddMintsNativeUID(t *testing.T) {
 	}
 }
 
+func TestAddAssignsExplicitUID(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	defer saveAddGlobals()()
+
+	const uid = "01KTC9C1G1CBJ84H6WB92J8A13"
+	if out, err := runCommand(t, dir, "add", "spec", "Spec", "--id", uid); err != nil {
+		t.Fatalf("add --id: %v\n%s", err, out)
+	}
+	f, err := storage.Read("spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.UID != uid {
+		t.Fatalf("UID = %q, want %q", f.UID, uid)
+	}
+	if out, err := runCommand(t, dir, "show", uid); err != nil || !strings.Contains(out, "Spec") {
+		t.Fatalf("show by assigned id: %v\n%s", err, out)
+	}
+
+	addUID = ""
+	_, err = runCommand(t, dir, "add", "other", "Other", "--id", strings.ToLower(uid))
+	if err == nil || !strings.Contains(err.Error(), "already used by spec") {
+		t.Fatalf("duplicate --id err = %v", err)
+	}
+	addUID = ""
+	_, err = runCommand(t, dir, "add", "other", "Other", "--id", "a/b")
+	if err == nil || !strings.Contains(err.Error(), `contains '/'`) {
+		t.Fatalf("invalid --id err = %v", err)
+	}
+	if _, err := os.Stat(storage.Path("other")); !os.IsNotExist(err) {
+		t.Fatalf("rejected add wrote a fiber: %v", err)
+	}
+}
+
+func TestAddRemintsCollidingSeededUID(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	defer saveAddGlobals()()
+	t.Setenv(felt.IDSeedEnv, "import")
+
+	// A hand-copied fiber already carries the id the seed yields for "spec".
+	seeded := felt.NewUID("spec")
+	if err := storage.Write(&felt.Felt{ID: "spec-copy", UID: seeded, Name: "Copy", CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runCommand(t, dir, "add", "spec", "Spec"); err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+	f, err := storage.Read("spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.UID == seeded || !looksLikeULID(f.UID) {
+		t.Fatalf("UID = %q, want a fresh ULID distinct from %s", f.UID, seeded)
+	}
+}
+
+func TestAddTransliteratesSlugWhenConfigured(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	defer saveAddGlobals()()
+
+	if out, err := runCommand(t, dir, "add", "Größe", "Größe"); err != nil || strings.TrimSpace(out) != "größe" {
+		t.Fatalf("add without config = %q, %v; want unicode slug kept", out, err)
+	}
+	if err := os.WriteFile(storage.ConfigPath(), []byte("slug:\n  transliterate: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runCommand(t, dir, "add", "Über Größe", "Über Größe"); err != nil || strings.TrimSpace(out) != "uber-grosse" {
+		t.Fatalf("add with transliterate = %q, %v; want uber-grosse", out, err)
+	}
+}
+
+func TestAddAcceptsRelativeDueDate(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	defer saveAddGlobals()()
+
+	if out, err := runCommand(t, dir, "add", "review", "Review", "--due", "in 3 days"); err != nil {
+		t.Fatalf("add --due: %v\n%s", err, out)
+	}
+	f, err := storage.Read("review")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	if f.Due == nil || f.Due.Format("2006-01-02") != want {
+		t.Fatalf("due = %v, want %s", f.Due, want)
+	}
+	if _, err := runCommand(t, dir, "add", "later", "Later", "--due", "someday"); err == nil || !strings.Contains(err.Error(), "next week") {
+		t.Fatalf("add --due someday error = %v, want accepted forms listed", err)
+	}
+}
+
 func TestAddStampsUpdatedAtAtCreatedAt(t *testing.T) {
 	dir := t.TempDir()
 	storage := felt.NewStorage(dir)
@@ -89,24 +199,78 @@ func TestAddStampsUpdatedAtAtCreatedAt(t *testing.T) {
 	}
 }
 
+func TestAddBodyFileReadsStdinAndPaths(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+
+	resetAdd := saveAddGlobals()
+	defer resetAdd()
+
+	body := "## Findings\n\nQuotes \"survive\" and `backticks` $too.\n"
+	rootCmd.SetIn(strings.NewReader(body))
+	defer rootCmd.SetIn(nil)
+	if out, err := runCommand(t, dir, "add", "from-stdin", "From stdin", "--body-file", "-"); err != nil {
+		t.Fatalf("add --body-file -: %v\n%s", err, out)
+	}
+	f, err := storage.Read("from-stdin")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if f.Body != strings.TrimSpace(body) {
+		t.Fatalf("Body = %q, want %q", f.Body, strings.TrimSpace(body))
+	}
+
+	path := filepath.Join(t.TempDir(), "body.md")
+	if err := os.WriteFile(path, []byte("From a file.\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	saveAddGlobals()
+	if out, err := runCommand(t, dir, "add", "from-file", "From file", "--body-file", path); err != nil {
+		t.Fatalf("add --body-file path: %v\n%s", err, out)
+	}
+	f, err = storage.Read("from-file")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if f.Body != "From a file." {
+		t.Fatalf("Body = %q, want file contents", f.Body)
+	}
+
+	saveAddGlobals()
+	if _, err := runCommand(t, dir, "add", "both", "Both", "-b", "x", "--body-file", path); err == nil {
+		t.Fatalf("--body with --body-file should be refused")
+	}
+}
+
 func saveAddGlobals() func() {
 	prevBody := addBody
+	prevBodyFile := addBodyFile
 	prevStatus := addStatus
 	prevDue := addDue
 	prevTags := addTags
 	prevOutcome := addOutcome
 	prevTopLevel := addTopLevel
+	prevUID := addUID
+	prevOwner := addOwner
+	prevImpact := addImpact
 	prevJSON := jsonOutput
 
 	addBody = ""
+	addBodyFile = ""
 	addStatus = ""
 	addDue = ""
 	addTags = nil
 	addOutcome = ""
 	addTopLevel = false
+	addUID = ""
+	addOwner = ""
+	addImpact = ""
 	jsonOutput = false
 
-	for _, name := range []string{"body", "status", "due", "tag", "outcome", "top-level", "json"} {
+	for _, name := range []string{"body", "body-file", "status", "due", "tag", "outcome", "top-level", "id", "owner", "impact", "json"} {
 		if f := addCmd.Flags().Lookup(name); f != nil {
 			f.Changed = false
 		}
@@ -114,11 +278,15 @@ func saveAddGlobals() func() {
 
 	return func() {
 		addBody = prevBody
+		addBodyFile = prevBodyFile
 		addStatus = prevStatus
 		addDue = prevDue
 		addTags = prevTags
 		addOutcome = prevOutcome
 		addTopLevel = prevTopLevel
+		addUID = prevUID
+		addOwner = prevOwner
+		addImpact = prevImpact
 		jsonOutput = prevJSON
 	}
 }
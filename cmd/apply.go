@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var applyPatch bool
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <ops.json>",
+	Short: "Apply a JSON list of operations all-or-nothing",
+	Long: `Applies a JSON array of operations (- reads stdin) as one batch, so a
+whole planning step lands in a single call:
+
+  add     {"op":"add", "name":"…", "parent":"…", "tags":[…], "body":"…"}
+          "status" is open by default; "none" makes a statusless note
+  edit    {"op":"edit", "id":"…", "name":"…", "outcome":"…", "body":"…"}
+  close   {"op":"close", "id":"…", "outcome":"…"}
+  reopen  {"op":"reopen", "id":"…"}
+  tag     {"op":"tag", "id":"…", "tags":["…"]}       (untag likewise)
+  link    {"op":"link", "id":"…", "from":"…"}        id reads from as an input
+  defer   {"op":"defer", "id":"…", "until":"2026-07-01T09:00:00Z"}
+
+Any op but add takes "ids":[…] in place of "id" to do the same to several
+fibers. Later ops can name fibers added earlier in the list: an add without
+"id" gets the slug of its name, under "parent" when given.
+
+Every op is checked and applied in memory first, so one bad op anywhere
+changes nothing. The changed files are then written with a journal of their
+old contents, and a failed write restores them all.
+
+With --patch, the input is instead a unified diff of fiber files, as
+felt --emit-patch prints it: paths relative to the project root, /dev/null
+for a created or deleted file. Every hunk must apply and every patched fiber
+must still parse, or nothing is written — so a change can be emitted,
+reviewed, and applied later.`,
+	Example: `  felt apply plan.json
+  echo '[{"op":"close","ids":["a","b"],"outcome":"Done"}]' | felt apply -
+  felt edit fit/prep --outcome "Done" --emit-patch 2> change.diff
+  felt apply --patch change.diff`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		if applyPatch {
+			patch, err := readTextInput(cmd, "patch", args[0])
+			if err != nil {
+				return err
+			}
+			changed, err := felt.NewStorage(root).ApplyPatch(patch)
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return outputJSON(changed)
+			}
+			for _, name := range changed {
+				fmt.Printf("Patched %s\n", name)
+			}
+			return nil
+		}
+		data, err := readTextInput(cmd, "operations", args[0])
+		if err != nil {
+			return err
+		}
+		var ops []felt.ApplyOp
+		if err := json.Unmarshal([]byte(data), &ops); err != nil {
+			return fmt.Errorf("parsing operations: %w", err)
+		}
+		if len(ops) == 0 {
+			return fmt.Errorf("no operations to apply")
+		}
+
+		applied, err := felt.NewStorage(root).ApplyOps(ops, time.Now())
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return outputJSON(applied)
+		}
+		for _, op := range applied {
+			switch op.Op {
+			case felt.OpAdd:
+				fmt.Printf("Added %s\n", op.ID)
+			case felt.OpEdit:
+				fmt.Printf("Edited %s\n", op.ID)
+			case felt.OpClose:
+				fmt.Printf("Closed %s\n", op.ID)
+			case felt.OpReopen:
+				fmt.Printf("Reopened %s\n", op.ID)
+			case felt.OpTag:
+				fmt.Printf("Tagged %s\n", op.ID)
+			case felt.OpUntag:
+				fmt.Printf("Untagged %s\n", op.ID)
+			case felt.OpLink:
+				fmt.Printf("Linked %s ← %s\n", op.ID, op.From)
+			case felt.OpDefer:
+				fmt.Printf("Deferred %s until %s\n", op.ID, displayTime(*op.Until).Format("2006-01-02 15:04"))
+			}
+		}
+		n := len(applied)
+		fmt.Printf("Applied %d %s\n", n, pluralize(n, "operation", "operations"))
+		return nil
+	},
+}
+
+func init() {
+	applyCmd.Flags().BoolVar(&applyPatch, "patch", false, "Apply a unified diff of fiber files instead of JSON operations")
+	rootCmd.AddCommand(applyCmd)
+}
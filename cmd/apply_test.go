@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestApplyCommand(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	for _, f := range []*felt.Felt{
+		{ID: "draft", Name: "Draft", Status: felt.StatusActive, CreatedAt: time.Now()},
+		{ID: "figures", Name: "Figures", Status: felt.StatusOpen, CreatedAt: time.Now()},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path := filepath.Join(dir, "ops.json")
+	ops := `[
+  {"op": "close", "id": "draft", "outcome": "Drafted"},
+  {"op": "add", "name": "Submit", "tags": ["paper"]},
+  {"op": "link", "id": "submit", "from": "figures"}
+]`
+	if err := os.WriteFile(path, []byte(ops), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runCommand(t, dir, "apply", path)
+	if err != nil {
+		t.Fatalf("apply: %v\n%s", err, out)
+	}
+	for _, want := range []string{"Closed draft\n", "Added submit\n", "Linked submit ← figures\n", "Applied 3 operations\n"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("output missing %q:\n%s", want, out)
+		}
+	}
+	if draft, _ := storage.Read("draft"); !draft.IsClosed() || draft.Outcome != "Drafted" {
+		t.Fatalf("draft = %+v", draft)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`[{"op":"close","id":"figures"},{"op":"explode","id":"submit"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCommand(t, dir, "apply", bad); err == nil || !strings.Contains(err.Error(), "unknown op") {
+		t.Fatalf("apply with an unknown op = %v, want error", err)
+	}
+	if figures, _ := storage.Read("figures"); figures.IsClosed() {
+		t.Fatal("a bad operation file still applied its valid entries")
+	}
+}
+
+func TestEmitPatchThenApplyPatch(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "draft", Name: "Draft", Status: felt.StatusActive, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(storage.Path("draft"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reset := saveEditGlobals()
+	defer reset()
+	t.Setenv(felt.EmitPatchEnv, "")
+	defer func() { emitPatch = false }()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldStderr := os.Stderr
+	os.Stderr = w
+	out, err := runCommand(t, dir, "edit", "draft", "--outcome", "Drafted", "--emit-patch")
+	os.Stderr = oldStderr
+	w.Close()
+	if err != nil {
+		t.Fatalf("edit --emit-patch: %v\n%s", err, out)
+	}
+	patch, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(patch), "+outcome: Drafted\n") {
+		t.Fatalf("emitted patch missing the outcome:\n%s", patch)
+	}
+	if data, _ := os.ReadFile(storage.Path("draft")); !bytes.Equal(data, original) {
+		t.Fatal("edit --emit-patch wrote the fiber")
+	}
+
+	emitPatch = false
+	os.Setenv(felt.EmitPatchEnv, "")
+	path := filepath.Join(dir, "change.diff")
+	if err := os.WriteFile(path, patch, 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCommand(t, dir, "apply", "--patch", path)
+	applyPatch = false
+	if err != nil {
+		t.Fatalf("apply --patch: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Patched .felt/draft/draft.md\n") {
+		t.Fatalf("apply --patch output:\n%s", out)
+	}
+	if draft, _ := storage.Read("draft"); draft.Outcome != "Drafted" {
+		t.Fatalf("draft outcome after apply --patch = %q", draft.Outcome)
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var artifactHash bool
+
+var artifactCmd = &cobra.Command{
+	Use:   "artifact",
+	Short: "Register data files on the fibers that produce them",
+	Long: `Connects produced data files back to the fiber (decision) that made them.
+Artifacts live in the fiber's artifacts: frontmatter list, with paths
+relative to the project root.
+
+  felt artifact add <id> <path>... --hash   register files, with SHA-256
+  felt artifact verify [id]                 report changed or missing files`,
+}
+
+var artifactAddCmd = &cobra.Command{
+	Use:   "add <id> <path>...",
+	Short: "Register produced files on a fiber",
+	Long: `Registers one or more files on the producing fiber. With --hash the file's
+SHA-256 and size are recorded, so verify can tell when it changes; without
+it, verify only checks the file still exists. Registering a path again
+updates its entry.`,
+	Args: cobra.MinimumNArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return completeFiberIDs(cmd, args, toComplete)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC().Truncate(time.Second)
+		var artifacts []felt.Artifact
+		for _, p := range args[1:] {
+			a, err := describeArtifact(root, p, artifactHash, now)
+			if err != nil {
+				return err
+			}
+			artifacts = append(artifacts, a)
+		}
+
+		f, unlock, err := lockAndReloadFiber(storage, target)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		for _, a := range artifacts {
+			if err := f.RegisterArtifact(a); err != nil {
+				return err
+			}
+		}
+		f.Touch(time.Now())
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+		for _, a := range artifacts {
+			fmt.Printf("Registered %s on %s\n", a.Path, f.ID)
+		}
+		return nil
+	},
+}
+
+// describeArtifact resolves p (relative to the working directory) to a
+// project-relative path, refusing files outside the project, and hashes it
+// when asked.
+func describeArtifact(root, p string, hash bool, now time.Time) (felt.Artifact, error) {
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return felt.Artifact{}, err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return felt.Artifact{}, err
+	}
+	if info.IsDir() {
+		return felt.Artifact{}, fmt.Errorf("%s is a directory; register the files inside it", p)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return felt.Artifact{}, fmt.Errorf("%s is outside the project root %s", p, root)
+	}
+	a := felt.Artifact{Path: filepath.ToSlash(rel), RecordedAt: now}
+	if hash {
+		if a.SHA256, a.Size, err = felt.HashFile(abs); err != nil {
+			return felt.Artifact{}, err
+		}
+	}
+	return a, nil
+}
+
+var artifactVerifyCmd = &cobra.Command{
+	Use:   "verify [id]",
+	Short: "Check registered artifacts for changes or deletion",
+	Long: `Checks every registered artifact — or only one fiber's — against the files
+on disk, and fails when any has changed (checksum mismatch) or disappeared.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+
+		var felts []*felt.Felt
+		if len(args) == 1 {
+			f, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+			if err != nil {
+				return err
+			}
+			felts = []*felt.Felt{f}
+		} else if felts, err = storage.ListMetadataHavingFrontmatterFields([]string{felt.ArtifactsKey}); err != nil {
+			return err
+		}
+
+		statuses, err := felt.VerifyArtifacts(root, felts)
+		if err != nil {
+			return err
+		}
+		bad := 0
+		for _, st := range statuses {
+			if st.Status != felt.ArtifactOK {
+				bad++
+			}
+		}
+		if jsonOutput {
+			if statuses == nil {
+				statuses = []felt.ArtifactStatus{}
+			}
+			if err := outputJSON(statuses); err != nil {
+				return err
+			}
+		} else {
+			if len(statuses) == 0 {
+				fmt.Println("No artifacts registered")
+			}
+			for _, st := range statuses {
+				line := fmt.Sprintf("%-8s %s  %s", st.Status, st.FiberID, st.Path)
+				if st.Status == felt.ArtifactChanged {
+					line += fmt.Sprintf(" (sha256 %s → %s)", shortSum(st.SHA256), shortSum(st.Actual))
+				}
+				fmt.Println(line)
+			}
+		}
+		if bad > 0 {
+			return fmt.Errorf("%d %s changed or missing", bad, pluralize(bad, "artifact", "artifacts"))
+		}
+		return nil
+	},
+}
+
+func shortSum(sum string) string {
+	if len(sum) > 12 {
+		return sum[:12]
+	}
+	return sum
+}
+
+func init() {
+	rootCmd.AddCommand(artifactCmd)
+	artifactCmd.AddCommand(artifactAddCmd)
+	artifactCmd.AddCommand(artifactVerifyCmd)
+	artifactAddCmd.Flags().BoolVar(&artifactHash, "hash", false, "Record each file's SHA-256 and size")
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestArtifactAddAndVerify(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusActive, CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	chains := filepath.Join(dir, "data", "chains.txt")
+	plot := filepath.Join(dir, "plot.pdf")
+	if err := os.MkdirAll(filepath.Dir(chains), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{chains, plot} {
+		if err := os.WriteFile(p, []byte("v1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prevHash, prevJSON := artifactHash, jsonOutput
+	defer func() { artifactHash, jsonOutput = prevHash, prevJSON }()
+	artifactHash, jsonOutput = false, false
+
+	if out, err := runCommand(t, dir, "artifact", "add", "fit", chains, plot, "--hash"); err != nil {
+		t.Fatalf("artifact add: %v\n%s", err, out)
+	}
+	f, err := storage.Read("fit")
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	artifacts, err := f.Artifacts()
+	if err != nil || len(artifacts) != 2 || artifacts[0].Path != "data/chains.txt" || artifacts[0].SHA256 == "" || artifacts[0].Size != 2 {
+		t.Fatalf("artifacts = %+v, %v", artifacts, err)
+	}
+
+	if out, err := runCommand(t, dir, "artifact", "verify"); err != nil || !strings.Contains(out, "ok       fit  data/chains.txt") {
+		t.Fatalf("clean verify = %v\n%s", err, out)
+	}
+
+	if err := os.WriteFile(chains, []byte("v2"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(plot); err != nil {
+		t.Fatal(err)
+	}
+	out, err := runCommand(t, dir, "artifact", "verify", "fit")
+	if err == nil || !strings.Contains(err.Error(), "2 artifacts changed or missing") {
+		t.Fatalf("verify after changes: err = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "changed  fit  data/chains.txt (sha256 ") || !strings.Contains(out, "missing  fit  plot.pdf") {
+		t.Fatalf("verify output:\n%s", out)
+	}
+
+	if _, err := runCommand(t, dir, "artifact", "add", "fit", os.TempDir()); err == nil {
+		t.Fatalf("registering a directory outside the project should fail")
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <id>",
+	Short: "Show the commit that set each of a fiber's fields",
+	Long: `Prints, for each frontmatter field of a fiber and for its body, the last git
+commit that set the value it has now: short hash, date, author, and subject.
+A field whose value on disk differs from the last commit is shown as
+uncommitted.
+
+History is read from the fiber file at its current path, as felt diff reads
+it, so a fiber whose file was moved starts over at the move. "Who closed
+this, and why?" is answered by the status, closed-at, and outcome lines.`,
+	Example: `  felt blame fit/prep
+  felt blame fit/prep --json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		feltDir := filepath.Join(root, felt.DirName)
+		if err := exec.Command("git", "-C", feltDir, "rev-parse", "--is-inside-work-tree").Run(); err != nil {
+			return fmt.Errorf("%s is not in a git repository", feltDir)
+		}
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		current, err := storage.Read(target.ID)
+		if err != nil {
+			return err
+		}
+
+		path := storage.Path(current.ID)
+		commits, err := fiberCommits(path)
+		if err != nil {
+			return err
+		}
+		history := make([]*felt.Felt, len(commits))
+		for i, c := range commits {
+			if history[i], err = committedFiber(path, current.ID, c.Hash); err != nil {
+				return err
+			}
+		}
+		blames, err := felt.BlameFields(current, history)
+		if err != nil {
+			return fmt.Errorf("%s: %w", current.ID, err)
+		}
+
+		lines := make([]blameLine, len(blames))
+		for i, b := range blames {
+			lines[i] = blameLine{Field: b.Field}
+			if b.Version >= 0 {
+				lines[i].Commit = &commits[b.Version]
+			}
+		}
+		if jsonOutput {
+			return outputJSON(map[string]any{"id": current.ID, "fields": lines})
+		}
+		fmt.Print(renderBlame(current.ID, lines))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(blameCmd)
+}
+
+// blameCommit is one commit that touched a fiber file.
+type blameCommit struct {
+	Hash    string    `json:"hash"`
+	Short   string    `json:"-"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+// blameLine is one field and the commit that set its value, nil when the
+// value is uncommitted.
+type blameLine struct {
+	Field  string       `json:"field"`
+	Commit *blameCommit `json:"commit"`
+}
+
+// fiberCommits lists the commits that touched path, newest first. A
+// repository with no commits yet has none.
+func fiberCommits(path string) ([]blameCommit, error) {
+	c := exec.Command("git", "log", "--format=%H%x1f%h%x1f%an%x1f%aI%x1f%s", "--", filepath.Base(path))
+	c.Dir = filepath.Dir(path)
+	c.Env = append(os.Environ(), "LC_ALL=C") // the no-commits message below is matched in English
+	var stderr strings.Builder
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		if strings.Contains(stderr.String(), "does not have any commits yet") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("git log %s: %s", path, strings.TrimSpace(stderr.String()))
+	}
+	var commits []blameCommit
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		parts := strings.SplitN(line, "\x1f", 5)
+		if len(parts) != 5 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, parts[3])
+		if err != nil {
+			return nil, fmt.Errorf("git log %s: bad date %q", path, parts[3])
+		}
+		commits = append(commits, blameCommit{Hash: parts[0], Short: parts[1], Author: parts[2], Date: date, Subject: parts[4]})
+	}
+	return commits, nil
+}
+
+// renderBlame renders one fiber's blame for the terminal, fields aligned.
+func renderBlame(id string, lines []blameLine) string {
+	width := 0
+	for _, l := range lines {
+		width = max(width, len(l.Field))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s\n", id)
+	for _, l := range lines {
+		if l.Commit == nil {
+			fmt.Fprintf(&sb, "  %-*s  (uncommitted)\n", width, l.Field)
+			continue
+		}
+		c := l.Commit
+		fmt.Fprintf(&sb, "  %-*s  %s  %s  %s  %s\n", width, l.Field, c.Short, displayTime(c.Date).Format("2006-01-02"), c.Author, c.Subject)
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestBlameNamesTheCommitThatSetEachField(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitIn(t, dir, "init", "-q")
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatal(err)
+	}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, CreatedAt: time.Now(), Body: "Notes."}
+	if err := storage.Write(fit); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, dir, "add", "-A")
+	gitIn(t, dir, "commit", "-q", "-m", "Add fit")
+
+	closed := time.Now()
+	fit.Status, fit.ClosedAt, fit.Outcome = felt.StatusClosed, &closed, "Converged"
+	if err := storage.Write(fit); err != nil {
+		t.Fatal(err)
+	}
+	gitIn(t, dir, "commit", "-q", "-am", "Close fit")
+
+	fit.Tags = []string{"methods"}
+	if err := storage.Write(fit); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, dir, "blame", "fit")
+	if err != nil {
+		t.Fatalf("blame fit: %v\n%s", err, out)
+	}
+	for field, subject := range map[string]string{
+		"name":    "Add fit",
+		"body":    "Add fit",
+		"status":  "Close fit",
+		"outcome": "Close fit",
+		"tags":    "(uncommitted)",
+	} {
+		if !regexp.MustCompile(`(?m)^  ` + field + ` .*` + regexp.QuoteMeta(subject) + `$`).MatchString(out) {
+			t.Errorf("blame %s: want %q in\n%s", field, subject, out)
+		}
+	}
+	if !strings.Contains(out, "  T  Close fit") {
+		t.Errorf("blame does not name the author:\n%s", out)
+	}
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var bodySection string
+
+var bodyCmd = &cobra.Command{
+	Use:   "body <id> <get|set|append> [text|-]",
+	Short: "Read or update a fiber's body, whole or by section",
+	Long: `Reads or updates a fiber's body, optionally one markdown section at a time.
+
+  felt body <id> get                                  whole body
+  felt body <id> get --section "## Findings"          one section's content
+  felt body <id> set --section "## Findings" "..."    replace a section's content
+  felt body <id> append --section Findings -          append stdin to a section
+
+--section takes a full heading line ("## Findings"), which must match level
+and text, or bare heading text ("Findings"), which matches any level. A
+section runs to the next heading of the same or a higher level; headings in
+fenced code are ignored. set and append create a missing section at the end
+of the body when given a full heading line.
+
+Text comes from the argument, or stdin when it is "-" or omitted. Without
+--section, set replaces and append extends the whole body.`,
+	Args:              cobra.RangeArgs(2, 3),
+	ValidArgsFunction: completeFirstFiberID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		op := args[1]
+		switch op {
+		case "get":
+			if len(args) == 3 {
+				return fmt.Errorf("body get takes no text argument")
+			}
+		case "set", "append":
+		default:
+			return fmt.Errorf("unknown body operation %q (valid: get, set, append)", op)
+		}
+
+		storage := felt.NewStorage(root)
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		f, err := storage.Read(target.ID)
+		if err != nil {
+			return err
+		}
+
+		if op == "get" {
+			content := f.Body
+			if bodySection != "" {
+				if content, err = felt.GetBodySection(f.Body, bodySection); err != nil {
+					return fmt.Errorf("%s: %w", f.ID, err)
+				}
+			}
+			if jsonOutput {
+				return outputJSON(map[string]string{"id": f.ID, "section": bodySection, "content": content})
+			}
+			if content != "" {
+				fmt.Println(content)
+			}
+			return nil
+		}
+
+		text := "-"
+		if len(args) == 3 {
+			text = args[2]
+		}
+		if text == "-" {
+			if text, err = readTextInput(cmd, "body text", "-"); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case bodySection == "" && op == "set":
+			f.Body = text
+		case bodySection == "":
+			f.Body = appendBodyText(f.Body, text)
+		case op == "set":
+			f.Body, err = felt.SetBodySection(f.Body, bodySection, text)
+		default:
+			f.Body, err = felt.AppendBodySection(f.Body, bodySection, text)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.ID, err)
+		}
+
+		f.Touch(time.Now())
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+		fmt.Printf("Updated %s\n", f.ID)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(bodyCmd)
+	bodyCmd.Flags().StringVar(&bodySection, "section", "", "Operate on one markdown section (\"## Findings\" or \"Findings\")")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestBodySectionCommand(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{
+		ID:        "fiber-a",
+		Name:      "Fiber A",
+		CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z"),
+		Body:      "## Approach\n\nFit.\n\n## Findings\n\nNone yet.",
+	}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reset := saveBodyGlobals()
+	defer reset()
+
+	if out, err := runCommand(t, dir, "body", "fiber-a", "set", "--section", "## Findings", "Converges."); err != nil {
+		t.Fatalf("body set: %v\n%s", err, out)
+	}
+	rootCmd.SetIn(strings.NewReader("Residuals are flat.\n"))
+	defer rootCmd.SetIn(nil)
+	if out, err := runCommand(t, dir, "body", "fiber-a", "append", "--section", "Findings"); err != nil {
+		t.Fatalf("body append: %v\n%s", err, out)
+	}
+
+	out, err := runCommand(t, dir, "body", "fiber-a", "get", "--section", "## Findings")
+	if err != nil {
+		t.Fatalf("body get: %v\n%s", err, out)
+	}
+	if out != "Converges.\n\nResiduals are flat.\n" {
+		t.Fatalf("section = %q", out)
+	}
+	f, err := storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !strings.HasPrefix(f.Body, "## Approach\n\nFit.\n\n## Findings") {
+		t.Fatalf("other sections disturbed: %q", f.Body)
+	}
+
+	if _, err := runCommand(t, dir, "body", "fiber-a", "get", "--section", "Missing"); err == nil {
+		t.Fatalf("missing section should error")
+	}
+	bodySection = ""
+	if _, err := runCommand(t, dir, "body", "fiber-a", "frob"); err == nil {
+		t.Fatalf("unknown operation should error")
+	}
+}
+
+func saveBodyGlobals() func() {
+	prevSection := bodySection
+	prevJSON := jsonOutput
+
+	bodySection = ""
+	jsonOutput = false
+	if f := bodyCmd.Flags().Lookup("section"); f != nil {
+		f.Changed = false
+	}
+
+	return func() {
+		bodySection = prevSection
+		jsonOutput = prevJSON
+	}
+}
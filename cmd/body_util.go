@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// readTextInput returns the text a file-or-stdin flag names: a path, or "-"
+// for stdin (refusing an interactive terminal, like --outcome's stdin
+// fallback). The text is taken verbatim apart from trailing newlines, so long
+// markdown survives without passing through shell quoting. label names the
+// input in errors (e.g. "--body-file").
+func readTextInput(cmd *cobra.Command, label, source string) (string, error) {
+	if source != "-" {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", label, err)
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	}
+
+	in := cmd.InOrStdin()
+	if file, ok := in.(*os.File); ok {
+		if stat, err := file.Stat(); err == nil && (stat.Mode()&os.ModeCharDevice) != 0 {
+			return "", fmt.Errorf("%s: pipe the input on stdin", label)
+		}
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("reading %s from stdin: %w", label, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// appendBodyText adds text to the end of body as a new paragraph.
+func appendBodyText(body, text string) string {
+	text = strings.Trim(text, "\n")
+	if strings.TrimSpace(body) == "" {
+		return text
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + text
+}
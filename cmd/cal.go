@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var calCmd = &cobra.Command{
+	Use:   "cal [month]",
+	Short: "Month calendar of due and snoozed fibers",
+	Long: `Draws a month calendar with each day marked by what falls on it, and a
+keyed list of those fibers below — the dates the data-flow views leave out.
+
+  !  a fiber is due
+  ~  a snoozed fiber wakes (defer-until, from felt snooze)
+  *  both
+
+Closed fibers are left out; a due date already past is listed as overdue.
+Weeks start on Monday and today is bracketed. The month defaults to this
+one; otherwise give 2026-11, a month name (this year's), next, or last.`,
+	Example: `  felt cal
+  felt cal next
+  felt cal 2026-12 --json`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		now := displayTime(time.Now())
+		arg := ""
+		if len(args) == 1 {
+			arg = args[0]
+		}
+		first, err := felt.ParseMonth(arg, now)
+		if err != nil {
+			return err
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		last := first.AddDate(0, 1, -1)
+		entries := felt.CalendarEntries(felts, first, last, now.Location())
+		if jsonOutput {
+			if entries == nil {
+				entries = []felt.CalendarEntry{}
+			}
+			return outputJSON(entries)
+		}
+		fmt.Print(renderCalendar(first, entries, now))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(calCmd)
+}
+
+// renderCalendar draws the month starting at first as a Monday-first grid
+// of five-column cells — the day number, bracketed for today, then its
+// mark — followed by the entries' key.
+func renderCalendar(first time.Time, entries []felt.CalendarEntry, now time.Time) string {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	marks := make(map[int]byte)
+	for _, e := range entries {
+		mark := byte('!')
+		if e.Kind == felt.CalendarWakes {
+			mark = '~'
+		}
+		if prev, ok := marks[e.Day.Day()]; ok && prev != mark {
+			mark = '*'
+		}
+		marks[e.Day.Day()] = mark
+	}
+
+	var sb strings.Builder
+	title := first.Format("January 2006")
+	fmt.Fprintf(&sb, "%*s\n", (34+len(title))/2, title)
+	sb.WriteString(" Mo   Tu   We   Th   Fr   Sa   Su\n")
+	offset := (int(first.Weekday()) + 6) % 7 // Monday first
+	sb.WriteString(strings.Repeat("     ", offset))
+	days := first.AddDate(0, 1, -1).Day()
+	for day := 1; day <= days; day++ {
+		mark := byte(' ')
+		if mk, ok := marks[day]; ok {
+			mark = mk
+		}
+		cell := fmt.Sprintf(" %2d%c ", day, mark)
+		if first.AddDate(0, 0, day-1).Equal(today) {
+			cell = fmt.Sprintf("[%2d]%c", day, mark)
+		}
+		if (offset+day)%7 == 0 || day == days {
+			sb.WriteString(strings.TrimRight(cell, " ") + "\n")
+		} else {
+			sb.WriteString(cell)
+		}
+	}
+
+	if len(entries) == 0 {
+		sb.WriteString("\nNothing due or waking this month\n")
+		return sb.String()
+	}
+	sb.WriteString("\n")
+	for _, e := range entries {
+		mark, note := "!", ""
+		if e.Kind == felt.CalendarWakes {
+			mark = "~"
+		} else if e.Day.Before(today) {
+			note = " (overdue)"
+		}
+		fmt.Fprintf(&sb, "%s  %s %s — %s%s\n", e.Day.Format("Mon Jan _2"), mark, e.ID, e.Name, note)
+	}
+	return sb.String()
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestRenderCalendarMarksDaysAndKeysThem(t *testing.T) {
+	due := func(y int, m time.Month, d int) *time.Time { t := time.Date(y, m, d, 0, 0, 0, 0, time.UTC); return &t }
+	snoozed := &felt.Felt{ID: "mocks", Name: "Rerun mocks", Status: felt.StatusOpen, Due: due(2026, 10, 20)}
+	if err := snoozed.SetDeferUntil(time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	felts := []*felt.Felt{
+		{ID: "draft", Name: "Send the draft", Status: felt.StatusOpen, Due: due(2026, 10, 2)},
+		{ID: "done", Name: "Already done", Status: felt.StatusClosed, Due: due(2026, 10, 5)},
+		{ID: "later", Name: "Next month", Status: felt.StatusOpen, Due: due(2026, 11, 1)},
+		snoozed,
+	}
+	first, err := felt.ParseMonth("october", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := felt.CalendarEntries(felts, first, first.AddDate(0, 1, -1), time.UTC)
+	got := renderCalendar(first, entries, time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC))
+	want := `           October 2026
+ Mo   Tu   We   Th   Fr   Sa   Su
+                 1    2!   3    4
+  5    6    7    8    9   10   11
+ 12   13  [14]  15   16   17   18
+ 19   20*  21   22   23   24   25
+ 26   27   28   29   30   31
+
+Fri Oct  2  ! draft — Send the draft (overdue)
+Tue Oct 20  ! mocks — Rerun mocks
+Tue Oct 20  ~ mocks — Rerun mocks
+`
+	if got != want {
+		t.Fatalf("calendar =\n%s\nwant\n%s", got, want)
+	}
+}
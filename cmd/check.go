@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cailmdaley/felt/internal/felt"
 	"github.com/spf13/cobra"
@@ -20,7 +21,9 @@ Current checks cover:
   - legacy MyST body anchors
   - slug collisions between bare and nested fiber forms
   - multiple bare .md files at .felt/ root
-  - orphaned pins: fibers claiming a pinned role with no shuttle: block (warning)`,
+  - orphaned pins: fibers claiming a pinned role with no shuttle: block (warning)
+  - closed fibers not read in 30 days, when access.log is enabled in
+    .felt/config.yaml (warning)`,
 	Args:         cobra.NoArgs,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -46,6 +49,17 @@ Current checks cover:
 			return err
 		}
 		issues = append(issues, legacyIssues...)
+		cfg, err := storage.LoadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.Access.Log {
+			access, err := storage.LoadAccessLog()
+			if err != nil {
+				return err
+			}
+			issues = append(issues, felt.CheckUnread(felts, access, time.Now())...)
+		}
 		if jsonOutput {
 			return outputJSON(issues)
 		}
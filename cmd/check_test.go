@@ -102,6 +102,15 @@ Body.
 	}
 }
 
+// The tests assert on felt's default output, so the environment variables
+// that change it are cleared once for the whole package; a test that wants
+// one sets it with t.Setenv.
+func init() {
+	for _, name := range []string{asciiEnv, noColorEnv, felt.OwnerEnv, felt.IDSeedEnv, felt.EmitPatchEnv, felt.FeltDirEnv, hookStrictEnv} {
+		os.Unsetenv(name)
+	}
+}
+
 func runCommand(t *testing.T, dir string, args ...string) (string, error) {
 	t.Helper()
 
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var citeCmd = &cobra.Command{
+	Use:   "cite <id>...",
+	Short: "Emit BibTeX for reference fibers",
+	Long: `Emits a BibTeX entry for each reference fiber: a fiber whose frontmatter
+carries a reference: block.
+
+  reference:
+    authors: ["Heymans, C.", "Tröster, T."]   # or "Heymans, C. and Tröster, T."
+    year: 2021
+    journal: A&A
+    doi: 10.1051/0004-6361/202039063
+    url: https://arxiv.org/abs/2007.15632
+    key: heymans2021                          # optional; derived when absent
+    title: ...                                # optional; defaults to the name
+
+Decision fibers depend on a reference like any upstream, with
+inputs[].from: <reference-id>, so the evidence behind a decision shows in
+its consumers and data flow.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeFiberIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		storage := felt.NewStorage(root)
+		scopeID := resolveCommandScope(root)
+		for i, query := range args {
+			f, err := storage.FindMetadataInScope(scopeID, query)
+			if err != nil {
+				return err
+			}
+			ref, ok, err := f.Reference()
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("%s is not a reference fiber (no %s: block)", f.ID, felt.ReferenceKey)
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(ref.BibTeX(f))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(citeCmd)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestCiteEmitsBibTeXForReferenceFibers(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	ref := &felt.Felt{ID: "asgari-2021", Name: "KiDS-1000 COSEBIs", CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}
+	if err := ref.SetExtraField(felt.ReferenceKey, map[string]any{"authors": []string{"Asgari, M."}, "year": 2021, "key": "asgari21"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*felt.Felt{ref, {ID: "plain", Name: "Plain", CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", f.ID, err)
+		}
+	}
+
+	out, err := runCommand(t, dir, "cite", "asgari-2021")
+	if err != nil {
+		t.Fatalf("cite: %v\n%s", err, out)
+	}
+	if !strings.HasPrefix(out, "@misc{asgari21,\n  title   = {KiDS-1000 COSEBIs},\n  author  = {Asgari, M.},") {
+		t.Fatalf("cite output:\n%s", out)
+	}
+	if _, err := runCommand(t, dir, "cite", "plain"); err == nil || !strings.Contains(err.Error(), "not a reference fiber") {
+		t.Fatalf("cite on a plain fiber should fail, got %v", err)
+	}
+}
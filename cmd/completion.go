@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+// completeFiberIDs completes every positional argument as a fiber ID,
+// offering each with its name as the description. A word without a slash
+// also matches the last segment of nested IDs, as lookups do: such a
+// fiber is offered by that short name when no other fiber shares it, and
+// by its full ID otherwise.
+func completeFiberIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	felts := completionFelts()
+	bases := make(map[string]int, len(felts))
+	for _, f := range felts {
+		bases[path.Base(f.ID)]++
+	}
+	out := make([]string, 0, len(felts))
+	for _, f := range felts {
+		base := path.Base(f.ID)
+		switch {
+		case strings.HasPrefix(f.ID, toComplete):
+			out = append(out, f.ID+"\t"+f.Name)
+		case strings.Contains(toComplete, "/") || !strings.HasPrefix(base, toComplete):
+			continue
+		case bases[base] == 1:
+			out = append(out, base+"\t"+f.Name)
+		default:
+			out = append(out, f.ID+"\t"+f.Name)
+		}
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFirstFiberID completes a fiber ID for the first positional
+// argument only, for commands like snooze <id> <duration> whose later
+// arguments are free text.
+func completeFirstFiberID(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeFiberIDs(cmd, args, toComplete)
+}
+
+// completeTags completes a tag flag from the tags already in the store,
+// with how many fibers carry each.
+func completeTags(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	counts := make(map[string]int)
+	for _, f := range completionFelts() {
+		for _, tag := range f.Tags {
+			counts[tag]++
+		}
+	}
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		if strings.HasPrefix(tag, toComplete) {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = fmt.Sprintf("%s\t%d %s", tag, counts[tag], pluralize(counts[tag], "fiber", "fibers"))
+	}
+	return out, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerTagCompletion attaches completeTags to each named flag of cmd.
+func registerTagCompletion(cmd *cobra.Command, flags ...string) {
+	for _, name := range flags {
+		_ = cmd.RegisterFlagCompletionFunc(name, completeTags)
+	}
+}
+
+// registerImpactCompletion completes cmd's --impact flag with the impact
+// levels.
+func registerImpactCompletion(cmd *cobra.Command) {
+	_ = cmd.RegisterFlagCompletionFunc("impact", cobra.FixedCompletions(felt.ImpactLevels, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// completionFelts lists the store's headers for a completion, or nothing
+// outside a felt repository — a completion has nowhere to report errors.
+func completionFelts() []*felt.Felt {
+	root, err := resolveProjectRoot()
+	if err != nil {
+		return nil
+	}
+	felts, err := felt.NewStorage(root).ListMetadata()
+	if err != nil {
+		return nil
+	}
+	sort.Slice(felts, func(i, j int) bool { return felts[i].ID < felts[j].ID })
+	return felts
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestCompletionOffersFiberIDsAndTags(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	for _, f := range []*felt.Felt{
+		{ID: "mocks-3f2a", Name: "Mocks", Status: felt.StatusOpen, Tags: []string{"sim", "thread:cosebis"}, CreatedAt: created},
+		{ID: "mocks-unbiased-9c1d", Name: "Unbiased mocks", Status: felt.StatusOpen, Tags: []string{"sim"}, CreatedAt: created},
+		{ID: "paper-77e0", Name: "Paper", Status: felt.StatusOpen, CreatedAt: created},
+		{ID: "cosebis/fit", Name: "COSEBIs fit", Status: felt.StatusOpen, CreatedAt: created},
+		{ID: "cosebis/mocks-y3", Name: "Y3 mocks", Status: felt.StatusOpen, CreatedAt: created},
+		{ID: "pseudo-cl/fit", Name: "Pseudo-Cl fit", Status: felt.StatusOpen, CreatedAt: created},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	complete := func(args ...string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		if out, err := runCommand(t, dir, append([]string{"__complete"}, args...)...); err != nil {
+			t.Fatalf("__complete %v: %v\n%s", args, err, out)
+		}
+		return buf.String()
+	}
+
+	out := complete("show", "mocks")
+	for _, want := range []string{"mocks-3f2a\tMocks\n", "mocks-unbiased-9c1d\tUnbiased mocks\n"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("show completion missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "paper-77e0") {
+		t.Fatalf("show completion ignored the prefix:\n%s", out)
+	}
+	if !strings.Contains(out, "mocks-y3\tY3 mocks\n") {
+		t.Fatalf("show completion did not offer a nested fiber by its short name:\n%s", out)
+	}
+	out = complete("show", "fi")
+	for _, want := range []string{"cosebis/fit\tCOSEBIs fit\n", "pseudo-cl/fit\tPseudo-Cl fit\n"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("a short name two fibers share should complete to their full IDs, missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, ":4\n") {
+		t.Fatalf("show completion should suppress file completion:\n%s", out)
+	}
+
+	if out := complete("snooze", "paper-77e0", ""); strings.Contains(out, "mocks-3f2a") {
+		t.Fatalf("snooze completed a fiber id for its duration:\n%s", out)
+	}
+
+	out = complete("ls", "-t", "")
+	for _, want := range []string{"sim\t2 fibers\n", "thread:cosebis\t1 fiber\n"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("tag completion missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestDemoStoreIsConsistent(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "play")
+	prevJSON := jsonOutput
+	defer func() { jsonOutput = prevJSON }()
+	jsonOutput = false
+
+	out, err := runCommand(t, "", "demo", dir)
+	if err != nil {
+		t.Fatalf("demo: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Created demo store with 11 fibers") {
+		t.Fatalf("demo output:\n%s", out)
+	}
+	if out, err := runCommand(t, dir, "check"); err != nil {
+		t.Fatalf("check on demo store: %v\n%s", err, out)
+	}
+
+	felts, err := felt.NewStorage(dir).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ready []string
+	for _, f := range felt.ReadyFelts(felts, time.Now()) {
+		ready = append(ready, f.ID)
+	}
+	if got := strings.Join(ready, ","); got != "bao-analysis/mocks-unbiased" {
+		t.Fatalf("ready = %s, want only the open question", got)
+	}
+
+	if _, err := runCommand(t, "", "demo", dir); err == nil || !strings.Contains(err.Error(), "already has a .felt store") {
+		t.Fatalf("second demo into same dir: err = %v", err)
+	}
+}
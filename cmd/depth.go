@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/cailmdaley/felt/internal/felt"
 )
@@ -32,17 +33,26 @@ func validateDepth(d string) error {
 	return fmt.Errorf("invalid depth %q (valid: %s)", d, strings.Join(ValidDepths, ", "))
 }
 
-// renderFelt renders a felt at the given depth level.
-func renderFelt(f *felt.Felt, g *Graph, depth string, citations []felt.Citation, consumers []felt.DataFlowConsumer) string {
+// upstreamNotes is the data-flow ancestry show warns about: ancestors of
+// less than high confidence (felt.ShakyUpstreams) and invalidated ones
+// (felt.InvalidatedUpstreams).
+type upstreamNotes struct {
+	Shaky       []*felt.Felt
+	Invalidated []*felt.Felt
+}
+
+// renderFelt renders a felt at the given depth level. Only summary and full
+// render upstream.
+func renderFelt(f *felt.Felt, g *Graph, depth string, citations []felt.Citation, consumers []felt.DataFlowConsumer, upstream upstreamNotes) string {
 	switch depth {
 	case DepthName:
 		return renderName(f)
 	case DepthCompact:
 		return renderCompact(f)
 	case DepthSummary:
-		return renderSummary(f, g, citations, consumers)
+		return renderSummary(f, g, citations, consumers, upstream)
 	default:
-		return renderFull(f, g, citations, consumers)
+		return renderFull(f, g, citations, consumers, upstream)
 	}
 }
 
@@ -64,6 +74,21 @@ func writeHeader(sb *strings.Builder, f *felt.Felt) {
 	if len(f.Tags) > 0 {
 		fmt.Fprintf(sb, "Tags:     %s\n", strings.Join(f.Tags, ", "))
 	}
+	if impact := f.Impact(); impact != "" {
+		fmt.Fprintf(sb, "Impact:   %s\n", impact)
+	}
+	if c, ok := f.Confidence(); ok {
+		fmt.Fprintf(sb, "Confidence: %s\n", c)
+	}
+	if next := f.SupersededBy(); next != "" {
+		fmt.Fprintf(sb, "Superseded by: %s\n", next)
+	}
+	if prev := f.Supersedes(); prev != "" {
+		fmt.Fprintf(sb, "Supersedes: %s\n", prev)
+	}
+	if inv, ok := f.Invalidation(); ok {
+		fmt.Fprintf(sb, "Invalidated: %s (%s)\n", inv.Reason, displayTime(inv.At).Format("2006-01-02"))
+	}
 }
 
 func renderCompact(f *felt.Felt) string {
@@ -72,12 +97,16 @@ func renderCompact(f *felt.Felt) string {
 	if f.Outcome != "" {
 		fmt.Fprintf(&sb, "Outcome:  %s\n", f.Outcome)
 	}
+	if criteria := f.Acceptance(); len(criteria) > 0 {
+		fmt.Fprintf(&sb, "Acceptance: %d/%d met\n", felt.AcceptanceMet(criteria), len(criteria))
+	}
 	writeExtraFieldKeys(&sb, f)
 	return sb.String()
 }
 
-func renderSummary(f *felt.Felt, g *Graph, citations []felt.Citation, consumers []felt.DataFlowConsumer) string {
+func renderSummary(f *felt.Felt, g *Graph, citations []felt.Citation, consumers []felt.DataFlowConsumer, upstream upstreamNotes) string {
 	var sb strings.Builder
+	writeInvalidatedUpstreams(&sb, upstream.Invalidated)
 	writeHeader(&sb, f)
 	if f.Due != nil {
 		fmt.Fprintf(&sb, "Due:      %s\n", f.Due.Format("2006-01-02"))
@@ -85,36 +114,47 @@ func renderSummary(f *felt.Felt, g *Graph, citations []felt.Citation, consumers
 	if f.Outcome != "" {
 		fmt.Fprintf(&sb, "Outcome:  %s\n", f.Outcome)
 	}
+	writeAcceptance(&sb, f.Acceptance())
 	writeBodyRefs(&sb, f, g)
 	writeCitations(&sb, citations)
 	writeConsumers(&sb, consumers)
+	writeShakyUpstreams(&sb, upstream.Shaky)
 	writeExtraFieldKeys(&sb, f)
 	if f.Body != "" {
 		lede := extractLede(f.Body)
-		fmt.Fprintf(&sb, "\n%s\n", lede)
-		if remaining := len(f.Body) - len(lede); remaining > 0 {
+		// The lede can stop at a blank line inside a code fence; close it so
+		// the "more chars" marker is not read as code.
+		fmt.Fprintf(&sb, "\n%s\n", strings.TrimRight(closeOpenMarkdown(lede), "\n"))
+		if remaining := utf8.RuneCountInString(f.Body) - utf8.RuneCountInString(lede); remaining > 0 {
 			fmt.Fprintf(&sb, "[... %d more chars]\n", remaining)
 		}
 	}
 	return sb.String()
 }
 
-func renderFull(f *felt.Felt, g *Graph, citations []felt.Citation, consumers []felt.DataFlowConsumer) string {
+func renderFull(f *felt.Felt, g *Graph, citations []felt.Citation, consumers []felt.DataFlowConsumer, upstream upstreamNotes) string {
 	var sb strings.Builder
+	writeInvalidatedUpstreams(&sb, upstream.Invalidated)
 	writeHeader(&sb, f)
 	writeBodyRefs(&sb, f, g)
 	writeCitations(&sb, citations)
 	writeConsumers(&sb, consumers)
+	writeShakyUpstreams(&sb, upstream.Shaky)
 	if f.Due != nil {
 		fmt.Fprintf(&sb, "Due:      %s\n", f.Due.Format("2006-01-02"))
 	}
-	fmt.Fprintf(&sb, "Created:  %s\n", f.CreatedAt.Format("2006-01-02T15:04:05-07:00"))
+	fmt.Fprintf(&sb, "Created:  %s\n", displayTime(f.CreatedAt).Format("2006-01-02T15:04:05-07:00"))
+	if f.ActivatedAt != nil {
+		fmt.Fprintf(&sb, "Active:   %s\n", displayTime(*f.ActivatedAt).Format("2006-01-02T15:04:05-07:00"))
+	}
 	if f.ClosedAt != nil {
-		fmt.Fprintf(&sb, "Closed:   %s\n", f.ClosedAt.Format("2006-01-02T15:04:05-07:00"))
+		fmt.Fprintf(&sb, "Closed:   %s\n", displayTime(*f.ClosedAt).Format("2006-01-02T15:04:05-07:00"))
 	}
 	if f.Outcome != "" {
 		fmt.Fprintf(&sb, "Outcome:  %s\n", f.Outcome)
 	}
+	writeAcceptance(&sb, f.Acceptance())
+	writeCommits(&sb, f.Commits())
 	writeExtraFrontmatter(&sb, f)
 	if f.Body != "" {
 		fmt.Fprintf(&sb, "\n%s\n", f.Body)
@@ -122,6 +162,14 @@ func renderFull(f *felt.Felt, g *Graph, citations []felt.Citation, consumers []f
 	return sb.String()
 }
 
+// writeAcceptance renders the acceptance checklist, numbered as felt accept
+// takes it.
+func writeAcceptance(sb *strings.Builder, criteria []felt.Criterion) {
+	if len(criteria) > 0 {
+		sb.WriteString(formatAcceptance(criteria))
+	}
+}
+
 func writeExtraFieldKeys(sb *strings.Builder, f *felt.Felt) {
 	keys := f.ExtraFieldKeys()
 	if len(keys) == 0 {
@@ -145,6 +193,23 @@ func writeExtraFrontmatter(sb *strings.Builder, f *felt.Felt) {
 	}
 }
 
+// writeCommits renders the Commits section: the commits recorded through
+// Felt: trailers (felt setup git), newest first.
+func writeCommits(sb *strings.Builder, commits []felt.Commit) {
+	if len(commits) == 0 {
+		return
+	}
+	sb.WriteString("\nCommits:\n")
+	for i := len(commits) - 1; i >= 0; i-- {
+		c := commits[i]
+		sha := c.SHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		fmt.Fprintf(sb, "  %s  %s  %s\n", sha, displayTime(c.At).Format("2006-01-02"), c.Subject)
+	}
+}
+
 func writeCitations(sb *strings.Builder, citations []felt.Citation) {
 	if len(citations) == 0 {
 		return
@@ -156,7 +221,7 @@ func writeCitations(sb *strings.Builder, citations []felt.Citation) {
 			ref += "#" + citation.Fragment
 		}
 		if strings.TrimSpace(citation.SourceName) != "" {
-			ref += " (" + truncateTitle(citation.SourceName, refTitleMaxLen) + ")"
+			ref += " (" + truncateText(citation.SourceName, refTitleMaxLen) + ")"
 		}
 		parts = append(parts, ref)
 	}
@@ -174,7 +239,7 @@ func writeConsumers(sb *strings.Builder, consumers []felt.DataFlowConsumer) {
 			ref += "#" + consumer.InputID
 		}
 		if strings.TrimSpace(consumer.SourceName) != "" {
-			ref += " (" + truncateTitle(consumer.SourceName, refTitleMaxLen) + ")"
+			ref += " (" + truncateText(consumer.SourceName, refTitleMaxLen) + ")"
 		}
 		if consumer.OutputID != "" {
 			ref = consumer.OutputID + " \u2192 " + ref
@@ -184,6 +249,34 @@ func writeConsumers(sb *strings.Builder, consumers []felt.DataFlowConsumer) {
 	fmt.Fprintf(sb, "Consumed by: %s\n", strings.Join(parts, ", "))
 }
 
+// writeShakyUpstreams renders the "Rests on:" line: the less-than-high
+// confidence ancestors this fiber builds on, least confident first, so the
+// ground worth revisiting is named before the work that stands on it.
+func writeShakyUpstreams(sb *strings.Builder, shaky []*felt.Felt) {
+	if len(shaky) == 0 {
+		return
+	}
+	parts := make([]string, 0, len(shaky))
+	for _, f := range shaky {
+		c, _ := f.Confidence()
+		parts = append(parts, fmt.Sprintf("%s [%s]", f.ID, c))
+	}
+	fmt.Fprintf(sb, "Rests on: %s\n", strings.Join(parts, ", "))
+}
+
+// writeInvalidatedUpstreams renders a warning banner above the header for
+// each invalidated decision upstream, so work standing on a decision that
+// turned out wrong says so before anything else.
+func writeInvalidatedUpstreams(sb *strings.Builder, invalidated []*felt.Felt) {
+	for _, f := range invalidated {
+		inv, _ := f.Invalidation()
+		fmt.Fprintf(sb, "⚠ Upstream decision %s was invalidated: %s\n", f.ID, inv.Reason)
+	}
+	if len(invalidated) > 0 {
+		sb.WriteString("\n")
+	}
+}
+
 // writeBodyRefs extracts markdown and wikilinks from the body and renders them
 // as a "Refs:" line, annotating which ones resolve to known fibers.
 func writeBodyRefs(sb *strings.Builder, f *felt.Felt, g *Graph) {
@@ -210,7 +303,7 @@ func writeBodyRefs(sb *strings.Builder, f *felt.Felt, g *Graph) {
 					if ref.Fragment != "" {
 						label += "#" + ref.Fragment
 					}
-					parts = append(parts, fmt.Sprintf("%s (%s)", label, truncateTitle(node.DisplayName(), refTitleMaxLen)))
+					parts = append(parts, fmt.Sprintf("%s (%s)", label, truncateText(node.DisplayName(), refTitleMaxLen)))
 					continue
 				}
 			}
@@ -222,7 +315,7 @@ func writeBodyRefs(sb *strings.Builder, f *felt.Felt, g *Graph) {
 		parts = append(parts, ref.Target)
 		if g != nil {
 			if node, ok := g.Nodes[ref.Target]; ok {
-				parts[len(parts)-1] = fmt.Sprintf("%s (%s)", ref.Target, truncateTitle(node.DisplayName(), refTitleMaxLen))
+				parts[len(parts)-1] = fmt.Sprintf("%s (%s)", ref.Target, truncateText(node.DisplayName(), refTitleMaxLen))
 				continue
 			}
 		}
@@ -230,13 +323,6 @@ func writeBodyRefs(sb *strings.Builder, f *felt.Felt, g *Graph) {
 	fmt.Fprintf(sb, "Refs:     %s\n", strings.Join(parts, ", "))
 }
 
-func truncateTitle(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
-	}
-	return s[:maxLen-1] + "\u2026"
-}
-
 // extractLede extracts the first substantive paragraph from a body.
 // Skips a title-level heading (# ...) since it repeats the fiber name,
 // then takes the first section heading (if any) plus its first paragraph.
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"testing"
+	"unicode/utf8"
 )
 
 func TestExtractLede(t *testing.T) {
@@ -82,3 +83,31 @@ func TestValidateDepth(t *testing.T) {
 		t.Error("validateDepth(\"bogus\") should return error")
 	}
 }
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"fits", "short", 10, "short"},
+		{"runes not bytes", "größe größe größe", 8, "größe…"},
+		{"no break", "überprüfungsverfahren", 6, "überp…"},
+		{"sentence", "First finding holds. Second one is longer than the budget allows", 30, "First finding holds.…"},
+		{"word", "jackknife on one hundred fifty patches", 20, "jackknife on one…"},
+		{"inline code", "run `felt ls --sort last-read` first", 16, "run `felt ls`…"},
+		{"fence", "Setup:\n```\ngo test ./...\ngo vet ./...\n```", 30, "Setup:\n```\ngo test ./...\n```\n…"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateText(tt.in, tt.max)
+			if got != tt.want {
+				t.Fatalf("truncateText(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Fatalf("truncateText produced invalid UTF-8: %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [id]",
+	Short: "Show field-level changes to fibers since the last commit",
+	Long: `Compares fibers on disk with their last git-committed version and prints what
+changed field by field: scalar fields as old → new, list fields (tags,
+inputs) as the items added and removed, and the body as a unified diff.
+
+With an id, diffs that fiber. Without one, diffs every fiber file git sees
+as modified or untracked under .felt/ — a pre-commit review of what changed
+today. A fiber with no committed version is reported as new.`,
+	Example: `  felt diff
+  felt diff fit/prep
+  felt diff --json`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		feltDir := filepath.Join(root, felt.DirName)
+		if err := exec.Command("git", "-C", feltDir, "rev-parse", "--is-inside-work-tree").Run(); err != nil {
+			return fmt.Errorf("%s is not in a git repository", feltDir)
+		}
+
+		var ids []string
+		if len(args) == 1 {
+			f, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+			if err != nil {
+				return err
+			}
+			ids = []string{f.ID}
+		} else if ids, err = changedFiberIDs(feltDir); err != nil {
+			return err
+		}
+
+		var diffs []fiberDiff
+		for _, id := range ids {
+			d, err := diffAgainstHead(storage, id)
+			if err != nil {
+				return err
+			}
+			if d.New || len(d.Changes) > 0 {
+				diffs = append(diffs, d)
+			}
+		}
+		if jsonOutput {
+			return outputJSON(diffs)
+		}
+		if len(diffs) == 0 {
+			fmt.Println("No fiber changes since the last commit")
+			return nil
+		}
+		for i, d := range diffs {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(renderFiberDiff(d))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+}
+
+// fiberDiff is one fiber's changes since HEAD.
+type fiberDiff struct {
+	ID      string             `json:"id"`
+	New     bool               `json:"new,omitempty"`
+	Changes []felt.FieldChange `json:"changes"`
+}
+
+// diffAgainstHead compares the fiber id on disk with its HEAD version.
+func diffAgainstHead(storage *felt.Storage, id string) (fiberDiff, error) {
+	current, err := storage.Read(id)
+	if err != nil {
+		return fiberDiff{}, err
+	}
+	path := storage.Path(id)
+	committed, err := committedFiber(path, id, "HEAD")
+	if err != nil {
+		return fiberDiff{}, err
+	}
+	changes, err := felt.DiffFelts(committed, current)
+	if err != nil {
+		return fiberDiff{}, fmt.Errorf("%s: %w", id, err)
+	}
+	return fiberDiff{ID: id, New: committed == nil, Changes: changes}, nil
+}
+
+// committedFiber parses path as of the commit rev, body sidecar included,
+// or returns nil when rev has no such file.
+func committedFiber(path, id, rev string) (*felt.Felt, error) {
+	data, ok, err := gitShow(path, rev)
+	if err != nil || !ok {
+		return nil, err
+	}
+	f, err := felt.Parse(id, data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing committed %s: %w", id, err)
+	}
+	if name := f.BodyFile(); name != "" {
+		side, ok, err := gitShow(filepath.Join(filepath.Dir(path), name), rev)
+		if err != nil {
+			return nil, err
+		}
+		if external := strings.TrimSpace(string(side)); ok && external != "" {
+			if f.Body == "" {
+				f.Body = external
+			} else {
+				f.Body = external + "\n\n" + f.Body
+			}
+		}
+	}
+	return f, nil
+}
+
+// gitShow returns the contents of path as of the commit rev. ok is false
+// when rev does not have it — an untracked file, or a repository with no
+// commits yet.
+func gitShow(path, rev string) ([]byte, bool, error) {
+	c := exec.Command("git", "show", rev+":./"+filepath.Base(path))
+	c.Dir = filepath.Dir(path)
+	c.Env = append(os.Environ(), "LC_ALL=C") // the not-found messages below are matched in English
+	var stderr strings.Builder
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err == nil {
+		return out, true, nil
+	}
+	msg := stderr.String()
+	if strings.Contains(msg, "does not exist in '"+rev+"'") || strings.Contains(msg, "exists on disk, but not in '"+rev+"'") ||
+		strings.Contains(msg, "invalid object name '"+rev+"'") || strings.Contains(msg, "bad revision '"+rev+"'") {
+		return nil, false, nil
+	}
+	return nil, false, fmt.Errorf("git show %s: %s", path, strings.TrimSpace(msg))
+}
+
+// changedFiberIDs returns the fibers whose files under feltDir git reports
+// as modified since HEAD or untracked, sorted.
+func changedFiberIDs(feltDir string) ([]string, error) {
+	var paths []string
+	for _, args := range [][]string{
+		{"diff", "-z", "--name-only", "--relative", "HEAD", "--", "."},
+		{"ls-files", "-z", "--others", "--exclude-standard", "--", "."},
+	} {
+		c := exec.Command("git", args...)
+		c.Dir = feltDir
+		out, err := c.Output()
+		if err != nil {
+			if args[0] == "diff" {
+				// No HEAD yet: every fiber is untracked, which ls-files lists.
+				continue
+			}
+			return nil, fmt.Errorf("git %s: %w", args[0], err)
+		}
+		for _, rel := range strings.Split(string(out), "\x00") {
+			if rel != "" {
+				paths = append(paths, rel)
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, rel := range paths {
+		abs := filepath.Join(feltDir, filepath.FromSlash(rel))
+		if _, err := os.Stat(abs); err != nil {
+			continue // deleted since HEAD
+		}
+		_, id, ok := fiberFromEditedPath(abs)
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// renderFiberDiff renders one fiber's changes for the terminal.
+func renderFiberDiff(d fiberDiff) string {
+	var sb strings.Builder
+	if d.New {
+		fmt.Fprintf(&sb, "%s (new)\n", d.ID)
+	} else {
+		fmt.Fprintf(&sb, "%s\n", d.ID)
+	}
+	for _, c := range d.Changes {
+		switch {
+		case c.Field == "body":
+			sb.WriteString("  body:\n")
+			writeIndented(&sb, felt.UnifiedDiff(c.Old, c.New), "    ")
+		case c.Added != nil || c.Removed != nil:
+			fmt.Fprintf(&sb, "  %s:\n", c.Field)
+			for _, item := range c.Removed {
+				fmt.Fprintf(&sb, "    - %s\n", item)
+			}
+			for _, item := range c.Added {
+				fmt.Fprintf(&sb, "    + %s\n", item)
+			}
+		case !strings.Contains(c.Old, "\n") && !strings.Contains(c.New, "\n"):
+			fmt.Fprintf(&sb, "  %s: %s → %s\n", c.Field, diffValue(c.Old), diffValue(c.New))
+		default:
+			fmt.Fprintf(&sb, "  %s:\n", c.Field)
+			writeIndented(&sb, felt.UnifiedDiff(c.Old, c.New), "    ")
+		}
+	}
+	return sb.String()
+}
+
+func diffValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+func writeIndented(sb *strings.Builder, text, indent string) {
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		sb.WriteString(indent + line + "\n")
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestDiffShowsFieldChangesSinceCommit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitIn(t, dir, "init", "-q")
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatal(err)
+	}
+	prep := &felt.Felt{ID: "prep", Name: "Prep", Status: felt.StatusOpen, CreatedAt: time.Now()}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, Tags: []string{"draft"}, CreatedAt: time.Now(), Body: "First line.\nSecond line."}
+	for _, f := range []*felt.Felt{prep, fit} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitIn(t, dir, "add", "-A")
+	gitIn(t, dir, "commit", "-q", "-m", "fibers")
+
+	if out, err := runCommand(t, dir, "diff"); err != nil || !strings.Contains(out, "No fiber changes") {
+		t.Fatalf("clean diff = %v\n%s", err, out)
+	}
+
+	fit.Status = felt.StatusClosed
+	fit.Tags = []string{"fit"}
+	fit.Body = "First line.\nSecond line, revised."
+	if err := fit.AddDataFlowInput("prep"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Write(fit); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "fresh", Name: "Fresh", Status: felt.StatusOpen, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, dir, "diff", "fit")
+	if err != nil {
+		t.Fatalf("diff fit: %v", err)
+	}
+	for _, want := range []string{
+		"status: open → closed",
+		"  tags:\n    - draft\n    + fit\n",
+		"  inputs:\n    + {id: prep, from: prep}\n",
+		"    -Second line.\n    +Second line, revised.\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff fit missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCommand(t, dir, "diff")
+	if err != nil || !strings.Contains(out, "fit\n") || !strings.Contains(out, "fresh (new)") || strings.Contains(out, "\nprep\n") {
+		t.Fatalf("diff = %v\n%s", err, out)
+	}
+}
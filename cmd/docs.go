@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	docsDir    string
+	docsFormat string
+)
+
+// docsCmd is build tooling (make man, the release hooks), so it stays off
+// the visible command surface.
+var docsCmd = &cobra.Command{
+	Use:    "docs",
+	Short:  "Generate reference documentation from the command tree",
+	Hidden: true,
+}
+
+var docsGenerateCmd = &cobra.Command{
+	Use:   "generate [--dir man] [--format man|markdown]",
+	Short: "Write man pages (or markdown) for every command",
+	Long: `Renders one page per visible command from its cobra metadata — usage,
+long description, flags, examples, and subcommands — so the reference never
+drifts from the binary. Man pages are named felt.1, felt-add.1,
+felt-shuttle-status.1, ...; markdown pages use the same stems.`,
+	Example: `  felt docs generate --dir man
+  felt docs generate --format markdown --dir docs/reference`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var render func(*cobra.Command) string
+		var ext string
+		switch docsFormat {
+		case "man":
+			render, ext = renderManPage, ".1"
+		case "markdown", "md":
+			render, ext = renderMarkdownPage, ".md"
+		default:
+			return fmt.Errorf("unknown --format %q (want man or markdown)", docsFormat)
+		}
+		if err := os.MkdirAll(docsDir, 0755); err != nil {
+			return err
+		}
+		n := 0
+		var walk func(*cobra.Command) error
+		walk = func(c *cobra.Command) error {
+			if !docsVisible(c) {
+				return nil
+			}
+			path := filepath.Join(docsDir, docsPageStem(c)+ext)
+			if err := os.WriteFile(path, []byte(render(c)), 0644); err != nil {
+				return err
+			}
+			n++
+			for _, sub := range c.Commands() {
+				if err := walk(sub); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := walk(rootCmd); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %d %s to %s\n", n, pluralize(n, "page", "pages"), docsDir)
+		return nil
+	},
+}
+
+// docsVisible excludes hidden commands, help, and cobra's completion
+// scaffolding from generated reference pages.
+func docsVisible(c *cobra.Command) bool {
+	return !c.Hidden && c.IsAvailableCommand() || c == rootCmd
+}
+
+// docsPageStem is the command path joined by dashes: "felt-shuttle-status".
+func docsPageStem(c *cobra.Command) string {
+	return strings.ReplaceAll(c.CommandPath(), " ", "-")
+}
+
+func docsDescription(c *cobra.Command) string {
+	if c.Long != "" {
+		return c.Long
+	}
+	return c.Short
+}
+
+func docsSubcommands(c *cobra.Command) []*cobra.Command {
+	var subs []*cobra.Command
+	for _, sub := range c.Commands() {
+		if docsVisible(sub) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// renderManPage renders c as a section-1 roff man page.
+func renderManPage(c *cobra.Command) string {
+	var sb strings.Builder
+	name := strings.ToUpper(docsPageStem(c))
+	fmt.Fprintf(&sb, ".TH %q 1 %q \"felt %s\" \"felt manual\"\n", name, time.Now().UTC().Format("2006-01-02"), Version)
+	sb.WriteString(".SH NAME\n")
+	fmt.Fprintf(&sb, "%s \\- %s\n", docsPageStem(c), roffEscape(c.Short))
+	sb.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&sb, ".B %s\n", roffEscape(c.UseLine()))
+	sb.WriteString(".SH DESCRIPTION\n")
+	writeRoffBlock(&sb, docsDescription(c))
+	if flags := docsFlagLines(c.NonInheritedFlags()); len(flags) > 0 {
+		sb.WriteString(".SH OPTIONS\n")
+		for _, f := range flags {
+			// Flag dashes are real minus signs (\-), so they copy-paste.
+			fmt.Fprintf(&sb, ".TP\n.B %s\n%s\n", strings.ReplaceAll(roffEscape(f.spec), "-", `\-`), roffEscape(f.usage))
+		}
+	}
+	if c.Example != "" {
+		sb.WriteString(".SH EXAMPLES\n.nf\n")
+		sb.WriteString(roffEscape(c.Example))
+		sb.WriteString("\n.fi\n")
+	}
+	if subs := docsSubcommands(c); len(subs) > 0 {
+		sb.WriteString(".SH COMMANDS\n")
+		for _, sub := range subs {
+			fmt.Fprintf(&sb, ".TP\n.B %s\n%s\n", roffEscape(sub.Name()), roffEscape(sub.Short))
+		}
+	}
+	sb.WriteString(".SH SEE ALSO\n")
+	var see []string
+	if c.HasParent() {
+		see = append(see, docsPageStem(c.Parent())+"(1)")
+	}
+	for _, sub := range docsSubcommands(c) {
+		see = append(see, docsPageStem(sub)+"(1)")
+	}
+	if len(see) == 0 {
+		see = append(see, "felt(1)")
+	}
+	sb.WriteString(strings.Join(see, ", "))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// renderMarkdownPage renders c as a markdown reference page.
+func renderMarkdownPage(c *cobra.Command) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n%s\n\n", c.CommandPath(), c.Short)
+	fmt.Fprintf(&sb, "```\n%s\n```\n\n", c.UseLine())
+	if c.Long != "" {
+		fmt.Fprintf(&sb, "%s\n\n", strings.TrimSpace(c.Long))
+	}
+	if flags := docsFlagLines(c.NonInheritedFlags()); len(flags) > 0 {
+		sb.WriteString("## Options\n\n")
+		for _, f := range flags {
+			fmt.Fprintf(&sb, "- `%s` — %s\n", f.spec, f.usage)
+		}
+		sb.WriteString("\n")
+	}
+	if c.Example != "" {
+		fmt.Fprintf(&sb, "## Examples\n\n```\n%s\n```\n\n", c.Example)
+	}
+	if subs := docsSubcommands(c); len(subs) > 0 {
+		sb.WriteString("## Commands\n\n")
+		for _, sub := range subs {
+			fmt.Fprintf(&sb, "- [%s](%s.md) — %s\n", sub.Name(), docsPageStem(sub), sub.Short)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+type docsFlag struct{ spec, usage string }
+
+func docsFlagLines(flags *pflag.FlagSet) []docsFlag {
+	var out []docsFlag
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Name == "help" {
+			return
+		}
+		spec := "--" + f.Name
+		if f.Shorthand != "" {
+			spec = "-" + f.Shorthand + ", " + spec
+		}
+		if f.Value.Type() != "bool" {
+			spec += " " + f.Value.Type()
+		}
+		out = append(out, docsFlag{spec: spec, usage: f.Usage})
+	})
+	return out
+}
+
+// writeRoffBlock writes text as roff paragraphs, keeping indented lines
+// (command sketches in Long text) verbatim.
+func writeRoffBlock(sb *strings.Builder, text string) {
+	for _, para := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if strings.HasPrefix(para, " ") || strings.Contains(para, "\n ") {
+			sb.WriteString(".PP\n.nf\n")
+			sb.WriteString(roffEscape(para))
+			sb.WriteString("\n.fi\n")
+			continue
+		}
+		sb.WriteString(".PP\n")
+		sb.WriteString(roffEscape(para))
+		sb.WriteString("\n")
+	}
+}
+
+// roffEscape escapes backslashes and guards lines that roff would read as
+// requests (leading "." or "'").
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var helpExamples bool
+
+// helpCmd replaces cobra's default help command to add --examples, which
+// prints only a command's runnable example block.
+var helpCmd = &cobra.Command{
+	Use:   "help [command]",
+	Short: "Help about any command",
+	Long: `Help provides help for any command in the application.
+Simply type felt help [path to command] for full details.
+With --examples, print only the command's usage examples.`,
+	RunE: func(c *cobra.Command, args []string) error {
+		target, _, err := rootCmd.Find(args)
+		if target == nil || err != nil {
+			c.Printf("Unknown help topic %#q\n", args)
+			return rootCmd.Usage()
+		}
+		if helpExamples {
+			if target.Example == "" {
+				return fmt.Errorf("no examples for %s; see felt help %s", target.CommandPath(), strings.Join(args, " "))
+			}
+			fmt.Println(target.Example)
+			return nil
+		}
+		target.InitDefaultHelpFlag()
+		target.InitDefaultVersionFlag()
+		return target.Help()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+	docsCmd.AddCommand(docsGenerateCmd)
+	docsGenerateCmd.Flags().StringVar(&docsDir, "dir", "man", "Output directory")
+	docsGenerateCmd.Flags().StringVar(&docsFormat, "format", "man", "Page format: man or markdown")
+
+	helpCmd.Flags().BoolVar(&helpExamples, "examples", false, "Print only the command's usage examples")
+	rootCmd.SetHelpCommand(helpCmd)
+}
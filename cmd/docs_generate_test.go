@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDocsGenerateManPages(t *testing.T) {
+	out := t.TempDir()
+	prevDir, prevFormat := docsDir, docsFormat
+	defer func() { docsDir, docsFormat = prevDir, prevFormat }()
+
+	if _, err := runCommand(t, t.TempDir(), "docs", "generate", "--dir", out, "--format", "man"); err != nil {
+		t.Fatalf("docs generate: %v", err)
+	}
+	page, err := os.ReadFile(filepath.Join(out, "felt-add.1"))
+	if err != nil {
+		t.Fatalf("felt-add.1 missing: %v", err)
+	}
+	for _, want := range []string{".TH \"FELT-ADD\" 1", ".SH SYNOPSIS", ".SH EXAMPLES", `felt add mocks-unbiased "Are the mocks unbiased?"`, ".B \\-\\-body"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("felt-add.1 missing %q:\n%s", want, page)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(out, "felt-shuttle-status.1")); err != nil {
+		t.Errorf("nested command page missing: %v", err)
+	}
+	for _, hidden := range []string{"felt-docs.1", "felt-help.1", "felt-completion.1"} {
+		if _, err := os.Stat(filepath.Join(out, hidden)); err == nil {
+			t.Errorf("%s should not be generated", hidden)
+		}
+	}
+}
+
+func TestHelpExamplesPrintsExampleBlock(t *testing.T) {
+	prev := helpExamples
+	defer func() { helpExamples = prev }()
+
+	out, err := runCommand(t, t.TempDir(), "help", "add", "--examples")
+	helpExamples = false
+	if err != nil || !strings.Contains(out, `felt add pure_eb/covariance "Covariance method"`) || strings.Contains(out, "Flags:") {
+		t.Fatalf("help add --examples = %v\n%s", err, out)
+	}
+	if _, err := runCommand(t, t.TempDir(), "help", "init", "--examples"); err == nil {
+		t.Fatal("help --examples on a command without examples should fail")
+	}
+	helpExamples = false
+}
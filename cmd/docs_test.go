@@ -73,6 +73,7 @@ func TestRootCommandSurfaceIsConsolidated(t *testing.T) {
 		"init",
 		"ls",
 		"migrate",
+		"milestone",
 		"nest",
 		"rm",
 		"session",
@@ -99,24 +100,63 @@ func TestRootCommandSurfaceIsConsolidated(t *testing.T) {
 	// absorbed from the standalone shuttle-ctl. It groups all `felt shuttle
 	// <verb>` dispatch verbs so the top-level surface stays about notes.
 	expectedVisible := []string{
+		"accept",
 		"add",
+		"apply",
+		"artifact",
 		"backfill-ids",
+		"blame",
+		"body",
+		"cal",
 		"check",
+		"cite",
+		"demo",
+		"diff",
+		"doctor",
 		"edit",
+		"forecast",
+		"handoff",
 		"hook",
+		"in",
+		"index",
+		"ingest",
 		"init",
+		"invalidate",
+		"knowledge",
 		"ls",
+		"matrix",
+		"merge",
 		"migrate",
+		"milestone",
 		"nest",
+		"order",
+		"release",
+		"report",
+		"review",
 		"rm",
+		"run",
+		"serve",
 		"session",
 		"setup",
+		"share",
 		"show",
 		"shuttle",
+		"snooze",
+		"sprint",
+		"stats",
+		"supersede",
+		"sync",
+		"targets",
+		"telemetry",
+		"timeline",
+		"today",
 		"tree",
+		"triage",
 		"uninstall",
 		"unnest",
 		"update",
+		"watch",
+		"workload",
 	}
 	slices.Sort(visible)
 	visible = slices.DeleteFunc(visible, func(name string) bool { return name == "help" })
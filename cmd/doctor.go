@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var doctorFix bool
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [--fix]",
+	Short: "Find and repair store damage: sync conflicts, duplicate ids, broken files",
+	Long: `Where check lints fibers, doctor looks for damage to the store itself.
+
+Conflicted copies: Dropbox, Nextcloud, and Syncthing keep both versions of
+a file edited on two machines at once, as "fiber (conflicted copy).md" or
+"fiber.sync-conflict-<date>-<time>-<device>.md". felt never reads these as
+fibers; doctor lists them, and --fix walks through each one:
+
+  [k]eep    keep the current version, discard the copy
+  [t]ake    replace the current version with the copy
+  [m]erge   merge field by field: fields in one version are kept, and
+            differing fields take the version with the later updated-at
+  [s]kip    leave both for now
+
+Duplicate ids: a fiber directory copied by hand carries the original's
+frontmatter id, so two fibers claim one identity. --fix keeps the id on the
+oldest fiber and mints a new one for each copy.
+
+Non-UTC and zoneless timestamps: felt writes instants in UTC, but fibers
+last written by an older felt carry whatever zone that machine was in, and
+hand-written ones may carry none ("2026-04-10 09:00"), which felt reads as
+UTC. --fix rewrites both in UTC; the instants felt reads do not change.
+
+Missing created-at: --fix restores it from the file's modification time.
+
+Doctor also reports what it cannot safely repair, for a hand to fix:
+fiber files whose frontmatter does not parse (listings skip them), fiber
+frontmatter misfiled where no fiber is read from — a <dir>/<name>.md in a
+directory with no <dir>/<dir>.md, as after renaming the directory — tracked
+fibers sharing a name, inputs[].from that resolve to no fiber, and ids
+differing only in case, which name one file on a case-insensitive
+filesystem (macOS). Ids not in canonical lower case are listed too; felt
+migrate lowercases them. So are fibers whose filenames felt would not write:
+longer than the 200-byte slug limit, or with spaces or punctuation in them.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		report, err := gatherDoctorReport(storage)
+		if err != nil {
+			return err
+		}
+		if jsonOutput && !doctorFix {
+			return outputJSON(report)
+		}
+		if report.empty() {
+			fmt.Println("Doctor OK")
+			return nil
+		}
+		if !doctorFix {
+			for _, c := range report.Conflicts {
+				fmt.Println(c.String())
+			}
+			for _, d := range report.DuplicateIDs {
+				fmt.Printf("duplicate id %s on %s\n", d.UID, strings.Join(d.IDs, ", "))
+			}
+			for _, id := range report.NonUTCTimestamps {
+				fmt.Printf("non-UTC timestamps in %s\n", id)
+			}
+			for _, id := range report.ZonelessTimestamps {
+				fmt.Printf("zoneless timestamps in %s\n", id)
+			}
+			for _, id := range report.MissingCreatedAt {
+				fmt.Printf("no created-at on %s\n", id)
+			}
+			printDoctorManual(report)
+			if report.fixable() == 0 {
+				return fmt.Errorf("%s; fix by hand", report.summary())
+			}
+			return fmt.Errorf("%s; run felt doctor --fix to resolve", report.summary())
+		}
+
+		in := bufio.NewReader(cmd.InOrStdin())
+		remaining := 0
+		for _, c := range report.Conflicts {
+			resolution, err := promptConflictResolution(storage, c, in)
+			if err != nil {
+				return err
+			}
+			if resolution == "" {
+				remaining++
+				continue
+			}
+			if err := storage.ResolveSyncConflict(c, resolution); err != nil {
+				return err
+			}
+			fmt.Printf("Resolved %s (%s)\n", c.Path, resolution)
+		}
+		// The oldest fiber keeps a duplicated id; every later copy gets a new one.
+		for _, d := range report.DuplicateIDs {
+			for _, id := range d.IDs[1:] {
+				uid, err := storage.RemintUID(id)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("Reminted id on %s: %s → %s (kept on %s)\n", id, d.UID, uid, d.IDs[0])
+			}
+		}
+		for _, id := range report.MissingCreatedAt {
+			created, err := storage.RestoreCreatedAt(id)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Restored created-at on %s: %s\n", id, created.Format(time.RFC3339))
+		}
+		zoned := append(append([]string{}, report.NonUTCTimestamps...), report.ZonelessTimestamps...)
+		for _, id := range zoned {
+			if err := storage.NormalizeTimestamps(id); err != nil {
+				return err
+			}
+		}
+		if len(zoned) > 0 {
+			fmt.Printf("Normalized timestamps to UTC in %d %s\n", len(zoned), pluralize(len(zoned), "fiber", "fibers"))
+		}
+		if remaining > 0 {
+			return fmt.Errorf("%d conflicted %s left unresolved", remaining, pluralize(remaining, "copy", "copies"))
+		}
+		if manual := report.manual(); manual > 0 {
+			printDoctorManual(report)
+			return fmt.Errorf("%d %s left to fix by hand", manual, pluralize(manual, "problem", "problems"))
+		}
+		return nil
+	},
+}
+
+// doctorReport is doctor's --json output. The first five kinds --fix
+// repairs; the rest it only reports.
+type doctorReport struct {
+	Conflicts    []felt.SyncConflict `json:"conflicts"`
+	DuplicateIDs []felt.DuplicateUID `json:"duplicate_ids"`
+	// NonUTCTimestamps lists fibers last written with local-zone instants.
+	NonUTCTimestamps []string `json:"non_utc_timestamps"`
+	// ZonelessTimestamps lists fibers with an instant written with no zone.
+	ZonelessTimestamps []string              `json:"zoneless_timestamps"`
+	MissingCreatedAt   []string              `json:"missing_created_at"`
+	Malformed          []felt.MalformedFiber `json:"malformed"`
+	Misfiled           []felt.MisfiledFiber  `json:"misfiled"`
+	DuplicateNames     []felt.DuplicateName  `json:"duplicate_names"`
+	DanglingInputs     []felt.DanglingInput  `json:"dangling_inputs"`
+	CaseCollisions     []felt.CaseCollision  `json:"case_collisions"`
+	NonCanonicalIDs    []string              `json:"non_canonical_ids"`
+	Filenames          []felt.FilenameIssue  `json:"non_canonical_filenames"`
+}
+
+// gatherDoctorReport runs every doctor finder against storage.
+func gatherDoctorReport(storage *felt.Storage) (*doctorReport, error) {
+	r := &doctorReport{}
+	var err error
+	if r.Conflicts, err = storage.FindSyncConflicts(); err != nil {
+		return nil, err
+	}
+	if r.DuplicateIDs, err = storage.FindDuplicateUIDs(); err != nil {
+		return nil, err
+	}
+	if r.NonUTCTimestamps, err = storage.FindNonUTCTimestamps(); err != nil {
+		return nil, err
+	}
+	if r.ZonelessTimestamps, err = storage.FindZonelessTimestamps(); err != nil {
+		return nil, err
+	}
+	if r.Malformed, err = storage.FindMalformedFibers(); err != nil {
+		return nil, err
+	}
+	if r.Misfiled, err = storage.FindMisfiledFibers(); err != nil {
+		return nil, err
+	}
+	felts, err := storage.List()
+	if err != nil {
+		return nil, err
+	}
+	r.MissingCreatedAt = felt.MissingCreatedAt(felts)
+	r.DuplicateNames = felt.DuplicateNames(felts)
+	r.DanglingInputs = felt.DanglingInputs(felts)
+	r.CaseCollisions = felt.CaseCollisions(felts)
+	r.NonCanonicalIDs = felt.NonCanonicalIDs(felts)
+	r.Filenames = felt.NonCanonicalFilenames(felts)
+	return r, nil
+}
+
+// fixable counts the problems --fix repairs.
+func (r *doctorReport) fixable() int {
+	return len(r.Conflicts) + len(r.DuplicateIDs) + len(r.NonUTCTimestamps) + len(r.ZonelessTimestamps) + len(r.MissingCreatedAt)
+}
+
+// manual counts the problems doctor only reports.
+func (r *doctorReport) manual() int {
+	return len(r.Malformed) + len(r.Misfiled) + len(r.DuplicateNames) + len(r.DanglingInputs) + len(r.CaseCollisions) + len(r.NonCanonicalIDs) + len(r.Filenames)
+}
+
+func (r *doctorReport) empty() bool {
+	return r.fixable() == 0 && r.manual() == 0
+}
+
+func (r *doctorReport) summary() string {
+	var parts []string
+	add := func(n int, what string) {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, what))
+		}
+	}
+	add(len(r.Conflicts), "conflicted "+pluralize(len(r.Conflicts), "copy", "copies"))
+	add(len(r.DuplicateIDs), "duplicate "+pluralize(len(r.DuplicateIDs), "id", "ids"))
+	add(len(r.NonUTCTimestamps), pluralize(len(r.NonUTCTimestamps), "fiber", "fibers")+" with non-UTC timestamps")
+	add(len(r.ZonelessTimestamps), pluralize(len(r.ZonelessTimestamps), "fiber", "fibers")+" with zoneless timestamps")
+	add(len(r.MissingCreatedAt), pluralize(len(r.MissingCreatedAt), "fiber", "fibers")+" without created-at")
+	add(len(r.Malformed), "malformed "+pluralize(len(r.Malformed), "file", "files"))
+	add(len(r.Misfiled), "misfiled "+pluralize(len(r.Misfiled), "fiber", "fibers"))
+	add(len(r.DuplicateNames), "duplicate "+pluralize(len(r.DuplicateNames), "name", "names"))
+	add(len(r.DanglingInputs), "dangling "+pluralize(len(r.DanglingInputs), "input", "inputs"))
+	add(len(r.CaseCollisions), "case "+pluralize(len(r.CaseCollisions), "collision", "collisions"))
+	add(len(r.NonCanonicalIDs), "non-canonical "+pluralize(len(r.NonCanonicalIDs), "id", "ids"))
+	add(len(r.Filenames), "non-canonical "+pluralize(len(r.Filenames), "filename", "filenames"))
+	return strings.Join(parts, ", ")
+}
+
+// printDoctorManual lists the problems --fix leaves alone.
+func printDoctorManual(r *doctorReport) {
+	for _, m := range r.Malformed {
+		fmt.Printf("malformed frontmatter in %s: %s\n", m.Path, m.Error)
+	}
+	for _, m := range r.Misfiled {
+		fmt.Printf("misfiled fiber %s (felt reads %s)\n", m.Path, m.Want)
+	}
+	for _, d := range r.DuplicateNames {
+		fmt.Printf("duplicate name %q on %s\n", d.Name, strings.Join(d.IDs, ", "))
+	}
+	for _, d := range r.DanglingInputs {
+		fmt.Printf("dangling input on %s: %s resolves to no fiber\n", d.ID, d.From)
+	}
+	for _, c := range r.CaseCollisions {
+		fmt.Printf("ids differ only in case: %s\n", strings.Join(c.IDs, ", "))
+	}
+	for _, id := range r.NonCanonicalIDs {
+		fmt.Printf("id not lowercase: %s (felt migrate renames it)\n", id)
+	}
+	for _, f := range r.Filenames {
+		fmt.Printf("filename of %s %s (felt would name it %s)\n", f.ID, f.Reason, f.Want)
+	}
+}
+
+// promptConflictResolution shows c and reads a choice; "" means skip. End of
+// input skips the rest rather than guessing.
+func promptConflictResolution(storage *felt.Storage, c felt.SyncConflict, in *bufio.Reader) (string, error) {
+	fmt.Println(c.String())
+	options := "[k]eep current, [t]ake copy, [s]kip"
+	if c.IsFiber {
+		if fields, err := storage.SyncConflictFields(c); err == nil {
+			if len(fields) == 0 {
+				fmt.Println("  identical to the current version")
+			} else {
+				fmt.Printf("  differs in: %s\n", strings.Join(fields, ", "))
+			}
+		}
+		options = "[k]eep current, [t]ake copy, [m]erge fields, [s]kip"
+	}
+	for {
+		fmt.Printf("  %s? ", options)
+		line, err := in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "k", "keep":
+			return felt.ConflictKeep, nil
+		case "t", "take":
+			return felt.ConflictTake, nil
+		case "m", "merge":
+			if c.IsFiber {
+				return felt.ConflictMerge, nil
+			}
+		case "s", "skip":
+			return "", nil
+		}
+		if err == io.EOF {
+			fmt.Println()
+			return "", nil
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Resolve conflicted copies interactively, re-mint duplicate ids, normalize timestamps, and restore missing created-at")
+}
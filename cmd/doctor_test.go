@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestDoctorFixResolvesConflictedCopies(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	for _, id := range []string{"alpha", "beta"} {
+		if err := storage.Write(&felt.Felt{ID: id, Name: id, CreatedAt: created}); err != nil {
+			t.Fatal(err)
+		}
+		copyPath := filepath.Join(dir, felt.DirName, id, id+" (conflicted copy).md")
+		if err := os.WriteFile(copyPath, []byte("---\nname: "+id+" copy\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prevFix, prevJSON := doctorFix, jsonOutput
+	defer func() { doctorFix, jsonOutput = prevFix, prevJSON }()
+	doctorFix, jsonOutput = false, false
+
+	out, err := runCommand(t, dir, "doctor")
+	if err == nil || !strings.Contains(err.Error(), "2 conflicted copies") {
+		t.Fatalf("doctor err = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "alpha/alpha (conflicted copy).md (conflicted copy of alpha/alpha.md)") {
+		t.Fatalf("doctor output:\n%s", out)
+	}
+
+	// Take alpha's copy; skip beta, then fail on the one left.
+	rootCmd.SetIn(strings.NewReader("t\ns\n"))
+	defer rootCmd.SetIn(nil)
+	out, err = runCommand(t, dir, "doctor", "--fix")
+	if err == nil || !strings.Contains(err.Error(), "1 conflicted copy left") {
+		t.Fatalf("doctor --fix err = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "differs in: created-at, name") || !strings.Contains(out, "Resolved alpha/alpha (conflicted copy).md (take)") {
+		t.Fatalf("doctor --fix output:\n%s", out)
+	}
+	if f, err := storage.Read("alpha"); err != nil || f.Name != "alpha copy" {
+		t.Fatalf("alpha after take = %+v, %v", f, err)
+	}
+	if f, err := storage.Read("beta"); err != nil || f.Name != "beta" {
+		t.Fatalf("beta should be untouched = %+v, %v", f, err)
+	}
+}
+
+func TestDoctorRemintsDuplicateIDs(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	const uid = "01KTC9C1G1CBJ84H6WB92J8A13"
+	for i, id := range []string{"copy", "original"} {
+		created := mustParseTime(t, "2026-04-10T09:00:00Z").AddDate(0, 0, -i)
+		if err := storage.Write(&felt.Felt{ID: id, UID: uid, Name: id, CreatedAt: created}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prevFix, prevJSON := doctorFix, jsonOutput
+	defer func() { doctorFix, jsonOutput = prevFix, prevJSON }()
+	doctorFix, jsonOutput = false, false
+
+	out, err := runCommand(t, dir, "doctor")
+	if err == nil || !strings.Contains(err.Error(), "1 duplicate id;") {
+		t.Fatalf("doctor err = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "duplicate id "+uid+" on original, copy") {
+		t.Fatalf("doctor output:\n%s", out)
+	}
+
+	out, err = runCommand(t, dir, "doctor", "--fix")
+	if err != nil {
+		t.Fatalf("doctor --fix: %v\n%s", err, out)
+	}
+	original, _ := storage.Read("original")
+	copied, _ := storage.Read("copy")
+	if original.UID != uid || copied.UID == uid || !felt.LooksLikeUID(copied.UID) {
+		t.Fatalf("after fix: original=%s copy=%s", original.UID, copied.UID)
+	}
+	doctorFix = false
+	if out, err := runCommand(t, dir, "doctor"); err != nil || !strings.Contains(out, "Doctor OK") {
+		t.Fatalf("doctor after fix: %v\n%s", err, out)
+	}
+}
+
+func TestDoctorNormalizesZonedTimestamps(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	path := storage.Path("zoned")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	raw := "---\nname: Zoned\ncreated-at: 2026-04-10T11:00:00+02:00\nupdated-at: 2026-04-11T08:30:00-07:00\n---\n\nBody.\n"
+	if err := os.WriteFile(path, []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prevFix, prevJSON := doctorFix, jsonOutput
+	defer func() { doctorFix, jsonOutput = prevFix, prevJSON }()
+	doctorFix, jsonOutput = false, false
+
+	out, err := runCommand(t, dir, "doctor")
+	if err == nil || !strings.Contains(err.Error(), "1 fiber with non-UTC timestamps") || !strings.Contains(out, "non-UTC timestamps in zoned") {
+		t.Fatalf("doctor err = %v\n%s", err, out)
+	}
+	if out, err := runCommand(t, dir, "doctor", "--fix"); err != nil {
+		t.Fatalf("doctor --fix: %v\n%s", err, out)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"created-at: 2026-04-10T09:00:00Z", "updated-at: 2026-04-11T15:30:00Z", "Body."} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("normalized file missing %q:\n%s", want, data)
+		}
+	}
+}
+
+func TestDoctorFixRestoresCreatedAtAndReportsManualProblems(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	write := func(id, raw string) string {
+		t.Helper()
+		path := storage.Path(id)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(raw), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	undated := write("undated", "---\nname: Undated\n---\n")
+	mtime := mustParseTime(t, "2026-03-01T12:00:00Z")
+	if err := os.Chtimes(undated, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	local := write("local", "---\nname: Local\ncreated-at: 2026-04-10 09:00:00\n---\n")
+	write("reader", "---\nname: Reader\nstatus: open\ncreated-at: 2026-04-10T09:00:00Z\ninputs:\n  - {id: gone, from: gone}\n---\n")
+	prevFix, prevJSON := doctorFix, jsonOutput
+	defer func() { doctorFix, jsonOutput = prevFix, prevJSON }()
+	doctorFix, jsonOutput = false, false
+
+	out, err := runCommand(t, dir, "doctor")
+	if err == nil || !strings.Contains(err.Error(), "1 fiber with zoneless timestamps, 1 fiber without created-at, 1 dangling input") {
+		t.Fatalf("doctor err = %v\n%s", err, out)
+	}
+	for _, want := range []string{"zoneless timestamps in local", "no created-at on undated", "dangling input on reader: gone resolves to no fiber"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("doctor output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCommand(t, dir, "doctor", "--fix")
+	if err == nil || !strings.Contains(err.Error(), "1 problem left to fix by hand") {
+		t.Fatalf("doctor --fix err = %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Restored created-at on undated: 2026-03-01T12:00:00Z") {
+		t.Fatalf("doctor --fix output:\n%s", out)
+	}
+	if f, err := storage.Read("undated"); err != nil || !f.CreatedAt.Equal(mtime) {
+		t.Fatalf("undated after fix = %+v, %v", f, err)
+	}
+	data, err := os.ReadFile(local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "created-at: 2026-04-10T09:00:00Z") {
+		t.Fatalf("zoneless timestamp not pinned to UTC:\n%s", data)
+	}
+}
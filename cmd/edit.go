@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -12,15 +13,24 @@ import (
 
 // Edit command flags
 var (
-	editName    string
-	editStatus  string
-	editDue     string
-	editTags    []string
-	editUntag   []string
-	editBody    string
-	editOutcome string
-	editSet     []string
-	editUnset   []string
+	editName     string
+	editStatus   string
+	editDue      string
+	editTags     []string
+	editUntag    []string
+	editBody     string
+	editBodyFile string
+	editAppend   string
+	editPatch    bool
+	editOutcome  string
+	editSet      []string
+	editUnset    []string
+	editOwner    string
+	editImpact   string
+
+	editActivateNext string
+	editPropagate    bool
+	editReopen       bool
 )
 
 var editCmd = &cobra.Command{
@@ -28,18 +38,38 @@ var editCmd = &cobra.Command{
 	Short: "Modify a felt's native metadata via flags",
 	Long: `Modifies a felt's native metadata via flags.
 
-Examples:
-  felt edit abc123 --name "New name" -s active
+Closing a fiber lists the open fibers it unblocked: data-flow consumers
+(inputs[].from) whose other inputs have all closed. --activate-next sets the
+first of them active; --activate-next=<id> picks a specific one. Closing
+with acceptance criteria still unticked (felt accept) warns but goes ahead.
+
+--propagate, also with --status closed, appends "Upstream closed: <id> —
+<outcome first line>" to the body of each direct data-flow consumer that is
+not itself closed, so downstream context is self-contained when picked up.
+
+--status changes are checked against status.transitions in
+.felt/config.yaml when it is set (e.g. closed: [] keeps closed work
+closed). --reopen moves a closed fiber back to open whatever it says: the
+deliberate way back.
+
+--set/--unset write top-level scalar frontmatter felt does not parse natively
+(the value is read as a YAML scalar, so true/false/123 keep their type). Native
+keys have dedicated flags; use those.`,
+	Example: `  felt edit abc123 --name "New name" -s active
   felt edit abc123 --tag decision --untag stale
   felt edit abc123 --body "Full replacement body text"  # overwrites body
+  felt edit abc123 --body-file notes.md                 # overwrites body from a file (- for stdin)
+  felt edit abc123 --append-body "New finding."          # adds a paragraph at the end
+  diff -u old.md new.md | felt edit abc123 --patch-body  # applies a unified diff to the body
   felt edit abc123 --outcome "What landed"
+  felt edit abc123 --owner ana                      # who is responsible ("" clears)
+  felt edit abc123 --impact high                    # how much it hurts ("" clears)
   felt edit abc123 --set horizon=stashed --set cold=true  # opaque scalar frontmatter
   felt edit abc123 --unset horizon --unset cold
-
---set/--unset write top-level scalar frontmatter felt does not parse natively
-(the value is read as a YAML scalar, so true/false/123 keep their type). Native
-keys have dedicated flags; use those.`,
-	Args: cobra.ExactArgs(1),
+  felt edit abc123 -s closed --activate-next       # close, then start what it unblocked
+  felt edit abc123 --reopen                        # back to open, whatever status.transitions says`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := resolveProjectRoot()
 		if err != nil {
@@ -62,13 +92,61 @@ keys have dedicated flags; use those.`,
 			return fmt.Errorf("no changes requested: use edit flags (use --body only when you intend to overwrite the full body)")
 		}
 
+		for _, name := range []string{"activate-next", "propagate"} {
+			if cmd.Flags().Changed(name) && editStatus != felt.StatusClosed {
+				return fmt.Errorf("--%s requires --status closed", name)
+			}
+		}
+
+		if editReopen {
+			if cmd.Flags().Changed("status") {
+				return fmt.Errorf("--reopen and --status are mutually exclusive")
+			}
+			if !f.IsClosed() {
+				return fmt.Errorf("%s is not closed", f.ID)
+			}
+		}
+		cfg, err := storage.LoadConfig()
+		if err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("body-file") {
+			if cmd.Flags().Changed("body") {
+				return fmt.Errorf("--body and --body-file are mutually exclusive")
+			}
+			if editBody, err = readTextInput(cmd, "--body-file", editBodyFile); err != nil {
+				return err
+			}
+		}
+		bodyReplaced := cmd.Flags().Changed("body") || cmd.Flags().Changed("body-file")
+		bodyModes := 0
+		for _, name := range []string{"append-body", "patch-body"} {
+			if cmd.Flags().Changed(name) {
+				bodyModes++
+			}
+		}
+		if bodyModes > 1 || (bodyModes == 1 && bodyReplaced) {
+			return fmt.Errorf("body flags are mutually exclusive: choose one of --body, --body-file, --append-body, or --patch-body")
+		}
+
 		bodyOverwritten := false
 		bodyCleared := false
+		closing := false
 
 		if cmd.Flags().Changed("name") {
 			f.Name = editName
 		}
+		if editReopen {
+			f.Status = felt.StatusOpen
+			f.ClosedAt = nil
+			f.NoteStatusChange(felt.StatusClosed, time.Now())
+		}
 		if cmd.Flags().Changed("status") {
+			prevStatus := f.Status
+			if err := cfg.CheckStatusTransition(prevStatus, editStatus); err != nil && isStatusValue(editStatus) {
+				return fmt.Errorf("%w; --reopen moves closed work back to open", err)
+			}
 			switch editStatus {
 			case felt.StatusOpen, felt.StatusActive:
 				if f.IsClosed() {
@@ -77,6 +155,7 @@ keys have dedicated flags; use those.`,
 				f.Status = editStatus
 			case felt.StatusClosed:
 				if !f.IsClosed() {
+					closing = true
 					now := time.Now()
 					f.Status = felt.StatusClosed
 					f.ClosedAt = &now
@@ -87,8 +166,9 @@ keys have dedicated flags; use those.`,
 			default:
 				return fmt.Errorf("invalid status %q (valid: open, active, closed, or empty to clear)", editStatus)
 			}
+			f.NoteStatusChange(prevStatus, time.Now())
 		}
-		if cmd.Flags().Changed("body") {
+		if bodyReplaced {
 			if f.Body != "" && editBody != f.Body && !f.HasEmptyBody() {
 				bodyOverwritten = true
 			}
@@ -97,6 +177,23 @@ keys have dedicated flags; use those.`,
 			}
 			f.Body = editBody
 		}
+		if cmd.Flags().Changed("append-body") {
+			if strings.TrimSpace(editAppend) == "" {
+				return fmt.Errorf("--append-body is empty")
+			}
+			f.Body = appendBodyText(f.Body, editAppend)
+		}
+		if editPatch {
+			patch, err := readTextInput(cmd, "--patch-body", "-")
+			if err != nil {
+				return err
+			}
+			patched, err := felt.ApplyUnifiedPatch(f.Body, patch)
+			if err != nil {
+				return fmt.Errorf("--patch-body: %w", err)
+			}
+			f.Body = patched
+		}
 		if cmd.Flags().Changed("outcome") {
 			f.Outcome = editOutcome
 		}
@@ -104,13 +201,27 @@ keys have dedicated flags; use those.`,
 			if editDue == "" {
 				f.Due = nil
 			} else {
-				due, err := time.Parse("2006-01-02", editDue)
+				due, err := felt.ParseDateExpr(editDue, time.Now())
 				if err != nil {
-					return fmt.Errorf("invalid due date (use YYYY-MM-DD): %w", err)
+					return fmt.Errorf("invalid due date: %w", err)
 				}
 				f.Due = &due
 			}
 		}
+		if cmd.Flags().Changed("owner") {
+			owner, err := resolveOwnerFlag(storage, editOwner)
+			if err != nil {
+				return err
+			}
+			if err := f.SetOwner(owner); err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed("impact") {
+			if err := f.SetImpact(editImpact); err != nil {
+				return err
+			}
+		}
 		if cmd.Flags().Changed("tag") {
 			for _, raw := range editTags {
 				for _, tag := range splitTags(raw) {
@@ -164,14 +275,127 @@ keys have dedicated flags; use those.`,
 		default:
 			fmt.Printf("Updated %s\n", f.ID)
 		}
+
+		if criteria := f.Acceptance(); closing && felt.AcceptanceMet(criteria) < len(criteria) {
+			unmet := len(criteria) - felt.AcceptanceMet(criteria)
+			fmt.Fprintf(os.Stderr, "warning: closed %s with %d of %d acceptance criteria unmet (felt accept %s lists them)\n", f.ID, unmet, len(criteria), f.ID)
+		}
+		if closing && editPropagate {
+			if err := propagateOutcome(storage, f); err != nil {
+				return err
+			}
+		}
+		if closing {
+			return reportUnblocked(storage, scopeID, f.ID, cmd.Flags().Changed("activate-next"), editActivateNext)
+		}
 		return nil
 	},
 }
 
+// propagateOutcome appends an "Upstream closed" line naming closed and the
+// first line of its outcome to each direct, not-yet-closed data-flow consumer.
+// A consumer that already carries the exact line is left alone, so re-running
+// a close never stacks duplicates.
+func propagateOutcome(storage *felt.Storage, closed *felt.Felt) error {
+	felts, err := storage.ListMetadata()
+	if err != nil {
+		return err
+	}
+	line := "Upstream closed: " + closed.ID
+	if outcome, _, _ := strings.Cut(strings.TrimSpace(closed.Outcome), "\n"); outcome != "" {
+		line += " — " + strings.TrimSpace(outcome)
+	}
+
+	seen := make(map[string]bool)
+	now := time.Now()
+	for _, consumer := range felt.ConsumersFromFelts(felts, closed.ID) {
+		if seen[consumer.SourceID] {
+			continue
+		}
+		seen[consumer.SourceID] = true
+		f, err := storage.Read(consumer.SourceID)
+		if err != nil {
+			return err
+		}
+		if f.IsClosed() || strings.Contains(f.Body, line) {
+			continue
+		}
+		f.Body = appendBodyText(f.Body, line)
+		f.Touch(now)
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+		fmt.Printf("propagated: %s\n", f.ID)
+	}
+	return nil
+}
+
+// activateNextAuto is --activate-next's value when given without an id.
+const activateNextAuto = "auto"
+
+// reportUnblocked prints the fibers closing closedID unblocked and, when
+// asked, activates one of them in the same step.
+func reportUnblocked(storage *felt.Storage, scopeID, closedID string, activate bool, pick string) error {
+	felts, err := storage.ListMetadata()
+	if err != nil {
+		return err
+	}
+	unblocked := felt.NewlyUnblocked(felts, closedID)
+	for _, f := range unblocked {
+		fmt.Printf("unblocked: %s — %s\n", f.ID, f.DisplayName())
+	}
+	if !activate {
+		return nil
+	}
+	if len(unblocked) == 0 {
+		return fmt.Errorf("--activate-next: closing %s unblocked nothing", closedID)
+	}
+
+	next := unblocked[0]
+	if pick != activateNextAuto {
+		chosen, err := felt.FindByScope(unblocked, scopeID, pick)
+		if err != nil {
+			return fmt.Errorf("--activate-next: %s is not among the fibers %s unblocked", pick, closedID)
+		}
+		next = chosen
+	}
+
+	full, err := storage.Read(next.ID)
+	if err != nil {
+		return err
+	}
+	cfg, err := storage.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := cfg.CheckStatusTransition(full.Status, felt.StatusActive); err != nil {
+		return fmt.Errorf("--activate-next: %w", err)
+	}
+	now := time.Now()
+	full.Status = felt.StatusActive
+	full.NoteStatusChange(felt.StatusOpen, now)
+	full.Touch(now)
+	if err := storage.Write(full); err != nil {
+		return err
+	}
+	fmt.Printf("Activated %s\n", full.ID)
+	return nil
+}
+
+// isStatusValue reports whether s is a status --status accepts, so an
+// unknown one is reported as invalid rather than as a forbidden transition.
+func isStatusValue(s string) bool {
+	switch s {
+	case "", felt.StatusOpen, felt.StatusActive, felt.StatusClosed:
+		return true
+	}
+	return false
+}
+
 // editFlagNames is the canonical list of edit's top-level metadata flags, in
 // the order they are reported. Drives both the "any change requested?" gate and
 // the mechanical event's fields_changed payload.
-var editFlagNames = []string{"name", "status", "due", "tag", "untag", "body", "outcome", "set", "unset"}
+var editFlagNames = []string{"name", "status", "reopen", "due", "owner", "impact", "tag", "untag", "body", "body-file", "append-body", "patch-body", "outcome", "set", "unset"}
 
 // collectChangedEditFields lists which top-level edit flags the user actually
 // flipped, so the mechanical event payload reflects intent.
@@ -249,11 +473,22 @@ func init() {
 func initEditFlags() {
 	editCmd.Flags().StringVar(&editName, "name", "", "Set name")
 	editCmd.Flags().StringVarP(&editStatus, "status", "s", "", "Set status (open, active, closed)")
+	editCmd.Flags().BoolVar(&editReopen, "reopen", false, "Move a closed fiber back to open, whatever status.transitions allows")
 	editCmd.Flags().StringArrayVarP(&editTags, "tag", "t", nil, "Add tag(s) (repeatable; comma-separated accepted)")
 	editCmd.Flags().StringArrayVar(&editUntag, "untag", nil, "Remove tag(s)")
+	registerTagCompletion(editCmd, "tag", "untag")
 	editCmd.Flags().StringVarP(&editBody, "body", "b", "", "Replace full body text (destructive overwrite)")
+	editCmd.Flags().StringVar(&editBodyFile, "body-file", "", "Replace full body text from a file (- for stdin)")
+	editCmd.Flags().StringVar(&editAppend, "append-body", "", "Append a paragraph to the body")
+	editCmd.Flags().BoolVar(&editPatch, "patch-body", false, "Apply a unified diff read from stdin to the body")
 	editCmd.Flags().StringVarP(&editOutcome, "outcome", "o", "", "Set outcome")
-	editCmd.Flags().StringVarP(&editDue, "due", "D", "", "Set due date (YYYY-MM-DD, empty to clear)")
+	editCmd.Flags().StringVarP(&editDue, "due", "D", "", "Set due date (YYYY-MM-DD, friday, next week, in 3 days, ...; empty to clear)")
+	editCmd.Flags().StringVar(&editOwner, "owner", "", "Set who is responsible (me for the default owner; empty to clear)")
+	editCmd.Flags().StringVar(&editImpact, "impact", "", "Set how much it hurts: low, medium, high, critical (empty to clear)")
+	registerImpactCompletion(editCmd)
 	editCmd.Flags().StringArrayVar(&editSet, "set", nil, "Set a non-native top-level scalar key (key=value; YAML-typed; repeatable)")
 	editCmd.Flags().StringArrayVar(&editUnset, "unset", nil, "Remove a non-native top-level key (repeatable)")
+	editCmd.Flags().StringVar(&editActivateNext, "activate-next", "", "With --status closed, set an unblocked consumer active (optionally =<id>)")
+	editCmd.Flags().Lookup("activate-next").NoOptDefVal = activateNextAuto
+	editCmd.Flags().BoolVar(&editPropagate, "propagate", false, "With --status closed, note the outcome in each direct data-flow consumer's body")
 }
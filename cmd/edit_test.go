@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
+	"os"
 	"strings"
 	"testing"
 
@@ -236,6 +238,299 @@ func TestEditSetUnsetGuards(t *testing.T) {
 	}
 }
 
+func TestEditStampsActivatedAt(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{
+		ID:        "fiber-a",
+		Name:      "Fiber A",
+		Status:    felt.StatusOpen,
+		CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z"),
+	}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reset := saveEditGlobals()
+	defer reset()
+
+	if out, err := runCommand(t, dir, "edit", "fiber-a", "-s", "active"); err != nil {
+		t.Fatalf("edit -s active: %v\n%s", err, out)
+	}
+	f, err := storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if f.ActivatedAt == nil {
+		t.Fatalf("going active did not stamp activated-at")
+	}
+	activated := *f.ActivatedAt
+
+	saveEditGlobals()
+	if out, err := runCommand(t, dir, "edit", "fiber-a", "-s", "closed"); err != nil {
+		t.Fatalf("edit -s closed: %v\n%s", err, out)
+	}
+	f, err = storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	// Closing keeps the stamp, so activated-at → closed-at reads as cycle time.
+	if f.ActivatedAt == nil || !f.ActivatedAt.Equal(activated) {
+		t.Fatalf("activated-at after close = %v, want %v", f.ActivatedAt, activated)
+	}
+
+	saveEditGlobals()
+	if out, err := runCommand(t, dir, "edit", "fiber-a", "-s", "open"); err != nil {
+		t.Fatalf("edit -s open: %v\n%s", err, out)
+	}
+	f, err = storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if f.ActivatedAt != nil {
+		t.Fatalf("reopening kept activated-at %v, want cleared", f.ActivatedAt)
+	}
+}
+
+func TestEditEnforcesStatusTransitions(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := os.WriteFile(storage.ConfigPath(), []byte("status:\n  transitions:\n    closed: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	closedAt := mustParseTime(t, "2026-04-11T09:00:00Z")
+	if err := storage.Write(&felt.Felt{
+		ID:        "fiber-a",
+		Name:      "Fiber A",
+		Status:    felt.StatusClosed,
+		CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z"),
+		ClosedAt:  &closedAt,
+	}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reset := saveEditGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "edit", "fiber-a", "-s", "open")
+	if err == nil || !strings.Contains(err.Error(), "closed → open is not allowed by status.transitions") || !strings.Contains(err.Error(), "--reopen") {
+		t.Fatalf("edit -s open on a closed fiber: err = %v\n%s", err, out)
+	}
+
+	saveEditGlobals()
+	if out, err := runCommand(t, dir, "edit", "fiber-a", "--reopen"); err != nil {
+		t.Fatalf("edit --reopen: %v\n%s", err, out)
+	}
+	f, err := storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if f.Status != felt.StatusOpen || f.ClosedAt != nil {
+		t.Fatalf("after --reopen: status %q, closed-at %v", f.Status, f.ClosedAt)
+	}
+
+	saveEditGlobals()
+	if _, err := runCommand(t, dir, "edit", "fiber-a", "--reopen"); err == nil || !strings.Contains(err.Error(), "not closed") {
+		t.Fatalf("--reopen on an open fiber: err = %v", err)
+	}
+}
+
+func TestEditCloseReportsAndActivatesUnblocked(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	base := mustParseTime(t, "2026-04-10T09:00:00Z")
+	write := func(f *felt.Felt, from ...string) {
+		t.Helper()
+		f.CreatedAt = base
+		if len(from) > 0 {
+			var inputs []map[string]any
+			for i, src := range from {
+				inputs = append(inputs, map[string]any{"id": fmt.Sprintf("in%d", i), "from": src})
+			}
+			if err := f.SetExtraField("inputs", inputs); err != nil {
+				t.Fatalf("SetExtraField: %v", err)
+			}
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	write(&felt.Felt{ID: "producer", Name: "Producer", Status: felt.StatusActive})
+	write(&felt.Felt{ID: "other", Name: "Other", Status: felt.StatusOpen})
+	write(&felt.Felt{ID: "consumer", Name: "Consumer", Status: felt.StatusOpen}, "producer.out")
+	write(&felt.Felt{ID: "still-blocked", Name: "Still blocked", Status: felt.StatusOpen}, "producer", "other")
+
+	reset := saveEditGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "edit", "producer", "-s", "closed", "--activate-next")
+	if err != nil {
+		t.Fatalf("edit close: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "unblocked: consumer — Consumer") || !strings.Contains(out, "Activated consumer") {
+		t.Fatalf("close output missing unblocked/activated lines:\n%s", out)
+	}
+	if strings.Contains(out, "still-blocked") {
+		t.Fatalf("fiber with another open input reported as unblocked:\n%s", out)
+	}
+	consumer, err := storage.Read("consumer")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !consumer.IsActive() || consumer.ActivatedAt == nil {
+		t.Fatalf("consumer status = %q activated-at = %v, want active and stamped", consumer.Status, consumer.ActivatedAt)
+	}
+
+	saveEditGlobals()
+	if _, err := runCommand(t, dir, "edit", "other", "--activate-next"); err == nil {
+		t.Fatalf("--activate-next without --status closed should be refused")
+	}
+}
+
+func TestEditClosePropagatesOutcomeToConsumers(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	base := mustParseTime(t, "2026-04-10T09:00:00Z")
+	if err := storage.Write(&felt.Felt{ID: "producer", Name: "Producer", Status: felt.StatusActive, CreatedAt: base}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	for _, c := range []struct {
+		id, status string
+	}{{"consumer", felt.StatusOpen}, {"done", felt.StatusClosed}} {
+		f := &felt.Felt{ID: c.id, Name: c.id, Status: c.status, CreatedAt: base, Body: "Existing context."}
+		if err := f.SetExtraField("inputs", []map[string]any{{"id": "a", "from": "producer"}}); err != nil {
+			t.Fatalf("SetExtraField: %v", err)
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	reset := saveEditGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "edit", "producer", "-s", "closed", "--propagate",
+		"--outcome", "Calibration holds.\nDetails follow.")
+	if err != nil {
+		t.Fatalf("edit close --propagate: %v\n%s", err, out)
+	}
+	consumer, err := storage.Read("consumer")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := "Existing context.\n\nUpstream closed: producer — Calibration holds."
+	if consumer.Body != want {
+		t.Fatalf("consumer body = %q, want %q", consumer.Body, want)
+	}
+	done, err := storage.Read("done")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if done.Body != "Existing context." {
+		t.Fatalf("closed consumer body changed: %q", done.Body)
+	}
+}
+
+func TestEditBodyFileReplacesBody(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{
+		ID:        "fiber-a",
+		Name:      "Fiber A",
+		CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z"),
+		Body:      "Old body.",
+	}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reset := saveEditGlobals()
+	defer reset()
+
+	rootCmd.SetIn(strings.NewReader("Line one.\n\n- line 'two'\n"))
+	defer rootCmd.SetIn(nil)
+	out, err := runCommand(t, dir, "edit", "fiber-a", "--body-file", "-")
+	if err != nil {
+		t.Fatalf("edit --body-file: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "body overwritten") {
+		t.Fatalf("edit output = %q, want overwrite notice", out)
+	}
+	f, err := storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if f.Body != "Line one.\n\n- line 'two'" {
+		t.Fatalf("Body = %q", f.Body)
+	}
+}
+
+func TestEditAppendAndPatchBody(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{
+		ID:        "fiber-a",
+		Name:      "Fiber A",
+		CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z"),
+		Body:      "## Approach\n\nFit the model.\n\n## Findings\n\nNone yet.",
+	}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reset := saveEditGlobals()
+	defer reset()
+
+	if out, err := runCommand(t, dir, "edit", "fiber-a", "--append-body", "Appended note."); err != nil {
+		t.Fatalf("edit --append-body: %v\n%s", err, out)
+	}
+
+	patch := `--- a/body
++++ b/body
+@@ -5,3 +5,3 @@
+ ## Findings
+ 
+-None yet.
++The fit converges.
+`
+	saveEditGlobals()
+	rootCmd.SetIn(strings.NewReader(patch))
+	defer rootCmd.SetIn(nil)
+	if out, err := runCommand(t, dir, "edit", "fiber-a", "--patch-body"); err != nil {
+		t.Fatalf("edit --patch-body: %v\n%s", err, out)
+	}
+	f, err := storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := "## Approach\n\nFit the model.\n\n## Findings\n\nThe fit converges.\n\nAppended note."
+	if f.Body != want {
+		t.Fatalf("Body = %q, want %q", f.Body, want)
+	}
+
+	// The same patch no longer matches; the body is left untouched.
+	saveEditGlobals()
+	rootCmd.SetIn(strings.NewReader(patch))
+	if _, err := runCommand(t, dir, "edit", "fiber-a", "--patch-body"); err == nil || !strings.Contains(err.Error(), "does not apply") {
+		t.Fatalf("stale patch err = %v, want does-not-apply", err)
+	}
+}
+
 func saveEditGlobals() func() {
 	prev := struct {
 		name    string
@@ -244,11 +539,19 @@ func saveEditGlobals() func() {
 		tags    []string
 		untag   []string
 		body    string
+		bodyF   string
+		appendB string
+		patchB  bool
 		outcome string
 		set     []string
 		unset   []string
+		next    string
+		prop    bool
+		owner   string
+		impact  string
+		reopen  bool
 	}{
-		editName, editStatus, editDue, editTags, editUntag, editBody, editOutcome, editSet, editUnset,
+		editName, editStatus, editDue, editTags, editUntag, editBody, editBodyFile, editAppend, editPatch, editOutcome, editSet, editUnset, editActivateNext, editPropagate, editOwner, editImpact, editReopen,
 	}
 
 	editName = ""
@@ -257,9 +560,17 @@ func saveEditGlobals() func() {
 	editTags = nil
 	editUntag = nil
 	editBody = ""
+	editBodyFile = ""
+	editAppend = ""
+	editPatch = false
 	editOutcome = ""
 	editSet = nil
 	editUnset = nil
+	editActivateNext = ""
+	editPropagate = false
+	editOwner = ""
+	editImpact = ""
+	editReopen = false
 
 	editCmd.ResetFlags()
 	initEditFlags()
@@ -271,8 +582,16 @@ func saveEditGlobals() func() {
 		editTags = prev.tags
 		editUntag = prev.untag
 		editBody = prev.body
+		editBodyFile = prev.bodyF
+		editAppend = prev.appendB
+		editPatch = prev.patchB
 		editOutcome = prev.outcome
 		editSet = prev.set
 		editUnset = prev.unset
+		editActivateNext = prev.next
+		editPropagate = prev.prop
+		editOwner = prev.owner
+		editImpact = prev.impact
+		editReopen = prev.reopen
 	}
 }
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestForecastTagReportsPercentiles(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	now := time.Now()
+	start, end := now.AddDate(0, 0, -10), now.AddDate(0, 0, -7)
+	for _, f := range []*felt.Felt{
+		{ID: "h1", Name: "h1", Status: felt.StatusClosed, CreatedAt: start, ClosedAt: &end},
+		{ID: "h2", Name: "h2", Status: felt.StatusClosed, CreatedAt: start, ClosedAt: &end},
+		{ID: "h3", Name: "h3", Status: felt.StatusClosed, CreatedAt: start, ClosedAt: &end},
+		{ID: "draft", Name: "Draft", Status: felt.StatusOpen, CreatedAt: now, Tags: []string{"paper"}},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", f.ID, err)
+		}
+	}
+
+	prevTrials, prevSeed, prevJSON := forecastTrials, forecastSeed, jsonOutput
+	defer func() { forecastTrials, forecastSeed, jsonOutput = prevTrials, prevSeed, prevJSON }()
+	forecastTrials, forecastSeed, jsonOutput = 200, 7, false
+
+	out, err := runCommand(t, dir, "forecast", "paper")
+	if err != nil {
+		t.Fatalf("forecast: %v\n%s", err, out)
+	}
+	want := now.AddDate(0, 0, 3).Format("2006-01-02")
+	if !strings.Contains(out, `Forecast for tag "paper" — 1 unfinished fiber`) || !strings.Contains(out, "P85: "+want+" (3 days)") {
+		t.Fatalf("forecast output:\n%s", out)
+	}
+
+	if _, err := runCommand(t, dir, "forecast", "nothing-here"); err == nil {
+		t.Fatalf("unknown target should error")
+	}
+}
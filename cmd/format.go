@@ -11,7 +11,7 @@ import (
 // Line 1: status icon + ID
 // Line 2: indented name with metadata (tags)
 func formatFeltTwoLine(f *felt.Felt) string {
-	icon := felt.StatusIcon(f.Status)
+	icon := fiberIcon(f)
 
 	line1 := fmt.Sprintf("%s %s\n", icon, f.ID)
 
@@ -20,7 +20,80 @@ func formatFeltTwoLine(f *felt.Felt) string {
 		metaStr = fmt.Sprintf(" (%s)", strings.Join(f.Tags, ", "))
 	}
 
-	line2 := fmt.Sprintf("    %s%s\n", f.DisplayName(), metaStr)
+	line2 := fmt.Sprintf("    %s%s%s%s\n", f.DisplayName(), metaStr, impactBadge(f), confidenceMarker(f))
 
 	return line1 + line2
 }
+
+// confidenceMarker renders f's confidence level as a " [low confidence]"
+// suffix for list and tree lines, or "" when f carries none.
+func confidenceMarker(f *felt.Felt) string {
+	c, ok := f.Confidence()
+	if !ok {
+		return ""
+	}
+	return " [" + c.Level + " confidence]"
+}
+
+// truncateText shortens s to at most maxRunes runes, ellipsis included, for
+// one-line previews of titles, outcomes, and bodies. It counts runes, never
+// bytes, so a multi-byte character is never split; it prefers to stop at the
+// end of a sentence, then at a word break, in the back half of the budget;
+// and it closes a code fence or inline code span the cut left open, so a
+// preview cannot swallow the markdown that follows it.
+func truncateText(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	if maxRunes < 1 {
+		return ""
+	}
+	head := string(runes[:maxRunes-1])
+	half := len(string(runes[:(maxRunes-1)/2]))
+	cut := head
+	if i := lastSentenceEnd(head); i >= half {
+		cut = head[:i]
+	} else if i := strings.LastIndexAny(head, " \t\n"); i >= half {
+		cut = head[:i]
+	}
+	cut = strings.TrimRight(cut, " \t\n")
+	return closeOpenMarkdown(cut) + "…"
+}
+
+// lastSentenceEnd returns the byte offset just past the last sentence-ending
+// punctuation in s that is followed by whitespace, or -1.
+func lastSentenceEnd(s string) int {
+	for i := len(s) - 2; i >= 0; i-- {
+		switch s[i] {
+		case '.', '!', '?':
+			if next := s[i+1]; next == ' ' || next == '\n' || next == '\t' {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// closeOpenMarkdown appends the closers for a ``` fence or ` code span left
+// open at the end of s.
+func closeOpenMarkdown(s string) string {
+	inFence := false
+	ticks := 0
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			ticks += strings.Count(line, "`")
+		}
+	}
+	switch {
+	case inFence:
+		return s + "\n```\n"
+	case ticks%2 == 1:
+		return s + "`"
+	}
+	return s
+}
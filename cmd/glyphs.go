@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+// asciiEnv turns on ASCII glyphs for every invocation, like --ascii.
+const asciiEnv = "FELT_ASCII"
+
+// noColorEnv turns off colored output, per no-color.org.
+const noColorEnv = "NO_COLOR"
+
+var asciiFlag bool
+
+// glyphTheme is the single rendering layer for status icons, per-tag icons,
+// session section headers, and the zone timestamps are shown in. Every text
+// renderer goes through statusIcon, fiberIcon, sectionHeader, and
+// displayTime rather than writing glyph literals or formatting stored times
+// directly, so one config block restyles all output.
+type glyphTheme struct {
+	ascii    bool
+	icons    map[string]string // status key (open, active, closed, none) → glyph
+	tagIcons map[string]string // tag → glyph shown after the status icon
+	headers  map[string]string // default section title → replacement
+	location *time.Location    // display.timezone; nil means time.Local
+	color    bool              // stdout is a terminal and NO_COLOR is unset
+}
+
+// theme is resolved once per invocation (applyRootFlags) from
+// --ascii, FELT_ASCII, and the display: block of .felt/config.yaml, so
+// rendering a long listing reads no config.
+var theme glyphTheme
+
+// resolveTheme builds this invocation's glyph theme.
+func resolveTheme() glyphTheme {
+	var t glyphTheme
+	if root, err := resolveProjectRoot(); err == nil {
+		if cfg, err := felt.NewStorage(root).LoadConfig(); err == nil {
+			t.ascii = cfg.Display.ASCII
+			t.icons = cfg.Display.Icons
+			t.tagIcons = cfg.Display.TagIcons
+			t.headers = cfg.Display.Headers
+			// An unknown zone falls back to local time rather than failing
+			// every command.
+			loc, err := cfg.DisplayLocation()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: %v; showing local time\n", err)
+			}
+			t.location = loc
+		}
+	}
+	if asciiFlag {
+		t.ascii = true
+	}
+	if v := strings.TrimSpace(os.Getenv(asciiEnv)); v != "" && v != "0" && !strings.EqualFold(v, "false") {
+		t.ascii = true
+	}
+	if stat, err := os.Stdout.Stat(); err == nil && (stat.Mode()&os.ModeCharDevice) != 0 {
+		t.color = os.Getenv(noColorEnv) == ""
+	}
+	return t
+}
+
+// statusIconKey names a status in the display.icons map.
+func statusIconKey(status string) string {
+	if status == "" {
+		return "none"
+	}
+	return status
+}
+
+// statusIcon returns the glyph for a status: a configured display.icons
+// entry, else bracketed words in ASCII mode (for screen readers and
+// terminals without the glyphs), else felt.StatusIcon's unicode circles.
+func statusIcon(status string) string {
+	if icon, ok := theme.icons[statusIconKey(status)]; ok {
+		return icon
+	}
+	if !theme.ascii {
+		return felt.StatusIcon(status)
+	}
+	switch status {
+	case felt.StatusOpen:
+		return "[open]"
+	case felt.StatusActive:
+		return "[act]"
+	case felt.StatusClosed:
+		return "[done]"
+	case "":
+		return "[-]"
+	default:
+		return "[?]"
+	}
+}
+
+// fiberIcon is statusIcon plus the display.tag-icons glyph of f's first
+// tag that has one.
+func fiberIcon(f *felt.Felt) string {
+	icon := statusIcon(f.Status)
+	for _, tag := range f.Tags {
+		if tagIcon, ok := theme.tagIcons[tag]; ok {
+			return icon + " " + tagIcon
+		}
+	}
+	return icon
+}
+
+// impactColors are the SGR codes impact badges are drawn in, from dim for
+// low to bold red for critical.
+var impactColors = map[string]string{
+	felt.ImpactLow:      "2",
+	felt.ImpactMedium:   "33",
+	felt.ImpactHigh:     "31",
+	felt.ImpactCritical: "1;31",
+}
+
+// impactBadge renders f's impact level as a " [high impact]" suffix for
+// list lines, colored when writing to a terminal, or "" when f carries none.
+func impactBadge(f *felt.Felt) string {
+	level := f.Impact()
+	if level == "" {
+		return ""
+	}
+	badge := "[" + level + " impact]"
+	if theme.color {
+		badge = "\033[" + impactColors[level] + "m" + badge + "\033[0m"
+	}
+	return " " + badge
+}
+
+// sectionHeader returns the display title for a session section, remapped
+// by display.headers when configured.
+func sectionHeader(title string) string {
+	if h, ok := theme.headers[title]; ok && strings.TrimSpace(h) != "" {
+		return h
+	}
+	return title
+}
+
+// displayTime converts a stored (UTC) instant to the zone it is shown in:
+// display.timezone when configured, else the machine's local zone.
+func displayTime(t time.Time) time.Time {
+	if theme.location != nil {
+		return t.In(theme.location)
+	}
+	return t.Local()
+}
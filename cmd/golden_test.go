@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/fixtures"
+)
+
+// TestGoldenOutput pins the text rendering of the listing surfaces against
+// testdata/golden. After an intended formatting change, regenerate with
+//
+//	go test ./cmd -run TestGoldenOutput -update
+//
+// and review the golden diff like any other.
+func TestGoldenOutput(t *testing.T) {
+	prevLocal := time.Local
+	time.Local = time.UTC
+	defer func() { time.Local = prevLocal }()
+	theme = glyphTheme{}
+
+	dir := fixtures.BuildFile(t, "testdata/fixtures/project.yaml")
+	for _, tc := range []struct {
+		name string
+		args []string
+	}{
+		{"ls", []string{"ls"}},
+		{"ls-all", []string{"ls", "-s", "all"}},
+		{"ls-tag", []string{"ls", "-s", "all", "-t", "decision"}},
+		{"tree", []string{"tree"}},
+		{"tree-scope", []string{"tree", "bao"}},
+		{"show", []string{"show", "bao/fit"}},
+		{"show-field", []string{"show", "bao/damping-prior", "--field", "decisions"}},
+		{"show-json", []string{"show", "bao/paper", "--json"}},
+		{"check", []string{"check"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer saveLsGlobals()()
+			prevField := showField
+			defer func() { showField = prevField }()
+
+			out, err := runCommand(t, dir, tc.args...)
+			if err != nil {
+				t.Fatalf("%s: %v\n%s", strings.Join(tc.args, " "), err, out)
+			}
+			fixtures.Golden(t, tc.name, strings.ReplaceAll(out, dir, "$ROOT"))
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+// handoffSection is the body section handoff notes accumulate under.
+const handoffSection = "## Handoffs"
+
+var (
+	handoffTo    string
+	handoffState string
+	handoffNext  []string
+)
+
+var handoffCmd = &cobra.Command{
+	Use:   "handoff <id> --to <owner>",
+	Short: "Reassign a fiber with a note on where it stands",
+	Long: `Reassigns a fiber and records why and how: the owner: field becomes
+--to, and a dated note naming the previous and new owner, the current
+state, and the next steps is appended to the fiber's "## Handoffs"
+section, so whoever picks it up — a collaborator or an agent — starts
+from the same place.
+
+--state and --next (repeatable) write the note; with neither, $VISUAL or
+$EDITOR opens a template to fill in, and an empty note aborts. --to me
+means $FELT_OWNER, else owner.default. The fiber's status is left alone.`,
+	Example: `  felt handoff mocks --to ben --state "Covariance converged; fig 4 stale" --next "Regenerate fig 4" --next "Rerun the chi2 table"
+  felt handoff mocks --to me`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		to, err := resolveOwnerFlag(storage, handoffTo)
+		if err != nil {
+			return err
+		}
+		if to == "" || to == "none" {
+			return fmt.Errorf("--to names who takes the fiber over")
+		}
+
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		f, err := storage.Read(target.ID)
+		if err != nil {
+			return err
+		}
+		from := f.Owner()
+		if from == to {
+			return fmt.Errorf("%s is already owned by %s", f.ID, to)
+		}
+
+		state, next := strings.TrimSpace(handoffState), handoffNext
+		if state == "" && len(next) == 0 {
+			if state, next, err = editHandoffNote(f.ID, to); err != nil {
+				return err
+			}
+			if state == "" && len(next) == 0 {
+				return fmt.Errorf("empty handoff note; %s not handed off", f.ID)
+			}
+		}
+
+		now := time.Now()
+		if err := f.SetOwner(to); err != nil {
+			return err
+		}
+		if f.Body, err = felt.AppendBodySection(f.Body, handoffSection, formatHandoffNote(from, to, displayTime(now), state, next)); err != nil {
+			return err
+		}
+		f.Touch(now)
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+		if jsonOutput {
+			return outputJSON(f)
+		}
+		fmt.Printf("Handed off %s to %s\n", f.ID, to)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(handoffCmd)
+	handoffCmd.Flags().StringVar(&handoffTo, "to", "", "New owner (me for the default owner)")
+	handoffCmd.Flags().StringVar(&handoffState, "state", "", "Where the work stands")
+	handoffCmd.Flags().StringArrayVar(&handoffNext, "next", nil, "A next step (repeatable)")
+	_ = handoffCmd.MarkFlagRequired("to")
+}
+
+// formatHandoffNote renders one handoff as a paragraph of the Handoffs
+// section: a bold dated header, the state, and the next steps as a list.
+func formatHandoffNote(from, to string, at time.Time, state string, next []string) string {
+	if from == "" {
+		from = "(unowned)"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "**%s, %s → %s.**", at.Format("2006-01-02 15:04"), from, to)
+	if state != "" {
+		sb.WriteString(" " + state)
+	}
+	var steps []string
+	for _, step := range next {
+		if step = strings.TrimSpace(step); step != "" {
+			steps = append(steps, "- "+step)
+		}
+	}
+	if len(steps) > 0 {
+		sb.WriteString("\n\nNext steps:\n" + strings.Join(steps, "\n"))
+	}
+	return sb.String()
+}
+
+// handoffTemplate is what $EDITOR opens when no note is given by flag.
+const handoffTemplate = `State:
+
+
+Next steps:
+-
+
+# Handing off %s to %s. Lines starting with # are dropped;
+# an empty note aborts the handoff.
+`
+
+// editHandoffNote opens the handoff template in $VISUAL or $EDITOR (vi
+// when neither is set) and parses the saved note.
+func editHandoffNote(id, to string) (state string, next []string, err error) {
+	file, err := os.CreateTemp("", "felt-handoff-*.md")
+	if err != nil {
+		return "", nil, err
+	}
+	path := file.Name()
+	defer os.Remove(path)
+	if _, err := fmt.Fprintf(file, handoffTemplate, id, to); err != nil {
+		file.Close()
+		return "", nil, err
+	}
+	if err := file.Close(); err != nil {
+		return "", nil, err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	argv := append(strings.Fields(editor), path)
+	run := exec.Command(argv[0], argv[1:]...)
+	run.Stdin, run.Stdout, run.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := run.Run(); err != nil {
+		return "", nil, fmt.Errorf("editor %s: %w", editor, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	state, next = parseHandoffNote(string(data))
+	return state, next, nil
+}
+
+// parseHandoffNote reads a filled-in handoffTemplate: the lines under
+// "State:" are the state, and each line under "Next steps:" a step, with
+// any leading "- " dropped. Comment lines are ignored.
+func parseHandoffNote(text string) (state string, next []string) {
+	var stateLines []string
+	inNext := false
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+		case strings.EqualFold(trimmed, "State:"):
+			inNext = false
+		case strings.EqualFold(trimmed, "Next steps:"):
+			inNext = true
+		case inNext:
+			if step := strings.TrimSpace(strings.TrimPrefix(trimmed, "-")); step != "" {
+				next = append(next, step)
+			}
+		default:
+			stateLines = append(stateLines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(stateLines, "\n")), next
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/pflag"
+)
+
+func resetHandoffFlags() {
+	handoffTo, handoffState, handoffNext = "", "", nil
+	handoffCmd.Flags().VisitAll(func(f *pflag.Flag) { f.Changed = false })
+}
+
+func TestHandoffReassignsAndAppendsNote(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	f := &felt.Felt{ID: "mocks", Name: "Mocks", Status: felt.StatusActive, Body: "Covariance mocks.", CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}
+	if err := f.SetOwner("ana"); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Write(f); err != nil {
+		t.Fatal(err)
+	}
+	defer resetHandoffFlags()
+
+	resetHandoffFlags()
+	out, err := runCommand(t, dir, "handoff", "mocks", "--to", "ben", "--state", "Converged; fig 4 stale.", "--next", "Regenerate fig 4", "--next", "Rerun chi2")
+	if err != nil {
+		t.Fatalf("handoff: %v\n%s", err, out)
+	}
+	got, err := storage.Read("mocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Owner() != "ben" || got.Status != felt.StatusActive {
+		t.Fatalf("owner = %q, status = %q", got.Owner(), got.Status)
+	}
+	for _, want := range []string{"Covariance mocks.\n\n## Handoffs\n\n**", ", ana → ben.** Converged; fig 4 stale.\n\nNext steps:\n- Regenerate fig 4\n- Rerun chi2"} {
+		if !strings.Contains(got.Body, want) {
+			t.Fatalf("body missing %q:\n%s", want, got.Body)
+		}
+	}
+
+	resetHandoffFlags()
+	if _, err := runCommand(t, dir, "handoff", "mocks", "--to", "ben", "--state", "again"); err == nil || !strings.Contains(err.Error(), "already owned by ben") {
+		t.Fatalf("handoff to the current owner: err = %v", err)
+	}
+
+	// With no note flags the template opens in $EDITOR.
+	editor := filepath.Join(t.TempDir(), "editor.sh")
+	script := "#!/bin/sh\nprintf 'State:\\nHalfway through fig 4.\\n\\nNext steps:\\n- Finish fig 4\\n# ignored\\n' > \"$1\"\n"
+	if err := os.WriteFile(editor, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", editor)
+	resetHandoffFlags()
+	if out, err := runCommand(t, dir, "handoff", "mocks", "--to", "ana"); err != nil {
+		t.Fatalf("handoff via editor: %v\n%s", err, out)
+	}
+	got, _ = storage.Read("mocks")
+	if got.Owner() != "ana" || !strings.HasSuffix(got.Body, ", ben → ana.** Halfway through fig 4.\n\nNext steps:\n- Finish fig 4") {
+		t.Fatalf("owner = %q, body:\n%s", got.Owner(), got.Body)
+	}
+	if strings.Count(got.Body, handoffSection) != 1 {
+		t.Fatalf("handoffs should share one section:\n%s", got.Body)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -18,11 +19,24 @@ var sessionCmd = &cobra.Command{
 	Short: "Print the session context text",
 	Long: `Print the plain text context that felt contributes at agent session
 start: the activation directive plus active and recently touched fibers.
+Fibers whose last upstream closed since the previous session context lead
+under Newly Unblocked; the time of each run is kept in .felt/session-mark.
 
 Hook adapters wrap this text in whatever envelope their harness expects. For
-Claude/Codex's current SessionStart wire format, see ` + "`felt hook session`" + `.`,
+Claude/Codex's current SessionStart wire format, see ` + "`felt hook session`" + `.
+
+With --json, prints the same sections as data for orchestrators that build
+their own prompts: in-flight fibers with their bodies, the ready queue,
+recently touched fibers with outcomes, and the rest as the text lists them.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if jsonOutput {
+			data, err := buildSessionJSON()
+			if err != nil {
+				return err
+			}
+			return outputJSON(data)
+		}
 		fmt.Print(buildSessionContext())
 		return nil
 	},
@@ -78,7 +92,12 @@ var hookPreToolCmd = &cobra.Command{
 	Long: `Reads the PreToolUse payload from stdin and emits either a deny envelope
 (if the felt skill hasn't been activated this session in a felt-enabled
 project) or nothing (pass through). Outside felt-enabled projects, or in
-non-Claude sessions like Codex, this is a pass-through.`,
+non-Claude sessions like Codex, this is a pass-through.
+
+A payload felt cannot read passes through too, so a harness schema change
+goes unnoticed. --strict (or FELT_HOOK_STRICT=1) instead fails the hook and
+appends what was wrong to .felt/hook-debug.log; --replay <file> reruns the
+hook on a saved payload. Both flags work the same on posttool.`,
 	Args:         cobra.NoArgs,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -97,7 +116,8 @@ This is what makes direct Edit-tool body edits count toward recency without
 felt's own read commands ever writing files: the harness fires this hook at the
 moment of the edit, so the stamping happens in the agent layer, not in felt's
 Sync. Edits felt makes itself (felt add/edit) already stamp inline. Silent
-pass-through for non-edit tools, non-felt files, and any error.`,
+pass-through for non-edit tools, non-felt files, and any error — except a
+malformed payload under --strict.`,
 	Args:         cobra.NoArgs,
 	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -105,12 +125,62 @@ pass-through for non-edit tools, non-felt files, and any error.`,
 	},
 }
 
+var hookReplayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Rerun the hook a saved payload was sent to",
+	Long: `Reads a saved hook payload — a file from .felt/hook-log/ (recorded when
+hook.log: true is set in .felt/config.yaml), or one captured by hand — and
+runs the hook its hook_event_name names, as if the harness had just sent
+it. Output goes to stdout as the harness would see it. Replayed payloads
+are not logged again.
+
+With --strict, a malformed payload fails and is logged to
+.felt/hook-debug.log, as 'felt hook pretool --strict' would.`,
+	Example: `  felt hook replay .felt/hook-log/20261014T091500.123456789Z-posttooluse.json
+  felt hook replay --strict payload.json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("reading replay payload: %w", err)
+		}
+		var env struct {
+			HookEventName string `json:"hook_event_name"`
+		}
+		if err := json.Unmarshal(data, &env); err != nil {
+			return fmt.Errorf("%s: not a hook payload: %w", args[0], err)
+		}
+		hookReplay = args[0]
+		defer func() { hookReplay = "" }()
+		switch env.HookEventName {
+		case "PreToolUse":
+			return runPreToolHook(os.Stdin, os.Stdout)
+		case "PostToolUse":
+			return runPostToolHook(os.Stdin)
+		case "SessionEnd":
+			return runSessionEndHook(os.Stdin, time.Now())
+		case "PreCompact":
+			return runPreCompactHook(os.Stdin, os.Stdout)
+		case "Stop":
+			return runStopHook(os.Stdin, os.Stdout)
+		case "":
+			return fmt.Errorf("%s has no hook_event_name; rerun it with 'felt hook pretool|posttool|session-end|precompact|stop --replay'", args[0])
+		}
+		return fmt.Errorf("%s: felt has no %s hook", args[0], env.HookEventName)
+	},
+}
+
 func init() {
 	rootCmd.AddCommand(sessionCmd)
 	rootCmd.AddCommand(hookCmd)
 	hookCmd.AddCommand(hookSessionCmd)
 	hookCmd.AddCommand(hookPreToolCmd)
 	hookCmd.AddCommand(hookPostToolCmd)
+	hookCmd.AddCommand(hookReplayCmd)
+	hookReplayCmd.Flags().BoolVar(&hookStrict, "strict", false, "Reject a malformed payload and log it to .felt/"+felt.HookDebugLogName)
+	addHookPayloadFlags(hookPreToolCmd)
+	addHookPayloadFlags(hookPostToolCmd)
 }
 
 // ----------------------------------------------------------------------------
@@ -160,6 +230,162 @@ func buildSessionContext() string {
 		return sb.String()
 	}
 
+	now := time.Now()
+	st := gatherSessionState(storage, felts, now)
+	unblocked, inFlight, waking, recent := st.unblocked, st.inFlight, st.waking, st.recent
+	recency := func(f *felt.Felt) time.Time { return f.RecencyAnchor() }
+
+	// With parent stores included, say which store the main sections
+	// describe — the one plain `felt` commands act on.
+	cfg, err := storage.LoadConfig()
+	includeParents := err == nil && cfg.Hook.IncludeParents && len(felt.FindAncestorRoots(root)) > 0
+	if includeParents {
+		fmt.Fprintf(&sb, "*Nearest store: %s. Enclosing stores are listed under %s below.*\n\n", root, sectionHeader("Parent Project"))
+	}
+
+	if len(unblocked) > 0 {
+		sb.WriteString(formatSessionUnblocked(unblocked))
+		sb.WriteString("\n")
+	}
+
+	if len(inFlight) > 0 {
+		fmt.Fprintf(&sb, "## %s\n\n", sectionHeader("Active / Open"))
+		for _, f := range inFlight {
+			sb.WriteString(formatHookEntry(f, recency(f), false))
+		}
+		if st.held > 0 {
+			fmt.Fprintf(&sb, "\n*%d more held back by hook.quotas — felt ls lists them.*\n", st.held)
+		}
+		sb.WriteString("\n")
+	} else if len(unblocked) == 0 {
+		sb.WriteString(sessionNoTrackedNote)
+		sb.WriteString("\n\n")
+	}
+
+	if len(waking) > 0 {
+		sb.WriteString(formatSessionWaking(waking))
+		sb.WriteString("\n")
+	}
+
+	if aging := buildSessionAging(storage, felts, now); aging != "" {
+		sb.WriteString(aging)
+		sb.WriteString("\n")
+	}
+
+	if len(recent) > 0 {
+		fmt.Fprintf(&sb, "## %s\n\n", sectionHeader("Recently Touched"))
+		for _, f := range recent {
+			sb.WriteString(formatHookEntry(f, recency(f), true))
+		}
+		sb.WriteString("\n")
+	}
+
+	if attention := buildSessionAttention(felts, now); attention != "" {
+		sb.WriteString(attention)
+		sb.WriteString("\n")
+	}
+
+	if includeParents {
+		sb.WriteString(buildSessionParents(root))
+	}
+
+	return sb.String()
+}
+
+// sessionState is the fiber selection both session renderings share: the
+// markdown context and `felt session --json`.
+type sessionState struct {
+	unblocked []felt.Unblocked
+	inFlight  []*felt.Felt
+	waking    []*felt.Felt
+	recent    []*felt.Felt
+	// held counts the in-flight fibers hook.quotas kept off the list.
+	held int
+}
+
+// sessionJSON is `felt session --json`: the session sections as data.
+type sessionJSON struct {
+	Root           string             `json:"root"`
+	NewlyUnblocked []sessionUnblocked `json:"newly_unblocked"`
+	InFlight       []*felt.Felt       `json:"in_flight"`
+	Ready          []*felt.Felt       `json:"ready"`
+	ReadyHeldBack  []string           `json:"ready_held_back,omitempty"`
+	Waking         []*felt.Felt       `json:"waking"`
+	AgingWIP       []sessionAging     `json:"aging_wip"`
+	Recent         []*felt.Felt       `json:"recent"`
+	Attention      []string           `json:"attention"`
+}
+
+type sessionUnblocked struct {
+	ID string    `json:"id"`
+	By string    `json:"by"`
+	At time.Time `json:"at"`
+}
+
+type sessionAging struct {
+	ID          string    `json:"id"`
+	ActiveSince time.Time `json:"active_since"`
+}
+
+// buildSessionJSON gathers the session sections for --json. In-flight
+// fibers are re-read in full so their bodies come along; the ready queue is
+// every ready fiber, oldest first, rather than a capped section — short of
+// those hook.quotas holds back, which are named in ready_held_back.
+func buildSessionJSON() (*sessionJSON, error) {
+	root, err := resolveProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("not in a felt repository")
+	}
+	storage := felt.NewStorage(root)
+	felts, err := storage.ListMetadata()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	st := gatherSessionState(storage, felts, now)
+
+	out := &sessionJSON{
+		Root:           root,
+		NewlyUnblocked: []sessionUnblocked{},
+		InFlight:       []*felt.Felt{},
+		Ready:          felt.ReadyFelts(felts, now),
+		Waking:         append([]*felt.Felt{}, st.waking...),
+		AgingWIP:       []sessionAging{},
+		Recent:         append([]*felt.Felt{}, st.recent...),
+		Attention:      append([]string{}, sessionAttentionNotes(felts, now)...),
+	}
+	for _, u := range st.unblocked {
+		out.NewlyUnblocked = append(out.NewlyUnblocked, sessionUnblocked{ID: u.Felt.ID, By: u.By, At: u.At})
+	}
+	for _, f := range st.inFlight {
+		full, err := storage.Read(f.ID)
+		if err != nil {
+			return nil, err
+		}
+		out.InFlight = append(out.InFlight, full)
+	}
+	if out.Ready == nil {
+		out.Ready = []*felt.Felt{}
+	}
+	sortFibersByCreatedAt(out.Ready)
+	if cfg, err := storage.LoadConfig(); err == nil {
+		var held []*felt.Felt
+		out.Ready, held = felt.ApplyTagQuotas(out.Ready, cfg.Hook.Quotas)
+		for _, f := range held {
+			out.ReadyHeldBack = append(out.ReadyHeldBack, f.ID)
+		}
+		if maxAge, err := cfg.WIPMaxAge(); err == nil {
+			for _, a := range sessionAgingWIP(felts, maxAge, now) {
+				out.AgingWIP = append(out.AgingWIP, sessionAging{ID: a.f.ID, ActiveSince: a.since})
+			}
+		}
+	}
+	return out, nil
+}
+
+// gatherSessionState partitions felts into the session sections and advances
+// the store's session watermark.
+func gatherSessionState(storage *felt.Storage, felts []*felt.Felt, now time.Time) *sessionState {
 	// Recency signal is the git-durable frontmatter anchor — updated-at when
 	// present, else created-at (RecencyAnchor) — never file mtime. felt is
 	// git-synced across machines: mtime is flattened by every
@@ -175,50 +401,176 @@ func buildSessionContext() string {
 		})
 	}
 
-	// Partition once so every fiber appears in exactly one section. Active and
+	// Work freed since the last session context was built leads, so it is
+	// not buried in the in-flight list. The first build in a store only
+	// records the watermark: with no previous look, nothing is "new".
+	var unblocked []felt.Unblocked
+	if mark, ok, err := storage.SessionMark(); err == nil && ok {
+		unblocked = felt.UnblockedSince(felts, mark, now)
+		if len(unblocked) > sessionSectionLimit {
+			unblocked = unblocked[:sessionSectionLimit]
+		}
+	}
+	_ = storage.SetSessionMark(now)
+	isUnblocked := make(map[string]bool, len(unblocked))
+	for _, u := range unblocked {
+		isUnblocked[u.Felt.ID] = true
+	}
+
+	// Partition once so every fiber appears in at most one section. Active and
 	// open fibers are the in-flight working set; closed and untracked fibers
-	// form the recent context tail.
-	var inFlight, recent []*felt.Felt
+	// form the recent context tail. Snoozed fibers sit out until they wake,
+	// and fibers whose snooze ends today get their own section instead.
+	var inFlight, waking, recent []*felt.Felt
 	for _, f := range felts {
-		if f.IsActive() || f.IsOpen() {
-			inFlight = append(inFlight, f)
-		} else {
+		switch {
+		case !f.IsActive() && !f.IsOpen():
 			recent = append(recent, f)
+		case f.WakesOn(now):
+			waking = append(waking, f)
+		case f.IsDeferred(now), isUnblocked[f.ID]:
+		default:
+			inFlight = append(inFlight, f)
 		}
 	}
 	byRecencyDesc(inFlight)
 	byRecencyDesc(recent)
+	var held []*felt.Felt
+	if cfg, err := storage.LoadConfig(); err == nil {
+		inFlight, held = felt.ApplyTagQuotas(inFlight, cfg.Hook.Quotas)
+	}
 	if len(inFlight) > sessionSectionLimit {
 		inFlight = inFlight[:sessionSectionLimit]
 	}
 	if len(recent) > sessionSectionLimit {
 		recent = recent[:sessionSectionLimit]
 	}
+	wake := func(f *felt.Felt) time.Time { t, _ := f.DeferUntil(); return t }
+	sort.SliceStable(waking, func(i, j int) bool { return wake(waking[i]).Before(wake(waking[j])) })
+	if len(waking) > sessionSectionLimit {
+		waking = waking[:sessionSectionLimit]
+	}
+	return &sessionState{unblocked: unblocked, inFlight: inFlight, waking: waking, recent: recent, held: len(held)}
 
-	if len(inFlight) > 0 {
-		sb.WriteString("## Active / Open\n\n")
+}
+
+// buildSessionParents renders one section per enclosing felt store, nearest
+// first, listing its in-flight fibers. Each section names the store's root
+// and the -C invocation that reaches it, since plain `felt` commands from
+// here resolve to the nearest store only.
+func buildSessionParents(root string) string {
+	var sb strings.Builder
+	for _, parent := range felt.FindAncestorRoots(root) {
+		fmt.Fprintf(&sb, "## %s: %s\n\n", sectionHeader("Parent Project"), parent)
+		fmt.Fprintf(&sb, "*Enclosing store — reach these with `felt -C %s`.*\n\n", parent)
+		felts, err := felt.NewStorage(parent).ListMetadata()
+		if err != nil {
+			fmt.Fprintf(&sb, "*felt listing failed: %s*\n\n", err)
+			continue
+		}
+		now := time.Now()
+		var inFlight []*felt.Felt
+		for _, f := range felts {
+			if (f.IsActive() || f.IsOpen()) && !f.IsDeferred(now) {
+				inFlight = append(inFlight, f)
+			}
+		}
+		if len(inFlight) == 0 {
+			sb.WriteString(sessionNoTrackedNote)
+			sb.WriteString("\n\n")
+			continue
+		}
+		sort.SliceStable(inFlight, func(i, j int) bool {
+			return inFlight[i].RecencyAnchor().After(inFlight[j].RecencyAnchor())
+		})
+		if len(inFlight) > sessionSectionLimit {
+			inFlight = inFlight[:sessionSectionLimit]
+		}
 		for _, f := range inFlight {
-			sb.WriteString(formatHookEntry(f, recency(f), false))
+			sb.WriteString(formatHookEntry(f, f.RecencyAnchor(), false))
 		}
 		sb.WriteString("\n")
-	} else {
-		sb.WriteString(sessionNoTrackedNote)
-		sb.WriteString("\n\n")
 	}
+	return sb.String()
+}
 
-	if len(recent) > 0 {
-		sb.WriteString("## Recently Touched\n\n")
-		for _, f := range recent {
-			sb.WriteString(formatHookEntry(f, recency(f), true))
+// buildSessionAging flags active fibers whose current active stretch has run
+// past wip.max-age (default 7d), oldest first: long-lived WIP usually wants
+// closing, splitting, or demoting back to open. Shuttle fibers are skipped —
+// their active status means "scheduled", not "in progress". A malformed
+// threshold is reported in-band rather than failing the hook.
+func buildSessionAging(storage *felt.Storage, felts []*felt.Felt, now time.Time) string {
+	cfg, err := storage.LoadConfig()
+	if err != nil {
+		return fmt.Sprintf("*felt config: %s*\n", err)
+	}
+	maxAge, err := cfg.WIPMaxAge()
+	if err != nil {
+		return fmt.Sprintf("*felt config: %s*\n", err)
+	}
+	return formatSessionAging(felts, maxAge, now)
+}
+
+func formatSessionAging(felts []*felt.Felt, maxAge time.Duration, now time.Time) string {
+	stale := sessionAgingWIP(felts, maxAge, now)
+	if len(stale) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\nActive longer than %s — close, split, or demote:\n\n", sectionHeader("Aging WIP"), felt.FormatSpan(maxAge))
+	for _, a := range stale {
+		days := int(now.Sub(a.since).Hours() / 24)
+		fmt.Fprintf(&sb, "%s %s — active %d %s\n", fiberIcon(a.f), a.f.ID, days, pluralize(days, "day", "days"))
+	}
+	return sb.String()
+}
+
+// agedFiber is an active fiber and the start of its current active stretch.
+type agedFiber struct {
+	f     *felt.Felt
+	since time.Time
+}
+
+// sessionAgingWIP returns the Aging WIP entries, oldest first and capped.
+func sessionAgingWIP(felts []*felt.Felt, maxAge time.Duration, now time.Time) []agedFiber {
+	var stale []agedFiber
+	for _, f := range felts {
+		since, ok := f.ActiveSince()
+		if !ok || f.HasShuttleFacet() || f.IsDeferred(now) || now.Sub(since) <= maxAge {
+			continue
 		}
-		sb.WriteString("\n")
+		stale = append(stale, agedFiber{f: f, since: since})
 	}
+	sort.SliceStable(stale, func(i, j int) bool { return stale[i].since.Before(stale[j].since) })
+	if len(stale) > sessionSectionLimit {
+		stale = stale[:sessionSectionLimit]
+	}
+	return stale
+}
 
-	if attention := buildSessionAttention(felts, time.Now()); attention != "" {
-		sb.WriteString(attention)
-		sb.WriteString("\n")
+// formatSessionUnblocked lists the fibers freed since the last session
+// context, each headed by when its last upstream closed and naming it.
+func formatSessionUnblocked(unblocked []felt.Unblocked) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\n", sectionHeader("Newly Unblocked"))
+	for _, u := range unblocked {
+		sb.WriteString(formatHookEntry(u.Felt, u.At, false))
+		fmt.Fprintf(&sb, "    ← %s closed\n", u.By)
 	}
+	return sb.String()
+}
 
+// formatSessionWaking lists the snoozed fibers whose wake time falls today,
+// each headed by its wake time rather than its recency. gatherSessionState
+// has already put them soonest first.
+func formatSessionWaking(waking []*felt.Felt) string {
+	wake := func(f *felt.Felt) time.Time { t, _ := f.DeferUntil(); return t }
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\n", sectionHeader("Waking Today"))
+	for _, f := range waking {
+		sb.WriteString(formatHookEntry(f, wake(f), false))
+	}
 	return sb.String()
 }
 
@@ -228,26 +580,22 @@ func buildSessionContext() string {
 // form (head, then indented name + tags); recently-touched entries add a third
 // line with a truncated outcome.
 func formatHookEntry(f *felt.Felt, recency time.Time, withOutcome bool) string {
-	icon := felt.StatusIcon(f.Status)
+	icon := fiberIcon(f)
 	line1 := fmt.Sprintf("%s %s\n", icon, hookEntryHead(f, recency))
 
 	tagStr := ""
 	if len(f.Tags) > 0 {
 		tagStr = fmt.Sprintf(" (%s)", strings.Join(f.Tags, ", "))
 	}
-	line2 := fmt.Sprintf("    %s%s\n", f.DisplayName(), tagStr)
+	line2 := fmt.Sprintf("    %s%s%s\n", f.DisplayName(), tagStr, impactBadge(f))
 
 	if !withOutcome || f.Outcome == "" {
 		return line1 + line2
 	}
 
 	outcome := strings.TrimSpace(f.Outcome)
-	// Match the previous hook's one-line outcome treatment: collapse internal
-	// whitespace and truncate at 100 chars with ellipsis.
-	outcome = strings.Join(strings.Fields(outcome), " ")
-	if len(outcome) > 100 {
-		outcome = outcome[:100] + "..."
-	}
+	// One line: collapse internal whitespace and cap at 100 characters.
+	outcome = truncateText(strings.Join(strings.Fields(outcome), " "), 100)
 	line3 := fmt.Sprintf("    → %s\n", outcome)
 	return line1 + line2 + line3
 }
@@ -260,10 +608,26 @@ func hookEntryHead(f *felt.Felt, recency time.Time) string {
 	if recency.IsZero() {
 		return f.ID
 	}
-	return recency.Local().Format("2006-01-02 15:04") + " — " + f.ID
+	return displayTime(recency).Format("2006-01-02 15:04") + " — " + f.ID
 }
 
 func buildSessionAttention(felts []*felt.Felt, now time.Time) string {
+	notes := sessionAttentionNotes(felts, now)
+	if len(notes) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## %s\n\n", sectionHeader("Attention"))
+	for _, note := range notes {
+		sb.WriteString(note)
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// sessionAttentionNotes returns up to three advisory notes on the shape of
+// the tree and the size and age of the tracked queue.
+func sessionAttentionNotes(felts []*felt.Felt, now time.Time) []string {
 	childrenByParent := make(map[string]int)
 	for _, f := range felts {
 		parts := strings.Split(f.ID, "/")
@@ -339,20 +703,10 @@ func buildSessionAttention(felts []*felt.Felt, now time.Time) string {
 		))
 	}
 
-	if len(notes) == 0 {
-		return ""
-	}
 	if len(notes) > 3 {
 		notes = notes[:3]
 	}
-
-	var sb strings.Builder
-	sb.WriteString("## Attention\n\n")
-	for _, note := range notes {
-		sb.WriteString(note)
-		sb.WriteString("\n\n")
-	}
-	return sb.String()
+	return notes
 }
 
 func isStaleSessionFiber(f *felt.Felt, now time.Time) bool {
@@ -412,11 +766,11 @@ type preToolInput struct {
 //   - Codex (transcript_path not under ~/.claude/projects/, or empty): mark, pass.
 //   - flag already set: pass.
 //   - otherwise: emit deny envelope.
-func runPreToolHook(stdin *os.File, stdout *os.File) error {
+func runPreToolHook(stdin io.Reader, stdout *os.File) error {
 	var input preToolInput
-	if err := json.NewDecoder(stdin).Decode(&input); err != nil {
+	if ok, err := parseHookPayload(stdin, "PreToolUse", &input, "session_id", "tool_name", "cwd"); !ok || err != nil {
 		// Can't parse input: silent pass. Better to lose the gate than block.
-		return nil
+		return err
 	}
 
 	if input.CWD == "" {
@@ -493,10 +847,10 @@ var postToolEditTools = map[string]struct{}{
 // PostToolUse hook must never fail the tool call, and losing one stamp is
 // cheaper than blocking. The frontmatter stamp is the recency mechanism —
 // a missed stamp just means one edit reads slightly stale.
-func runPostToolHook(stdin *os.File) error {
+func runPostToolHook(stdin io.Reader) error {
 	var input postToolInput
-	if err := json.NewDecoder(stdin).Decode(&input); err != nil {
-		return nil
+	if ok, err := parseHookPayload(stdin, "PostToolUse", &input, "tool_name", "tool_input"); !ok || err != nil {
+		return err
 	}
 	if _, ok := postToolEditTools[input.ToolName]; !ok {
 		return nil
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var hookSessionEndCmd = &cobra.Command{
+	Use:   "session-end",
+	Short: "SessionEnd: record what the session changed in the day's log fiber",
+	Long: `Reads the SessionEnd payload from stdin and, when hook.digest: true is set
+in .felt/config.yaml, appends a section to the day's log fiber
+(log/<date>, created on first use) listing the fibers the session opened,
+closed, and otherwise touched, so each day keeps a durable record in the
+store itself.
+
+The session is taken to have started at the first timestamp in its
+transcript, or, without one, when the session context was last built. A
+session that changed nothing writes nothing. Like the other hooks it
+passes silently on any error, except a malformed payload under --strict.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSessionEndHook(os.Stdin, time.Now())
+	},
+}
+
+func init() {
+	hookCmd.AddCommand(hookSessionEndCmd)
+	addHookPayloadFlags(hookSessionEndCmd)
+}
+
+type sessionEndInput struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	CWD            string `json:"cwd"`
+}
+
+// runSessionEndHook writes the session's digest into the daily log of the
+// store at the payload's cwd.
+func runSessionEndHook(stdin io.Reader, now time.Time) error {
+	var input sessionEndInput
+	if ok, err := parseHookPayload(stdin, "SessionEnd", &input, "session_id", "cwd"); !ok || err != nil {
+		return err
+	}
+	root, ok, err := felt.ProjectRootAt(input.CWD)
+	if err != nil || !ok {
+		return nil
+	}
+	storage := felt.NewStorage(root)
+	if cfg, err := storage.LoadConfig(); err != nil || !cfg.Hook.Digest {
+		return nil
+	}
+	start, ok := transcriptStart(input.TranscriptPath)
+	if !ok {
+		if start, ok, err = storage.SessionMark(); err != nil || !ok {
+			return nil
+		}
+	}
+	felts, err := storage.ListMetadata()
+	if err != nil {
+		return nil
+	}
+	digest := felt.BuildSessionDigest(felts, start, now)
+	if digest.Empty() {
+		return nil
+	}
+	_ = appendDailyLog(storage, digest, input.SessionID)
+	return nil
+}
+
+// appendDailyLog adds digest as a section at the end of its day's log
+// fiber, creating the fiber on the day's first digest.
+func appendDailyLog(storage *felt.Storage, digest *felt.SessionDigest, session string) error {
+	section := digest.Section(session, displayTime(digest.End).Location())
+	_, err := updateDailyLog(storage, digest.End, func(log *felt.Felt) {
+		if body := strings.TrimRight(log.Body, "\n"); body != "" {
+			log.Body = body + "\n\n" + section
+		} else {
+			log.Body = section
+		}
+	})
+	return err
+}
+
+// transcriptStart returns the first timestamp recorded in a Claude Code
+// transcript, a JSONL file whose entries carry a "timestamp" field. Only
+// the opening lines are read; ok is false when none of them has one.
+func transcriptStart(path string) (time.Time, bool) {
+	if path == "" {
+		return time.Time{}, false
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for n := 0; n < 50 && scanner.Scan(); n++ {
+		var entry struct {
+			Timestamp time.Time `json:"timestamp"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && !entry.Timestamp.IsZero() {
+			return entry.Timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
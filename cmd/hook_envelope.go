@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+// hookEnvelopeVersion is the newest hook payload schema felt reads. Claude
+// Code's payloads carry no version today, which reads as version 1. Fields
+// are looked up by name and unknown ones ignored, so a payload declaring a
+// newer version still parses; only --strict treats the mismatch as a problem.
+const hookEnvelopeVersion = 1
+
+// hookStrictEnv turns on --strict for every hook invocation, for plugin
+// hook scripts that cannot pass the flag through.
+const hookStrictEnv = "FELT_HOOK_STRICT"
+
+var (
+	hookStrict bool
+	hookReplay string
+)
+
+// addHookPayloadFlags registers --strict and --replay on a hook command that
+// reads its payload through parseHookPayload.
+func addHookPayloadFlags(c *cobra.Command) {
+	c.Flags().BoolVar(&hookStrict, "strict", false, "Reject a malformed payload and log it to .felt/"+felt.HookDebugLogName+"; also "+hookStrictEnv+"=1")
+	c.Flags().StringVar(&hookReplay, "replay", "", "Read the payload from this file instead of stdin")
+}
+
+// hookEnvelope is the part of a hook payload common to every event.
+type hookEnvelope struct {
+	Version       int
+	CWD           string
+	HookEventName string
+}
+
+// parseHookPayload reads a hook's payload — stdin, or the --replay file —
+// into v and validates it for event: the JSON must be an object, required
+// top-level fields must be present and non-empty, a hook_event_name must
+// match, and every field must decode into its type.
+//
+// ok is false when the payload could not be decoded at all; the hook then
+// passes silently, as it always has. Other problems are tolerated and v
+// holds what decoded. Under --strict, any problem is appended to the
+// store's hook debug log and returned as err instead.
+func parseHookPayload(stdin io.Reader, event string, v any, required ...string) (ok bool, err error) {
+	data, err := readHookPayload(stdin)
+	if err != nil {
+		return false, err
+	}
+	env, problems, ok := decodeHookPayload(data, event, v, required)
+	if hookReplay == "" {
+		recordHookPayload(event, env, data)
+	}
+	if len(problems) == 0 || !hookStrictMode() {
+		return ok, nil
+	}
+	logHookProblems(event, env, problems)
+	return ok, fmt.Errorf("hook %s: payload rejected: %s", event, strings.Join(problems, "; "))
+}
+
+func readHookPayload(stdin io.Reader) ([]byte, error) {
+	if hookReplay != "" {
+		data, err := os.ReadFile(hookReplay)
+		if err != nil {
+			return nil, fmt.Errorf("reading replay payload: %w", err)
+		}
+		return data, nil
+	}
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		// An unreadable stdin is an undecodable payload, not a hook failure.
+		return nil, nil
+	}
+	return data, nil
+}
+
+// decodeHookPayload is parseHookPayload's validation, without the I/O.
+func decodeHookPayload(data []byte, event string, v any, required []string) (hookEnvelope, []string, bool) {
+	env := hookEnvelope{Version: 1}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || fields == nil {
+		return env, []string{"payload is not a JSON object"}, false
+	}
+
+	var problems []string
+	if raw, ok := fields["version"]; ok {
+		if err := json.Unmarshal(raw, &env.Version); err != nil {
+			problems = append(problems, "version is not an integer")
+			env.Version = 1
+		} else if env.Version > hookEnvelopeVersion {
+			problems = append(problems, fmt.Sprintf("envelope version %d is newer than felt reads (%d)", env.Version, hookEnvelopeVersion))
+		}
+	}
+	_ = json.Unmarshal(fields["cwd"], &env.CWD)
+	_ = json.Unmarshal(fields["hook_event_name"], &env.HookEventName)
+	if env.HookEventName != "" && env.HookEventName != event {
+		problems = append(problems, fmt.Sprintf("hook_event_name is %q, not %q", env.HookEventName, event))
+	}
+	for _, key := range required {
+		raw, ok := fields[key]
+		if !ok || string(raw) == "null" || string(raw) == `""` {
+			problems = append(problems, "missing "+key)
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return env, append(problems, err.Error()), false
+		}
+		// encoding/json keeps decoding past a mistyped field, so v holds
+		// everything else; report the field rather than drop the payload.
+		problems = append(problems, fmt.Sprintf("%s is %s, want %s", typeErr.Field, typeErr.Value, typeErr.Type.Kind()))
+	}
+	return env, problems, true
+}
+
+func hookStrictMode() bool {
+	if hookStrict {
+		return true
+	}
+	v := strings.TrimSpace(os.Getenv(hookStrictEnv))
+	return v != "" && v != "0" && !strings.EqualFold(v, "false")
+}
+
+// hookStore returns the store at the payload's cwd, or the process's when
+// the payload did not name one.
+func hookStore(env hookEnvelope) (*felt.Storage, bool) {
+	dir := env.CWD
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	root, ok, err := felt.ProjectRootAt(dir)
+	if err != nil || !ok {
+		return nil, false
+	}
+	return felt.NewStorage(root), true
+}
+
+// recordHookPayload saves the raw payload in the store's hook log when
+// hook.log is on. Like the hooks themselves it never fails: a payload that
+// could not be saved is only a debugging aid lost.
+func recordHookPayload(event string, env hookEnvelope, data []byte) {
+	storage, ok := hookStore(env)
+	if !ok {
+		return
+	}
+	cfg, err := storage.LoadConfig()
+	if err != nil || !cfg.Hook.Log {
+		return
+	}
+	_, _ = storage.RecordHookPayload(event, data, time.Now(), cfg.HookLogKeep())
+}
+
+// logHookProblems records problems in the hook store's debug log. Logging
+// is best-effort: the returned rejection is what the harness sees.
+func logHookProblems(event string, env hookEnvelope, problems []string) {
+	storage, ok := hookStore(env)
+	if !ok {
+		return
+	}
+	p := felt.HookProblem{At: time.Now().UTC(), Event: event, Version: env.Version, Problems: problems, Replay: hookReplay}
+	if err := storage.RecordHookProblem(p); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var hookPreCompactCmd = &cobra.Command{
+	Use:   "precompact",
+	Short: "PreCompact: carry the active fibers into the compaction summary",
+	Long: `Reads the PreCompact payload from stdin and emits an additionalContext
+envelope listing the store's active fibers — id, name, and the last
+paragraph of each body, the latest note left on it — so the summary
+Claude Code writes when it compacts a long session keeps the work in
+flight instead of losing it with the dropped turns.
+
+At most ` + fmt.Sprint(preCompactLimit) + ` fibers are listed, most recently touched first. Outside a felt
+store, or with nothing active, it emits nothing. Like the other hooks it
+passes silently on any error, except a malformed payload under --strict.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPreCompactHook(os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	hookCmd.AddCommand(hookPreCompactCmd)
+	addHookPayloadFlags(hookPreCompactCmd)
+}
+
+// preCompactLimit caps the fibers the summary carries: compaction keeps a
+// digest, and a long list would crowd out the conversation it summarizes.
+const preCompactLimit = 12
+
+type preCompactInput struct {
+	SessionID string `json:"session_id"`
+	CWD       string `json:"cwd"`
+	Trigger   string `json:"trigger"`
+}
+
+func runPreCompactHook(stdin io.Reader, stdout *os.File) error {
+	var input preCompactInput
+	if ok, err := parseHookPayload(stdin, "PreCompact", &input, "session_id", "cwd"); !ok || err != nil {
+		return err
+	}
+	root, ok, err := felt.ProjectRootAt(input.CWD)
+	if err != nil || !ok {
+		return nil
+	}
+	felts, err := felt.NewStorage(root).List()
+	if err != nil {
+		return nil
+	}
+	context := buildPreCompactContext(felts)
+	if context == "" {
+		return nil
+	}
+	return encodeHookJSON(stdout, sessionEnvelope{HookSpecificOutput: sessionInner{
+		HookEventName:     "PreCompact",
+		AdditionalContext: context,
+	}})
+}
+
+// buildPreCompactContext renders the active fibers for a compaction summary,
+// or "" when none are active.
+func buildPreCompactContext(felts []*felt.Felt) string {
+	var active []*felt.Felt
+	for _, f := range felts {
+		if f.IsActive() {
+			active = append(active, f)
+		}
+	}
+	if len(active) == 0 {
+		return ""
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].RecencyAnchor().After(active[j].RecencyAnchor())
+	})
+
+	var sb strings.Builder
+	sb.WriteString("# Felt state before compaction\n\n")
+	sb.WriteString("Keep these active fibers in the summary; `felt show <id>` has the rest.\n\n")
+	for _, f := range active[:min(len(active), preCompactLimit)] {
+		sb.WriteString(formatHookEntry(f, f.RecencyAnchor(), false))
+		if note := latestNote(f.Body); note != "" {
+			fmt.Fprintf(&sb, "    latest: %s\n", note)
+		}
+	}
+	if held := len(active) - preCompactLimit; held > 0 {
+		fmt.Fprintf(&sb, "\n*%d more active — felt ls -s active lists them.*\n", held)
+	}
+	return sb.String()
+}
+
+// latestNote returns the last paragraph of body on one line, capped at 200
+// characters: fibers gather notes at the end, so it is the freshest.
+func latestNote(body string) string {
+	paragraphs := strings.Split(strings.TrimSpace(body), "\n\n")
+	last := strings.Join(strings.Fields(paragraphs[len(paragraphs)-1]), " ")
+	return truncateText(last, 200)
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var hookStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop: remind the agent of active fibers before it ends its turn",
+	Long: `Reads the Stop payload from stdin and, when the store has active fibers,
+lists them so the work is commented on or closed before the agent stops.
+
+hook.stop in .felt/config.yaml picks what happens:
+
+  remind  (default) emit the list as a systemMessage the user sees
+  block   exit 2 with the list on stderr, which Claude Code hands back to
+          the agent, so it records outcomes before it may stop
+  off     do nothing
+
+A Stop payload with stop_hook_active set — the agent is already
+continuing because a Stop hook blocked — is only reminded, never blocked
+again, so a fiber the agent leaves active on purpose cannot hold it in a
+loop. At most ` + fmt.Sprint(stopLimit) + ` fibers are listed, most recently touched first.
+Outside a felt store, or with nothing active, it emits nothing. Like the
+other hooks it passes silently on any error, except a malformed payload
+under --strict.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStopHook(os.Stdin, os.Stdout)
+	},
+}
+
+func init() {
+	hookCmd.AddCommand(hookStopCmd)
+	addHookPayloadFlags(hookStopCmd)
+}
+
+// stopLimit caps the fibers a Stop reminder names; past a handful the
+// agent skims rather than acts.
+const stopLimit = 8
+
+type stopInput struct {
+	SessionID      string `json:"session_id"`
+	CWD            string `json:"cwd"`
+	StopHookActive bool   `json:"stop_hook_active"`
+}
+
+// stopEnvelope is the Stop hook's non-blocking output: Claude Code shows
+// SystemMessage to the user.
+type stopEnvelope struct {
+	SystemMessage string `json:"systemMessage"`
+}
+
+// hookBlockError ends a hook with exit code 2, which Claude Code reads as
+// "block": Reason, printed to stderr, is handed to the agent.
+type hookBlockError struct {
+	Reason string
+}
+
+func (e *hookBlockError) Error() string { return strings.TrimRight(e.Reason, "\n") }
+
+func runStopHook(stdin io.Reader, stdout *os.File) error {
+	var input stopInput
+	if ok, err := parseHookPayload(stdin, "Stop", &input, "session_id", "cwd"); !ok || err != nil {
+		return err
+	}
+	root, ok, err := felt.ProjectRootAt(input.CWD)
+	if err != nil || !ok {
+		return nil
+	}
+	storage := felt.NewStorage(root)
+	cfg, err := storage.LoadConfig()
+	if err != nil {
+		return nil
+	}
+	if cfg.Hook.Stop == felt.HookStopOff {
+		return nil
+	}
+	felts, err := storage.ListMetadata()
+	if err != nil {
+		return nil
+	}
+	reminder := buildStopReminder(felts)
+	if reminder == "" {
+		return nil
+	}
+	if cfg.Hook.Stop == felt.HookStopBlock && !input.StopHookActive {
+		return &hookBlockError{Reason: reminder}
+	}
+	return encodeHookJSON(stdout, stopEnvelope{SystemMessage: reminder})
+}
+
+// buildStopReminder lists the active fibers and asks for their outcomes,
+// or returns "" when none are active.
+func buildStopReminder(felts []*felt.Felt) string {
+	var active []*felt.Felt
+	for _, f := range felts {
+		if f.IsActive() {
+			active = append(active, f)
+		}
+	}
+	if len(active) == 0 {
+		return ""
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].RecencyAnchor().After(active[j].RecencyAnchor())
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "felt: %d active %s. Before stopping, note progress on each (felt edit <id> --append-body) or close it (felt edit <id> -s closed -o \"...\"):\n",
+		len(active), pluralize(len(active), "fiber", "fibers"))
+	for _, f := range active[:min(len(active), stopLimit)] {
+		fmt.Fprintf(&sb, "  %s — %s\n", f.ID, f.DisplayName())
+	}
+	if held := len(active) - stopLimit; held > 0 {
+		fmt.Fprintf(&sb, "  … %d more (felt ls -s active)\n", held)
+	}
+	return sb.String()
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var hookTranscriptDryRun bool
+
+var hookTranscriptCmd = &cobra.Command{
+	Use:   "transcript",
+	Short: "SessionEnd: file the session's decisions and open questions as fibers",
+	Long: `Reads a SessionEnd payload from stdin, opens the transcript it names, and
+mines the session's messages for decisions ("we decided …", "Decision: …")
+and unresolved questions ("Open question: …", "should we …?"), so context
+that never became a to-do still lands in the DAG.
+
+Nothing is written unless hook.transcript.mine: true is set in
+.felt/config.yaml. Decisions become notes tagged decision with the
+decision as their outcome; questions become open fibers tagged question.
+Each body quotes the line it came from. A candidate named like an existing
+fiber is skipped, so replaying a session files nothing twice.
+
+hook.transcript.under files the fibers beneath an existing fiber, and
+hook.transcript.decisions and .questions add regular expressions for each
+kind; a pattern's first capture group names the fiber. --dry-run lists the
+candidates (as JSON with -j) and writes nothing, whatever the config says —
+with --replay, a way to tune the patterns against a real session. Like the
+other hooks it otherwise passes silently on any error, except a malformed
+payload under --strict.`,
+	Example:      `  felt hook transcript --replay session-end.json --dry-run`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTranscriptHook(os.Stdin, hookTranscriptDryRun)
+	},
+}
+
+func init() {
+	hookCmd.AddCommand(hookTranscriptCmd)
+	hookTranscriptCmd.Flags().BoolVar(&hookTranscriptDryRun, "dry-run", false, "List the candidates without creating anything")
+	addHookPayloadFlags(hookTranscriptCmd)
+}
+
+// runTranscriptHook mines the session transcript named by a SessionEnd
+// payload. Under dryRun the candidates are printed and problems reported;
+// otherwise they are filed, and problems pass silently.
+func runTranscriptHook(stdin io.Reader, dryRun bool) error {
+	var input sessionEndInput
+	if ok, err := parseHookPayload(stdin, "SessionEnd", &input, "session_id", "cwd", "transcript_path"); !ok || err != nil {
+		return err
+	}
+	root, ok, err := felt.ProjectRootAt(input.CWD)
+	if err != nil || !ok {
+		return nil
+	}
+	storage := felt.NewStorage(root)
+	cfg, err := storage.LoadConfig()
+	if err != nil || !(cfg.Hook.Transcript.Mine || dryRun) {
+		return nil
+	}
+	var felts []*felt.Felt
+	candidates, err := mineSessionTranscript(cfg, input.TranscriptPath)
+	if err == nil {
+		if felts, err = storage.ListMetadata(); err == nil {
+			candidates = unfiledCandidates(felts, candidates)
+		}
+	}
+	if dryRun {
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			if candidates == nil {
+				candidates = []felt.TranscriptCandidate{}
+			}
+			return outputJSON(candidates)
+		}
+		for _, c := range candidates {
+			fmt.Printf("%d: %s: %s\n", c.Line, c.Kind, c.Name)
+		}
+		return nil
+	}
+	if err != nil || len(candidates) == 0 {
+		return nil
+	}
+
+	parent := ""
+	if under := cfg.Hook.Transcript.Under; under != "" {
+		target, err := felt.FindByScope(felts, "", under)
+		if err != nil {
+			return nil
+		}
+		parent = target.ID
+	}
+	session := input.SessionID
+	if len(session) > 8 {
+		session = session[:8]
+	}
+	for _, c := range candidates {
+		if _, err := createTranscriptFiber(storage, cfg, parent, "session "+session, c); err != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+// mineSessionTranscript extracts candidates from the text of each user and
+// assistant message in a Claude Code transcript. A candidate's Line is the
+// transcript line holding its message.
+func mineSessionTranscript(cfg *felt.Config, path string) ([]felt.TranscriptCandidate, error) {
+	rules, err := felt.CompileSessionRules(cfg.Hook.Transcript)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var out []felt.TranscriptCandidate
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		for _, c := range felt.ExtractSessionCandidates(transcriptMessageText(scanner.Bytes()), rules) {
+			c.Line = n
+			out = append(out, c)
+		}
+	}
+	return out, scanner.Err()
+}
+
+// transcriptMessageText returns the prose of one transcript entry: a user
+// or assistant message's string content or text blocks. Tool calls, tool
+// results, and other entries yield "".
+func transcriptMessageText(line []byte) string {
+	var entry struct {
+		Type    string `json:"type"`
+		Message struct {
+			Content json.RawMessage `json:"content"`
+		} `json:"message"`
+	}
+	if json.Unmarshal(line, &entry) != nil || (entry.Type != "user" && entry.Type != "assistant") {
+		return ""
+	}
+	var text string
+	if json.Unmarshal(entry.Message.Content, &text) == nil {
+		return text
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(entry.Message.Content, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, b := range blocks {
+		if b.Type == "text" {
+			parts = append(parts, b.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// unfiledCandidates drops candidates named like an existing fiber, or like
+// an earlier candidate, ignoring case.
+func unfiledCandidates(felts []*felt.Felt, candidates []felt.TranscriptCandidate) []felt.TranscriptCandidate {
+	seen := make(map[string]bool, len(felts))
+	for _, f := range felts {
+		seen[strings.ToLower(f.Name)] = true
+	}
+	var out []felt.TranscriptCandidate
+	for _, c := range candidates {
+		key := strings.ToLower(c.Name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var inCmd = &cobra.Command{
+	Use:   "in <thought>",
+	Short: "Capture a thought to the inbox",
+	Long: `Files a thought as a new fiber named by it and tagged inbox — no slug,
+status, or tags to decide up front. The slug is derived from the thought.
+Process the inbox later with felt triage.`,
+	Example: `  felt in "check whether the mocks use the new n(z)"
+  felt in ask Ana about jackknife patch counts`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository (run 'felt init' first)")
+		}
+		f, err := felt.NewStorage(root).Capture(strings.Join(args, " "), time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Println(f.ID)
+		return nil
+	},
+}
+
+var triageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Process inbox captures into fibers",
+	Long: `Walks the inbox (fibers tagged inbox), oldest first, and asks what each
+capture is. Tags, data-flow inputs, and a new slug can be added first; then
+file it as open, active, or closed work or as an untracked note — which drops
+the inbox tag — discard it, or skip it for next time.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+		inbox := felt.InboxFelts(felts)
+		if len(inbox) == 0 {
+			fmt.Println("Inbox empty")
+			return nil
+		}
+
+		in := bufio.NewReader(cmd.InOrStdin())
+		filed, discarded := 0, 0
+		for i, meta := range inbox {
+			fmt.Printf("[%d/%d] %s — %s\n", i+1, len(inbox), meta.ID, meta.DisplayName())
+			result, err := triageFiber(storage, felts, meta.ID, in)
+			if err != nil {
+				return err
+			}
+			switch result {
+			case triageFiled:
+				filed++
+			case triageDiscarded:
+				discarded++
+			}
+			if result == triageQuit {
+				break
+			}
+		}
+		left := len(inbox) - filed - discarded
+		fmt.Printf("Filed %d, discarded %d, %d left in inbox\n", filed, discarded, left)
+		return nil
+	},
+}
+
+const (
+	triageFiled     = "filed"
+	triageDiscarded = "discarded"
+	triageSkipped   = "skipped"
+	triageQuit      = "quit"
+)
+
+// triageFiber prompts for one inbox capture until it is filed, discarded,
+// skipped, or the walk is quit. Tag, input, and move edits are written as
+// they are made; felts resolves input refs.
+func triageFiber(storage *felt.Storage, felts []*felt.Felt, id string, in *bufio.Reader) (string, error) {
+	f, err := storage.Read(id)
+	if err != nil {
+		return "", err
+	}
+	cfg, err := storage.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	write := func() error {
+		f.Touch(time.Now())
+		return storage.Write(f)
+	}
+	for {
+		line, eof, err := promptLine(in, "  [o]pen, [a]ctive, [c]losed, [n]ote, [t]ag, [i]nput, [m]ove, [d]iscard, [s]kip, [q]uit? ")
+		if err != nil {
+			return "", err
+		}
+		status := ""
+		switch strings.ToLower(line) {
+		case "o", "open":
+			status = felt.StatusOpen
+		case "a", "active":
+			status = felt.StatusActive
+		case "c", "closed", "close":
+			status = felt.StatusClosed
+		case "n", "note":
+		case "t", "tag":
+			tags, _, err := promptLine(in, "  tags: ")
+			if err != nil {
+				return "", err
+			}
+			for _, tag := range splitTags(tags) {
+				f.AddTag(tag)
+			}
+			if err := write(); err != nil {
+				return "", err
+			}
+			continue
+		case "i", "input":
+			ref, _, err := promptLine(in, "  input from: ")
+			if err != nil {
+				return "", err
+			}
+			if err := triageAddInput(f, felts, ref); err != nil {
+				fmt.Printf("  %s\n", err)
+				continue
+			}
+			if err := write(); err != nil {
+				return "", err
+			}
+			continue
+		case "m", "move":
+			slug, _, err := promptLine(in, "  new slug: ")
+			if err != nil {
+				return "", err
+			}
+			newID := felt.SlugifyPath(slug)
+			if newID == "" || newID == f.ID {
+				continue
+			}
+			if err := storage.MoveSubtree(f.ID, newID); err != nil {
+				fmt.Printf("  %s\n", err)
+				continue
+			}
+			fmt.Printf("  Moved to %s\n", newID)
+			if f, err = storage.Read(newID); err != nil {
+				return "", err
+			}
+			continue
+		case "d", "discard":
+			if err := storage.Delete(f.ID); err != nil {
+				return "", err
+			}
+			fmt.Printf("  Discarded %s\n", f.ID)
+			return triageDiscarded, nil
+		case "s", "skip":
+			return triageSkipped, nil
+		case "q", "quit":
+			return triageQuit, nil
+		default:
+			if eof {
+				fmt.Println()
+				return triageQuit, nil
+			}
+			continue
+		}
+
+		if err := cfg.CheckStatusTransition(f.Status, status); err != nil {
+			fmt.Printf("  %s\n", err)
+			continue
+		}
+		now := time.Now()
+		if status == felt.StatusClosed {
+			outcome, _, err := promptLine(in, "  outcome: ")
+			if err != nil {
+				return "", err
+			}
+			f.Outcome = outcome
+			f.ClosedAt = &now
+		}
+		prev := f.Status
+		f.Status = status
+		f.NoteStatusChange(prev, now)
+		f.RemoveTag(felt.InboxTag)
+		if err := write(); err != nil {
+			return "", err
+		}
+		if status == "" {
+			status = "note"
+		}
+		fmt.Printf("  Filed %s as %s\n", f.ID, status)
+		return triageFiled, nil
+	}
+}
+
+// triageAddInput resolves ref's fiber against the store and records it as a
+// data-flow input on f.
+func triageAddInput(f *felt.Felt, felts []*felt.Felt, ref string) error {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return fmt.Errorf("no input ref given")
+	}
+	fiberID, fragment, _ := strings.Cut(ref, ".")
+	target, err := felt.FindByPrefix(felts, fiberID)
+	if err != nil {
+		return err
+	}
+	if fragment != "" {
+		ref = target.ID + "." + fragment
+	} else {
+		ref = target.ID
+	}
+	return f.AddDataFlowInput(ref)
+}
+
+// promptLine prints label and reads one trimmed line; eof reports that
+// input ended, so callers stop rather than re-prompt forever.
+func promptLine(in *bufio.Reader, label string) (line string, eof bool, err error) {
+	fmt.Print(label)
+	line, err = in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", false, err
+	}
+	return strings.TrimSpace(line), err == io.EOF, nil
+}
+
+func init() {
+	rootCmd.AddCommand(inCmd)
+	rootCmd.AddCommand(triageCmd)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestInCapturesUntrackedInboxFiber(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+
+	for _, want := range []string{"check-the-n-z-cut", "check-the-n-z-cut-2"} {
+		out, err := runCommand(t, dir, "in", "check", "the n(z) cut")
+		if err != nil || strings.TrimSpace(out) != want {
+			t.Fatalf("in = %q, %v; want %s", out, err, want)
+		}
+	}
+	f, err := storage.Read("check-the-n-z-cut")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name != "check the n(z) cut" || f.Status != "" || strings.Join(f.Tags, ",") != felt.InboxTag {
+		t.Fatalf("capture = name %q status %q tags %v", f.Name, f.Status, f.Tags)
+	}
+}
+
+func TestTriageFilesDiscardsAndSkipsInboxItems(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "methods/covariance", Name: "Covariance", Status: felt.StatusClosed}); err != nil {
+		t.Fatal(err)
+	}
+	base := mustParseTime(t, "2026-05-01T09:00:00Z")
+	for i, thought := range []string{"rerun the fit", "old idea", "maybe later"} {
+		if _, err := storage.Capture(thought, base.Add(time.Duration(i)*time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rootCmd.SetIn(strings.NewReader("t\nmethods\ni\ncovariance\nx\no\nd\ns\n"))
+	defer rootCmd.SetIn(nil)
+	out, err := runCommand(t, dir, "triage")
+	if err != nil {
+		t.Fatalf("triage: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"[1/3] rerun-the-fit — rerun the fit",
+		"Filed rerun-the-fit as open",
+		"Discarded old-idea",
+		"Filed 1, discarded 1, 1 left in inbox",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("triage output missing %q:\n%s", want, out)
+		}
+	}
+
+	f, err := storage.Read("rerun-the-fit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := f.DataFlowInputs()
+	if f.Status != felt.StatusOpen || strings.Join(f.Tags, ",") != "methods" || len(inputs) != 1 || inputs[0].From != "methods/covariance" || inputs[0].InputID != "covariance" {
+		t.Fatalf("filed fiber = status %q tags %v inputs %v", f.Status, f.Tags, inputs)
+	}
+	if _, err := storage.Read("old-idea"); err == nil {
+		t.Fatal("discarded capture still exists")
+	}
+	left, err := storage.Read("maybe-later")
+	if err != nil || !left.HasTag(felt.InboxTag) {
+		t.Fatalf("skipped capture = %v, %v; want still in inbox", left, err)
+	}
+}
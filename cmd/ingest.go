@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ingestYes    bool
+	ingestDryRun bool
+	ingestUnder  string
+	ingestTSV    bool
+)
+
+var ingestCmd = &cobra.Command{
+	Use:   "ingest",
+	Short: "Turn external notes and spreadsheets into fibers",
+}
+
+var ingestTranscriptCmd = &cobra.Command{
+	Use:   "transcript <file>",
+	Short: "Propose fibers for the actions and decisions in a transcript",
+	Long: `Scans a meeting transcript or dictated notes for lines that read as an
+action ("Action: …", "TODO: …", "- [ ] …", "I'll …", "Ana will …") or a
+decision ("Decision: …", "we decided …", "let's go with …"), and proposes
+one fiber per line. Each candidate is confirmed, renamed, or dropped before
+anything is written; --yes creates them all and --dry-run only lists them.
+
+Actions become open fibers; decisions become notes tagged decision with the
+decision as their outcome. Each body quotes the source line. --under files
+the new fibers beneath an existing fiber, such as the meeting's own.`,
+	Example: `  felt ingest transcript standup-2026-05-12.txt
+  felt ingest transcript memo.txt --under meetings/telecon --yes
+  felt ingest transcript notes.md --dry-run -j`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		candidates := felt.ExtractTranscriptCandidates(string(data))
+		if ingestDryRun {
+			if jsonOutput {
+				if candidates == nil {
+					candidates = []felt.TranscriptCandidate{}
+				}
+				return outputJSON(candidates)
+			}
+			for _, c := range candidates {
+				fmt.Printf("%d: %s: %s\n", c.Line, c.Kind, c.Name)
+			}
+			return nil
+		}
+		if len(candidates) == 0 {
+			fmt.Println("No actions or decisions found")
+			return nil
+		}
+
+		storage := felt.NewStorage(root)
+		parent := ""
+		if ingestUnder != "" {
+			target, err := storage.FindMetadataInScope(resolveCommandScope(root), ingestUnder)
+			if err != nil {
+				return err
+			}
+			parent = target.ID
+		}
+		cfg, err := storage.LoadConfig()
+		if err != nil {
+			return err
+		}
+
+		in := bufio.NewReader(cmd.InOrStdin())
+		source := filepath.Base(args[0])
+		created := 0
+	candidates:
+		for i, c := range candidates {
+			if !ingestYes {
+				fmt.Printf("[%d/%d] %s: %s (line %d)\n", i+1, len(candidates), c.Kind, c.Name, c.Line)
+			prompt:
+				for {
+					line, eof, err := promptLine(in, "  [y]es, [n]o, [r]ename, [q]uit? ")
+					if err != nil {
+						return err
+					}
+					switch strings.ToLower(line) {
+					case "y", "yes":
+						break prompt
+					case "n", "no":
+						continue candidates
+					case "r", "rename":
+						name, _, err := promptLine(in, "  name: ")
+						if err != nil {
+							return err
+						}
+						if name != "" {
+							c.Name = name
+						}
+						break prompt
+					case "q", "quit":
+						break candidates
+					}
+					if eof {
+						fmt.Println()
+						break candidates
+					}
+				}
+			}
+			f, err := createTranscriptFiber(storage, cfg, parent, source, c)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Created %s\n", f.ID)
+			created++
+		}
+		fmt.Printf("Created %d of %d %s\n", created, len(candidates), pluralize(len(candidates), "candidate", "candidates"))
+		return nil
+	},
+}
+
+var ingestCSVCmd = &cobra.Command{
+	Use:   "csv <file>",
+	Short: "Create fibers from a CSV or TSV spreadsheet",
+	Long: `Creates one fiber per row of a spreadsheet, such as one felt ls --csv
+wrote or a tracker exported. The header line names the columns, in any
+order and any case:
+
+  id          the row's id; kept when it is free, else generated
+  title       the fiber's name (required)
+  status      open, active, or closed (empty is a statusless note)
+  tags        tags, separated by ";"
+  depends_on  ids of the fibers it reads, separated by ";"
+  created     RFC 3339 time or 2006-01-02 date (empty is now)
+  closed      the same; implies status closed
+  outcome     the outcome
+
+A row whose id is missing, taken, or not a valid fiber id gets one
+generated from its title, as felt add does. Each depends_on value names
+another row by its id in the file, or an existing fiber, and becomes a
+data-flow input. The whole file is checked before anything is written and
+is created in one transaction, so a bad row creates nothing.
+
+A file ending in .tsv, or --tsv, is read as tab-separated.`,
+	Example: `  felt ingest csv tracker-export.csv
+  felt ingest csv plan.tsv --dry-run`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		comma := ','
+		if ingestTSV || strings.EqualFold(filepath.Ext(args[0]), ".tsv") {
+			comma = '\t'
+		}
+		rows, err := felt.ReadCSV(file, comma)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(args[0]), err)
+		}
+		storage := felt.NewStorage(root)
+		ops, err := storage.CSVImportOps(rows)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(args[0]), err)
+		}
+		if !ingestDryRun {
+			if ops, err = storage.ApplyOps(ops, time.Now()); err != nil {
+				return err
+			}
+		}
+		if jsonOutput {
+			if ops == nil {
+				ops = []felt.ApplyOp{}
+			}
+			return outputJSON(ops)
+		}
+		verb, links := "Created", 0
+		if ingestDryRun {
+			verb = "Would create"
+		}
+		for _, op := range ops {
+			switch op.Op {
+			case felt.OpAdd:
+				fmt.Printf("%s %s — %s\n", verb, op.ID, op.Name)
+			case felt.OpLink:
+				links++
+			}
+		}
+		fmt.Printf("%s %d %s, %d %s\n", verb, len(rows), pluralize(len(rows), "fiber", "fibers"), links, pluralize(links, "dependency", "dependencies"))
+		return nil
+	},
+}
+
+// createTranscriptFiber writes c as a new fiber under parent (or at the top
+// level), slugged from its name like felt in.
+func createTranscriptFiber(storage *felt.Storage, cfg *felt.Config, parent, source string, c felt.TranscriptCandidate) (*felt.Felt, error) {
+	title := c.Name
+	if cfg.Slug.Transliterate {
+		title = felt.Transliterate(title)
+	}
+	base, err := felt.GenerateID(title)
+	if err != nil {
+		return nil, err
+	}
+	if parent != "" {
+		base = parent + "/" + base
+	}
+	id, err := storage.AvailableID(base)
+	if err != nil {
+		return nil, err
+	}
+	f, err := felt.New(id, c.Name)
+	if err != nil {
+		return nil, err
+	}
+	if c.Kind == felt.CandidateAction || c.Kind == felt.CandidateQuestion {
+		if err := cfg.CheckStatusTransition("", felt.StatusOpen); err != nil {
+			return nil, err
+		}
+	}
+	switch c.Kind {
+	case felt.CandidateAction:
+		f.Status = felt.StatusOpen
+		f.NoteStatusChange("", f.CreatedAt)
+	case felt.CandidateDecision:
+		f.AddTag("decision")
+		f.Outcome = c.Name
+	case felt.CandidateQuestion:
+		f.Status = felt.StatusOpen
+		f.NoteStatusChange("", f.CreatedAt)
+		f.AddTag("question")
+	}
+	f.Body = fmt.Sprintf("From %s, line %d:\n\n> %s", source, c.Line, c.Text)
+	if err := storage.EnsureAvailableUID(f); err != nil {
+		return nil, err
+	}
+	f.Touch(f.CreatedAt)
+	if err := storage.Write(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func init() {
+	rootCmd.AddCommand(ingestCmd)
+	ingestCmd.AddCommand(ingestTranscriptCmd)
+	ingestTranscriptCmd.Flags().BoolVarP(&ingestYes, "yes", "y", false, "Create every candidate without asking")
+	ingestTranscriptCmd.Flags().BoolVar(&ingestDryRun, "dry-run", false, "List the candidates without creating anything")
+	ingestTranscriptCmd.Flags().StringVar(&ingestUnder, "under", "", "Create the fibers beneath this existing fiber")
+	ingestCmd.AddCommand(ingestCSVCmd)
+	ingestCSVCmd.Flags().BoolVar(&ingestDryRun, "dry-run", false, "List the fibers without creating anything")
+	ingestCSVCmd.Flags().BoolVar(&ingestTSV, "tsv", false, "Read the file as tab-separated, whatever its extension")
+}
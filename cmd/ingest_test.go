@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestIngestTranscriptConfirmsEachCandidate(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "telecon", Name: "Telecon"}); err != nil {
+		t.Fatal(err)
+	}
+	memo := filepath.Join(dir, "memo.txt")
+	if err := os.WriteFile(memo, []byte("Ana: I'll rerun the mocks.\nsmall talk\nDecision: keep the Gaussian prior\nTODO: tidy plots\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { ingestUnder, ingestYes, ingestDryRun = "", false, false }()
+
+	rootCmd.SetIn(strings.NewReader("y\nr\nGaussian damping prior\nn\n"))
+	defer rootCmd.SetIn(nil)
+	out, err := runCommand(t, dir, "ingest", "transcript", memo, "--under", "telecon")
+	if err != nil {
+		t.Fatalf("ingest: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"[1/3] action: I'll rerun the mocks (line 1)",
+		"Created telecon/i-ll-rerun-the-mocks",
+		"Created telecon/gaussian-damping-prior",
+		"Created 2 of 3 candidates",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("ingest output missing %q:\n%s", want, out)
+		}
+	}
+
+	action, err := storage.Read("telecon/i-ll-rerun-the-mocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if action.Status != felt.StatusOpen || action.Body != "From memo.txt, line 1:\n\n> Ana: I'll rerun the mocks." {
+		t.Fatalf("action = status %q body %q", action.Status, action.Body)
+	}
+	decision, err := storage.Read("telecon/gaussian-damping-prior")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decision.Status != "" || !decision.HasTag("decision") || decision.Outcome != "Gaussian damping prior" {
+		t.Fatalf("decision = status %q tags %v outcome %q", decision.Status, decision.Tags, decision.Outcome)
+	}
+	if _, err := storage.Read("telecon/tidy-plots"); err == nil {
+		t.Fatal("declined candidate was created")
+	}
+}
+
+func TestLsCSVExportsForIngestCSV(t *testing.T) {
+	src := t.TempDir()
+	storage := felt.NewStorage(src)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	mocks := &felt.Felt{ID: "mocks", Name: "Run the mocks", Status: felt.StatusOpen, Tags: []string{"sim"}, CreatedAt: created}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, CreatedAt: created.Add(time.Hour)}
+	if err := fit.AddDataFlowInput("mocks"); err != nil {
+		t.Fatal(err)
+	}
+	idea := &felt.Felt{ID: "idea", Name: "An idea", CreatedAt: created.Add(2 * time.Hour)}
+	for _, f := range []*felt.Felt{mocks, fit, idea} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func() { lsTSV, lsStatus, ingestDryRun = false, "", false }()
+
+	out, err := runCommand(t, src, "ls", "--tsv", "-s", "all")
+	if err != nil {
+		t.Fatalf("ls --tsv: %v\n%s", err, out)
+	}
+	if !strings.HasPrefix(out, "id\ttitle\tstatus\ttags\tdepends_on\tcreated\tclosed\toutcome\nmocks\tRun the mocks\topen\tsim\t\t2026-03-02T09:00:00Z") {
+		t.Fatalf("ls --tsv =\n%s", out)
+	}
+	lsTSV, lsStatus = false, ""
+
+	dst := t.TempDir()
+	if err := felt.NewStorage(dst).Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	sheet := filepath.Join(dst, "fibers.tsv")
+	if err := os.WriteFile(sheet, []byte(out), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCommand(t, dst, "ingest", "csv", sheet, "--dry-run")
+	if err != nil || !strings.Contains(out, "Would create 3 fibers, 1 dependency") {
+		t.Fatalf("dry run: %v\n%s", err, out)
+	}
+	if _, err := felt.NewStorage(dst).Read("mocks"); err == nil {
+		t.Fatal("dry run created a fiber")
+	}
+	ingestDryRun = false
+
+	out, err = runCommand(t, dst, "ingest", "csv", sheet)
+	if err != nil || !strings.Contains(out, "Created fit — Fit") {
+		t.Fatalf("ingest csv: %v\n%s", err, out)
+	}
+	got, err := felt.NewStorage(dst).Read("fit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in := got.DataFlowInputs(); len(in) != 1 || in[0].From != "mocks" || !got.CreatedAt.Equal(fit.CreatedAt) {
+		t.Fatalf("imported fit = %+v, inputs %+v", got, in)
+	}
+	if note, err := felt.NewStorage(dst).Read("idea"); err != nil || note.Status != "" {
+		t.Fatalf("imported note = %+v, %v; want it statusless", note, err)
+	}
+}
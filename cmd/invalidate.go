@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	invalidateReason   string
+	invalidateNoReopen bool
+)
+
+var invalidateCmd = &cobra.Command{
+	Use:   "invalidate <id>",
+	Short: "Mark a closed decision as wrong and flag the work built on it",
+	Long: `Records that a closed decision turned out wrong: the fiber gets an
+invalidated: entry with the reason and stays closed, so its outcome still
+says what was decided. Every fiber downstream of it through data-flow
+inputs is listed; those that already closed are reopened (--no-reopen
+leaves them closed), and felt show on any of them opens with a warning
+naming the invalidated decision.`,
+	Example: `  felt invalidate mask-choice -r "the star mask misses the bright halos"
+  felt invalidate prior-widths -r "priors were set from the blinded run" --no-reopen`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		if invalidateReason == "" {
+			return fmt.Errorf("--reason is required")
+		}
+
+		storage := felt.NewStorage(root)
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		affected, err := storage.Invalidate(target.ID, invalidateReason, time.Now(), !invalidateNoReopen)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			if affected == nil {
+				affected = []felt.InvalidatedDownstream{}
+			}
+			return outputJSON(affected)
+		}
+
+		fmt.Printf("Invalidated %s\n", target.ID)
+		if len(affected) == 0 {
+			fmt.Println("No downstream fibers")
+			return nil
+		}
+		fmt.Printf("%d downstream %s:\n", len(affected), pluralize(len(affected), "fiber", "fibers"))
+		for _, d := range affected {
+			mark := "flagged"
+			if d.Reopened {
+				mark = "reopened"
+			}
+			fmt.Printf("  %s %s  %s (%s)\n", fiberIcon(d.Felt), d.Felt.ID, d.Felt.DisplayName(), mark)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(invalidateCmd)
+	invalidateCmd.Flags().StringVarP(&invalidateReason, "reason", "r", "", "Why the decision no longer holds (required)")
+	invalidateCmd.Flags().BoolVar(&invalidateNoReopen, "no-reopen", false, "Flag closed downstream fibers without reopening them")
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestInvalidateFlagsDownstreamInShow(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	closedAt := time.Now().Add(-time.Hour)
+	mask := &felt.Felt{ID: "mask", Name: "Mask choice", Status: felt.StatusClosed, ClosedAt: &closedAt, CreatedAt: closedAt}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusClosed, ClosedAt: &closedAt, CreatedAt: closedAt}
+	mustShowExtra(t, fit, "inputs", []map[string]any{{"id": "mask", "from": "mask"}})
+	for _, f := range []*felt.Felt{mask, fit} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", f.ID, err)
+		}
+	}
+	defer func() { invalidateReason, invalidateNoReopen = "", false }()
+
+	if _, err := runCommand(t, dir, "invalidate", "mask"); err == nil {
+		t.Fatal("invalidate without --reason succeeded, want error")
+	}
+	out, err := runCommand(t, dir, "invalidate", "mask", "-r", "misses bright halos", "--no-reopen")
+	if err != nil {
+		t.Fatalf("invalidate: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Invalidated mask") || !strings.Contains(out, "fit  Fit (flagged)") {
+		t.Fatalf("invalidate output mismatch:\n%s", out)
+	}
+	if f, err := storage.Read("fit"); err != nil || !f.IsClosed() {
+		t.Fatalf("fit reopened despite --no-reopen: %v %v", f, err)
+	}
+
+	reset := saveShowGlobals()
+	defer reset()
+	out, err = runCommand(t, dir, "show", "fit")
+	if err != nil {
+		t.Fatalf("show: %v\n%s", err, out)
+	}
+	if !strings.HasPrefix(out, "⚠ Upstream decision mask was invalidated: misses bright halos\n") {
+		t.Fatalf("show missing invalidation banner:\n%s", out)
+	}
+	out, err = runCommand(t, dir, "show", "mask", "-d", "compact")
+	if err != nil {
+		t.Fatalf("show mask: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Invalidated: misses bright halos") {
+		t.Fatalf("show mask missing invalidation:\n%s", out)
+	}
+}
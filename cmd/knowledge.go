@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	knowledgeLimit int
+	knowledgeTags  []string
+)
+
+var knowledgeCmd = &cobra.Command{
+	Use:   "knowledge",
+	Short: "Search and export the conclusions recorded on closed fibers",
+	Long: `A closed fiber's outcome is what the work concluded. These commands read
+only those outcomes, so a past answer surfaces before the question is
+investigated again.`,
+}
+
+var knowledgeSearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Rank closed fibers' outcomes against a query",
+	Long: `Searches the outcomes of closed fibers, and nothing else, ranking the
+matches: outcomes containing more of the query's words first, then those
+where the words are rarer across the store's outcomes, then the most
+recently closed. A query word matches any word it begins, so "calib"
+finds "calibration".`,
+	Example: `  felt knowledge search "psf model"
+  felt knowledge search prior -n 3 --json`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		felts, err := knowledgeSource()
+		if err != nil {
+			return err
+		}
+		query := strings.Join(args, " ")
+		hits := felt.SearchKnowledge(felts, query)
+		if knowledgeLimit > 0 && len(hits) > knowledgeLimit {
+			hits = hits[:knowledgeLimit]
+		}
+		if jsonOutput {
+			if hits == nil {
+				hits = []felt.KnowledgeHit{}
+			}
+			return outputJSON(hits)
+		}
+		if len(hits) == 0 {
+			fmt.Printf("No closed fiber's outcome matches %q\n", query)
+			return nil
+		}
+		for _, hit := range hits {
+			f := hit.Fiber
+			fmt.Printf("%s %s — %s\n", fiberIcon(f), f.ID, f.DisplayName())
+			fmt.Printf("    %s\n", strings.Join(strings.Fields(f.Outcome), " "))
+		}
+		return nil
+	},
+}
+
+var knowledgeExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write closed fibers' outcomes as a FAQ-style markdown document",
+	Long: `Writes every closed fiber that recorded an outcome as a markdown FAQ:
+its name as the question, its outcome as the answer, and a line saying
+where and when it was settled. Most recently closed first; -t narrows to
+a tag (trailing colon for a prefix).`,
+	Example: `  felt knowledge export > docs/knowledge.md
+  felt knowledge export -t decision`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		felts, err := knowledgeSource()
+		if err != nil {
+			return err
+		}
+		fibers := felt.KnowledgeFibers(felts)
+		if jsonOutput {
+			if fibers == nil {
+				fibers = []*felt.Felt{}
+			}
+			return outputJSON(fibers)
+		}
+		fmt.Print(felt.KnowledgeFAQ(fibers))
+		return nil
+	},
+}
+
+// knowledgeSource lists the store's fibers, narrowed by -t.
+func knowledgeSource() ([]*felt.Felt, error) {
+	root, err := resolveProjectRoot()
+	if err != nil {
+		return nil, fmt.Errorf("not in a felt repository")
+	}
+	felts, err := felt.NewStorage(root).ListMetadata()
+	if err != nil {
+		return nil, err
+	}
+	if len(knowledgeTags) > 0 {
+		felts = filterByTags(felts, knowledgeTags)
+	}
+	return felts, nil
+}
+
+func init() {
+	rootCmd.AddCommand(knowledgeCmd)
+	knowledgeCmd.AddCommand(knowledgeSearchCmd)
+	knowledgeCmd.AddCommand(knowledgeExportCmd)
+	knowledgeCmd.PersistentFlags().StringArrayVarP(&knowledgeTags, "tag", "t", nil, "Only fibers with this tag (repeatable, AND; trailing colon for prefix)")
+	registerTagCompletion(knowledgeCmd, "tag")
+	knowledgeSearchCmd.Flags().IntVarP(&knowledgeLimit, "limit", "n", 10, "Show at most N matches (0 for all)")
+}
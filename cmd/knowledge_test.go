@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestKnowledgeSearchReadsOnlyClosedOutcomes(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	closed := time.Now().Add(-time.Hour)
+	for _, f := range []*felt.Felt{
+		{ID: "psf-model", Name: "Which PSF model?", Status: felt.StatusClosed, Tags: []string{"decision"}, CreatedAt: closed, ClosedAt: &closed, Outcome: "Use PSFEx;\nshapelets overfit."},
+		{ID: "psf-rerun", Name: "Rerun the PSF fit", Status: felt.StatusOpen, CreatedAt: closed, Outcome: "PSFEx rerun pending"},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func() { knowledgeTags, knowledgeLimit, jsonOutput = nil, 10, false }()
+	jsonOutput = false
+
+	out, err := runCommand(t, dir, "knowledge", "search", "psfex")
+	if err != nil {
+		t.Fatalf("knowledge search: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "psf-model — Which PSF model?\n    Use PSFEx; shapelets overfit.\n") || strings.Contains(out, "psf-rerun") {
+		t.Fatalf("knowledge search =\n%s", out)
+	}
+
+	out, err = runCommand(t, dir, "knowledge", "export", "-t", "decision")
+	if err != nil || !strings.Contains(out, "## Which PSF model?\n\nUse PSFEx;\nshapelets overfit.\n") {
+		t.Fatalf("knowledge export: %v\n%s", err, out)
+	}
+}
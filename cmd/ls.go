@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"os"
 	"path"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/cailmdaley/felt/internal/felt"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -20,6 +26,18 @@ var (
 	lsRegex      bool
 	lsHasFields  []string
 	lsJSONFields []string
+	lsReady      bool
+	lsBlocked    bool
+	lsFit        string
+	lsSort       string
+	lsConfidence []string
+	lsOwner      string
+	lsImpact     []string
+	lsCSV        bool
+	lsTSV        bool
+	lsGraphML    bool
+	lsGEXF       bool
+	lsJSONL      bool
 	treeDepth    int
 )
 
@@ -41,7 +59,61 @@ Optional query searches name, outcome, additional YAML field text, and fiber id
   felt ls -r "rule:.*data"    regex search (also applied to fiber id)
   felt ls -e "exact-slug"     exact name or exact id match
 
-Use --body with query to include body search, and with --json to emit body text.`,
+Use --body with query to include body search, and with --json to emit body text.
+
+Use --ready for open fibers whose data-flow inputs (inputs[].from) have all
+closed. --fit <span> narrows ready work to fibers whose estimate: fits the
+span — capped by what is left of capacity.daily in .felt/config.yaml after
+today's closed estimates — and suggests the combination that fills it best:
+  felt ls --fit 4h            end-of-day pick from ready, estimated work
+
+--blocked lists the open fibers still waiting on an input, each with the
+upstreams it waits on. With --json, --ready and --blocked add a readiness
+object to each fiber, so scripts need not re-derive the rule: its inputs
+split into closed, open (blocking), and missing (refs that resolve to no
+fiber), plus deferred_until while snoozed.
+
+Use --confidence to find fibers by how solid their confidence: field is
+(low, medium, high; numbers map to levels):
+  felt ls --confidence low,medium   shaky decisions worth revisiting
+
+Use --impact to find bug-like fibers by their impact: field (low, medium,
+high, critical); a trailing + means that level or worse:
+  felt ls --impact high+   what hurts most, beside the planned work
+
+Use --owner to split a shared store's work by who is responsible for it
+(the owner: field, set with felt add/edit --owner). "me" means $FELT_OWNER,
+else owner.default in .felt/config.yaml; "none" means unowned:
+  felt ls --ready --owner me  my unblocked work
+
+Use --sort last-read to order by the access log (access.log: true in
+.felt/config.yaml): never-read fibers first, then least recently read.
+
+Use --csv or --tsv to write the listed fibers as a spreadsheet, one row
+each under a header line, for felt ingest csv to read back:
+  id, title, status, tags, depends_on, created, closed, outcome
+tags and depends_on (the fibers it reads) hold ";"-separated values; times
+are RFC 3339.
+
+Use --graphml or --gexf to write the listed fibers as a directed graph for
+Gephi or NetworkX: one node per fiber, keyed by id, carrying name, status,
+tags, created, closed, due, outcome, and owner; and one edge per link
+among them, typed by a kind attribute — data_flow from an upstream to the
+fiber that reads it (with the input's name), reference from a fiber to one
+its body [[links]]:
+  felt ls -s all --graphml > felt.graphml
+
+Use --jsonl to stream the whole store, bodies included, as one JSON object
+per line — a backup of a store too large to list in memory. Fibers are
+read and written one at a time, in walk order, with progress on stderr.
+Every status is included unless -s narrows it; -t is the only other
+filter it takes:
+  felt ls --jsonl > fibers.jsonl`,
+	Example: `  felt ls                     open and active fibers
+  felt ls -s closed -t decision
+  felt ls cosebis --body      search bodies too
+  felt ls --ready --json      unblocked work, for scripts
+  felt ls -s all --csv > fibers.csv`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := resolveProjectRoot()
@@ -55,10 +127,56 @@ Use --body with query to include body search, and with --json to emit body text.
 			query = args[0]
 		}
 		hasFields := splitListFlag(lsHasFields)
+		confidences := splitListFlag(lsConfidence)
+		for _, level := range confidences {
+			if !slices.Contains(felt.ConfidenceLevels, level) {
+				return fmt.Errorf("invalid --confidence %q (valid: %s)", level, strings.Join(felt.ConfidenceLevels, ", "))
+			}
+		}
+		impacts, err := parseImpactFilter(splitListFlag(lsImpact))
+		if err != nil {
+			return err
+		}
+		owner := lsOwner
+		if cmd.Flags().Changed("owner") {
+			if owner, err = resolveOwnerFlag(storage, lsOwner); err != nil {
+				return err
+			}
+		}
 		jsonFields := splitListFlag(lsJSONFields)
 		if len(jsonFields) > 0 && !jsonOutput {
 			return fmt.Errorf("--json-field requires --json")
 		}
+		exports := 0
+		for _, set := range []bool{lsCSV, lsTSV, lsGraphML, lsGEXF} {
+			if set {
+				exports++
+			}
+		}
+		if exports > 1 {
+			return fmt.Errorf("--csv, --tsv, --graphml, and --gexf are mutually exclusive")
+		}
+		if exports > 0 && jsonOutput {
+			return fmt.Errorf("--csv, --tsv, --graphml, and --gexf cannot be combined with --json")
+		}
+		if lsJSONL {
+			var other []string
+			cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+				if f.Changed && f.Name != "jsonl" && f.Name != "status" && f.Name != "tag" {
+					other = append(other, "--"+f.Name)
+				}
+			})
+			if jsonOutput {
+				other = append(other, "--json")
+			}
+			if query != "" {
+				other = append(other, "a query")
+			}
+			if len(other) > 0 {
+				return fmt.Errorf("--jsonl streams the whole store and takes only -s and -t, not %s", strings.Join(other, ", "))
+			}
+			return streamJSONL(cmd, storage)
+		}
 
 		// Compile regex if needed
 		var re *regexp.Regexp
@@ -69,10 +187,33 @@ Use --body with query to include body search, and with --json to emit body text.
 			}
 		}
 
+		var fitSpan time.Duration
+		if lsFit != "" {
+			fitSpan, err = felt.ParseSpan(lsFit)
+			if err != nil {
+				return fmt.Errorf("--fit: %w", err)
+			}
+		}
+		readyOnly := lsReady || lsFit != ""
+		if readyOnly && lsBlocked {
+			return fmt.Errorf("--blocked cannot be combined with --ready or --fit")
+		}
+		switch lsSort {
+		case "", "last-read":
+		default:
+			return fmt.Errorf("invalid --sort %q (valid: last-read)", lsSort)
+		}
+		if lsSort != "" && lsRecent > 0 {
+			return fmt.Errorf("--sort and --recent are mutually exclusive")
+		}
+
 		queryLower := strings.ToLower(query)
 		var felts []*felt.Felt
 		frontmatterFields, canPrefilterFrontmatter := frontmatterPrefilterFields(hasFields)
-		if jsonOutput {
+		if lsGraphML || lsGEXF {
+			// Reference edges come from [[links]] in bodies.
+			felts, err = storage.List()
+		} else if jsonOutput {
 			if canPrefilterFrontmatter && len(frontmatterFields) > 0 {
 				felts, err = storage.ListMetadataWithModTimeHavingFrontmatterFields(frontmatterFields)
 			} else {
@@ -92,12 +233,19 @@ Use --body with query to include body search, and with --json to emit body text.
 		// If any filter is active (tags, query, recent) and -s wasn't explicitly set,
 		// widen to all statuses. Bare `felt ls` stays open+active (actionable view).
 		statusExplicit := cmd.Flags().Changed("status")
-		hasFilters := len(lsTags) > 0 || len(hasFields) > 0 || query != "" || lsRecent > 0
+		hasFilters := len(lsTags) > 0 || len(hasFields) > 0 || len(confidences) > 0 || len(impacts) > 0 || owner != "" || query != "" || lsRecent > 0 || readyOnly || lsBlocked
 		effectiveStatus := lsStatus
 		if !statusExplicit && hasFilters {
 			effectiveStatus = "all"
 		}
 
+		// Readiness depends on upstream status, so it is computed over the
+		// whole store before any filter narrows the set.
+		var readiness map[string]*felt.Readiness
+		if readyOnly || lsBlocked {
+			readiness = felt.ExplainReadiness(felts, time.Now())
+		}
+
 		// Filter
 		var exactMatches []*felt.Felt
 		var filtered []*felt.Felt
@@ -121,6 +269,13 @@ Use --body with query to include body search, and with --json to emit body text.
 				}
 			}
 
+			if readyOnly && (readiness[f.ID] == nil || !readiness[f.ID].Ready) {
+				continue
+			}
+			if lsBlocked && (readiness[f.ID] == nil || !readiness[f.ID].Blocked()) {
+				continue
+			}
+
 			// Tag filter: must have ALL specified tags (AND logic, prefix supported)
 			if len(lsTags) > 0 {
 				hasAll := true
@@ -135,6 +290,10 @@ Use --body with query to include body search, and with --json to emit body text.
 				}
 			}
 
+			if (owner == "none" && f.Owner() != "") || (owner != "" && owner != "none" && f.Owner() != owner) {
+				continue
+			}
+
 			if len(hasFields) > 0 {
 				hasAll := true
 				for _, field := range hasFields {
@@ -148,6 +307,16 @@ Use --body with query to include body search, and with --json to emit body text.
 				}
 			}
 
+			if len(confidences) > 0 {
+				c, ok := f.Confidence()
+				if !ok || !slices.Contains(confidences, c.Level) {
+					continue
+				}
+			}
+			if len(impacts) > 0 && !slices.Contains(impacts, f.Impact()) {
+				continue
+			}
+
 			// Text search (if query provided)
 			if query != "" {
 				nameLower := strings.ToLower(f.DisplayName())
@@ -192,8 +361,15 @@ Use --body with query to include body search, and with --json to emit body text.
 		// Exact name matches first, then the rest
 		filtered = append(exactMatches, filtered...)
 
-		// Sort: --recent sorts by recency, otherwise by priority then creation
-		if lsRecent > 0 {
+		// Sort: --recent sorts by recency, --sort last-read by the access log,
+		// otherwise by creation
+		if lsSort == "last-read" {
+			access, err := storage.LoadAccessLog()
+			if err != nil {
+				return err
+			}
+			sortByLastRead(filtered, access)
+		} else if lsRecent > 0 {
 			// Sort by most recent activity (closed-at for closed, created-at otherwise)
 			sort.Slice(filtered, func(i, j int) bool {
 				ti := filtered[i].CreatedAt
@@ -217,6 +393,15 @@ Use --body with query to include body search, and with --json to emit body text.
 			})
 		}
 
+		var fit *fitPlan
+		if lsFit != "" {
+			fit, err = planFit(storage, felts, filtered, fitSpan, time.Now())
+			if err != nil {
+				return err
+			}
+			filtered = fit.Fits
+		}
+
 		// Output
 		if jsonOutput {
 			if lsBody {
@@ -235,6 +420,9 @@ Use --body with query to include body search, and with --json to emit body text.
 			if err := attachShuttleResolution(filtered...); err != nil {
 				return err
 			}
+			for _, f := range filtered {
+				f.Readiness = readiness[f.ID]
+			}
 			if len(jsonFields) > 0 {
 				projected, err := projectFeltsJSON(filtered, jsonFields)
 				if err != nil {
@@ -245,6 +433,20 @@ Use --body with query to include body search, and with --json to emit body text.
 			return outputJSON(filtered)
 		}
 
+		if lsCSV || lsTSV {
+			comma := ','
+			if lsTSV {
+				comma = '\t'
+			}
+			return felt.WriteCSV(os.Stdout, filtered, comma)
+		}
+		if lsGraphML {
+			return felt.WriteGraphML(os.Stdout, filtered)
+		}
+		if lsGEXF {
+			return felt.WriteGEXF(os.Stdout, filtered)
+		}
+
 		if len(filtered) == 0 {
 			if query != "" {
 				fmt.Printf("No felts matching %q\n", query)
@@ -254,8 +456,14 @@ Use --body with query to include body search, and with --json to emit body text.
 		} else {
 			for _, f := range filtered {
 				fmt.Print(formatFeltTwoLine(f))
+				if lsBlocked {
+					fmt.Printf("    waiting on %s\n", strings.Join(readiness[f.ID].Open, ", "))
+				}
 			}
 		}
+		if fit != nil {
+			fmt.Print(fit.summary())
+		}
 
 		// Show count of hidden fibers when the default filter is active
 		if !statusExplicit && !hasFilters {
@@ -273,12 +481,131 @@ func init() {
 	rootCmd.AddCommand(lsCmd)
 	lsCmd.Flags().StringVarP(&lsStatus, "status", "s", "", "Filter by status (open, active, closed, all)")
 	lsCmd.Flags().StringArrayVarP(&lsTags, "tag", "t", nil, "Filter by tag (repeatable, AND logic; trailing colon for prefix match)")
+	registerTagCompletion(lsCmd, "tag")
 	lsCmd.Flags().IntVarP(&lsRecent, "recent", "n", 0, "Show N most recent (by closed-at or created-at)")
 	lsCmd.Flags().BoolVar(&lsBody, "body", false, "Include body search for queries and body field in JSON output")
 	lsCmd.Flags().BoolVarP(&lsExact, "exact", "e", false, "Exact name match only (with query)")
 	lsCmd.Flags().BoolVarP(&lsRegex, "regex", "r", false, "Treat query as regular expression")
 	lsCmd.Flags().StringArrayVar(&lsHasFields, "has-field", nil, "Filter to fibers with this top-level frontmatter/JSON field (repeatable or comma-separated)")
 	lsCmd.Flags().StringArrayVar(&lsJSONFields, "json-field", nil, "With --json, emit only this top-level field (repeatable or comma-separated)")
+	lsCmd.Flags().BoolVar(&lsReady, "ready", false, "Only open fibers whose data-flow inputs have all closed")
+	lsCmd.Flags().BoolVar(&lsBlocked, "blocked", false, "Only open fibers waiting on a data-flow input that has not closed")
+	lsCmd.Flags().StringVar(&lsSort, "sort", "", "Order by: last-read (never-read, then least recently read, first)")
+	lsCmd.Flags().StringArrayVar(&lsConfidence, "confidence", nil, "Filter to fibers with this confidence level: low, medium, high (repeatable or comma-separated)")
+	lsCmd.Flags().StringArrayVar(&lsImpact, "impact", nil, "Filter to fibers with this impact level: low, medium, high, critical; high+ for high or worse (repeatable or comma-separated)")
+	registerImpactCompletion(lsCmd)
+	lsCmd.Flags().StringVar(&lsOwner, "owner", "", "Filter by owner (me for the default owner; none for unowned)")
+	lsCmd.Flags().BoolVar(&lsCSV, "csv", false, "Write the listed fibers as CSV (see felt ingest csv)")
+	lsCmd.Flags().BoolVar(&lsTSV, "tsv", false, "Write the listed fibers as tab-separated values")
+	lsCmd.Flags().BoolVar(&lsGraphML, "graphml", false, "Write the listed fibers and the edges among them as GraphML")
+	lsCmd.Flags().BoolVar(&lsGEXF, "gexf", false, "Write the listed fibers and the edges among them as GEXF")
+	lsCmd.Flags().BoolVar(&lsJSONL, "jsonl", false, "Stream every fiber, body included, as JSON lines (a backup; see above)")
+	lsCmd.Flags().StringVar(&lsFit, "fit", "", "Only ready fibers whose estimate fits this span and today's remaining capacity (e.g. 4h)")
+}
+
+// jsonlProgressEvery is how many fibers `ls --jsonl` streams between
+// progress lines.
+const jsonlProgressEvery = 1000
+
+// streamJSONL writes the fibers `ls --jsonl` selects to stdout, one JSON
+// object per line, holding one fiber in memory at a time.
+func streamJSONL(cmd *cobra.Command, storage *felt.Storage) error {
+	out := bufio.NewWriter(os.Stdout)
+	enc := json.NewEncoder(out)
+	progress := cmd.ErrOrStderr()
+	written := 0
+	err := storage.Stream(func(f *felt.Felt, done, total int) error {
+		if done%jsonlProgressEvery == 0 {
+			fmt.Fprintf(progress, "Read %d of %d fiber files\n", done, total)
+		}
+		if lsStatus != "" && lsStatus != "all" && f.Status != lsStatus {
+			return nil
+		}
+		for _, tag := range lsTags {
+			if !f.HasTag(tag) {
+				return nil
+			}
+		}
+		written++
+		return enc.Encode(f)
+	})
+	if err != nil {
+		return err
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(progress, "Streamed %d %s\n", written, pluralize(written, "fiber", "fibers"))
+	return nil
+}
+
+// fitPlan is the capacity arithmetic behind `ls --fit`.
+type fitPlan struct {
+	Budget   time.Duration
+	Capacity time.Duration // zero when capacity.daily is not configured
+	Consumed time.Duration
+	Fits     []*felt.Felt
+	Best     []*felt.Felt
+}
+
+// planFit caps the requested span by today's remaining capacity (when one is
+// configured) and fits the ready, estimated candidates into it. all is the
+// whole store, which today's consumed estimates are summed over.
+func planFit(storage *felt.Storage, all, candidates []*felt.Felt, span time.Duration, now time.Time) (*fitPlan, error) {
+	cfg, err := storage.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	capacity, hasCapacity, err := cfg.DailyCapacity()
+	if err != nil {
+		return nil, err
+	}
+	plan := &fitPlan{Budget: span}
+	if hasCapacity {
+		plan.Capacity = capacity
+		plan.Consumed = felt.ConsumedToday(all, now)
+		if remaining := capacity - plan.Consumed; remaining < plan.Budget {
+			plan.Budget = max(remaining, 0)
+		}
+	}
+	plan.Fits, plan.Best = felt.FitBudget(candidates, plan.Budget)
+	return plan, nil
+}
+
+func (p *fitPlan) summary() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\nBudget: %s", felt.FormatSpan(p.Budget))
+	if p.Capacity > 0 {
+		fmt.Fprintf(&sb, " (capacity %s, %s closed today)", felt.FormatSpan(p.Capacity), felt.FormatSpan(p.Consumed))
+	}
+	sb.WriteString("\n")
+	if len(p.Best) > 0 {
+		var total time.Duration
+		ids := make([]string, len(p.Best))
+		for i, f := range p.Best {
+			d, _ := f.Estimate()
+			total += d
+			ids[i] = f.ID
+		}
+		fmt.Fprintf(&sb, "Best fill (%s): %s\n", felt.FormatSpan(total), strings.Join(ids, ", "))
+	}
+	return sb.String()
+}
+
+// sortByLastRead orders felts for --sort last-read: fibers the access log has
+// never seen first (oldest created first), then by last read, oldest first.
+func sortByLastRead(felts []*felt.Felt, access *felt.AccessLog) {
+	sort.SliceStable(felts, func(i, j int) bool {
+		ti, oki := access.LastRead(felts[i].ID)
+		tj, okj := access.LastRead(felts[j].ID)
+		if oki != okj {
+			return !oki
+		}
+		if !oki {
+			return felts[i].CreatedAt.Before(felts[j].CreatedAt)
+		}
+		return ti.Before(tj)
+	})
 }
 
 func splitListFlag(values []string) []string {
@@ -325,6 +652,8 @@ var nativeFields = map[string]nativeFieldSpec{
 	"tags":           {accessor: func(f *felt.Felt) (any, bool) { return f.Tags, len(f.Tags) > 0 }, prefilterKey: "tags", prefilterable: true},
 	"created_at":     {accessor: feltCreatedAtValue, prefilterKey: "created-at", prefilterable: true},
 	"created-at":     {accessor: feltCreatedAtValue, prefilterKey: "created-at", prefilterable: true},
+	"activated_at":   {accessor: feltActivatedAtValue, prefilterKey: "activated-at", prefilterable: true},
+	"activated-at":   {accessor: feltActivatedAtValue, prefilterKey: "activated-at", prefilterable: true},
 	"closed_at":      {accessor: feltClosedAtValue, prefilterKey: "closed-at", prefilterable: true},
 	"closed-at":      {accessor: feltClosedAtValue, prefilterKey: "closed-at", prefilterable: true},
 	"outcome":        {accessor: func(f *felt.Felt) (any, bool) { return f.Outcome, f.Outcome != "" }, prefilterKey: "outcome", prefilterable: true},
@@ -338,12 +667,14 @@ var nativeFields = map[string]nativeFieldSpec{
 	"report-path":    {accessor: func(f *felt.Felt) (any, bool) { return f.ReportPath, f.ReportPath != "" }, prefilterable: false},
 	"entry_point":    {accessor: func(f *felt.Felt) (any, bool) { return f.EntryPoint, f.EntryPoint }, prefilterable: false},
 	"entry-point":    {accessor: func(f *felt.Felt) (any, bool) { return f.EntryPoint, f.EntryPoint }, prefilterable: false},
+	"readiness":      {accessor: func(f *felt.Felt) (any, bool) { return f.Readiness, f.Readiness != nil }, prefilterable: false},
 }
 
-func feltUIDValue(f *felt.Felt) (any, bool)        { return f.UID, f.UID != "" }
-func feltCreatedAtValue(f *felt.Felt) (any, bool)  { return f.CreatedAt, !f.CreatedAt.IsZero() }
-func feltClosedAtValue(f *felt.Felt) (any, bool)   { return f.ClosedAt, f.ClosedAt != nil }
-func feltModifiedAtValue(f *felt.Felt) (any, bool) { return f.ModifiedAt, !f.ModifiedAt.IsZero() }
+func feltUIDValue(f *felt.Felt) (any, bool)         { return f.UID, f.UID != "" }
+func feltCreatedAtValue(f *felt.Felt) (any, bool)   { return f.CreatedAt, !f.CreatedAt.IsZero() }
+func feltActivatedAtValue(f *felt.Felt) (any, bool) { return f.ActivatedAt, f.ActivatedAt != nil }
+func feltClosedAtValue(f *felt.Felt) (any, bool)    { return f.ClosedAt, f.ClosedAt != nil }
+func feltModifiedAtValue(f *felt.Felt) (any, bool)  { return f.ModifiedAt, !f.ModifiedAt.IsZero() }
 
 func feltHasField(f *felt.Felt, field string) bool {
 	if spec, ok := nativeFields[field]; ok {
@@ -484,7 +815,10 @@ var treeCmd = &cobra.Command{
 	Use:   "tree [id]",
 	Short: "Show containment tree",
 	Long:  `Shows the containment tree (filesystem nesting) for fibers.`,
-	Args:  cobra.MaximumNArgs(1),
+	Example: `  felt tree
+  felt tree pure_eb           one subtree`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := resolveProjectRoot()
 		if err != nil {
@@ -614,7 +948,7 @@ func printContainmentNode(node *ContainmentNode, prefix string, last bool, depth
 		connector = ""
 	}
 
-	fmt.Printf("%s%s%s %s  %s\n", prefix, connector, felt.StatusIcon(node.Status), treeDisplayID(node.ID), node.Name)
+	fmt.Printf("%s%s%s %s  %s%s%s\n", prefix, connector, fiberIcon(node.Felt), treeDisplayID(node.ID), node.Name, impactBadge(node.Felt), confidenceMarker(node.Felt))
 
 	var childPrefix string
 	if prefix == "" {
@@ -634,3 +968,46 @@ func init() {
 	rootCmd.AddCommand(treeCmd)
 	treeCmd.Flags().IntVar(&treeDepth, "depth", 0, "Maximum nesting depth to display (0 = unlimited)")
 }
+
+// resolveOwnerFlag interprets an --owner value: "me" becomes the default
+// owner ($FELT_OWNER, else owner.default), which must then be set; any
+// other value is taken as given.
+func resolveOwnerFlag(storage *felt.Storage, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value != "me" {
+		return value, nil
+	}
+	cfg, err := storage.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	owner := cfg.DefaultOwner()
+	if owner == "" {
+		return "", fmt.Errorf("--owner me needs %s or owner.default in .felt/config.yaml", felt.OwnerEnv)
+	}
+	return owner, nil
+}
+
+// parseImpactFilter expands --impact values into the levels they admit: a
+// level admits itself, and a level with a trailing + admits it and every
+// more severe one.
+func parseImpactFilter(values []string) ([]string, error) {
+	var levels []string
+	for _, v := range values {
+		orWorse := strings.HasSuffix(v, "+")
+		level, err := felt.ParseImpact(strings.TrimSuffix(v, "+"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --impact %q (valid: %s, optionally with a trailing +)", v, strings.Join(felt.ImpactLevels, ", "))
+		}
+		admitted := []string{level}
+		if orWorse {
+			admitted = felt.ImpactLevels[felt.ImpactRank(level):]
+		}
+		for _, l := range admitted {
+			if !slices.Contains(levels, l) {
+				levels = append(levels, l)
+			}
+		}
+	}
+	return levels, nil
+}
@@ -6,8 +6,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/pflag"
 )
 
 func TestTreeDisplayID(t *testing.T) {
@@ -182,6 +184,110 @@ func TestLsJSONReportPath(t *testing.T) {
 	}
 }
 
+func TestLsFitCapsByRemainingCapacity(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := os.WriteFile(storage.ConfigPath(), []byte("capacity:\n  daily: 6h\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	closedToday := time.Now()
+	write := func(f *felt.Felt, estimate string) {
+		t.Helper()
+		f.CreatedAt = created
+		if estimate != "" {
+			if err := f.SetExtraField("estimate", estimate); err != nil {
+				t.Fatalf("SetExtraField: %v", err)
+			}
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	// 3h of today's capacity is already spent, leaving 3h under a 4h --fit.
+	write(&felt.Felt{ID: "spent", Name: "Spent", Status: felt.StatusClosed, ClosedAt: &closedToday}, "3h")
+	write(&felt.Felt{ID: "two-hours", Name: "Two hours", Status: felt.StatusOpen}, "2h")
+	write(&felt.Felt{ID: "one-hour", Name: "One hour", Status: felt.StatusOpen}, "1h")
+	write(&felt.Felt{ID: "too-big", Name: "Too big", Status: felt.StatusOpen}, "3h30m")
+	write(&felt.Felt{ID: "unestimated", Name: "Unestimated", Status: felt.StatusOpen}, "")
+	write(&felt.Felt{ID: "upstream", Name: "Upstream", Status: felt.StatusOpen}, "4h")
+	blocked := &felt.Felt{ID: "blocked", Name: "Blocked", Status: felt.StatusOpen}
+	if err := blocked.SetExtraField("inputs", []map[string]any{{"id": "data", "from": "upstream"}}); err != nil {
+		t.Fatalf("SetExtraField: %v", err)
+	}
+	write(blocked, "30m")
+
+	reset := saveLsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "ls", "--fit", "4h")
+	if err != nil {
+		t.Fatalf("ls --fit: %v\n%s", err, out)
+	}
+	for _, want := range []string{"two-hours", "one-hour", "Budget: 3h (capacity 6h, 3h closed today)", "Best fill (3h): one-hour, two-hours"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("ls --fit missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"too-big", "unestimated", "blocked", "○ upstream", "spent"} {
+		if strings.Contains(out, unwanted) {
+			t.Fatalf("ls --fit should exclude %q:\n%s", unwanted, out)
+		}
+	}
+
+	saveLsGlobals()
+	out, err = runCommand(t, dir, "ls", "--ready")
+	if err != nil {
+		t.Fatalf("ls --ready: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "unestimated") || !strings.Contains(out, "upstream") || strings.Contains(out, "blocked") {
+		t.Fatalf("ls --ready should list open unblocked fibers only:\n%s", out)
+	}
+}
+
+func TestLsConfidenceFilter(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	for id, confidence := range map[string]any{"shaky": "low", "hedged": 0.5, "solid": "high", "unrated": nil} {
+		f := &felt.Felt{ID: id, Name: id, Tags: []string{"decision"}, CreatedAt: created}
+		if confidence != nil {
+			if err := f.SetExtraField(felt.ConfidenceKey, confidence); err != nil {
+				t.Fatalf("SetExtraField: %v", err)
+			}
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	reset := saveLsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "ls", "--confidence", "low,medium")
+	if err != nil {
+		t.Fatalf("ls --confidence: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "shaky (decision) [low confidence]") || !strings.Contains(out, "hedged (decision) [medium confidence]") {
+		t.Fatalf("ls --confidence missing shaky fibers:\n%s", out)
+	}
+	if strings.Contains(out, "solid") || strings.Contains(out, "unrated") {
+		t.Fatalf("ls --confidence should exclude high and unrated fibers:\n%s", out)
+	}
+
+	saveLsGlobals()
+	if _, err := runCommand(t, dir, "ls", "--confidence", "shaky"); err == nil {
+		t.Fatal("ls --confidence shaky succeeded, want error")
+	}
+}
+
 func saveLsGlobals() func() {
 	prevStatus := lsStatus
 	prevTags := lsTags
@@ -191,6 +297,15 @@ func saveLsGlobals() func() {
 	prevRegex := lsRegex
 	prevHasFields := lsHasFields
 	prevJSONFields := lsJSONFields
+	prevReady := lsReady
+	prevBlocked := lsBlocked
+	prevFit := lsFit
+	prevSort := lsSort
+	prevConfidence := lsConfidence
+	prevOwner := lsOwner
+	prevImpact := lsImpact
+	prevGraphML, prevGEXF := lsGraphML, lsGEXF
+	prevJSONL := lsJSONL
 	prevJSON := jsonOutput
 
 	lsStatus = ""
@@ -201,17 +316,22 @@ func saveLsGlobals() func() {
 	lsRegex = false
 	lsHasFields = nil
 	lsJSONFields = nil
+	lsReady = false
+	lsBlocked = false
+	lsFit = ""
+	lsSort = ""
+	lsConfidence = nil
+	lsOwner = ""
+	lsImpact = nil
+	lsGraphML, lsGEXF = false, false
+	lsJSONL = false
 	jsonOutput = false
 
 	// Reset cobra's per-flag Changed bookkeeping. Without this, a prior test
 	// that passed e.g. `-s active` leaves Changed("status") == true, and
 	// subsequent tests inspecting `cmd.Flags().Changed("status")` see stale
 	// state even though the underlying string variable was reset above.
-	for _, name := range []string{"status", "tag", "recent", "body", "exact", "regex", "has-field", "json-field", "json"} {
-		if f := lsCmd.Flags().Lookup(name); f != nil {
-			f.Changed = false
-		}
-	}
+	lsCmd.Flags().VisitAll(func(f *pflag.Flag) { f.Changed = false })
 
 	return func() {
 		lsStatus = prevStatus
@@ -222,6 +342,367 @@ func saveLsGlobals() func() {
 		lsRegex = prevRegex
 		lsHasFields = prevHasFields
 		lsJSONFields = prevJSONFields
+		lsReady = prevReady
+		lsBlocked = prevBlocked
+		lsFit = prevFit
+		lsSort = prevSort
+		lsConfidence = prevConfidence
+		lsOwner = prevOwner
+		lsImpact = prevImpact
+		lsGraphML, lsGEXF = prevGraphML, prevGEXF
+		lsJSONL = prevJSONL
 		jsonOutput = prevJSON
 	}
 }
+
+func TestLsASCIIIcons(t *testing.T) {
+	restore := saveLsGlobals()
+	defer restore()
+	prevASCII := asciiFlag
+	defer func() { asciiFlag, theme = prevASCII, glyphTheme{} }()
+	asciiFlag = false
+
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	for _, f := range []*felt.Felt{
+		{ID: "draft", Name: "Draft", Status: felt.StatusOpen, CreatedAt: created},
+		{ID: "fit", Name: "Fit", Status: felt.StatusActive, CreatedAt: created.Add(time.Hour)},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s): %v", f.ID, err)
+		}
+	}
+
+	out, err := runCommand(t, dir, "ls")
+	if err != nil || !strings.Contains(out, "○ draft") || strings.Contains(out, "[open]") {
+		t.Fatalf("default ls = %v\n%s", err, out)
+	}
+	out, err = runCommand(t, dir, "ls", "--ascii")
+	asciiFlag = false
+	if err != nil || !strings.Contains(out, "[open] draft") || !strings.Contains(out, "[act] fit") || strings.Contains(out, "○") {
+		t.Fatalf("ls --ascii = %v\n%s", err, out)
+	}
+
+	cfg := filepath.Join(dir, felt.DirName, felt.ConfigName)
+	if err := os.WriteFile(cfg, []byte("display:\n  ascii: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCommand(t, dir, "ls")
+	if err != nil || !strings.Contains(out, "[open] draft") {
+		t.Fatalf("ls with display.ascii = %v\n%s", err, out)
+	}
+}
+
+func TestOwnerDefaultsEditAndLsFilter(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := os.WriteFile(storage.ConfigPath(), []byte("owner:\n  default: ana\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	t.Setenv(felt.OwnerEnv, "")
+
+	resetAdd := saveAddGlobals()
+	defer resetAdd()
+	resetEdit := saveEditGlobals()
+	defer resetEdit()
+	resetLs := saveLsGlobals()
+	defer resetLs()
+
+	for _, args := range [][]string{
+		{"add", "mine", "Mine", "-s", "open"},
+		{"add", "theirs", "Theirs", "-s", "open", "--owner", "ben"},
+		{"add", "loose", "Loose", "-s", "open"},
+		{"add", "note", "Note"},
+	} {
+		saveAddGlobals()
+		if out, err := runCommand(t, dir, args...); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, out)
+		}
+	}
+	for id, want := range map[string]string{"mine": "ana", "theirs": "ben", "loose": "ana", "note": ""} {
+		f, err := storage.Read(id)
+		if err != nil {
+			t.Fatalf("Read %s: %v", id, err)
+		}
+		if f.Owner() != want {
+			t.Fatalf("%s owner = %q, want %q", id, f.Owner(), want)
+		}
+	}
+
+	if out, err := runCommand(t, dir, "edit", "loose", "--owner", ""); err != nil {
+		t.Fatalf("edit --owner '': %v\n%s", err, out)
+	}
+	if f, _ := storage.Read("loose"); f.Owner() != "" {
+		t.Fatalf("edit --owner '' left owner %q", f.Owner())
+	}
+
+	t.Setenv(felt.OwnerEnv, "ben")
+	out, err := runCommand(t, dir, "ls", "--owner", "me")
+	if err != nil {
+		t.Fatalf("ls --owner me: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "theirs") || strings.Contains(out, "mine") || strings.Contains(out, "loose") {
+		t.Fatalf("ls --owner me with FELT_OWNER=ben should list theirs only:\n%s", out)
+	}
+
+	saveLsGlobals()
+	out, err = runCommand(t, dir, "ls", "--owner", "none", "-s", "open")
+	if err != nil {
+		t.Fatalf("ls --owner none: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "loose") || strings.Contains(out, "mine") || strings.Contains(out, "theirs") {
+		t.Fatalf("ls --owner none should list unowned fibers only:\n%s", out)
+	}
+
+	if err := os.WriteFile(storage.ConfigPath(), nil, 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	t.Setenv(felt.OwnerEnv, "")
+	saveLsGlobals()
+	if _, err := runCommand(t, dir, "ls", "--owner", "me"); err == nil || !strings.Contains(err.Error(), felt.OwnerEnv) {
+		t.Fatalf("ls --owner me without a default owner err = %v", err)
+	}
+}
+
+func TestLsGraphMLExportsFilteredGraph(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	mocks := &felt.Felt{ID: "mocks", Name: "Run the mocks", Status: felt.StatusOpen, Tags: []string{"sim"}, CreatedAt: created}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, Tags: []string{"sim"}, CreatedAt: created, Body: "See [[mocks]]."}
+	if err := fit.AddDataFlowInput("mocks"); err != nil {
+		t.Fatal(err)
+	}
+	other := &felt.Felt{ID: "other", Name: "Other", Status: felt.StatusOpen, CreatedAt: created, Body: "Unrelated to [[fit]]."}
+	for _, f := range []*felt.Felt{mocks, fit, other} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	reset := saveLsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "ls", "-t", "sim", "--graphml")
+	if err != nil {
+		t.Fatalf("ls --graphml: %v\n%s", err, out)
+	}
+	for _, want := range []string{`<node id="mocks">`, `<edge id="e0" source="fit" target="mocks">`, `<data key="kind">reference</data>`, `source="mocks" target="fit"`, `<data key="kind">data_flow</data>`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("ls --graphml missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "other") {
+		t.Fatalf("ls -t sim --graphml should leave out fibers the filter drops:\n%s", out)
+	}
+
+	saveLsGlobals()
+	if _, err := runCommand(t, dir, "ls", "--graphml", "--gexf"); err == nil {
+		t.Fatal("ls --graphml --gexf succeeded, want error")
+	}
+}
+
+func TestLsReadyAndBlockedExplainReadinessInJSON(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	done := &felt.Felt{ID: "done", Name: "Done", Status: felt.StatusClosed, CreatedAt: created}
+	busy := &felt.Felt{ID: "busy", Name: "Busy", Status: felt.StatusActive, CreatedAt: created}
+	waiting := &felt.Felt{ID: "waiting", Name: "Waiting", Status: felt.StatusOpen, CreatedAt: created}
+	free := &felt.Felt{ID: "free", Name: "Free", Status: felt.StatusOpen, CreatedAt: created}
+	for _, link := range [][2]*felt.Felt{{waiting, done}, {waiting, busy}, {free, done}} {
+		if err := link[0].AddDataFlowInput(link[1].ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := free.AddDataFlowInput("nowhere"); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*felt.Felt{done, busy, waiting, free} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	reset := saveLsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "ls", "--blocked")
+	if err != nil {
+		t.Fatalf("ls --blocked: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "waiting\n") || !strings.Contains(out, "    waiting on busy\n") || strings.Contains(out, "free") {
+		t.Fatalf("ls --blocked should list waiting, on busy:\n%s", out)
+	}
+
+	saveLsGlobals()
+	out, err = runCommand(t, dir, "ls", "--ready", "--json", "--json-field", "id,readiness")
+	if err != nil {
+		t.Fatalf("ls --ready --json: %v\n%s", err, out)
+	}
+	var got []struct {
+		ID        string         `json:"id"`
+		Readiness felt.Readiness `json:"readiness"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if len(got) != 1 || got[0].ID != "free" {
+		t.Fatalf("ls --ready --json = %s", out)
+	}
+	r := got[0].Readiness
+	if !r.Ready || len(r.Closed) != 1 || r.Closed[0] != "done" || len(r.Open) != 0 || len(r.Missing) != 1 || r.Missing[0] != "nowhere" {
+		t.Fatalf("readiness = %+v", r)
+	}
+
+	saveLsGlobals()
+	if _, err := runCommand(t, dir, "ls", "--ready", "--blocked"); err == nil {
+		t.Fatal("ls --ready --blocked succeeded, want error")
+	}
+}
+
+func TestImpactBadgeAndLsFilter(t *testing.T) {
+	dir := t.TempDir()
+	if err := felt.NewStorage(dir).Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	resetAdd := saveAddGlobals()
+	defer resetAdd()
+	resetEdit := saveEditGlobals()
+	defer resetEdit()
+	resetLs := saveLsGlobals()
+	defer resetLs()
+
+	for _, args := range [][]string{
+		{"add", "crash", "Crash on empty input", "-s", "open", "--impact", "critical"},
+		{"add", "typo", "Typo in help", "-s", "open", "--impact", "low"},
+		{"add", "plan", "Planned work", "-s", "open"},
+	} {
+		saveAddGlobals()
+		if out, err := runCommand(t, dir, args...); err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, out)
+		}
+	}
+	if out, err := runCommand(t, dir, "edit", "typo", "--impact", "high"); err != nil {
+		t.Fatalf("edit --impact: %v\n%s", err, out)
+	}
+
+	out, err := runCommand(t, dir, "ls")
+	if err != nil {
+		t.Fatalf("ls: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Crash on empty input [critical impact]") || !strings.Contains(out, "Typo in help [high impact]") {
+		t.Fatalf("ls should badge impact:\n%s", out)
+	}
+	if strings.Contains(out, "\033[") {
+		t.Fatalf("ls colored output that is not a terminal:\n%s", out)
+	}
+
+	saveLsGlobals()
+	out, err = runCommand(t, dir, "ls", "--impact", "high+")
+	if err != nil {
+		t.Fatalf("ls --impact high+: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "crash") || !strings.Contains(out, "typo") || strings.Contains(out, "plan") {
+		t.Fatalf("ls --impact high+ should list crash and typo only:\n%s", out)
+	}
+
+	saveLsGlobals()
+	out, err = runCommand(t, dir, "ls", "--impact", "critical")
+	if err != nil {
+		t.Fatalf("ls --impact critical: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "crash") || strings.Contains(out, "typo") {
+		t.Fatalf("ls --impact critical should list crash only:\n%s", out)
+	}
+
+	saveLsGlobals()
+	if _, err := runCommand(t, dir, "ls", "--impact", "urgent"); err == nil {
+		t.Fatal("ls --impact urgent should fail")
+	}
+}
+
+func TestLsJSONLStreamsEveryFiberWithBody(t *testing.T) {
+	restore := saveLsGlobals()
+	defer restore()
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*felt.Felt{
+		{ID: "open", Name: "Open", Status: felt.StatusOpen, Tags: []string{"sim"}, Body: "Open body.", CreatedAt: time.Now()},
+		{ID: "done", Name: "Done", Status: felt.StatusClosed, Body: "Done body.", CreatedAt: time.Now()},
+		{ID: "note", Name: "Note", Body: "Note body.", CreatedAt: time.Now()},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runCommand(t, dir, "ls", "--jsonl")
+	if err != nil {
+		t.Fatalf("ls --jsonl: %v\n%s", err, out)
+	}
+	bodies := map[string]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var f struct {
+			ID   string `json:"id"`
+			Body string `json:"body"`
+		}
+		if err := json.Unmarshal([]byte(line), &f); err != nil {
+			t.Fatalf("line %q: %v", line, err)
+		}
+		bodies[f.ID] = f.Body
+	}
+	if len(bodies) != 3 || bodies["note"] != "Note body." || bodies["done"] != "Done body." {
+		t.Fatalf("ls --jsonl streamed %v", bodies)
+	}
+
+	restore()
+	restore = saveLsGlobals()
+	out, err = runCommand(t, dir, "ls", "--jsonl", "-t", "sim")
+	if err != nil || strings.Count(out, "\n") != 1 || !strings.Contains(out, `"Open body."`) {
+		t.Fatalf("ls --jsonl -t sim = %v\n%s", err, out)
+	}
+	var streamed struct {
+		ModifiedAt time.Time `json:"modified_at"`
+	}
+	if err := json.Unmarshal([]byte(out), &streamed); err != nil {
+		t.Fatal(err)
+	}
+	restore()
+	restore = saveLsGlobals()
+	out, err = runCommand(t, dir, "ls", "-j", "-t", "sim")
+	if err != nil {
+		t.Fatalf("ls -j -t sim: %v\n%s", err, out)
+	}
+	var listed []struct {
+		ModifiedAt time.Time `json:"modified_at"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil || len(listed) != 1 {
+		t.Fatalf("ls -j -t sim = %v\n%s", err, out)
+	}
+	if streamed.ModifiedAt.IsZero() || !streamed.ModifiedAt.Equal(listed[0].ModifiedAt) {
+		t.Fatalf("ls --jsonl modified_at = %v, ls -j has %v", streamed.ModifiedAt, listed[0].ModifiedAt)
+	}
+
+	restore()
+	restore = saveLsGlobals()
+	if _, err := runCommand(t, dir, "ls", "--jsonl", "--ready"); err == nil || !strings.Contains(err.Error(), "takes only -s and -t") {
+		t.Fatalf("ls --jsonl --ready = %v, want refused", err)
+	}
+}
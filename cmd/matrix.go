@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var matrixTags []string
+
+var matrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Dependency matrix of fibers' data flow",
+	Long: `Prints a design-structure matrix: one row and one column per fiber, with
+an X where the row's fiber reads the column's through inputs[].from. Rows
+run in data-flow order, so marks sit below the diagonal; a mark above it
+is a loop (see felt stats --health). Blocks of marks close to the diagonal
+are fibers coupled more tightly to each other than to the rest.
+
+-t narrows the matrix to fibers with the tag — the subset worth reading
+this way — though inputs are resolved against the whole store. Every
+status is shown; statusless notes only when they read, or are read by,
+another fiber in the matrix.`,
+	Example: `  felt matrix -t cosebis
+  felt matrix -t thread: --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		m := felt.NewDependencyMatrix(felts, func(f *felt.Felt) bool { return hasAllTags(f, matrixTags) })
+		if jsonOutput {
+			if m.IDs == nil {
+				m.IDs, m.Cells = []string{}, [][]int{}
+			}
+			return outputJSON(m)
+		}
+		if len(m.Fibers) == 0 {
+			fmt.Println("No fibers to chart")
+			return nil
+		}
+		fmt.Print(renderMatrix(m))
+		return nil
+	},
+}
+
+func init() {
+	matrixCmd.Flags().StringArrayVarP(&matrixTags, "tag", "t", nil, "Only fibers with this tag (repeatable, AND logic; trailing colon for prefix match)")
+	registerTagCompletion(matrixCmd, "tag")
+	rootCmd.AddCommand(matrixCmd)
+}
+
+// renderMatrix draws m with numbered rows labelled by fiber id and columns
+// headed by the matching row numbers: X for a dependency, \ on the
+// diagonal, and . elsewhere.
+func renderMatrix(m *felt.DependencyMatrix) string {
+	n := len(m.Fibers)
+	cell := len(strconv.Itoa(n)) + 1
+	label := 0
+	for _, id := range m.IDs {
+		label = max(label, len(id))
+	}
+	rowHead := func(i int, id string) string {
+		return fmt.Sprintf("%*d %-*s ", cell-1, i+1, label, id)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat(" ", len(rowHead(0, ""))))
+	for j := range n {
+		fmt.Fprintf(&sb, "%*d", cell, j+1)
+	}
+	sb.WriteString("\n")
+	for i, id := range m.IDs {
+		sb.WriteString(rowHead(i, id))
+		for j := range n {
+			mark := "."
+			switch {
+			case i == j:
+				mark = `\`
+			case m.Cells[i][j] == 1:
+				mark = "X"
+			}
+			fmt.Fprintf(&sb, "%*s", cell, mark)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestMatrixMarksDependenciesBelowDiagonal(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	mocks := &felt.Felt{ID: "mocks", Name: "Mocks", Status: felt.StatusOpen, Tags: []string{"sim"}, CreatedAt: created}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, Tags: []string{"sim"}, CreatedAt: created}
+	unrelated := &felt.Felt{ID: "unrelated", Name: "Unrelated", Status: felt.StatusOpen, CreatedAt: created}
+	if err := fit.AddDataFlowInput("mocks"); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*felt.Felt{mocks, fit, unrelated} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	prevTags, prevJSON := matrixTags, jsonOutput
+	defer func() { matrixTags, jsonOutput = prevTags, prevJSON }()
+	matrixTags, jsonOutput = nil, false
+
+	out, err := runCommand(t, dir, "matrix", "-t", "sim")
+	if err != nil {
+		t.Fatalf("matrix: %v\n%s", err, out)
+	}
+	want := "         1 2\n" +
+		"1 mocks  \\ .\n" +
+		"2 fit    X \\\n"
+	if out != want {
+		t.Fatalf("matrix =\n%s\nwant\n%s", out, want)
+	}
+}
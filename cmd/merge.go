@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <keep-id> <dup-id>",
+	Short: "Fold a duplicate fiber into another and delete it",
+	Long: `Merges a fiber filed twice. The duplicate's body (and outcome, if it has
+one) is appended to the kept fiber as a "Merged from" section, its tags and
+inputs are added to the kept fiber's, and the kept fiber takes whichever
+created-at is earlier. Every inputs[].from and body link that named the
+duplicate is pointed at the kept fiber, then the duplicate is deleted. It
+all lands together or not at all.
+
+An input id both fibers use for different sources stays the kept fiber's;
+merge names it so the other can be added back by hand. A duplicate with
+fibers nested under it is refused: nest them elsewhere first.`,
+	Example:           `  felt merge mocks-unbiased-9c1d mocks-are-unbiased-4e07`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeFiberIDs,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		scopeID := resolveCommandScope(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+		keep, err := felt.FindByScope(felts, scopeID, args[0])
+		if err != nil {
+			return err
+		}
+		dup, err := felt.FindByScope(felts, scopeID, args[1])
+		if err != nil {
+			return err
+		}
+
+		result, err := storage.Merge(keep.ID, dup.ID, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Merged %s into %s\n", dup.ID, keep.ID)
+		if n := len(result.Redirected); n > 0 {
+			fmt.Printf("Redirected references in %d %s: %s\n", n, pluralize(n, "fiber", "fibers"), strings.Join(result.Redirected, ", "))
+		}
+		for _, id := range result.SkippedInputs {
+			fmt.Printf("Kept %s's input %s; %s's input of that id was dropped\n", keep.ID, id, dup.ID)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestMergeResolvesIDsAndReportsRedirects(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	for _, f := range []*felt.Felt{
+		{ID: "mocks-3f2a", Name: "Mocks", Status: felt.StatusOpen, CreatedAt: created},
+		{ID: "mocks-again-9c1d", Name: "Mocks again", Status: felt.StatusOpen, Tags: []string{"sim"}, CreatedAt: created},
+		{ID: "paper-77e0", Name: "Paper", Status: felt.StatusOpen, Body: "Uses [[mocks-again-9c1d]].", CreatedAt: created},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runCommand(t, dir, "merge", "mocks-3f2a", "mocks-again")
+	if err != nil {
+		t.Fatalf("merge: %v\n%s", err, out)
+	}
+	for _, want := range []string{"Merged mocks-again-9c1d into mocks-3f2a", "Redirected references in 1 fiber: paper-77e0"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("merge output missing %q:\n%s", want, out)
+		}
+	}
+	kept, err := storage.Read("mocks-3f2a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !kept.HasTag("sim") {
+		t.Fatalf("kept fiber tags = %v, want sim carried over", kept.Tags)
+	}
+	if _, err := runCommand(t, dir, "merge", "mocks-3f2a", "mocks-3f2a"); err == nil {
+		t.Fatal("merging a fiber into itself should fail")
+	}
+}
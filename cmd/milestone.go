@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var milestoneAssignClear bool
+
+// The `felt milestone` group is the layer above individual fibers. Like a
+// sprint, a milestone is itself a fiber (milestones/<slug>); membership is a
+// `milestone: <slug>` field on the assigned fibers, and progress is computed
+// from the store on every call rather than recorded anywhere.
+var milestoneCmd = &cobra.Command{
+	Use:   "milestone",
+	Short: "Group fibers under milestones and track their progress",
+	Long: `Groups work under milestones — "the B-modes paper" — and reports how far
+along each one is.
+
+A milestone is a fiber under milestones/. Fibers are assigned to it by a
+milestone: <slug> frontmatter field, which 'felt milestone assign' sets.
+Progress counts the assigned fibers, their nested children, and everything
+upstream of them through inputs[].from: the work that has to close first.
+
+  felt milestone create <slug> <name>      create milestones/<slug>
+  felt milestone assign <milestone> <id>...
+  felt milestone list                      every milestone with its percentage
+  felt milestone progress <milestone>      counts and the work remaining`,
+}
+
+var milestoneCreateCmd = &cobra.Command{
+	Use:     "create <slug> <name>",
+	Short:   "Create a milestone",
+	Example: `  felt milestone create bmodes-paper "B-modes paper"`,
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		f, m, err := felt.NewMilestone(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if err := storage.CheckAvailableID(f.ID); err != nil {
+			return err
+		}
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+		if jsonOutput {
+			return outputJSON(m)
+		}
+		fmt.Printf("Created %s\n", m.ID)
+		return nil
+	},
+}
+
+var milestoneAssignCmd = &cobra.Command{
+	Use:   "assign <milestone> <id>...",
+	Short: "Assign fibers to a milestone",
+	Long: `Sets milestone: <slug> on each fiber, replacing any milestone it was
+assigned to before. With --clear, takes only fiber ids and removes their
+assignment.`,
+	Example: `  felt milestone assign bmodes-paper cosebis covariance
+  felt milestone assign --clear cosebis`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeFiberIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		scopeID := resolveCommandScope(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+
+		var m *felt.Milestone
+		queries := args
+		if !milestoneAssignClear {
+			if len(args) < 2 {
+				return fmt.Errorf("name a milestone and at least one fiber")
+			}
+			if m, err = resolveMilestone(felts, scopeID, args[0]); err != nil {
+				return err
+			}
+			queries = args[1:]
+		}
+
+		now := time.Now()
+		var changed []string
+		for _, query := range queries {
+			f, err := felt.FindByScope(felts, scopeID, query)
+			if err != nil {
+				return err
+			}
+			slug := ""
+			if m != nil {
+				if f.ID == m.ID {
+					return fmt.Errorf("cannot assign milestone %s to itself", m.ID)
+				}
+				slug = m.Slug
+			}
+			if f.Milestone() == slug {
+				if m == nil {
+					fmt.Fprintf(os.Stderr, "%s has no milestone\n", f.ID)
+				} else {
+					fmt.Fprintf(os.Stderr, "%s is already in %s\n", f.ID, m.ID)
+				}
+				continue
+			}
+			if err := assignMilestone(storage, f.ID, slug, now); err != nil {
+				return err
+			}
+			changed = append(changed, f.ID)
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]any{"milestone": m, "changed": changed})
+		}
+		for _, id := range changed {
+			if m == nil {
+				fmt.Printf("Cleared milestone on %s\n", id)
+			} else {
+				fmt.Printf("Assigned %s to %s\n", id, m.ID)
+			}
+		}
+		return nil
+	},
+}
+
+var milestoneListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List milestones with their completion percentage",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		upstreams := felt.DataFlowUpstreams(felts)
+		progress := []*felt.MilestoneProgress{}
+		for _, m := range felt.Milestones(felts) {
+			progress = append(progress, felt.BuildMilestoneProgress(m, felts, upstreams))
+		}
+		if jsonOutput {
+			return outputJSON(progress)
+		}
+		if len(progress) == 0 {
+			fmt.Println("No milestones (create one with 'felt milestone create <slug> <name>')")
+			return nil
+		}
+		for _, p := range progress {
+			fmt.Printf("%3d%%  %s — %s  (%d of %d closed)\n", p.Percent, p.Milestone.ID, p.Milestone.Name, p.Closed, p.Total)
+		}
+		return nil
+	},
+}
+
+var milestoneProgressCmd = &cobra.Command{
+	Use:   "progress <milestone>",
+	Short: "Show a milestone's completion and remaining work",
+	Long: `Reports a milestone's completion percentage over the transitive closure of
+its assigned fibers — each one, its nested children, and its upstream
+inputs — with counts by status and the fibers still open or active.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		m, err := resolveMilestone(felts, resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		p := felt.BuildMilestoneProgress(m, felts, felt.DataFlowUpstreams(felts))
+		if jsonOutput {
+			return outputJSON(p)
+		}
+		fmt.Print(renderMilestoneProgress(p))
+		return nil
+	},
+}
+
+func init() {
+	milestoneAssignCmd.Flags().BoolVar(&milestoneAssignClear, "clear", false, "Remove the fibers' milestone assignment")
+	milestoneCmd.AddCommand(milestoneCreateCmd, milestoneAssignCmd, milestoneListCmd, milestoneProgressCmd)
+	rootCmd.AddCommand(milestoneCmd)
+}
+
+// resolveMilestone finds the milestone a query names: its slug, its id, or
+// any fiber query that resolves to a milestone fiber.
+func resolveMilestone(felts []*felt.Felt, scopeID, query string) (*felt.Milestone, error) {
+	for _, m := range felt.Milestones(felts) {
+		if m.Slug == query || m.ID == query {
+			return m, nil
+		}
+	}
+	f, err := felt.FindByScope(felts, scopeID, query)
+	if err != nil {
+		return nil, fmt.Errorf("no milestone matches %q", query)
+	}
+	m, ok := felt.MilestoneFromFelt(f)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a milestone (milestones live under %s/)", f.ID, felt.MilestoneContainerID)
+	}
+	return m, nil
+}
+
+// assignMilestone sets one fiber's milestone. The fiber is re-read in full so
+// the body survives the rewrite.
+func assignMilestone(storage *felt.Storage, id, slug string, now time.Time) error {
+	f, err := storage.Read(id)
+	if err != nil {
+		return err
+	}
+	if err := f.SetMilestone(slug); err != nil {
+		return err
+	}
+	f.Touch(now)
+	return storage.Write(f)
+}
+
+func renderMilestoneProgress(p *felt.MilestoneProgress) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s — %s\n", p.Milestone.ID, p.Milestone.Name)
+	if p.Total == 0 {
+		sb.WriteString("No work assigned (use 'felt milestone assign')\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "%d%% complete: %d of %d closed, %d active, %d open\n", p.Percent, p.Closed, p.Total, p.Active, p.Open)
+	fmt.Fprintf(&sb, "%d assigned %s\n", len(p.Assigned), pluralize(len(p.Assigned), "fiber", "fibers"))
+	if len(p.Remaining) > 0 {
+		sb.WriteString("\n## Remaining\n")
+		for _, f := range p.Remaining {
+			fmt.Fprintf(&sb, "%s %s — %s\n", fiberIcon(f), f.ID, f.DisplayName())
+		}
+	}
+	return sb.String()
+}
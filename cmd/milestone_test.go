@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestMilestoneCreateAssignProgress(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, f := range []*felt.Felt{
+		{ID: "draft", Name: "Draft", Status: felt.StatusClosed, Body: "Body survives.\n"},
+		{ID: "figures", Name: "Figures", Status: felt.StatusOpen},
+	} {
+		f.CreatedAt = mustParseTime(t, "2026-04-10T09:00:00Z")
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	prevClear, prevJSON := milestoneAssignClear, jsonOutput
+	defer func() { milestoneAssignClear, jsonOutput = prevClear, prevJSON }()
+	milestoneAssignClear, jsonOutput = false, false
+
+	if out, err := runCommand(t, dir, "milestone", "create", "bmodes-paper", "B-modes paper"); err != nil {
+		t.Fatalf("milestone create: %v\n%s", err, out)
+	}
+	out, err := runCommand(t, dir, "milestone", "assign", "bmodes-paper", "draft", "figures")
+	if err != nil {
+		t.Fatalf("milestone assign: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Assigned draft to milestones/bmodes-paper\n") {
+		t.Fatalf("assign output:\n%s", out)
+	}
+	draft, err := storage.Read("draft")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if draft.Milestone() != "bmodes-paper" || strings.TrimSpace(draft.Body) != "Body survives." {
+		t.Fatalf("draft after assign: milestone %q, body %q", draft.Milestone(), draft.Body)
+	}
+
+	out, err = runCommand(t, dir, "milestone", "list")
+	if err != nil {
+		t.Fatalf("milestone list: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, " 50%  milestones/bmodes-paper — B-modes paper  (1 of 2 closed)") {
+		t.Fatalf("list output:\n%s", out)
+	}
+	out, err = runCommand(t, dir, "milestone", "progress", "bmodes-paper")
+	if err != nil {
+		t.Fatalf("milestone progress: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "50% complete: 1 of 2 closed") || !strings.Contains(out, "figures — Figures") {
+		t.Fatalf("progress output:\n%s", out)
+	}
+
+	out, err = runCommand(t, dir, "milestone", "assign", "--clear", "figures")
+	milestoneAssignClear = false
+	if err != nil {
+		t.Fatalf("milestone assign --clear: %v\n%s", err, out)
+	}
+	if figures, _ := storage.Read("figures"); figures.Milestone() != "" {
+		t.Fatalf("--clear left milestone %q", figures.Milestone())
+	}
+	if _, err := runCommand(t, dir, "milestone", "progress", "draft"); err == nil || !strings.Contains(err.Error(), "not a milestone") {
+		t.Fatalf("progress on a plain fiber = %v, want error", err)
+	}
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var orderTags []string
+
+var orderCmd = &cobra.Command{
+	Use:   "order",
+	Short: "List open work in dependency order",
+	Long: `Lists open and active fibers so that each comes after the fibers its
+inputs[].from entries name — an order the work can be done in. Among fibers
+free to go next, the earlier-created goes first.
+
+Closed upstreams are already satisfied and do not appear. Fibers on an
+inputs[].from loop, or downstream of one, cannot be ordered and are listed
+after the rest. -t narrows the output to fibers with the tag, keeping the
+order the whole store gives them.`,
+	Example: `  felt order
+  felt order -t cosebis
+  felt order --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		ordered, cyclic := felt.WorkOrder(felts)
+		ordered, cyclic = filterByTags(ordered, orderTags), filterByTags(cyclic, orderTags)
+
+		if jsonOutput {
+			return outputJSON(map[string]any{"order": ordered, "cyclic": cyclic})
+		}
+		if len(ordered) == 0 && len(cyclic) == 0 {
+			fmt.Println("No open work")
+			return nil
+		}
+		for i, f := range ordered {
+			fmt.Printf("%3d. %s %s — %s\n", i+1, fiberIcon(f), f.ID, f.DisplayName())
+		}
+		if len(cyclic) > 0 {
+			fmt.Println("\n## On an inputs loop (see 'felt stats --health')")
+			for _, f := range cyclic {
+				fmt.Printf("     %s %s — %s\n", fiberIcon(f), f.ID, f.DisplayName())
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	orderCmd.Flags().StringArrayVarP(&orderTags, "tag", "t", nil, "Only fibers with this tag (repeatable, AND logic; trailing colon for prefix match)")
+	registerTagCompletion(orderCmd, "tag")
+	rootCmd.AddCommand(orderCmd)
+}
+
+// filterByTags keeps the fibers carrying every tag, in their given order.
+func filterByTags(felts []*felt.Felt, tags []string) []*felt.Felt {
+	out := []*felt.Felt{}
+	for _, f := range felts {
+		if hasAllTags(f, tags) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func hasAllTags(f *felt.Felt, tags []string) bool {
+	for _, tag := range tags {
+		if !f.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestOrderListsInputsFirstAndFiltersByTag(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, Tags: []string{"cosebis"}, CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}
+	if err := fit.AddDataFlowInput("prep"); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*felt.Felt{
+		fit,
+		{ID: "prep", Name: "Prep", Status: felt.StatusOpen, CreatedAt: mustParseTime(t, "2026-04-11T09:00:00Z")},
+		{ID: "draft", Name: "Draft", Status: felt.StatusOpen, Tags: []string{"cosebis"}, CreatedAt: mustParseTime(t, "2026-04-12T09:00:00Z")},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	prevTags, prevJSON := orderTags, jsonOutput
+	defer func() { orderTags, jsonOutput = prevTags, prevJSON }()
+	orderTags, jsonOutput = nil, false
+
+	out, err := runCommand(t, dir, "order")
+	if err != nil {
+		t.Fatalf("order: %v\n%s", err, out)
+	}
+	prep, fitLine, draft := strings.Index(out, "1. "), strings.Index(out, "2. "), strings.Index(out, "3. ")
+	if prep < 0 || fitLine < 0 || draft < 0 ||
+		!strings.Contains(out[prep:fitLine], "prep — Prep") || !strings.Contains(out[fitLine:draft], "fit — Fit") || !strings.Contains(out[draft:], "draft — Draft") {
+		t.Fatalf("order output:\n%s", out)
+	}
+
+	orderTags = nil
+	out, err = runCommand(t, dir, "order", "-t", "cosebis", "--json")
+	if err != nil {
+		t.Fatalf("order --json: %v\n%s", err, out)
+	}
+	var got struct {
+		Order  []struct{ ID string } `json:"order"`
+		Cyclic []struct{ ID string } `json:"cyclic"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("order --json: %v\n%s", err, out)
+	}
+	if len(got.Order) != 2 || got.Order[0].ID != "fit" || got.Order[1].ID != "draft" || got.Cyclic == nil {
+		t.Fatalf("order -t cosebis --json = %+v\n%s", got, out)
+	}
+}
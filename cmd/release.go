@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var releaseCutDryRun bool
+
+// The `felt release` group turns closed work into release notes with
+// provenance. Like a sprint, a release is itself a fiber (releases/<version>,
+// carrying a release: block with its version and cut time), and membership is
+// a `release:<version>` tag on the shipped fibers.
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Bundle closed work into releases",
+}
+
+var releaseCutCmd = &cobra.Command{
+	Use:   "cut <version>",
+	Short: "Cut a release from the work closed since the last one",
+	Long: `Collects every fiber closed since the previous release was cut (or ever,
+for the first) that is not already in a release, and records them:
+
+  - a closed release fiber, releases/<version>, whose body links each
+    shipped fiber with its outcome;
+  - a release:<version> tag on each shipped fiber;
+  - a Keep a Changelog section on stdout, one bullet per fiber — its
+    outcome, else its name — with the fiber id for provenance.
+
+--dry-run prints the section without writing anything.`,
+	Example: `  felt release cut v1.2
+  felt release cut v1.2 --dry-run`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		storage := felt.NewStorage(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+		var previous *felt.Release
+		for _, r := range felt.Releases(felts) {
+			if r.Version == args[0] {
+				return fmt.Errorf("release %s was already cut (%s)", r.Version, r.ID)
+			}
+			previous = r
+		}
+		members := felt.ReleaseCandidates(felts, previous)
+		if len(members) == 0 {
+			if previous != nil {
+				return fmt.Errorf("nothing closed since %s was cut", previous.Version)
+			}
+			return fmt.Errorf("no closed fibers to release")
+		}
+
+		f, r, err := felt.NewRelease(args[0], time.Now(), members)
+		if err != nil {
+			return err
+		}
+		if !releaseCutDryRun {
+			if err := storage.CheckAvailableID(f.ID); err != nil {
+				return err
+			}
+			tx, err := storage.Begin()
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback()
+			if err := tx.Write(f); err != nil {
+				return err
+			}
+			for _, m := range members {
+				if err := tagRelease(storage, tx, m.ID, r, r.Cut); err != nil {
+					return err
+				}
+			}
+			if err := tx.Commit(); err != nil {
+				return err
+			}
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]any{"release": r, "fibers": members})
+		}
+		fmt.Print(felt.ReleaseNotes(r, members))
+		if !releaseCutDryRun {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Cut %s with %d %s\n", r.ID, len(members), pluralize(len(members), "fiber", "fibers"))
+		}
+		return nil
+	},
+}
+
+// tagRelease stages tagging one fiber into r. The fiber is re-read in full
+// so the body survives the rewrite.
+func tagRelease(storage *felt.Storage, tx *felt.Tx, id string, r *felt.Release, now time.Time) error {
+	f, err := storage.Read(id)
+	if err != nil {
+		return err
+	}
+	f.AddTag(r.Tag())
+	f.Touch(now)
+	return tx.Write(f)
+}
+
+func init() {
+	releaseCutCmd.Flags().BoolVar(&releaseCutDryRun, "dry-run", false, "Print the changelog section without writing anything")
+	releaseCmd.AddCommand(releaseCutCmd)
+	rootCmd.AddCommand(releaseCmd)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestReleaseCutTagsClosedWorkAndEmitsNotes(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := time.Now().Add(-48 * time.Hour)
+	closedAt := time.Now().Add(-time.Hour)
+	for _, f := range []*felt.Felt{
+		{ID: "fix-mask", Name: "Fix the mask", Status: felt.StatusClosed, ClosedAt: &closedAt, Outcome: "Mask edges no longer clip halos", CreatedAt: created, Body: "Body survives.\n"},
+		{ID: "new-plots", Name: "New plots", Status: felt.StatusClosed, ClosedAt: &closedAt, CreatedAt: created},
+		{ID: "in-flight", Name: "In flight", Status: felt.StatusActive, CreatedAt: created},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	defer func() { releaseCutDryRun = false }()
+
+	out, err := runCommand(t, dir, "release", "cut", "v1.2", "--dry-run")
+	if err != nil {
+		t.Fatalf("release cut --dry-run: %v\n%s", err, out)
+	}
+	if _, err := storage.Read("releases/v1-2"); err == nil {
+		t.Fatal("--dry-run wrote the release fiber")
+	}
+	releaseCutDryRun = false
+
+	out, err = runCommand(t, dir, "release", "cut", "v1.2")
+	if err != nil {
+		t.Fatalf("release cut: %v\n%s", err, out)
+	}
+	for _, want := range []string{"## [1.2] - ", "- Mask edges no longer clip halos (`fix-mask`)", "- New plots (`new-plots`)"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("release notes missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "in-flight") {
+		t.Fatalf("release notes include open work:\n%s", out)
+	}
+
+	rel, err := storage.Read("releases/v1-2")
+	if err != nil {
+		t.Fatalf("release fiber: %v", err)
+	}
+	if !rel.IsClosed() || !strings.Contains(rel.Body, "- [[fix-mask]] Fix the mask — Mask edges no longer clip halos") {
+		t.Fatalf("release fiber = status %q body:\n%s", rel.Status, rel.Body)
+	}
+	fixed, err := storage.Read("fix-mask")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fixed.HasTag("release:v1.2") || strings.TrimSpace(fixed.Body) != "Body survives." {
+		t.Fatalf("member = tags %v body %q", fixed.Tags, fixed.Body)
+	}
+
+	if _, err := runCommand(t, dir, "release", "cut", "v1.2"); err == nil {
+		t.Fatal("recutting v1.2 succeeded, want error")
+	}
+	if _, err := runCommand(t, dir, "release", "cut", "v1.3"); err == nil || !strings.Contains(err.Error(), "nothing closed since v1.2") {
+		t.Fatalf("empty cut error = %v, want nothing closed since v1.2", err)
+	}
+}
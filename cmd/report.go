@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportTags      []string
+	reportMilestone string
+	reportDays      int
+)
+
+// burndownWidth is how many columns the longest burndown bar spans.
+const burndownWidth = 40
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Progress reports reconstructed from the store",
+	Long: `Reports on progress over time, replayed from the fibers' created-at and
+closed-at rather than recorded anywhere.
+
+  felt report burndown -t <tag>              remaining effort, day by day
+  felt report burndown --milestone <slug>`,
+}
+
+var reportBurndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Chart remaining effort over recent days",
+	Long: `Charts, day by day, the effort still remaining across a set of fibers:
+those with the -t tags (a sprint's sprint:<start-date>, a thread), or a
+milestone's assigned fibers with their children and upstream inputs.
+
+Effort is each fiber's estimate: field. A span ("4h", "2d") counts in
+hours, a bare number ("3") as points; a set mixing the two is refused.
+Fibers without an estimate are left out and counted, and when none is
+estimated the chart counts fibers instead. Each bar is the remaining
+effort (#) against the scope created by that day (.).`,
+	Example: `  felt report burndown -t sprint:2026-10-14
+  felt report burndown --milestone bmodes-paper --days 30
+  felt report burndown -t thread:cosebis --json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		if len(reportTags) == 0 && reportMilestone == "" {
+			return fmt.Errorf("burndown needs -t <tag> or --milestone <slug>")
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		subset := felts
+		if reportMilestone != "" {
+			m, err := resolveMilestone(felts, resolveCommandScope(root), reportMilestone)
+			if err != nil {
+				return err
+			}
+			subset = felt.MilestoneFibers(m, felts, felt.DataFlowUpstreams(felts))
+		}
+		subset = filterByTags(subset, reportTags)
+
+		now := time.Now()
+		b, err := felt.BuildBurndown(subset, reportDays, now, displayTime(now).Location())
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return outputJSON(b)
+		}
+		fmt.Print(renderBurndown(b))
+		return nil
+	},
+}
+
+func init() {
+	reportBurndownCmd.Flags().StringArrayVarP(&reportTags, "tag", "t", nil, "Only fibers with this tag (repeatable, AND logic; trailing colon for prefix match)")
+	registerTagCompletion(reportBurndownCmd, "tag")
+	reportBurndownCmd.Flags().StringVar(&reportMilestone, "milestone", "", "Chart a milestone's fibers (slug or id)")
+	reportBurndownCmd.Flags().IntVar(&reportDays, "days", felt.DefaultBurndownDays, "Days of history to chart")
+	reportCmd.AddCommand(reportBurndownCmd)
+	rootCmd.AddCommand(reportCmd)
+}
+
+// renderBurndown draws one bar per day, scaled so the largest scope spans
+// burndownWidth columns.
+func renderBurndown(b *felt.Burndown) string {
+	var sb strings.Builder
+	last := b.Days[len(b.Days)-1]
+	fmt.Fprintf(&sb, "Burndown: %s remaining of %s across %d %s", formatEffort(last.Remaining, b.Unit), formatEffort(last.Scope, b.Unit), b.Fibers, pluralize(b.Fibers, "fiber", "fibers"))
+	if b.Unestimated > 0 {
+		fmt.Fprintf(&sb, " (%d unestimated, left out)", b.Unestimated)
+	}
+	sb.WriteString("\n")
+	if b.Fibers == 0 {
+		sb.WriteString("Nothing to chart\n")
+		return sb.String()
+	}
+
+	peak := 0.0
+	for _, d := range b.Days {
+		peak = math.Max(peak, d.Scope)
+	}
+	cols := func(v float64) int {
+		if peak == 0 {
+			return 0
+		}
+		return int(math.Round(v / peak * burndownWidth))
+	}
+	for _, d := range b.Days {
+		remaining, scope := cols(d.Remaining), cols(d.Scope)
+		bar := strings.Repeat("#", remaining) + strings.Repeat(".", scope-remaining)
+		fmt.Fprintf(&sb, "%s  %-*s  %s\n", d.Day.Format("Mon Jan _2"), burndownWidth, bar, formatEffort(d.Remaining, b.Unit))
+	}
+	return sb.String()
+}
+
+// formatEffort renders an amount of effort in unit: 6.5h, 3 pts, 4 fibers.
+func formatEffort(v float64, unit string) string {
+	n := strconv.FormatFloat(math.Round(v*10)/10, 'f', -1, 64)
+	switch unit {
+	case felt.BurndownHours:
+		return n + "h"
+	case felt.BurndownPoints:
+		return n + " pts"
+	}
+	return n + " " + pluralize(int(v), "fiber", "fibers")
+}
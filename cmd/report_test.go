@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestReportBurndownForMilestone(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := time.Now().Add(-48 * time.Hour)
+	closed := time.Now()
+	mocks := &felt.Felt{ID: "mocks", Name: "Mocks", Status: felt.StatusClosed, CreatedAt: created, ClosedAt: &closed}
+	draft := &felt.Felt{ID: "draft", Name: "Draft", Status: felt.StatusOpen, CreatedAt: created}
+	other := &felt.Felt{ID: "other", Name: "Other", Status: felt.StatusOpen, CreatedAt: created}
+	if err := draft.AddDataFlowInput("mocks"); err != nil {
+		t.Fatal(err)
+	}
+	for f, estimate := range map[*felt.Felt]string{mocks: "2", draft: "3", other: "5"} {
+		if err := f.SetExtraField(felt.EstimateKey, estimate); err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	prevTags, prevMilestone, prevDays, prevJSON := reportTags, reportMilestone, reportDays, jsonOutput
+	defer func() {
+		reportTags, reportMilestone, reportDays, jsonOutput = prevTags, prevMilestone, prevDays, prevJSON
+	}()
+	reportTags, reportMilestone, reportDays, jsonOutput = nil, "", felt.DefaultBurndownDays, false
+
+	if out, err := runCommand(t, dir, "milestone", "create", "paper", "Paper"); err != nil {
+		t.Fatalf("milestone create: %v\n%s", err, out)
+	}
+	if out, err := runCommand(t, dir, "milestone", "assign", "paper", "draft"); err != nil {
+		t.Fatalf("milestone assign: %v\n%s", err, out)
+	}
+
+	out, err := runCommand(t, dir, "report", "burndown", "--milestone", "paper", "--days", "3")
+	if err != nil {
+		t.Fatalf("report burndown: %v\n%s", err, out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 4 || lines[0] != "Burndown: 3 pts remaining of 5 pts across 2 fibers" {
+		t.Fatalf("report burndown =\n%s", out)
+	}
+	if !strings.HasSuffix(lines[2], "  5 pts") || !strings.HasSuffix(lines[3], strings.Repeat("#", 24)+strings.Repeat(".", 16)+"  3 pts") {
+		t.Fatalf("report burndown bars =\n%s", out)
+	}
+
+	if _, err := runCommand(t, dir, "report", "burndown", "--milestone", ""); err == nil {
+		t.Fatal("report burndown without a selection succeeded, want error")
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewStale string
+	reviewDefer string
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Batch cleanup proposals for tracked fibers",
+}
+
+var reviewProposeCmd = &cobra.Command{
+	Use:   "propose",
+	Short: "Propose closures, merges, and defers as JSON",
+	Long: `Looks over the open and active fibers and writes a JSON proposal list to
+stdout, one judgment call per entry:
+
+  close  a fiber whose children have all closed, with an outcome drafted
+         from theirs
+  merge  a fiber into an older tracked sibling with the same name
+  defer  a fiber untouched for --stale (default 30d), for --defer (2w)
+
+Nothing is changed. Edit the file — reword outcomes, drop entries you
+disagree with — then hand it to felt review apply.`,
+	Example: `  felt review propose > proposals.json
+  felt review propose --stale 14d --defer 1w`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		staleAfter, err := felt.ParseSpan(reviewStale)
+		if err != nil {
+			return fmt.Errorf("invalid --stale: %w", err)
+		}
+		deferFor, err := felt.ParseSpan(reviewDefer)
+		if err != nil {
+			return fmt.Errorf("invalid --defer: %w", err)
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		now := time.Now().UTC().Truncate(time.Second)
+		proposals := felt.ProposeReview(felts, now, staleAfter, deferFor)
+		if proposals == nil {
+			proposals = []felt.ReviewProposal{}
+		}
+		return outputJSON(felt.ReviewProposals{GeneratedAt: now, Proposals: proposals})
+	},
+}
+
+var reviewApplyCmd = &cobra.Command{
+	Use:   "apply <proposals.json>",
+	Short: "Apply a review proposal file",
+	Long: `Applies the proposals felt review propose wrote (or any file of the same
+shape; - reads stdin). Every entry is checked against the store first, so a
+file naming a missing fiber or an unknown action changes nothing. Then each
+is applied in order: close sets status closed and the outcome, merge does
+what felt merge <into> <id> does, and defer snoozes the fiber until the
+given time.`,
+	Example:      `  felt review apply proposals.json`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		data, err := readTextInput(cmd, "proposals", args[0])
+		if err != nil {
+			return err
+		}
+		var file felt.ReviewProposals
+		if err := json.Unmarshal([]byte(data), &file); err != nil {
+			return fmt.Errorf("parsing proposals: %w", err)
+		}
+
+		storage := felt.NewStorage(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+		cfg, err := storage.LoadConfig()
+		if err != nil {
+			return err
+		}
+		for _, p := range file.Proposals {
+			if err := felt.ValidateReviewProposal(p, felts, cfg); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		for _, p := range file.Proposals {
+			if err := storage.ApplyReviewProposal(p, now); err != nil {
+				return fmt.Errorf("%s %s: %w", p.Action, p.ID, err)
+			}
+			switch p.Action {
+			case felt.ReviewClose:
+				fmt.Printf("Closed %s\n", p.ID)
+			case felt.ReviewMerge:
+				fmt.Printf("Merged %s into %s\n", p.ID, p.Into)
+			case felt.ReviewDefer:
+				fmt.Printf("Deferred %s until %s\n", p.ID, displayTime(*p.Until).Format("2006-01-02 15:04"))
+			}
+		}
+		n := len(file.Proposals)
+		fmt.Printf("Applied %d %s\n", n, pluralize(n, "proposal", "proposals"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reviewCmd)
+	reviewCmd.AddCommand(reviewProposeCmd)
+	reviewCmd.AddCommand(reviewApplyCmd)
+	reviewProposeCmd.Flags().StringVar(&reviewStale, "stale", "30d", "Propose deferring tracked fibers untouched this long")
+	reviewProposeCmd.Flags().StringVar(&reviewDefer, "defer", "2w", "How long a proposed defer lasts")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestReviewProposeThenApply(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	old := time.Now().Add(-45 * 24 * time.Hour).UTC().Truncate(time.Second)
+	for _, f := range []*felt.Felt{
+		{ID: "paper", Name: "Paper", Status: felt.StatusActive, CreatedAt: time.Now()},
+		{ID: "paper/intro", Name: "Intro", Status: felt.StatusClosed, Outcome: "Intro drafted", CreatedAt: time.Now()},
+		{ID: "plots", Name: "Plots", Status: felt.StatusOpen, CreatedAt: old, UpdatedAt: &old},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := runCommand(t, dir, "review", "propose")
+	if err != nil {
+		t.Fatalf("review propose: %v\n%s", err, out)
+	}
+	var file felt.ReviewProposals
+	if err := json.Unmarshal([]byte(out), &file); err != nil {
+		t.Fatalf("propose output is not JSON: %v\n%s", err, out)
+	}
+	if len(file.Proposals) != 2 || file.Proposals[0].Action != felt.ReviewClose || file.Proposals[1].Action != felt.ReviewDefer {
+		t.Fatalf("proposals = %+v", file.Proposals)
+	}
+
+	// A reviewer rewords the drafted outcome before applying.
+	file.Proposals[0].Outcome = "Paper drafted"
+	data, _ := json.Marshal(file)
+	path := filepath.Join(dir, "proposals.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = runCommand(t, dir, "review", "apply", path)
+	if err != nil || !strings.Contains(out, "Closed paper\n") || !strings.Contains(out, "Deferred plots until") {
+		t.Fatalf("review apply = %v\n%s", err, out)
+	}
+	paper, err := storage.Read("paper")
+	if err != nil || !paper.IsClosed() || paper.Outcome != "Paper drafted" {
+		t.Fatalf("paper = %+v, %v", paper, err)
+	}
+	plots, err := storage.Read("plots")
+	if err != nil || !plots.IsDeferred(time.Now()) {
+		t.Fatalf("plots should be deferred: %v", err)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"proposals":[{"action":"close","id":"plots"},{"action":"close","id":"missing"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCommand(t, dir, "review", "apply", bad); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("apply with a missing fiber = %v, want error", err)
+	}
+	if plots, _ := storage.Read("plots"); plots.IsClosed() {
+		t.Fatal("a bad proposal file still applied its valid entries")
+	}
+}
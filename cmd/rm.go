@@ -1,17 +1,35 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/cailmdaley/felt/internal/felt"
 	"github.com/spf13/cobra"
 )
 
+var rmForce bool
+
 var rmCmd = &cobra.Command{
 	Use:   "rm <id>",
 	Short: "Delete a felt",
-	Long:  `Permanently removes a felt from the repository.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Permanently removes a felt from the repository.
+
+Before deleting, rm lists what depends on the fiber: fibers nested under
+it, fibers whose inputs read it, and fibers whose bodies link to it. Their
+references dangle once it is gone; felt merge folds a duplicate in and
+redirects them instead.
+
+In a terminal rm asks before deleting; --force skips the question. Piped or
+scripted, it deletes without asking.`,
+	Example: `  felt rm mocks-again-9c1d
+  felt rm --force scratch-notes`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := resolveProjectRoot()
 		if err != nil {
@@ -20,7 +38,7 @@ var rmCmd = &cobra.Command{
 
 		storage := felt.NewStorage(root)
 		scopeID := resolveCommandScope(root)
-		felts, err := storage.ListMetadata()
+		felts, err := storage.List()
 		if err != nil {
 			return err
 		}
@@ -30,6 +48,28 @@ var rmCmd = &cobra.Command{
 			return err
 		}
 
+		citations, consumers, err := felt.RelationshipsFromFelts(felts, f.ID)
+		if err != nil {
+			return err
+		}
+		dependents := printRmDependents(os.Stdout, felts, f.ID, citations, consumers)
+
+		if !rmForce && stdinIsTerminal(cmd) {
+			prompt := fmt.Sprintf("Delete %s (%s)", f.ID, f.DisplayName())
+			if dependents > 0 {
+				prompt += fmt.Sprintf(", leaving %d %s with dangling references", dependents, pluralize(dependents, "fiber", "fibers"))
+			}
+			fmt.Printf("%s? [y/N] ", prompt)
+			line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+			if err != nil && err != io.EOF {
+				return err
+			}
+			if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+				fmt.Println("Not deleted")
+				return nil
+			}
+		}
+
 		if err := storage.Delete(f.ID); err != nil {
 			return err
 		}
@@ -43,6 +83,56 @@ var rmCmd = &cobra.Command{
 	},
 }
 
+// printRmDependents lists what would be left pointing at id once it is
+// deleted, and returns how many distinct fibers that is.
+func printRmDependents(w io.Writer, felts []*felt.Felt, id string, citations []felt.Citation, consumers []felt.DataFlowConsumer) int {
+	seen := make(map[string]bool)
+	var nested, readers, linkers []string
+	for _, f := range felts {
+		if strings.HasPrefix(f.ID, id+"/") {
+			nested = append(nested, f.ID)
+			seen[f.ID] = true
+		}
+	}
+	for _, c := range consumers {
+		if c.SourceID != id && !seen[c.SourceID] {
+			readers = append(readers, c.SourceID)
+			seen[c.SourceID] = true
+		}
+	}
+	for _, c := range citations {
+		if c.SourceID != id && !seen[c.SourceID] {
+			linkers = append(linkers, c.SourceID)
+			seen[c.SourceID] = true
+		}
+	}
+	for _, group := range []struct {
+		label string
+		ids   []string
+	}{
+		{"Nested under it", nested},
+		{"Inputs read it", readers},
+		{"Linked from", linkers},
+	} {
+		if len(group.ids) > 0 {
+			fmt.Fprintf(w, "%s: %s\n", group.label, strings.Join(group.ids, ", "))
+		}
+	}
+	return len(seen)
+}
+
+// stdinIsTerminal reports whether cmd reads from an interactive terminal.
+// Tests replace it to drive the confirmation prompt.
+var stdinIsTerminal = func(cmd *cobra.Command) bool {
+	file, ok := cmd.InOrStdin().(*os.File)
+	if !ok {
+		return false
+	}
+	stat, err := file.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
 func init() {
 	rootCmd.AddCommand(rmCmd)
+	rmCmd.Flags().BoolVarP(&rmForce, "force", "f", false, "Delete without asking, even in a terminal")
 }
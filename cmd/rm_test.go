@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+func TestRmListsDependentsAndConfirmsInTerminal(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	for _, f := range []*felt.Felt{
+		{ID: "mocks", Name: "Mocks", Status: felt.StatusOpen, CreatedAt: created},
+		{ID: "mocks/seeds", Name: "Seeds", CreatedAt: created},
+		{ID: "paper", Name: "Paper", Status: felt.StatusOpen, Body: "Built on [[mocks]].", CreatedAt: created},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prevTerminal, prevForce := stdinIsTerminal, rmForce
+	defer func() { stdinIsTerminal, rmForce = prevTerminal, prevForce }()
+	stdinIsTerminal = func(*cobra.Command) bool { return true }
+	rootCmd.SetIn(strings.NewReader("n\n"))
+	defer rootCmd.SetIn(nil)
+
+	out, err := runCommand(t, dir, "rm", "mocks")
+	if err != nil {
+		t.Fatalf("rm: %v\n%s", err, out)
+	}
+	for _, want := range []string{"Nested under it: mocks/seeds", "Linked from: paper", "Delete mocks (Mocks), leaving 2 fibers with dangling references? [y/N] ", "Not deleted"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("rm output missing %q:\n%s", want, out)
+		}
+	}
+	if _, err := storage.Read("mocks"); err != nil {
+		t.Fatalf("declined rm deleted the fiber: %v", err)
+	}
+
+	out, err = runCommand(t, dir, "rm", "--force", "mocks")
+	rmForce = false
+	if err != nil || strings.Contains(out, "[y/N]") || !strings.Contains(out, "Deleted mocks") {
+		t.Fatalf("rm --force = %v:\n%s", err, out)
+	}
+}
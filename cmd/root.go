@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/cailmdaley/felt/internal/felt"
 	"github.com/spf13/cobra"
@@ -16,6 +19,7 @@ import (
 var (
 	jsonOutput bool
 	changeDir  string
+	emitPatch  bool
 )
 
 // Version is the current version, set via ldflags.
@@ -38,12 +42,32 @@ downstream tools.`,
 	CompletionOptions: cobra.CompletionOptions{
 		HiddenDefaultCmd: true,
 	},
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		applyRootFlags()
+	},
+}
+
+// applyRootFlags applies the root persistent flags (theme, --ascii,
+// --emit-patch) for the invocation. Cobra runs only the nearest
+// PersistentPreRun, so a command group with its own hook (felt shuttle) must
+// call this too.
+func applyRootFlags() {
+	theme = resolveTheme()
+	if emitPatch {
+		os.Setenv(felt.EmitPatchEnv, "1")
+	}
 }
 
 // Execute runs the root command.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	c, err := rootCmd.ExecuteC()
+	recordTelemetry(c, err, time.Now())
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		var block *hookBlockError
+		if errors.As(err, &block) {
+			os.Exit(2)
+		}
 		os.Exit(1)
 	}
 }
@@ -51,6 +75,8 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&jsonOutput, "json", "j", false, "Output in JSON format")
 	rootCmd.PersistentFlags().StringVarP(&changeDir, "directory", "C", "", "Run as if felt was started in `dir`")
+	rootCmd.PersistentFlags().BoolVar(&asciiFlag, "ascii", false, "Render status icons as ASCII words ([open] [act] [done]); also FELT_ASCII=1")
+	rootCmd.PersistentFlags().BoolVar(&emitPatch, "emit-patch", false, "Print fiber file changes to stderr as a unified diff instead of writing them; also FELT_EMIT_PATCH=1")
 }
 
 // resolveProjectRoot returns the project root, honoring -C if set.
@@ -60,11 +86,14 @@ func resolveProjectRoot() (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("resolving -C path: %w", err)
 		}
-		feltDir := filepath.Join(abs, felt.DirName)
-		if info, err := os.Stat(feltDir); err != nil || !info.IsDir() {
+		root, ok, err := felt.ProjectRootAt(abs)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
 			return "", fmt.Errorf("no .felt directory in %s", abs)
 		}
-		return abs, nil
+		return root, nil
 	}
 	return felt.FindProjectRoot()
 }
@@ -116,7 +145,13 @@ func resolveCommandScope(root string) string {
 // to an empty slice so listing endpoints always emit `[]` (not `null`) when
 // they have no results — consumers shouldn't have to handle both.
 func outputJSON(data interface{}) error {
-	enc := json.NewEncoder(os.Stdout)
+	return encodeJSON(os.Stdout, data)
+}
+
+// encodeJSON writes data to w as outputJSON prints it, for callers (the HTTP
+// API) that answer somewhere other than stdout.
+func encodeJSON(w io.Writer, data interface{}) error {
+	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
 		data = reflect.MakeSlice(v.Type(), 0, 0).Interface()
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Record computational runs on fibers",
+}
+
+var runRecordCmd = &cobra.Command{
+	Use:   "record <id> -- <cmd> [args...]",
+	Short: "Run a command and record it on a fiber",
+	Long: `Runs a command and appends a structured entry to the fiber's runs:
+frontmatter list, tying the result to the fiber that motivated it:
+
+  runs:
+    - cmd: python scripts/fit.py --chains 4
+      script: scripts/fit.py
+      exit: 0
+      duration: 3m12s
+      git-sha: 4f2c9e1            # "-dirty" when the worktree has changes
+      started-at: 2026-04-10T09:00:00Z
+
+The command inherits felt's stdin, stdout, and stderr. script is the first
+argument naming an existing file. The run is recorded whatever its exit
+status, and a failing command makes felt run record fail too.
+
+  felt run record fit-cosebis -- python scripts/fit.py --chains 4`,
+	// Everything after the id belongs to the command being run, so felt
+	// parses no flags of its own here; "--" is accepted and dropped.
+	DisableFlagParsing: true,
+	ValidArgsFunction:  completeFirstFiberID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+			return cmd.Help()
+		}
+		if len(args) > 1 && args[1] == "--" {
+			args = append(args[:1:1], args[2:]...)
+		}
+		if len(args) < 2 {
+			return fmt.Errorf("usage: felt run record <id> -- <cmd> [args...]")
+		}
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		storage := felt.NewStorage(root)
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+
+		command := args[1:]
+		rec := felt.RunRecord{
+			Cmd:       shellJoin(command),
+			Script:    runScriptPath(command),
+			GitSHA:    runGitSHA(),
+			StartedAt: time.Now().UTC().Truncate(time.Second),
+		}
+		start := time.Now()
+		child := exec.Command(command[0], command[1:]...)
+		child.Stdin = cmd.InOrStdin()
+		child.Stdout = os.Stdout
+		child.Stderr = os.Stderr
+		runErr := child.Run()
+		rec.Duration = time.Since(start).Round(time.Second).String()
+		var exitErr *exec.ExitError
+		switch {
+		case runErr == nil:
+		case errors.As(runErr, &exitErr):
+			rec.Exit = exitErr.ExitCode()
+		default:
+			return fmt.Errorf("running %s: %w", command[0], runErr)
+		}
+
+		// The command may run for hours: lock and re-read only now, so the
+		// append lands on the fiber as it is after the run.
+		f, unlock, err := lockAndReloadFiber(storage, target)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		if err := f.AppendRun(rec); err != nil {
+			return err
+		}
+		f.Touch(time.Now())
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+
+		// stderr, so the command's own stdout stays pipeable.
+		fmt.Fprintf(os.Stderr, "Recorded run on %s (exit %d, %s)\n", f.ID, rec.Exit, rec.Duration)
+		if rec.Exit != 0 {
+			return fmt.Errorf("command exited with status %d", rec.Exit)
+		}
+		return nil
+	},
+}
+
+// shellJoin renders argv as one copy-pasteable command line.
+func shellJoin(argv []string) string {
+	parts := make([]string, len(argv))
+	for i, a := range argv {
+		if a == "" || strings.ContainsAny(a, " \t\n'\"\\$`|&;<>()*?[]{}") {
+			parts[i] = strconv.Quote(a)
+		} else {
+			parts[i] = a
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// runScriptPath returns the first argument naming an existing file — the
+// script behind `python fit.py` or `./fit.sh` — or "".
+func runScriptPath(argv []string) string {
+	for _, a := range argv {
+		if strings.HasPrefix(a, "-") {
+			continue
+		}
+		if info, err := os.Stat(a); err == nil && info.Mode().IsRegular() {
+			return a
+		}
+	}
+	return ""
+}
+
+// runGitSHA returns the working directory's HEAD commit, suffixed "-dirty"
+// when tracked files have uncommitted changes, or "" outside a git repo.
+func runGitSHA() string {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	sha := strings.TrimSpace(string(out))
+	if status, err := exec.Command("git", "status", "--porcelain", "--untracked-files=no").Output(); err == nil && len(strings.TrimSpace(string(status))) > 0 {
+		sha += "-dirty"
+	}
+	return sha
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.AddCommand(runRecordCmd)
+}
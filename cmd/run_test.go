@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestRunRecordAppendsRunsEntry(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusActive, CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	script := filepath.Join(dir, "fit.sh")
+	if err := os.WriteFile(script, []byte("echo fitted\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, dir, "run", "record", "fit", "--", "sh", script)
+	if err != nil {
+		t.Fatalf("run record: %v\n%s", err, out)
+	}
+	if out != "fitted\n" {
+		t.Fatalf("command stdout = %q, want it passed through", out)
+	}
+	if _, err := runCommand(t, dir, "run", "record", "fit", "--", "sh", "-c", "exit 3"); err == nil || !strings.Contains(err.Error(), "status 3") {
+		t.Fatalf("failing command should fail run record, got %v", err)
+	}
+
+	f, err := storage.Read("fit")
+	if err != nil {
+		t.Fatalf("Read() error: %v", err)
+	}
+	runs, err := f.Runs()
+	if err != nil {
+		t.Fatalf("Runs() error: %v", err)
+	}
+	if len(runs) != 2 {
+		t.Fatalf("runs = %+v, want 2 entries", runs)
+	}
+	if runs[0].Cmd != "sh "+script || runs[0].Script != script || runs[0].Exit != 0 || runs[0].StartedAt.IsZero() {
+		t.Fatalf("first run = %+v", runs[0])
+	}
+	if runs[1].Cmd != `sh -c "exit 3"` || runs[1].Exit != 3 || runs[1].Script != "" {
+		t.Fatalf("second run = %+v", runs[1])
+	}
+
+	if _, err := runCommand(t, dir, "run", "record", "fit"); err == nil {
+		t.Fatalf("missing command should be a usage error")
+	}
+}
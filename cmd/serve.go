@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveHost      string
+	servePort      int
+	serveShareOnly bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the project to other tools",
+}
+
+var serveHTTPCmd = &cobra.Command{
+	Use:   "http",
+	Short: "Serve a read-only JSON API over HTTP",
+	Long: `Serves the project as read-only JSON, so dashboards and scripts can query
+the DAG without running felt once per question. The server keeps the fiber
+graph in memory and, on each request, re-reads only fibers whose files
+changed since the last one. Nothing is written.
+
+  GET /fibers          fibers as felt ls --json (?status=open|active|closed|all,
+                       default open and active; ?tag=X, repeatable, AND)
+  GET /fibers/{id}     one fiber as felt show --json
+  GET /graph           {nodes, edges}: every fiber, and one edge per
+                       data-flow input, from the upstream to the consumer
+  GET /ready           open fibers whose inputs have all closed, as
+                       felt ls --ready --json
+  GET /share/{id}      one fiber as a read-only HTML page, for a signed,
+                       unexpired link from felt share
+
+Listens on 127.0.0.1 unless --host says otherwise. The JSON endpoints have
+no authentication, so anyone who can reach a non-loopback listener can read
+the whole store. --share-only serves /share/ links and refuses everything
+else: the mode to expose beyond the machine for felt share.`,
+	Example: `  felt serve http --port 8080
+  curl localhost:8080/ready
+  felt serve http --host 0.0.0.0 --share-only`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		addr := net.JoinHostPort(serveHost, strconv.Itoa(servePort))
+		storage := felt.NewStorage(root)
+		if serveShareOnly {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Serving share links for %s on http://%s\n", root, addr)
+			return http.ListenAndServe(addr, newShareHandler(storage))
+		}
+		if !isLoopbackHost(serveHost) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s is not loopback; the unauthenticated JSON API exposes the whole store (see --share-only)\n", serveHost)
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Serving %s on http://%s\n", root, addr)
+		return http.ListenAndServe(addr, newAPIHandler(storage))
+	},
+}
+
+// apiGraph is the /graph response.
+type apiGraph struct {
+	Nodes []*felt.Felt `json:"nodes"`
+	Edges []apiEdge    `json:"edges"`
+}
+
+// apiEdge is one data-flow edge: To reads an output of From.
+type apiEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// newAPIHandler routes the read-only endpoints `felt serve http` exposes.
+func newAPIHandler(storage *felt.Storage) http.Handler {
+	store := &apiStore{storage: storage}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /fibers", func(w http.ResponseWriter, r *http.Request) {
+		status := r.URL.Query().Get("status")
+		switch status {
+		case "", "all", felt.StatusOpen, felt.StatusActive, felt.StatusClosed:
+		default:
+			writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid status %q (valid: open, active, closed, all)", status))
+			return
+		}
+		felts, _, err := store.snapshot()
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		tags := r.URL.Query()["tag"]
+		var out []*felt.Felt
+		for _, f := range felts {
+			if apiStatusMatches(f, status) && apiHasTags(f, tags) {
+				out = append(out, f)
+			}
+		}
+		writeAPIFelts(w, out)
+	})
+	mux.HandleFunc("GET /fibers/{id...}", func(w http.ResponseWriter, r *http.Request) {
+		f, err := storage.FindInScope("", r.PathValue("id"))
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		if err := attachShuttleResolution(f); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeAPIJSON(w, f)
+	})
+	mux.HandleFunc("GET /graph", func(w http.ResponseWriter, r *http.Request) {
+		felts, edges, err := store.snapshot()
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		graph := apiGraph{Nodes: felts, Edges: edges}
+		if graph.Nodes == nil {
+			graph.Nodes = []*felt.Felt{}
+		}
+		if err := attachShuttleResolution(felts...); err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeAPIJSON(w, graph)
+	})
+	mux.HandleFunc("GET /share/{id...}", serveShare(store))
+	mux.HandleFunc("GET /ready", func(w http.ResponseWriter, r *http.Request) {
+		felts, _, err := store.snapshot()
+		if err != nil {
+			writeAPIError(w, http.StatusInternalServerError, err)
+			return
+		}
+		writeAPIFelts(w, felt.ReadyFelts(felts, time.Now()))
+	})
+	return mux
+}
+
+// newShareHandler serves only /share/ links, for a listener reachable from
+// outside the machine: every other path is refused.
+func newShareHandler(storage *felt.Storage) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /share/{id...}", serveShare(&apiStore{storage: storage}))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "this server only serves share links", http.StatusForbidden)
+	})
+	return mux
+}
+
+// isLoopbackHost reports whether host only listens on this machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// apiStore is the graph the API answers from, shared across requests and
+// refreshed by file mtime at the start of each one.
+type apiStore struct {
+	storage *felt.Storage
+	mu      sync.Mutex
+	graph   *felt.Graph
+}
+
+// snapshot refreshes the graph and returns its fibers as felt ls --json
+// reads them — metadata with modification times, ordered by creation — plus
+// one edge per data-flow input. The fibers are copies, so a request can
+// decorate them without racing another.
+func (a *apiStore) snapshot() ([]*felt.Felt, []apiEdge, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.graph == nil {
+		felts, err := a.storage.ListMetadataWithModTime()
+		if err != nil {
+			return nil, nil, err
+		}
+		a.graph = felt.NewGraph(felts)
+	} else if _, err := a.storage.RefreshGraph(a.graph); err != nil {
+		return nil, nil, err
+	}
+
+	nodes := a.graph.Felts()
+	felts := make([]*felt.Felt, len(nodes))
+	edges := []apiEdge{}
+	for i, f := range nodes {
+		c := *f
+		felts[i] = &c
+		for _, up := range a.graph.Upstreams(f.ID) {
+			edges = append(edges, apiEdge{From: up, To: f.ID})
+		}
+	}
+	sort.SliceStable(felts, func(i, j int) bool { return felts[i].CreatedAt.Before(felts[j].CreatedAt) })
+	return felts, edges, nil
+}
+
+// apiStatusMatches applies felt ls's -s semantics: "" is open and active,
+// "all" is everything.
+func apiStatusMatches(f *felt.Felt, status string) bool {
+	switch status {
+	case "all":
+		return true
+	case "":
+		return f.IsOpen() || f.IsActive()
+	default:
+		return f.Status == status
+	}
+}
+
+func apiHasTags(f *felt.Felt, tags []string) bool {
+	for _, tag := range tags {
+		if !f.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func writeAPIFelts(w http.ResponseWriter, felts []*felt.Felt) {
+	if err := attachShuttleResolution(felts...); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeAPIJSON(w, felts)
+}
+
+func writeAPIJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = encodeJSON(w, data)
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = encodeJSON(w, map[string]string{"error": err.Error()})
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveHTTPCmd)
+	serveHTTPCmd.Flags().StringVar(&serveHost, "host", "127.0.0.1", "Address to listen on")
+	serveHTTPCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	serveHTTPCmd.Flags().BoolVar(&serveShareOnly, "share-only", false, "Serve only felt share links, refusing the JSON API")
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestServeHTTPEndpoints(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	closedAt := created.Add(time.Hour)
+	catalog := &felt.Felt{ID: "catalog", Name: "Catalog", Status: felt.StatusClosed, ClosedAt: &closedAt, CreatedAt: created, Body: "Built."}
+	fit := &felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, Tags: []string{"methods"}, CreatedAt: created.Add(time.Minute)}
+	mustShowExtra(t, fit, "inputs", []map[string]any{{"id": "data", "from": "catalog"}})
+	paper := &felt.Felt{ID: "paper", Name: "Paper", Status: felt.StatusOpen, CreatedAt: created.Add(2 * time.Minute)}
+	mustShowExtra(t, paper, "inputs", []map[string]any{{"id": "result", "from": "fit"}})
+	for _, f := range []*felt.Felt{catalog, fit, paper} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", f.ID, err)
+		}
+	}
+	handler := newAPIHandler(storage)
+
+	get := func(path string, want int, v any) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Fatalf("GET %s = %d, want %d: %s", path, rec.Code, want, rec.Body)
+		}
+		if v != nil {
+			if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+				t.Fatalf("GET %s: %v\n%s", path, err, rec.Body)
+			}
+		}
+	}
+	ids := func(felts []*felt.Felt) []string {
+		var out []string
+		for _, f := range felts {
+			out = append(out, f.ID)
+		}
+		return out
+	}
+
+	var listed []*felt.Felt
+	get("/fibers", http.StatusOK, &listed)
+	if got := ids(listed); len(got) != 2 || got[0] != "fit" || got[1] != "paper" {
+		t.Fatalf("/fibers = %v, want [fit paper]", got)
+	}
+	get("/fibers?status=all&tag=methods", http.StatusOK, &listed)
+	if got := ids(listed); len(got) != 1 || got[0] != "fit" {
+		t.Fatalf("/fibers?tag=methods = %v, want [fit]", got)
+	}
+	get("/fibers?status=bogus", http.StatusBadRequest, nil)
+
+	var one felt.Felt
+	get("/fibers/catalog", http.StatusOK, &one)
+	if one.ID != "catalog" || one.Body != "Built." {
+		t.Fatalf("/fibers/catalog = %+v", one)
+	}
+	get("/fibers/missing", http.StatusNotFound, nil)
+
+	var ready []*felt.Felt
+	get("/ready", http.StatusOK, &ready)
+	if got := ids(ready); len(got) != 1 || got[0] != "fit" {
+		t.Fatalf("/ready = %v, want [fit]", got)
+	}
+
+	var graph apiGraph
+	get("/graph", http.StatusOK, &graph)
+	if len(graph.Nodes) != 3 || len(graph.Edges) != 2 || graph.Edges[0] != (apiEdge{From: "catalog", To: "fit"}) || graph.Edges[1] != (apiEdge{From: "fit", To: "paper"}) {
+		t.Fatalf("/graph = %d nodes, edges %v", len(graph.Nodes), graph.Edges)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/fibers", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("POST /fibers = %d, want 405", rec.Code)
+	}
+}
+
+func TestShareLinkRendersOneFiberReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	closedAt := created.Add(time.Hour)
+	catalog := &felt.Felt{ID: "catalog", Name: "Catalog", Status: felt.StatusClosed, ClosedAt: &closedAt, CreatedAt: created, Outcome: "DES Y3 gold, 100M objects"}
+	weights := &felt.Felt{ID: "weights", Name: "Use <DES> weights", Status: felt.StatusClosed, ClosedAt: &closedAt, CreatedAt: created.Add(time.Minute), Outcome: "Y3 weights", Body: "Compared Y1 and Y3."}
+	mustShowExtra(t, weights, "inputs", []map[string]any{{"id": "data", "from": "catalog"}})
+	for _, f := range []*felt.Felt{catalog, weights} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", f.ID, err)
+		}
+	}
+	defer func() { shareUpstream, shareExpires = false, "7d" }()
+
+	out, err := runCommand(t, dir, "share", "weights", "--upstream", "--base", "http://example.test/")
+	if err != nil {
+		t.Fatalf("share: %v\n%s", err, out)
+	}
+	link := strings.TrimSpace(out)
+	if !strings.HasPrefix(link, "http://example.test/share/weights?") {
+		t.Fatalf("share printed %q", link)
+	}
+	handler := newAPIHandler(storage)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	rec := get(strings.TrimPrefix(link, "http://example.test"))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("GET share = %d %s: %s", rec.Code, rec.Header().Get("Content-Type"), rec.Body)
+	}
+	for _, want := range []string{"Use &lt;DES&gt; weights", "Y3 weights", "Compared Y1 and Y3.", "Upstream", "DES Y3 gold, 100M objects"} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Fatalf("share page missing %q:\n%s", want, rec.Body)
+		}
+	}
+
+	// A share-only listener serves the same link but refuses the JSON API.
+	shareOnly := newShareHandler(storage)
+	for path, want := range map[string]int{
+		strings.TrimPrefix(link, "http://example.test"): http.StatusOK,
+		"/fibers":         http.StatusForbidden,
+		"/fibers/weights": http.StatusForbidden,
+		"/graph":          http.StatusForbidden,
+	} {
+		rec := httptest.NewRecorder()
+		shareOnly.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != want {
+			t.Fatalf("share-only GET %s = %d, want %d", path, rec.Code, want)
+		}
+	}
+
+	tampered := strings.Replace(strings.TrimPrefix(link, "http://example.test"), "/share/weights", "/share/catalog", 1)
+	if rec := get(tampered); rec.Code != http.StatusForbidden {
+		t.Fatalf("GET tampered share = %d, want 403", rec.Code)
+	}
+	if rec := get("/share/weights?exp=1&sig=" + (&felt.Share{ID: "weights", Expires: time.Unix(1, 0)}).Sign(mustShareKey(t, storage))); rec.Code != http.StatusGone {
+		t.Fatalf("GET expired share = %d, want 410", rec.Code)
+	}
+}
+
+func mustShareKey(t *testing.T, storage *felt.Storage) []byte {
+	t.Helper()
+	key, err := storage.ShareKey(false, false)
+	if err != nil {
+		t.Fatalf("ShareKey: %v", err)
+	}
+	return key
+}
@@ -58,8 +58,12 @@ var setupClaudeCmd = &cobra.Command{
 	Long: `Install the felt plugin for Claude Code.
 
 Registers the felt plugin marketplace and installs the felt plugin from
-it. The plugin bundles the felt skill plus SessionStart and
-PreToolUse hooks. Idempotent — re-running is safe.
+it. The plugin bundles the felt skill plus SessionStart, PreToolUse,
+PostToolUse, PreCompact, Stop, and SessionEnd hooks; PreCompact keeps
+the active fibers in the summary of a compacted session, Stop reminds
+the agent of them when it ends a turn, and SessionEnd can file the
+session's decisions and open questions (hook.transcript.mine). Idempotent —
+re-running is safe.
 
 By default, registers ` + marketplaceRepo + ` directly from GitHub —
 Claude Code clones the marketplace itself, so no local checkout is
@@ -78,11 +82,39 @@ Resolution order for --source (override the default GitHub registration):
   2. $FELT_PLUGIN_DIR     env var pointing directly at the plugin directory
                           (the parent of which becomes the marketplace root)
 
-Use --uninstall to remove.`,
+--project writes the project's ` + claudeProjectSettingsPath + ` instead of
+going through the CLI, so the plugin can be checked in and is scoped to
+this repository. It registers the marketplace and enables the plugin,
+keeping the file's other settings:
+
+    "extraKnownMarketplaces": {"` + marketplaceName + `": {"source": {"source": "github", "repo": "` + marketplaceRepo + `"}}},
+    "enabledPlugins": {"felt@` + marketplaceName + `": true}
+
+Claude Code offers the plugin to whoever opens the project. --source
+records a local checkout instead, which only resolves on this machine.
+
+Use --uninstall to remove (with --project, from ` + claudeProjectSettingsPath + `).`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		source, _ := cmd.Flags().GetString("source")
 		uninstall, _ := cmd.Flags().GetBool("uninstall")
 
+		if project, _ := cmd.Flags().GetBool("project"); project {
+			projectRoot, err := resolveProjectRoot()
+			if err != nil {
+				return fmt.Errorf("not in a felt repository")
+			}
+			if uninstall {
+				return uninstallClaudeProject(projectRoot)
+			}
+			repoRoot := ""
+			if source != "" || os.Getenv("FELT_PLUGIN_DIR") != "" {
+				if repoRoot, err = findMarketplaceRoot(source); err != nil {
+					return err
+				}
+			}
+			return installClaudeProject(projectRoot, repoRoot)
+		}
+
 		if uninstall {
 			return uninstallPlugin()
 		}
@@ -203,6 +235,7 @@ Resolution order for --source:
 func init() {
 	setupClaudeCmd.Flags().Bool("uninstall", false, "Remove felt plugin from Claude Code")
 	setupClaudeCmd.Flags().String("source", "", "Path to felt repo checkout or plugin directory")
+	setupClaudeCmd.Flags().Bool("project", false, "Write the project's "+claudeProjectSettingsPath+" instead of installing for the user")
 	setupCodexCmd.Flags().Bool("uninstall", false, "Remove felt hooks from Codex")
 	setupCodexCmd.Flags().String("source", "", "Path to felt repo checkout or plugin directory")
 	setupSkillsCmd.Flags().String("target", "", "Target directory (default: ~/.claude/skills)")
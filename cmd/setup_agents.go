@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Agents without a plugin or hook system get felt's workflow context from
+// a convention file in the project instead: the AGENTS.md snippet `setup
+// codex` prints, plus a nudge to read `felt session` where a SessionStart
+// hook would have injected it. Each file felt writes opens with a sentinel
+// naming the command that owns it, so setup refreshes and --uninstall
+// removes only files felt wrote.
+
+// cursorRulePath and aiderConventionsPath are the files setup writes,
+// relative to the project root.
+const (
+	cursorRulePath       = ".cursor/rules/felt.mdc"
+	aiderConventionsPath = ".aider.felt.md"
+	aiderConfigPath      = ".aider.conf.yml"
+)
+
+var setupCursorCmd = &cobra.Command{
+	Use:   "cursor",
+	Short: "Install felt's workflow context as a Cursor project rule",
+	Long: `Writes ` + cursorRulePath + ` in the project, an always-applied Cursor
+rule carrying felt's conventions and asking the agent to read ` + "`felt session`" + `
+before starting work — the context the Claude Code and Codex plugins inject
+with their SessionStart hook. Check it in to share it with the project.
+
+Re-running refreshes the rule. A ` + cursorRulePath + ` that felt did not write is
+left alone. Use --uninstall to remove it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		path := filepath.Join(root, cursorRulePath)
+		if uninstall, _ := cmd.Flags().GetBool("uninstall"); uninstall {
+			return removeManagedFile(path, "cursor")
+		}
+		frontmatter := "---\ndescription: felt — track concerns as fibers in .felt/\nalwaysApply: true\n---\n"
+		if err := writeManagedFile(path, "cursor", frontmatter); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", cursorRulePath)
+		return nil
+	},
+}
+
+var setupAiderCmd = &cobra.Command{
+	Use:   "aider",
+	Short: "Install felt's workflow context as an aider read-only convention file",
+	Long: `Writes ` + aiderConventionsPath + ` in the project, carrying felt's conventions and
+asking the agent to read ` + "`felt session`" + ` before starting work, and adds it to
+the read: list in ` + aiderConfigPath + ` so every aider session loads it — the
+context the Claude Code and Codex plugins inject with their SessionStart
+hook. Other settings in ` + aiderConfigPath + ` are kept.
+
+Re-running refreshes the file. Use --uninstall to remove it and its read:
+entry.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		path := filepath.Join(root, aiderConventionsPath)
+		configPath := filepath.Join(root, aiderConfigPath)
+		if uninstall, _ := cmd.Flags().GetBool("uninstall"); uninstall {
+			if err := removeManagedFile(path, "aider"); err != nil {
+				return err
+			}
+			if removed, err := editAiderRead(configPath, aiderConventionsPath, false); err != nil {
+				return err
+			} else if removed {
+				fmt.Printf("Removed %s from read: in %s\n", aiderConventionsPath, aiderConfigPath)
+			}
+			return nil
+		}
+		if err := writeManagedFile(path, "aider", ""); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", aiderConventionsPath)
+		if added, err := editAiderRead(configPath, aiderConventionsPath, true); err != nil {
+			return err
+		} else if added {
+			fmt.Printf("Added %s to read: in %s\n", aiderConventionsPath, aiderConfigPath)
+		}
+		return nil
+	},
+}
+
+func init() {
+	setupCursorCmd.Flags().Bool("uninstall", false, "Remove the felt rule from the project")
+	setupAiderCmd.Flags().Bool("uninstall", false, "Remove the felt convention file and its read: entry")
+	setupCmd.AddCommand(setupCursorCmd)
+	setupCmd.AddCommand(setupAiderCmd)
+}
+
+// managedFileSentinel marks a file `felt setup <tool>` wrote.
+func managedFileSentinel(tool string) string {
+	return fmt.Sprintf("<!-- Written by felt setup %s; felt setup %s --uninstall removes it. -->", tool, tool)
+}
+
+// agentConventions is the body of a convention file: the AGENTS.md snippet
+// plus the session read a SessionStart hook would do.
+func agentConventions() string {
+	return claudeMDSnippet() + "\n" +
+		"**Session start.** Before starting work, run `felt session`: it lists the active, ready, and recently touched fibers, so you pick up where the last session left off.\n"
+}
+
+// writeManagedFile writes tool's convention file at path, after header,
+// refusing to replace a file felt did not write.
+func writeManagedFile(path, tool, header string) error {
+	sentinel := managedFileSentinel(tool)
+	if data, err := os.ReadFile(path); err == nil && !bytes.Contains(data, []byte(sentinel)) {
+		return fmt.Errorf("%s exists and was not written by felt; remove it or merge the felt conventions by hand", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(header+sentinel+"\n\n"+agentConventions()), 0644)
+}
+
+// removeManagedFile deletes tool's convention file at path when felt wrote
+// it. A missing file is not an error.
+func removeManagedFile(path, tool string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(data, []byte(managedFileSentinel(tool))) {
+		return fmt.Errorf("%s was not written by felt; leaving it", path)
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	fmt.Printf("Removed %s\n", path)
+	return nil
+}
+
+// editAiderRead adds file to (or, with add false, removes it from) the
+// read: list of the aider config at path, keeping the rest of the config
+// and its comments. read: may be a single string or a list; a config left
+// empty by a removal is deleted. changed reports whether anything was
+// written.
+func editAiderRead(path, file string, add bool) (changed bool, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		if !add {
+			return false, nil
+		}
+		return true, os.WriteFile(path, []byte("read:\n  - "+file+"\n"), 0644)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return false, fmt.Errorf("%s is not a YAML mapping", path)
+	}
+	mapping := doc.Content[0]
+	readAt := -1
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == "read" {
+			readAt = i
+		}
+	}
+
+	item := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: file}
+	switch {
+	case readAt < 0 && !add:
+		return false, nil
+	case readAt < 0:
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "read"},
+			&yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{item}})
+	default:
+		value := mapping.Content[readAt+1]
+		if value.Kind == yaml.ScalarNode {
+			// A lone `read: FILE` becomes a list so felt's entry can sit beside it.
+			value = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{value}}
+			mapping.Content[readAt+1] = value
+		}
+		if value.Kind != yaml.SequenceNode {
+			return false, fmt.Errorf("%s: read: is neither a file nor a list of files", path)
+		}
+		var kept []*yaml.Node
+		present := false
+		for _, n := range value.Content {
+			if strings.TrimSpace(n.Value) == file {
+				present = true
+				if !add {
+					continue
+				}
+			}
+			kept = append(kept, n)
+		}
+		if add == present {
+			return false, nil
+		}
+		if add {
+			kept = append(kept, item)
+		}
+		value.Content = kept
+		if len(kept) == 0 {
+			mapping.Content = append(mapping.Content[:readAt], mapping.Content[readAt+2:]...)
+		}
+	}
+
+	if len(mapping.Content) == 0 {
+		return true, os.Remove(path)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return false, err
+	}
+	if err := enc.Close(); err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, buf.Bytes(), 0644)
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestSetupCursorWritesAndRemovesRule(t *testing.T) {
+	dir := t.TempDir()
+	if err := felt.NewStorage(dir).Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer setupCursorCmd.Flags().Set("uninstall", "false")
+
+	for i := 0; i < 2; i++ {
+		if out, err := runCommand(t, dir, "setup", "cursor"); err != nil {
+			t.Fatalf("setup cursor: %v\n%s", err, out)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(dir, cursorRulePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "---\ndescription:") || !strings.Contains(string(data), "alwaysApply: true") || !strings.Contains(string(data), "`felt session`") {
+		t.Fatalf("cursor rule:\n%s", data)
+	}
+
+	if out, err := runCommand(t, dir, "setup", "cursor", "--uninstall"); err != nil {
+		t.Fatalf("setup cursor --uninstall: %v\n%s", err, out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, cursorRulePath)); !os.IsNotExist(err) {
+		t.Fatalf("rule survived --uninstall: %v", err)
+	}
+
+	// A rule felt did not write is never replaced.
+	if err := os.WriteFile(filepath.Join(dir, cursorRulePath), []byte("my own rule\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	setupCursorCmd.Flags().Set("uninstall", "false")
+	if _, err := runCommand(t, dir, "setup", "cursor"); err == nil || !strings.Contains(err.Error(), "not written by felt") {
+		t.Fatalf("setup cursor over a user rule: err = %v", err)
+	}
+}
+
+func TestEditAiderReadKeepsOtherSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), aiderConfigPath)
+	if err := os.WriteFile(path, []byte("# team settings\nmodel: sonnet\nread: CONVENTIONS.md\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := editAiderRead(path, aiderConventionsPath, true); err != nil || !changed {
+		t.Fatalf("add: changed=%v err=%v", changed, err)
+	}
+	if changed, err := editAiderRead(path, aiderConventionsPath, true); err != nil || changed {
+		t.Fatalf("second add: changed=%v err=%v", changed, err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "# team settings\nmodel: sonnet\nread:\n  - CONVENTIONS.md\n  - .aider.felt.md\n"; string(data) != want {
+		t.Fatalf("after add:\n%s\nwant:\n%s", data, want)
+	}
+
+	if changed, err := editAiderRead(path, aiderConventionsPath, false); err != nil || !changed {
+		t.Fatalf("remove: changed=%v err=%v", changed, err)
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Contains(string(data), aiderConventionsPath) || !strings.Contains(string(data), "CONVENTIONS.md") || !strings.Contains(string(data), "model: sonnet") {
+		t.Fatalf("after remove:\n%s", data)
+	}
+
+	fresh := filepath.Join(t.TempDir(), aiderConfigPath)
+	if _, err := editAiderRead(fresh, aiderConventionsPath, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := editAiderRead(fresh, aiderConventionsPath, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fresh); !os.IsNotExist(err) {
+		t.Fatalf("config felt created survived removal: %v", err)
+	}
+}
+
+func TestSetupClaudeProjectMergesAndUninstalls(t *testing.T) {
+	dir := t.TempDir()
+	if err := felt.NewStorage(dir).Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer setupClaudeCmd.Flags().Set("project", "false")
+	defer setupClaudeCmd.Flags().Set("uninstall", "false")
+
+	path := filepath.Join(dir, claudeProjectSettingsPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"permissions": {"allow": ["Bash(felt:*)"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if out, err := runCommand(t, dir, "setup", "claude", "--project"); err != nil {
+			t.Fatalf("setup claude --project: %v\n%s", err, out)
+		}
+	}
+	settings, err := readJSONSettings(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plugins, _ := settings["enabledPlugins"].(map[string]interface{}); plugins["felt@"+marketplaceName] != true {
+		t.Fatalf("enabledPlugins = %v", settings["enabledPlugins"])
+	}
+	marketplaces, _ := settings["extraKnownMarketplaces"].(map[string]interface{})
+	entry, _ := marketplaces[marketplaceName].(map[string]interface{})
+	if source, _ := entry["source"].(map[string]interface{}); source["source"] != "github" || source["repo"] != marketplaceRepo {
+		t.Fatalf("extraKnownMarketplaces = %v", settings["extraKnownMarketplaces"])
+	}
+	if settings["permissions"] == nil {
+		t.Fatalf("other settings dropped: %v", settings)
+	}
+
+	if out, err := runCommand(t, dir, "setup", "claude", "--project", "--uninstall"); err != nil {
+		t.Fatalf("setup claude --project --uninstall: %v\n%s", err, out)
+	}
+	data, _ := os.ReadFile(path)
+	if got := string(data); strings.Contains(got, marketplaceName) || !strings.Contains(got, "Bash(felt:*)") {
+		t.Fatalf("settings after uninstall:\n%s", got)
+	}
+}
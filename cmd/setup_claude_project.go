@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// claudeProjectSettingsPath is the project-scoped Claude Code settings file,
+// relative to the project root. Unlike ~/.claude/settings.json it is meant
+// to be checked in: Claude Code offers the marketplaces it lists to everyone
+// who trusts the project, and enables the plugins it names.
+const claudeProjectSettingsPath = ".claude/settings.json"
+
+// claudeProjectMarketplaceSource is the extraKnownMarketplaces source for
+// `setup claude --project`: GitHub, pinned like defaultMarketplaceRef, or a
+// local checkout when one was given.
+func claudeProjectMarketplaceSource(repoRoot string) map[string]interface{} {
+	if repoRoot != "" {
+		return map[string]interface{}{"source": "directory", "path": repoRoot}
+	}
+	source := map[string]interface{}{"source": "github", "repo": marketplaceRepo}
+	if ref := strings.TrimPrefix(defaultMarketplaceRef(), marketplaceRepo); ref != "" {
+		source["ref"] = strings.TrimPrefix(ref, "#")
+	}
+	return source
+}
+
+// installClaudeProject registers the felt marketplace and enables the felt
+// plugin in the project's .claude/settings.json, keeping every other
+// setting. repoRoot is a local marketplace checkout, or "" for GitHub.
+func installClaudeProject(projectRoot, repoRoot string) error {
+	path := filepath.Join(projectRoot, claudeProjectSettingsPath)
+	settings, err := readJSONSettings(path)
+	if err != nil {
+		return err
+	}
+	marketplaces, _ := settings["extraKnownMarketplaces"].(map[string]interface{})
+	if marketplaces == nil {
+		marketplaces = map[string]interface{}{}
+		settings["extraKnownMarketplaces"] = marketplaces
+	}
+	marketplaces[marketplaceName] = map[string]interface{}{"source": claudeProjectMarketplaceSource(repoRoot)}
+	plugins, _ := settings["enabledPlugins"].(map[string]interface{})
+	if plugins == nil {
+		plugins = map[string]interface{}{}
+		settings["enabledPlugins"] = plugins
+	}
+	plugins["felt@"+marketplaceName] = true
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := writeJSONSettings(path, settings); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Enabled felt@%s in %s\n", marketplaceName, claudeProjectSettingsPath)
+	if repoRoot != "" {
+		fmt.Printf("  (marketplace is the local checkout %s; other clones need the GitHub default)\n", repoRoot)
+	}
+	fmt.Println()
+	fmt.Println("Commit " + claudeProjectSettingsPath + " to share it; Claude Code offers the plugin when the project is next opened.")
+	return nil
+}
+
+// uninstallClaudeProject removes felt's marketplace and plugin entries from
+// the project's .claude/settings.json, deleting the file (and an emptied
+// .claude/) when nothing else is left in it.
+func uninstallClaudeProject(projectRoot string) error {
+	path := filepath.Join(projectRoot, claudeProjectSettingsPath)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("· No %s\n", claudeProjectSettingsPath)
+		return nil
+	}
+	settings, err := readJSONSettings(path)
+	if err != nil {
+		return err
+	}
+	removed := false
+	for key, entry := range map[string]string{"extraKnownMarketplaces": marketplaceName, "enabledPlugins": "felt@" + marketplaceName} {
+		m, ok := settings[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := m[entry]; ok {
+			delete(m, entry)
+			removed = true
+		}
+		if len(m) == 0 {
+			delete(settings, key)
+		}
+	}
+	if !removed {
+		fmt.Printf("· felt is not enabled in %s\n", claudeProjectSettingsPath)
+		return nil
+	}
+
+	if len(settings) > 0 {
+		err = writeJSONSettings(path, settings)
+	} else if err = os.Remove(path); err == nil {
+		_ = os.Remove(filepath.Dir(path)) // only succeeds when .claude/ is now empty
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Removed felt@%s from %s\n", marketplaceName, claudeProjectSettingsPath)
+	return nil
+}
@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Gemini CLI reads ~/.gemini/GEMINI.md into every session and runs the
+// hooks in ~/.gemini/settings.json. `felt setup gemini` writes felt's
+// conventions into GEMINI.md between sentinels, so the rest of the file stays
+// the user's, and registers `felt hook session` as a SessionStart hook, whose
+// additionalContext envelope Gemini CLI reads as Claude Code does.
+const (
+	geminiSentinelBegin      = "<!-- felt:begin — managed by felt setup gemini; edits inside are overwritten -->"
+	geminiSentinelEnd        = "<!-- felt:end -->"
+	geminiSessionHookCommand = "felt hook session"
+	geminiSessionHookName    = "felt-session"
+)
+
+var setupGeminiCmd = &cobra.Command{
+	Use:   "gemini",
+	Short: "Install felt context injection for Gemini CLI",
+	Long: `Install felt for Gemini CLI.
+
+Writes felt's conventions into ~/.gemini/GEMINI.md, between
+` + "`<!-- felt:begin … -->`" + ` and ` + "`<!-- felt:end -->`" + ` sentinels so the rest
+of the file is left alone, and registers a SessionStart hook in
+~/.gemini/settings.json:
+
+    "hooks": {
+      "SessionStart": [
+        {"hooks": [{"name": "` + geminiSessionHookName + `", "type": "command", "command": "` + geminiSessionHookCommand + `"}]}
+      ]
+    }
+
+so each session opens with the active and recently touched fibers, as the
+Claude Code and Codex plugins' SessionStart hooks do. Other settings and
+hooks are kept. Gemini CLI releases that gate hooks behind a setting need
+it turned on. Idempotent — re-running refreshes both.
+
+Use --uninstall to remove the block and the hook.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if uninstall, _ := cmd.Flags().GetBool("uninstall"); uninstall {
+			return uninstallGemini()
+		}
+		return installGemini()
+	},
+}
+
+func init() {
+	setupGeminiCmd.Flags().Bool("uninstall", false, "Remove felt's GEMINI.md block and SessionStart hook")
+	setupCmd.AddCommand(setupGeminiCmd)
+}
+
+// geminiDir returns ~/.gemini.
+func geminiDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".gemini"), nil
+}
+
+func installGemini() error {
+	dir, err := geminiDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	contextPath := filepath.Join(dir, "GEMINI.md")
+	text, err := readOptionalFile(contextPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(contextPath, []byte(upsertSentinelBlock(text, geminiSentinelBegin, geminiSentinelEnd, claudeMDSnippet())), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Wrote felt conventions: %s\n", contextPath)
+
+	settings, err := readGeminiSettings()
+	if err != nil {
+		return err
+	}
+	hooks, _ := settings["hooks"].(map[string]interface{})
+	if hooks == nil {
+		hooks = map[string]interface{}{}
+		settings["hooks"] = hooks
+	}
+	pruneGeminiSessionHook(hooks)
+	entries, _ := hooks["SessionStart"].([]interface{})
+	hooks["SessionStart"] = append(entries, map[string]interface{}{
+		"hooks": []interface{}{map[string]interface{}{
+			"name":    geminiSessionHookName,
+			"type":    "command",
+			"command": geminiSessionHookCommand,
+		}},
+	})
+	if err := writeGeminiSettings(settings); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Registered SessionStart hook: %s\n", geminiSessionHookCommand)
+	fmt.Println()
+	fmt.Println("Restart Gemini CLI for changes to take effect.")
+	return nil
+}
+
+func uninstallGemini() error {
+	dir, err := geminiDir()
+	if err != nil {
+		return err
+	}
+	contextPath := filepath.Join(dir, "GEMINI.md")
+	text, err := readOptionalFile(contextPath)
+	if err != nil {
+		return err
+	}
+	if rest, removed := removeSentinelBlock(text, geminiSentinelBegin, geminiSentinelEnd); removed {
+		if strings.TrimSpace(rest) == "" {
+			err = os.Remove(contextPath)
+		} else {
+			err = os.WriteFile(contextPath, []byte(rest), 0644)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Removed felt conventions: %s\n", contextPath)
+	} else {
+		fmt.Printf("· No felt block in %s\n", contextPath)
+	}
+
+	settings, err := readGeminiSettings()
+	if err != nil {
+		return err
+	}
+	hooks, _ := settings["hooks"].(map[string]interface{})
+	if hooks != nil && pruneGeminiSessionHook(hooks) > 0 {
+		if len(hooks) == 0 {
+			delete(settings, "hooks")
+		}
+		if err := writeGeminiSettings(settings); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Removed SessionStart hook: %s\n", geminiSessionHookCommand)
+	} else {
+		fmt.Println("· SessionStart hook not registered")
+	}
+	fmt.Println()
+	fmt.Println("Restart Gemini CLI for changes to take effect.")
+	return nil
+}
+
+// feltGeminiInstalled reports whether `felt setup gemini` has left either
+// of its pieces behind. Used by `felt uninstall`.
+func feltGeminiInstalled() bool {
+	dir, err := geminiDir()
+	if err != nil {
+		return false
+	}
+	if text, err := readOptionalFile(filepath.Join(dir, "GEMINI.md")); err == nil && strings.Contains(text, geminiSentinelBegin) {
+		return true
+	}
+	settings, err := readGeminiSettings()
+	if err != nil {
+		return false
+	}
+	hooks, _ := settings["hooks"].(map[string]interface{})
+	return hooks != nil && pruneGeminiSessionHook(hooks) > 0
+}
+
+func geminiSettingsPath() (string, error) {
+	dir, err := geminiDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "settings.json"), nil
+}
+
+// readGeminiSettings reads ~/.gemini/settings.json, or an empty map when it
+// does not exist yet.
+func readGeminiSettings() (map[string]interface{}, error) {
+	path, err := geminiSettingsPath()
+	if err != nil {
+		return nil, err
+	}
+	return readJSONSettings(path)
+}
+
+func writeGeminiSettings(settings map[string]interface{}) error {
+	path, err := geminiSettingsPath()
+	if err != nil {
+		return err
+	}
+	return writeJSONSettings(path, settings)
+}
+
+// readJSONSettings reads an agent's JSON settings file as a generic map, so
+// keys felt does not manage survive a rewrite. A missing or empty file is an
+// empty map.
+func readJSONSettings(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && strings.TrimSpace(string(data)) == "") {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	return settings, nil
+}
+
+// writeJSONSettings writes settings to path as indented JSON.
+func writeJSONSettings(path string, settings map[string]interface{}) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// pruneGeminiSessionHook removes felt's session hook command from the
+// SessionStart entries in hooks and returns how many it removed. Other
+// commands sharing an entry are kept; an entry left with no hooks is
+// dropped.
+func pruneGeminiSessionHook(hooks map[string]interface{}) int {
+	entries, ok := hooks["SessionStart"].([]interface{})
+	if !ok {
+		return 0
+	}
+	removed := 0
+	kept := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		cmds, _ := entryMap["hooks"].([]interface{})
+		if !ok || len(cmds) == 0 {
+			kept = append(kept, entry)
+			continue
+		}
+		others := make([]interface{}, 0, len(cmds))
+		for _, c := range cmds {
+			cmdMap, _ := c.(map[string]interface{})
+			if command, _ := cmdMap["command"].(string); strings.TrimSpace(command) == geminiSessionHookCommand {
+				removed++
+				continue
+			}
+			others = append(others, c)
+		}
+		if len(others) == 0 {
+			continue
+		}
+		entryMap["hooks"] = others
+		kept = append(kept, entryMap)
+	}
+	if len(kept) == 0 {
+		delete(hooks, "SessionStart")
+	} else {
+		hooks["SessionStart"] = kept
+	}
+	return removed
+}
+
+// readOptionalFile returns path's contents, or "" when it does not exist.
+func readOptionalFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return string(data), err
+}
+
+// upsertSentinelBlock replaces the text between begin and end (inclusive)
+// with body, or appends a new block after a blank line when there is none.
+func upsertSentinelBlock(text, begin, end, body string) string {
+	block := begin + "\n" + strings.TrimRight(body, "\n") + "\n" + end + "\n"
+	if start := strings.Index(text, begin); start >= 0 {
+		if stop := strings.Index(text[start:], end); stop >= 0 {
+			rest := strings.TrimPrefix(text[start+stop+len(end):], "\n")
+			return text[:start] + block + rest
+		}
+	}
+	if strings.TrimSpace(text) == "" {
+		return block
+	}
+	return strings.TrimRight(text, "\n") + "\n\n" + block
+}
+
+// removeSentinelBlock deletes the text between begin and end (inclusive)
+// with the blank line before it; removed is false when there is no block.
+func removeSentinelBlock(text, begin, end string) (rest string, removed bool) {
+	start := strings.Index(text, begin)
+	if start < 0 {
+		return text, false
+	}
+	stop := strings.Index(text[start:], end)
+	if stop < 0 {
+		return text, false
+	}
+	before := strings.TrimRight(text[:start], "\n")
+	after := strings.TrimLeft(text[start+stop+len(end):], "\n")
+	switch {
+	case before == "":
+		return after, true
+	case after == "":
+		return before + "\n", true
+	}
+	return before + "\n\n" + after, true
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetupGeminiInstallsAndUninstalls(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	defer setupGeminiCmd.Flags().Set("uninstall", "false")
+
+	dir := filepath.Join(home, ".gemini")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	contextPath := filepath.Join(dir, "GEMINI.md")
+	settingsPath := filepath.Join(dir, "settings.json")
+	if err := os.WriteFile(contextPath, []byte("# My notes\n\nPrefer tabs.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	settings := `{"theme": "Dracula", "hooks": {"SessionStart": [{"hooks": [{"type": "command", "command": "echo hi"}]}]}}`
+	if err := os.WriteFile(settingsPath, []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if out, err := runCommand(t, t.TempDir(), "setup", "gemini"); err != nil {
+			t.Fatalf("setup gemini: %v\n%s", err, out)
+		}
+	}
+	data, _ := os.ReadFile(contextPath)
+	if got := string(data); !strings.HasPrefix(got, "# My notes\n\nPrefer tabs.\n\n"+geminiSentinelBegin+"\n") ||
+		strings.Count(got, geminiSentinelBegin) != 1 || !strings.HasSuffix(got, geminiSentinelEnd+"\n") {
+		t.Fatalf("GEMINI.md:\n%s", got)
+	}
+	data, _ = os.ReadFile(settingsPath)
+	if got := string(data); strings.Count(got, geminiSessionHookCommand) != 1 || !strings.Contains(got, "echo hi") || !strings.Contains(got, "Dracula") {
+		t.Fatalf("settings.json:\n%s", got)
+	}
+	if !feltGeminiInstalled() {
+		t.Fatal("feltGeminiInstalled = false after setup")
+	}
+
+	if out, err := runCommand(t, t.TempDir(), "setup", "gemini", "--uninstall"); err != nil {
+		t.Fatalf("setup gemini --uninstall: %v\n%s", err, out)
+	}
+	data, _ = os.ReadFile(contextPath)
+	if got := string(data); got != "# My notes\n\nPrefer tabs.\n" {
+		t.Fatalf("GEMINI.md after uninstall:\n%q", got)
+	}
+	data, _ = os.ReadFile(settingsPath)
+	if got := string(data); strings.Contains(got, geminiSessionHookCommand) || !strings.Contains(got, "echo hi") {
+		t.Fatalf("settings.json after uninstall:\n%s", got)
+	}
+	if feltGeminiInstalled() {
+		t.Fatal("feltGeminiInstalled = true after uninstall")
+	}
+}
+
+func TestPruneGeminiSessionHookKeepsOtherCommandsInAnEntry(t *testing.T) {
+	hooks := map[string]interface{}{"SessionStart": []interface{}{
+		map[string]interface{}{"matcher": "startup", "hooks": []interface{}{
+			map[string]interface{}{"type": "command", "command": "echo hi"},
+			map[string]interface{}{"name": geminiSessionHookName, "type": "command", "command": geminiSessionHookCommand},
+		}},
+		map[string]interface{}{"hooks": []interface{}{
+			map[string]interface{}{"type": "command", "command": geminiSessionHookCommand},
+		}},
+	}}
+	if n := pruneGeminiSessionHook(hooks); n != 2 {
+		t.Fatalf("removed %d commands, want 2", n)
+	}
+	entries, _ := hooks["SessionStart"].([]interface{})
+	if len(entries) != 1 {
+		t.Fatalf("SessionStart = %v, want only the mixed entry", hooks["SessionStart"])
+	}
+	entry := entries[0].(map[string]interface{})
+	cmds, _ := entry["hooks"].([]interface{})
+	if entry["matcher"] != "startup" || len(cmds) != 1 || cmds[0].(map[string]interface{})["command"] != "echo hi" {
+		t.Fatalf("mixed entry after prune: %v", entry)
+	}
+	if n := pruneGeminiSessionHook(hooks); n != 0 {
+		t.Fatalf("second prune removed %d commands", n)
+	}
+}
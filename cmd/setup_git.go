@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+// gitHookMarker tags the hook scripts `felt setup git` writes, so re-running
+// setup replaces them and --uninstall removes only them.
+const gitHookMarker = "# felt-managed hook"
+
+// gitHooks are the scripts `felt setup git` installs, by hook name. Each
+// passes silently when felt is not on PATH and never fails the commit.
+var gitHooks = map[string]string{
+	"prepare-commit-msg": `#!/bin/sh
+` + gitHookMarker + ` (felt setup git): suggests Felt: trailers for active fibers.
+command -v felt >/dev/null 2>&1 || exit 0
+felt hook git-prepare "$@" || true
+`,
+	"post-commit": `#!/bin/sh
+` + gitHookMarker + ` (felt setup git): records the commit on the fibers its Felt: trailers name.
+command -v felt >/dev/null 2>&1 || exit 0
+felt hook git-commit || true
+`,
+}
+
+var gitHookNames = []string{"prepare-commit-msg", "post-commit"}
+
+var setupGitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Install git hooks that link commits to fibers through Felt: trailers",
+	Long: `Install a prepare-commit-msg and post-commit hook pair in the current git
+repository (honouring core.hooksPath).
+
+A commit whose message ends with a trailer naming fibers
+
+    Fit the covariance model
+
+    Felt: pure_eb/covariance
+
+is recorded on each named fiber: post-commit appends the SHA, subject, and
+time to the fiber's commits: list, which felt show prints as a Commits
+section. The fiber file changes after the commit, so the record lands with
+the next one. prepare-commit-msg lists active fibers as commented trailer
+suggestions when git opens the editor.
+
+Refuses to replace a hook felt did not write. Idempotent — re-running is
+safe. Use --uninstall to remove.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		uninstall, _ := cmd.Flags().GetBool("uninstall")
+		dir, err := gitHooksDir()
+		if err != nil {
+			return err
+		}
+		if uninstall {
+			removed, err := uninstallGitHooks(dir)
+			if err != nil {
+				return err
+			}
+			if len(removed) == 0 {
+				fmt.Println("No felt git hooks installed")
+				return nil
+			}
+			fmt.Printf("Removed %s from %s\n", strings.Join(removed, " and "), dir)
+			return nil
+		}
+		if err := installGitHooks(dir); err != nil {
+			return err
+		}
+		fmt.Printf("Installed %s hooks in %s\n", strings.Join(gitHookNames, " and "), dir)
+		return nil
+	},
+}
+
+var hookGitPrepareCmd = &cobra.Command{
+	Use:   "git-prepare <msg-file> [source] [sha]",
+	Short: "prepare-commit-msg: suggest Felt: trailers for active fibers",
+	Long: `Called by the prepare-commit-msg hook ` + "`felt setup git`" + ` installs. When git
+is about to open the editor on a fresh message, appends the active fibers as
+commented-out Felt: trailers to uncomment. Messages given with -m, -F, merges,
+amends, and messages already carrying a Felt: trailer are left alone.`,
+	Args:         cobra.RangeArgs(1, 3),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source := ""
+		if len(args) > 1 {
+			source = args[1]
+		}
+		runGitPrepareHook(args[0], source)
+		return nil
+	},
+}
+
+var hookGitCommitCmd = &cobra.Command{
+	Use:   "git-commit",
+	Short: "post-commit: record HEAD on the fibers its Felt: trailers name",
+	Long: `Called by the post-commit hook ` + "`felt setup git`" + ` installs. Reads HEAD's
+message and appends its SHA and subject to the commits: list of each fiber a
+Felt: trailer names. Unknown ids are reported on stderr; the command never
+fails, so a commit is never held up.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runGitCommitHook(cmd.ErrOrStderr(), time.Now())
+		return nil
+	},
+}
+
+func init() {
+	setupGitCmd.Flags().Bool("uninstall", false, "Remove the felt git hooks")
+	setupCmd.AddCommand(setupGitCmd)
+	hookCmd.AddCommand(hookGitPrepareCmd)
+	hookCmd.AddCommand(hookGitCommitCmd)
+}
+
+// gitCommand runs git where felt was asked to run, honoring -C.
+func gitCommand(args ...string) *exec.Cmd {
+	c := exec.Command("git", args...)
+	c.Dir = changeDir
+	return c
+}
+
+// gitHooksDir returns the absolute hooks directory of the repository the
+// working directory is in.
+func gitHooksDir() (string, error) {
+	out, err := gitCommand("rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("not in a git repository")
+	}
+	dir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(dir) && changeDir != "" {
+		dir = filepath.Join(changeDir, dir)
+	}
+	return filepath.Abs(dir)
+}
+
+func isFeltGitHook(path string) (exists, ours bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	return true, bytes.Contains(data, []byte(gitHookMarker)), nil
+}
+
+func installGitHooks(dir string) error {
+	// Check every hook before writing any, so a foreign hook leaves the pair
+	// uninstalled rather than half-installed.
+	for _, name := range gitHookNames {
+		exists, ours, err := isFeltGitHook(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if exists && !ours {
+			return fmt.Errorf("%s already has a %s hook; call `%s` from it instead", dir, name, gitHookCommand(name))
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+	for _, name := range gitHookNames {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(gitHooks[name]), 0755); err != nil {
+			return fmt.Errorf("writing %s: %w", path, err)
+		}
+		// WriteFile keeps an existing file's mode; the hook must be executable.
+		if err := os.Chmod(path, 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uninstallGitHooks(dir string) ([]string, error) {
+	var removed []string
+	for _, name := range gitHookNames {
+		path := filepath.Join(dir, name)
+		_, ours, err := isFeltGitHook(path)
+		if err != nil {
+			return removed, err
+		}
+		if !ours {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed = append(removed, name)
+	}
+	return removed, nil
+}
+
+// gitHookCommand is the felt command a hook script runs.
+func gitHookCommand(name string) string {
+	if name == "post-commit" {
+		return "felt hook git-commit"
+	}
+	return "felt hook git-prepare"
+}
+
+// runGitPrepareHook appends commented Felt: trailer suggestions to the
+// message file. Every failure is a silent pass: a hint is not worth blocking
+// a commit over.
+func runGitPrepareHook(msgFile, source string) {
+	if source != "" && source != "template" {
+		return
+	}
+	if char, err := gitCommand("config", "--get", "core.commentChar").Output(); err == nil && strings.TrimSpace(string(char)) != "#" {
+		return
+	}
+	data, err := os.ReadFile(msgFile)
+	if err != nil || len(felt.CommitTrailerIDs(uncommentedMessage(string(data)))) > 0 {
+		return
+	}
+	root, err := resolveProjectRoot()
+	if err != nil {
+		return
+	}
+	felts, err := felt.NewStorage(root).ListMetadata()
+	if err != nil {
+		return
+	}
+	var lines []string
+	for _, f := range felts {
+		if f.Status == felt.StatusActive {
+			lines = append(lines, fmt.Sprintf("# %s: %s  (%s)", felt.CommitTrailer, f.ID, truncateText(f.DisplayName(), refTitleMaxLen)))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	hint := "\n# Link this commit to fibers by uncommenting their trailers\n# (keep them last, after a blank line):\n" + strings.Join(lines, "\n") + "\n"
+	file, err := os.OpenFile(msgFile, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.WriteString(hint)
+}
+
+// uncommentedMessage drops git's #-comment lines from a message.
+func uncommentedMessage(message string) string {
+	var kept []string
+	for _, line := range strings.Split(message, "\n") {
+		if !strings.HasPrefix(line, "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// runGitCommitHook records HEAD on every fiber its Felt: trailers name.
+func runGitCommitHook(stderr io.Writer, now time.Time) {
+	out, err := gitCommand("log", "-1", "--format=%H%n%B").Output()
+	if err != nil {
+		return
+	}
+	sha, message, _ := strings.Cut(string(out), "\n")
+	ids := felt.CommitTrailerIDs(message)
+	if len(ids) == 0 {
+		return
+	}
+	root, err := resolveProjectRoot()
+	if err != nil {
+		fmt.Fprintf(stderr, "felt: commit names fibers but no felt repository was found\n")
+		return
+	}
+	storage := felt.NewStorage(root)
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	commit := felt.Commit{SHA: strings.TrimSpace(sha), Subject: subject, At: now}
+	for _, id := range ids {
+		f, err := storage.FindMetadataInScope("", id)
+		if err != nil {
+			fmt.Fprintf(stderr, "felt: %s trailer %q: %v\n", felt.CommitTrailer, id, err)
+			continue
+		}
+		if _, err := storage.RecordCommit(f.ID, commit); err != nil {
+			fmt.Fprintf(stderr, "felt: recording commit on %s: %v\n", f.ID, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func gitIn(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	c := exec.Command("git", append([]string{"-c", "user.name=T", "-c", "user.email=t@example.com", "-c", "core.hooksPath=" + filepath.Join(dir, "no-hooks")}, args...)...)
+	c.Dir = dir
+	if out, err := c.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestSetupGitRecordsTrailerCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	gitIn(t, dir, "init", "-q")
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "tune", Name: "Tune the fit", Status: felt.StatusActive, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := runCommand(t, dir, "setup", "git"); err != nil {
+		t.Fatalf("setup git: %v", err)
+	}
+	hooks := filepath.Join(dir, ".git", "hooks")
+	for _, name := range gitHookNames {
+		info, err := os.Stat(filepath.Join(hooks, name))
+		if err != nil || info.Mode()&0100 == 0 {
+			t.Fatalf("%s hook not installed executable: %v", name, err)
+		}
+	}
+
+	msg := filepath.Join(dir, "MSG")
+	os.WriteFile(msg, []byte("\n# Please enter the commit message.\n"), 0644)
+	if _, err := runCommand(t, dir, "hook", "git-prepare", msg); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(msg); !strings.Contains(string(data), "# Felt: tune  (Tune the fit)") {
+		t.Fatalf("prepare-commit-msg hint missing:\n%s", data)
+	}
+
+	os.WriteFile(filepath.Join(dir, "fit.py"), []byte("fit()\n"), 0644)
+	gitIn(t, dir, "add", "fit.py")
+	gitIn(t, dir, "commit", "-q", "-m", "Fit the model\n\nFelt: tune (Tune the fit)")
+	for i := 0; i < 2; i++ {
+		if _, err := runCommand(t, dir, "hook", "git-commit"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := storage.Read("tune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commits := f.Commits(); len(commits) != 1 || commits[0].Subject != "Fit the model" || len(commits[0].SHA) != 40 {
+		t.Fatalf("commits = %+v, want the one trailer commit", commits)
+	}
+	out, err := runCommand(t, dir, "show", "tune")
+	if err != nil || !strings.Contains(out, "Commits:\n  "+f.Commits()[0].SHA[:7]) || !strings.Contains(out, "Fit the model") {
+		t.Fatalf("show = %v\n%s", err, out)
+	}
+
+	if out, err := runCommand(t, dir, "setup", "git", "--uninstall"); err != nil || !strings.Contains(out, "Removed") {
+		t.Fatalf("uninstall = %v\n%s", err, out)
+	}
+	setupGitCmd.Flags().Set("uninstall", "false")
+	os.WriteFile(filepath.Join(hooks, "post-commit"), []byte("#!/bin/sh\nmake lint\n"), 0755)
+	if _, err := runCommand(t, dir, "setup", "git"); err == nil {
+		t.Fatal("setup git replaced a foreign hook")
+	}
+	if _, err := os.Stat(filepath.Join(hooks, "prepare-commit-msg")); err == nil {
+		t.Fatal("a refused setup should install neither hook")
+	}
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	shareExpires  string
+	shareUpstream bool
+	shareBase     string
+	shareRotate   bool
+)
+
+var shareCmd = &cobra.Command{
+	Use:   "share <id>",
+	Short: "Print a signed, expiring read-only link to one fiber",
+	Long: `Prints a link to one fiber that felt serve http renders as a read-only
+page: name, status, outcome, and body, and with --upstream the outcomes of
+the fibers it reads from — a decision's full context for someone outside
+the repository. The link names nothing else and works only until it
+expires (--expires, a span; default 7d).
+
+Links are signed with .felt/share.key, created on first use and never
+committed. --rotate replaces it, revoking every link handed out so far;
+with no id it only rotates.
+
+--base is the address the recipient reaches felt serve http at. To reach
+it from outside the machine, run felt serve http --host <addr> --share-only:
+without --share-only the same listener serves the unauthenticated JSON API,
+and the recipient could read the whole store, not just the shared fiber.`,
+	Example: `  felt share use-des-y3-weights --upstream
+  felt share mocks --expires 1d --base https://felt.example.org
+  felt share --rotate`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		if len(args) == 0 && !shareRotate {
+			return fmt.Errorf("share needs a fiber id (or --rotate)")
+		}
+		ttl, err := felt.ParseSpan(shareExpires)
+		if err != nil {
+			return fmt.Errorf("--expires: %w", err)
+		}
+
+		storage := felt.NewStorage(root)
+		var target *felt.Felt
+		if len(args) == 1 {
+			if target, err = storage.FindMetadataInScope(resolveCommandScope(root), args[0]); err != nil {
+				return err
+			}
+		}
+		key, err := storage.ShareKey(true, shareRotate)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			fmt.Println("Rotated the share key; earlier links no longer work")
+			return nil
+		}
+
+		sh := felt.Share{ID: target.ID, Expires: time.Now().Add(ttl).UTC().Truncate(time.Second), Upstream: shareUpstream}
+		link := shareURL(shareBase, sh, key)
+		if jsonOutput {
+			return outputJSON(map[string]any{"id": sh.ID, "url": link, "expires": sh.Expires, "upstream": sh.Upstream})
+		}
+		fmt.Println(link)
+		fmt.Fprintf(cmd.ErrOrStderr(), "Expires %s\n", displayTime(sh.Expires).Format("2006-01-02 15:04"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shareCmd)
+	shareCmd.Flags().StringVar(&shareExpires, "expires", "7d", "How long the link works (a span: 1h, 3d, 2w)")
+	shareCmd.Flags().BoolVar(&shareUpstream, "upstream", false, "Include the outcomes of the fibers it reads from")
+	shareCmd.Flags().StringVar(&shareBase, "base", "http://127.0.0.1:8080", "Address felt serve http is reached at")
+	shareCmd.Flags().BoolVar(&shareRotate, "rotate", false, "Replace the share key, revoking every earlier link")
+}
+
+// shareURL is the /share link for sh under base.
+func shareURL(base string, sh felt.Share, key []byte) string {
+	segments := strings.Split(sh.ID, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	q := url.Values{}
+	q.Set("exp", fmt.Sprint(sh.Expires.Unix()))
+	if sh.Upstream {
+		q.Set("up", "1")
+	}
+	q.Set("sig", sh.Sign(key))
+	return strings.TrimRight(base, "/") + "/share/" + strings.Join(segments, "/") + "?" + q.Encode()
+}
+
+// sharePage is what a share link renders.
+type sharePage struct {
+	Fiber    *felt.Felt
+	Expires  string
+	Upstream []*felt.Felt
+}
+
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>{{.Fiber.Name}}</title>
+<style>
+body { font: 16px/1.5 system-ui, sans-serif; max-width: 46em; margin: 2em auto; padding: 0 1em; color: #222; }
+.meta { color: #666; font-size: 0.9em; }
+.outcome { border-left: 3px solid #4a7; padding-left: 0.8em; }
+pre { white-space: pre-wrap; font: inherit; }
+</style>
+</head>
+<body>
+<h1>{{.Fiber.Name}}</h1>
+<p class="meta">{{.Fiber.ID}}{{with .Fiber.Status}} · {{.}}{{end}}{{range .Fiber.Tags}} · #{{.}}{{end}}{{with .Fiber.Due}} · due {{.Format "2006-01-02"}}{{end}}</p>
+{{with .Fiber.Outcome}}<p class="outcome"><strong>Outcome:</strong> {{.}}</p>{{end}}
+{{with .Fiber.Body}}<pre>{{.}}</pre>{{end}}
+{{if .Upstream}}<h2>Upstream</h2>
+<ul>
+{{range .Upstream}}<li><strong>{{.Name}}</strong> <span class="meta">{{.ID}}{{with .Status}} · {{.}}{{end}}</span>{{with .Outcome}}<br>{{.}}{{end}}</li>
+{{end}}</ul>
+{{end}}<p class="meta">Read-only copy shared from felt; this link expires {{.Expires}}.</p>
+</body>
+</html>
+`))
+
+// serveShare renders the fiber a verified share link names. A bad
+// signature is 403 and an expired link 410, both without saying which
+// fiber was asked for.
+func serveShare(store *apiStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		fail := func(status int, msg string) {
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.WriteHeader(status)
+			fmt.Fprintln(w, msg)
+		}
+		key, err := store.storage.ShareKey(false, false)
+		if err != nil {
+			fail(http.StatusForbidden, felt.ErrShareInvalid.Error())
+			return
+		}
+		q := r.URL.Query()
+		sh, err := felt.VerifyShare(key, r.PathValue("id"), q.Get("exp"), q.Get("up") == "1", q.Get("sig"), time.Now())
+		switch {
+		case errors.Is(err, felt.ErrShareExpired):
+			fail(http.StatusGone, err.Error())
+			return
+		case err != nil:
+			fail(http.StatusForbidden, err.Error())
+			return
+		}
+		f, err := store.storage.Read(sh.ID)
+		if err != nil {
+			fail(http.StatusNotFound, "fiber no longer exists")
+			return
+		}
+
+		page := sharePage{Fiber: f, Expires: displayTime(sh.Expires).Format("2006-01-02 15:04")}
+		if sh.Upstream {
+			felts, _, err := store.snapshot()
+			if err != nil {
+				fail(http.StatusInternalServerError, err.Error())
+				return
+			}
+			byID := make(map[string]*felt.Felt, len(felts))
+			for _, g := range felts {
+				byID[g.ID] = g
+			}
+			for _, id := range felt.DataFlowUpstreams(felts)[sh.ID] {
+				if up := byID[id]; up != nil {
+					page.Upstream = append(page.Upstream, up)
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = sharePageTemplate.Execute(w, page)
+	}
+}
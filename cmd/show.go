@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/cailmdaley/felt/internal/felt"
 	"github.com/spf13/cobra"
@@ -35,8 +36,12 @@ Targeted views:
   --field <name>    return one frontmatter field by raw YAML key, formatted
                     for shell consumers (scalars on one line, sequences of
                     scalars one-per-line, structured values as YAML)`,
-	Args: cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
+	Example: `  felt show mocks-unbiased
+  felt show mocks-unbiased -d summary
+  felt show mocks-unbiased --field inputs`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		root, err := resolveProjectRoot()
 		if err != nil {
 			return fmt.Errorf("not in a felt repository")
@@ -68,12 +73,22 @@ Targeted views:
 		storage := felt.NewStorage(root)
 		scopeID := resolveCommandScope(root)
 
+		// readID is the fiber this show resolved to; a successful read is
+		// recorded in the opt-in access log.
+		var readID string
+		defer func() {
+			if err == nil && readID != "" {
+				recordAccess(storage, readID)
+			}
+		}()
+
 		if selectorCount == 0 && !jsonOutput && (detail == DepthName || detail == DepthCompact) {
 			f, err := storage.FindMetadataInScope(scopeID, args[0])
 			if err != nil {
 				return err
 			}
-			fmt.Print(renderFelt(f, nil, detail, nil, nil))
+			readID = f.ID
+			fmt.Print(renderFelt(f, nil, detail, nil, nil, upstreamNotes{}))
 			return nil
 		}
 
@@ -83,6 +98,7 @@ Targeted views:
 			if err != nil {
 				return err
 			}
+			readID = f.ID
 
 			if showBodyOnly {
 				return outputShowBody(storage, f)
@@ -116,11 +132,13 @@ Targeted views:
 		if err != nil {
 			return err
 		}
+		readID = f.ID
 
 		graph := graphForBodyRefs(storage, f)
 
 		var citations []felt.Citation
 		var consumers []felt.DataFlowConsumer
+		var upstream upstreamNotes
 		if detail == DepthSummary || detail == DepthFull {
 			// Reverse-edge context is read straight from the markdown source of
 			// truth in a single walk, so the block is always fresh.
@@ -128,9 +146,17 @@ Targeted views:
 			if err != nil {
 				return err
 			}
+			if len(f.DataFlowInputs()) > 0 {
+				felts, err := storage.ListMetadata()
+				if err != nil {
+					return err
+				}
+				upstream.Shaky = felt.ShakyUpstreams(felts, f.ID)
+				upstream.Invalidated = felt.InvalidatedUpstreams(felts, f.ID)
+			}
 		}
 
-		fmt.Print(renderFelt(f, graph, detail, citations, consumers))
+		fmt.Print(renderFelt(f, graph, detail, citations, consumers, upstream))
 		return nil
 	},
 }
@@ -170,27 +196,32 @@ func init() {
 type showBodyOutput struct {
 	Body          string `json:"body" yaml:"body"`
 	BodyStartLine int    `json:"body_start_line" yaml:"body_start_line"`
+	// BodyFile is the sidecar holding an externalized body (`body-file:`);
+	// BodyStartLine then counts from the top of that file.
+	BodyFile string `json:"body_file,omitempty" yaml:"body_file,omitempty"`
 }
 
 func outputShowBody(storage *felt.Storage, f *felt.Felt) error {
-	data, err := os.ReadFile(storage.Path(f.ID))
-	if err != nil {
-		return fmt.Errorf("reading file %s: %w", storage.Path(f.ID), err)
-	}
-	startLine, err := felt.BodyStartLine(data)
-	if err != nil {
-		return err
-	}
-
-	payload := showBodyOutput{
-		Body:          f.Body,
-		BodyStartLine: startLine,
+	payload := showBodyOutput{Body: f.Body, BodyStartLine: 1}
+	if name := f.BodyFile(); name != "" {
+		payload.BodyFile = filepath.Join(filepath.Dir(storage.Path(f.ID)), name)
+	} else {
+		data, err := os.ReadFile(storage.Path(f.ID))
+		if err != nil {
+			return fmt.Errorf("reading file %s: %w", storage.Path(f.ID), err)
+		}
+		if payload.BodyStartLine, err = felt.BodyStartLine(data); err != nil {
+			return err
+		}
 	}
 	if jsonOutput {
 		return outputJSON(payload)
 	}
 
-	fmt.Printf("Body start line: %d\n", startLine)
+	if payload.BodyFile != "" {
+		fmt.Printf("Body file: %s\n", payload.BodyFile)
+	}
+	fmt.Printf("Body start line: %d\n", payload.BodyStartLine)
 	if f.Body != "" {
 		fmt.Printf("\n%s", f.Body)
 		if f.Body[len(f.Body)-1] != '\n' {
@@ -46,6 +46,32 @@ func mustShowExtra(t *testing.T, f *felt.Felt, key string, value any) {
 	}
 }
 
+func TestShowRendersTimestampsInDisplayTimezone(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "alpha", Name: "Alpha", CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(storage.ConfigPath(), []byte("display:\n  timezone: Asia/Tokyo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { theme = glyphTheme{} }()
+	prevJSON := jsonOutput
+	defer func() { jsonOutput = prevJSON }()
+	jsonOutput = false
+
+	out, err := runCommand(t, dir, "show", "alpha")
+	if err != nil {
+		t.Fatalf("show: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Created:  2026-04-10T18:00:00+09:00") {
+		t.Fatalf("show did not render created-at in Asia/Tokyo:\n%s", out)
+	}
+}
+
 func TestShowBodyIncludesStartLine(t *testing.T) {
 	dir := t.TempDir()
 	storage := felt.NewStorage(dir)
@@ -264,7 +290,7 @@ func TestRenderFullResolvesScopedBodyRefs(t *testing.T) {
 		sibling.ID: sibling,
 		child.ID:   child,
 	}}
-	out := renderFelt(current, graph, DepthFull, nil, nil)
+	out := renderFelt(current, graph, DepthFull, nil, nil, upstreamNotes{})
 	if !strings.Contains(out, "Refs:     project/question (Question), project/analysis/method#step-a (Method)") {
 		t.Fatalf("renderFelt() scoped refs mismatch:\n%s", out)
 	}
@@ -546,3 +572,92 @@ func TestShowFieldRefusesJSON(t *testing.T) {
 		t.Fatalf("unexpected error message: %v\n%s", err, out)
 	}
 }
+
+func TestShowRecordsAccessForLastReadSort(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	for i, id := range []string{"alpha", "beta", "gamma"} {
+		if err := storage.Write(&felt.Felt{
+			ID:        id,
+			Name:      id,
+			Status:    felt.StatusOpen,
+			CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z").Add(time.Duration(i) * time.Hour),
+		}); err != nil {
+			t.Fatalf("Write(%s) error: %v", id, err)
+		}
+	}
+
+	reset := saveShowGlobals()
+	defer reset()
+	resetLs := saveLsGlobals()
+	defer resetLs()
+
+	// Off by default: no log is written.
+	if _, err := runCommand(t, dir, "show", "alpha"); err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	if _, err := os.Stat(storage.AccessLogPath()); !os.IsNotExist(err) {
+		t.Fatalf("access log written without access.log enabled (stat err = %v)", err)
+	}
+
+	if err := os.WriteFile(storage.ConfigPath(), []byte("access:\n  log: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(accessSessionEnv, "session-1")
+	for _, id := range []string{"gamma", "alpha"} {
+		if _, err := runCommand(t, dir, "show", id); err != nil {
+			t.Fatalf("show %s: %v", id, err)
+		}
+	}
+	access, err := storage.LoadAccessLog()
+	if err != nil {
+		t.Fatalf("LoadAccessLog: %v", err)
+	}
+	if rec := access.Last["alpha"]; rec.Session != "session-1" {
+		t.Fatalf("alpha record = %+v", rec)
+	}
+
+	out, err := runCommand(t, dir, "ls", "--sort", "last-read")
+	if err != nil {
+		t.Fatalf("ls --sort: %v\n%s", err, out)
+	}
+	b, g, a := strings.Index(out, "beta"), strings.Index(out, "gamma"), strings.Index(out, "alpha")
+	if b < 0 || !(b < g && g < a) {
+		t.Fatalf("want never-read beta, then gamma, then alpha:\n%s", out)
+	}
+}
+
+func TestShowConfidenceAndShakyUpstreams(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	mask := &felt.Felt{ID: "mask", Name: "Mask choice", Tags: []string{"decision"}, CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}
+	mustShowExtra(t, mask, felt.ConfidenceKey, "low")
+	fit := &felt.Felt{ID: "fit", Name: "Fit", CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}
+	mustShowExtra(t, fit, felt.ConfidenceKey, 0.9)
+	mustShowExtra(t, fit, "inputs", []map[string]any{{"id": "mask", "from": "mask"}})
+	for _, fiber := range []*felt.Felt{mask, fit} {
+		if err := storage.Write(fiber); err != nil {
+			t.Fatalf("Write(%s) error: %v", fiber.ID, err)
+		}
+	}
+
+	reset := saveShowGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "show", "fit")
+	if err != nil {
+		t.Fatalf("show: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Confidence: high (0.9)") {
+		t.Fatalf("show missing confidence:\n%s", out)
+	}
+	if !strings.Contains(out, "Rests on: mask [low]") {
+		t.Fatalf("show missing shaky upstreams:\n%s", out)
+	}
+}
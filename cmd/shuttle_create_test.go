@@ -262,3 +262,33 @@ func TestShuttleRepeat_RefusesRemoteOwned(t *testing.T) {
 		t.Fatal("refused repeat must leave the mirror byte-identical")
 	}
 }
+
+// felt shuttle has its own PersistentPreRunE, which shadows rootCmd's; the
+// root flags must still apply beneath it.
+func TestShuttleHonorsEmitPatch(t *testing.T) {
+	defer saveShuttleGlobals()()
+	dir, storage := newShuttleStore(t)
+	seedPlainFiber(t, storage, "hub", "")
+	original, err := os.ReadFile(storage.Path("hub"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(felt.EmitPatchEnv, "")
+	defer func() { emitPatch = false }()
+
+	oldStderr := os.Stderr
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stderr = devNull
+	out, err := runCommand(t, dir, "shuttle", "pin", "hub", "--host", "testhost", "--project-dir", t.TempDir(), "--emit-patch")
+	os.Stderr = oldStderr
+	if err != nil {
+		t.Fatalf("shuttle pin --emit-patch: %v\n%s", err, out)
+	}
+	if data, _ := os.ReadFile(storage.Path("hub")); string(data) != string(original) {
+		t.Fatalf("shuttle pin --emit-patch wrote the fiber:\n%s", data)
+	}
+}
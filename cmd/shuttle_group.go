@@ -36,6 +36,8 @@ talk to the local daemon's :4000 API.`,
 	// daemon's `--felt-store <store>` invocations resolve through felt's existing
 	// store-resolution path unchanged.
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// This hook replaces rootCmd's, so apply the root flags here too.
+		applyRootFlags()
 		// Suppress cobra's usage block on a RunE error for every dispatch verb,
 		// matching felt's own automation verbs (check, hook). The daemon shells
 		// these verbs (transition.ex / lifecycle_controller.ex / dispatcher.ex) and
@@ -346,10 +346,11 @@ func printStatusTable(rows []FiberStatus) {
 // shuttleTruncateID truncates a fiber id to n runes, keeping the SUFFIX (the leaf
 // distinguishes sibling fibers) with a leading ellipsis when clipped.
 func shuttleTruncateID(s string, n int) string {
-	if len(s) <= n {
+	runes := []rune(s)
+	if len(runes) <= n {
 		return s
 	}
-	return "…" + s[len(s)-(n-1):]
+	return "…" + string(runes[len(runes)-(n-1):])
 }
 
 func registerShuttleStatusFlags() {
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var snoozeClear bool
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <id> <duration>",
+	Short: "Hide a fiber until a wake time",
+	Long: `Sets a fiber's defer-until so it leaves the ready queue (felt ls --ready)
+and the session hook's Active / Open list until it wakes. On its wake day it
+returns under a "Waking Today" section; after that it is ordinary work again.
+
+The duration is a span counted from now (4h, 3d, 2w) or a date (friday,
+next week, in 3 days, 2026-06-01), which wakes at the start of that day.
+--clear wakes the fiber immediately.`,
+	Example: `  felt snooze referee-report 3d
+  felt snooze paper-draft "next monday"
+  felt snooze paper-draft --clear`,
+	ValidArgsFunction: completeFirstFiberID,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if snoozeClear {
+			return cobra.ExactArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		storage := felt.NewStorage(root)
+		target, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		f, err := storage.Read(target.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var wake time.Time
+		if !snoozeClear {
+			if wake, err = felt.ParseWakeTime(args[1], now); err != nil {
+				return fmt.Errorf("invalid snooze duration: %w", err)
+			}
+			if !wake.After(now) {
+				return fmt.Errorf("snooze wake time %s is not in the future", displayTime(wake).Format("2006-01-02 15:04"))
+			}
+		}
+		if err := f.SetDeferUntil(wake); err != nil {
+			return err
+		}
+		f.Touch(now)
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+
+		if snoozeClear {
+			fmt.Printf("Woke %s\n", f.ID)
+		} else {
+			fmt.Printf("Snoozed %s until %s\n", f.ID, displayTime(wake).Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(snoozeCmd)
+	snoozeCmd.Flags().BoolVar(&snoozeClear, "clear", false, "Wake the fiber now instead of snoozing it")
+}
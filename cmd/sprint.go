@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sprintStartNoCarry bool
+	sprintStartForce   bool
+	sprintAddSprint    string
+)
+
+// The `felt sprint` group is time-boxed planning over the ordinary fiber tree.
+// A sprint is itself a fiber (sprints/<start-date>, carrying a sprint: block
+// with its window); membership is a `sprint:<start-date>` tag on the committed
+// fibers, so `felt ls -t sprint:2026-10-14` already lists a sprint's work and
+// nothing about a sprint lives outside the markdown.
+var sprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Time-boxed sprints over the fiber tree",
+	Long: `Plans work in time-boxed sprints.
+
+A sprint is a fiber under sprints/ whose sprint: block records its window.
+Fibers are committed to a sprint by the sprint:<start-date> tag, which
+'felt sprint add' applies for you.
+
+  felt sprint start 2w          start a two-week sprint today
+  felt sprint add <id>...       commit fibers to the current sprint
+  felt sprint report [sprint]   committed vs completed, with spillover`,
+}
+
+var sprintStartCmd = &cobra.Command{
+	Use:   "start <span>",
+	Short: "Start a sprint today",
+	Long: `Starts a sprint running from today for <span> (e.g. 1w, 2w, 10d; rounded
+up to whole days).
+
+Unfinished fibers from the most recent earlier sprint are carried into the new
+one: they gain the new sprint's tag and keep the old one, so the old sprint's
+report still shows them as spillover. Pass --no-carry to leave them behind.
+
+Starting a sprint while another is still running is refused unless --force.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		span, err := felt.ParseSpan(args[0])
+		if err != nil {
+			return err
+		}
+
+		storage := felt.NewStorage(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		sprints := felt.Sprints(felts)
+		previous := felt.LatestSprint(sprints, now)
+		if previous != nil && !previous.Ended(now) && !sprintStartForce {
+			return fmt.Errorf("sprint %s is still running (through %s); use --force to start another", previous.ID, previous.End)
+		}
+
+		f, sp, err := felt.NewSprint(now, span)
+		if err != nil {
+			return err
+		}
+		if err := storage.CheckAvailableID(f.ID); err != nil {
+			return err
+		}
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+
+		var carried []string
+		if previous != nil && previous.ID != sp.ID && !sprintStartNoCarry {
+			for _, member := range felt.SprintSpillover(previous, felts) {
+				if err := commitToSprint(storage, member.ID, sp, now); err != nil {
+					return err
+				}
+				carried = append(carried, member.ID)
+			}
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]any{
+				"sprint":       sp,
+				"carried_from": carriedFrom(previous, carried),
+				"carried":      carried,
+			})
+		}
+
+		length, _ := sp.Days(now)
+		fmt.Printf("Started %s (%s → %s, %d %s)\n", sp.ID, sp.Start, sp.End, length, pluralize(length, "day", "days"))
+		if len(carried) > 0 {
+			fmt.Printf("Carried %d %s from %s:\n", len(carried), pluralize(len(carried), "fiber", "fibers"), previous.ID)
+			for _, id := range carried {
+				fmt.Printf("  %s\n", id)
+			}
+		}
+		return nil
+	},
+}
+
+var sprintAddCmd = &cobra.Command{
+	Use:   "add <id>...",
+	Short: "Commit fibers to the current sprint",
+	Long: `Commits fibers to the running sprint by tagging them sprint:<start-date>.
+Use --sprint to target a specific sprint instead of the running one.`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeFiberIDs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		storage := felt.NewStorage(root)
+		scopeID := resolveCommandScope(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		sp, err := resolveSprint(felts, scopeID, sprintAddSprint, now, true)
+		if err != nil {
+			return err
+		}
+
+		var added []string
+		for _, query := range args {
+			f, err := felt.FindByScope(felts, scopeID, query)
+			if err != nil {
+				return err
+			}
+			if f.ID == sp.ID {
+				return fmt.Errorf("cannot commit sprint %s to itself", sp.ID)
+			}
+			if f.HasTag(sp.Tag()) {
+				fmt.Fprintf(os.Stderr, "%s is already in %s\n", f.ID, sp.ID)
+				continue
+			}
+			if err := commitToSprint(storage, f.ID, sp, now); err != nil {
+				return err
+			}
+			added = append(added, f.ID)
+		}
+
+		if jsonOutput {
+			return outputJSON(map[string]any{"sprint": sp, "added": added})
+		}
+		for _, id := range added {
+			fmt.Printf("Added %s to %s\n", id, sp.ID)
+		}
+		return nil
+	},
+}
+
+var sprintReportCmd = &cobra.Command{
+	Use:   "report [sprint]",
+	Short: "Show committed vs completed for a sprint",
+	Long: `Reports a sprint's committed fibers, which of them closed inside the sprint
+window, and the spillover still open. Defaults to the running sprint, or the
+one that most recently ended. Spillover already carried into the following
+sprint is marked as such.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		storage := felt.NewStorage(root)
+		scopeID := resolveCommandScope(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+
+		query := ""
+		if len(args) == 1 {
+			query = args[0]
+		}
+		now := time.Now()
+		sp, err := resolveSprint(felts, scopeID, query, now, false)
+		if err != nil {
+			return err
+		}
+
+		report := felt.BuildSprintReport(sp, felts, nextSprint(felt.Sprints(felts), sp), now)
+		if jsonOutput {
+			return outputJSON(report)
+		}
+		fmt.Print(renderSprintReport(report, now))
+		return nil
+	},
+}
+
+func init() {
+	sprintStartCmd.Flags().BoolVar(&sprintStartNoCarry, "no-carry", false, "Do not carry unfinished fibers from the previous sprint")
+	sprintStartCmd.Flags().BoolVar(&sprintStartForce, "force", false, "Start even if another sprint is still running")
+	sprintAddCmd.Flags().StringVar(&sprintAddSprint, "sprint", "", "Target sprint (default: the running sprint)")
+	sprintCmd.AddCommand(sprintStartCmd, sprintAddCmd, sprintReportCmd)
+	rootCmd.AddCommand(sprintCmd)
+}
+
+// resolveSprint picks the sprint a subcommand operates on: an explicit query
+// (a sprint fiber id, or a bare start date) when given, else the running
+// sprint. When running is false (report), the most recently ended sprint is an
+// acceptable fallback.
+func resolveSprint(felts []*felt.Felt, scopeID, query string, now time.Time, running bool) (*felt.Sprint, error) {
+	sprints := felt.Sprints(felts)
+	if query != "" {
+		query = strings.TrimPrefix(query, felt.SprintTagPrefix)
+		for _, sp := range sprints {
+			if sp.Start == query || sp.ID == query {
+				return sp, nil
+			}
+		}
+		f, err := felt.FindByScope(felts, scopeID, query)
+		if err != nil {
+			return nil, err
+		}
+		sp, ok := felt.SprintFromFelt(f)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a sprint", f.ID)
+		}
+		return sp, nil
+	}
+	if sp := felt.CurrentSprint(sprints, now); sp != nil {
+		return sp, nil
+	}
+	if !running {
+		if sp := felt.LatestSprint(sprints, now); sp != nil {
+			return sp, nil
+		}
+	}
+	return nil, fmt.Errorf("no sprint is running (start one with 'felt sprint start <span>')")
+}
+
+// commitToSprint tags one fiber into sp. The fiber is re-read in full so the
+// body survives the rewrite.
+func commitToSprint(storage *felt.Storage, id string, sp *felt.Sprint, now time.Time) error {
+	f, err := storage.Read(id)
+	if err != nil {
+		return err
+	}
+	f.AddTag(sp.Tag())
+	f.Touch(now)
+	return storage.Write(f)
+}
+
+func nextSprint(sprints []*felt.Sprint, sp *felt.Sprint) *felt.Sprint {
+	for i, candidate := range sprints {
+		if candidate.ID == sp.ID && i+1 < len(sprints) {
+			return sprints[i+1]
+		}
+	}
+	return nil
+}
+
+func carriedFrom(previous *felt.Sprint, carried []string) string {
+	if previous == nil || len(carried) == 0 {
+		return ""
+	}
+	return previous.ID
+}
+
+func renderSprintReport(r *felt.SprintReport, now time.Time) string {
+	var sb strings.Builder
+	length, day := r.Sprint.Days(now)
+	fmt.Fprintf(&sb, "%s  %s → %s", r.Sprint.ID, r.Sprint.Start, r.Sprint.End)
+	switch {
+	case r.Ended:
+		sb.WriteString("  (ended)\n")
+	case day > 0:
+		fmt.Fprintf(&sb, "  (day %d of %d)\n", day, length)
+	default:
+		sb.WriteString("  (not started)\n")
+	}
+	fmt.Fprintf(&sb, "Completed %d of %d committed\n", len(r.Completed), len(r.Committed))
+
+	if len(r.Completed) > 0 {
+		sb.WriteString("\n## Completed\n")
+		for _, f := range r.Completed {
+			fmt.Fprintf(&sb, "%s %s — %s\n", fiberIcon(f), f.ID, f.DisplayName())
+		}
+	}
+	if len(r.Remaining) > 0 {
+		if r.Ended {
+			sb.WriteString("\n## Spillover\n")
+		} else {
+			sb.WriteString("\n## Remaining\n")
+		}
+		carried := make(map[string]bool, len(r.Carried))
+		for _, id := range r.Carried {
+			carried[id] = true
+		}
+		for _, f := range r.Remaining {
+			line := fmt.Sprintf("%s %s — %s", fiberIcon(f), f.ID, f.DisplayName())
+			if carried[f.ID] {
+				line += fmt.Sprintf(" (carried to %s)", r.CarriedTo)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	return sb.String()
+}
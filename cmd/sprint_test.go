@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestSprintStartAddReport(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, id := range []string{"fiber-a", "fiber-b"} {
+		if err := storage.Write(&felt.Felt{
+			ID:        id,
+			Name:      strings.ToUpper(id),
+			Status:    felt.StatusOpen,
+			CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z"),
+			Body:      "Body survives.\n",
+		}); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	reset := saveSprintGlobals()
+	defer reset()
+
+	if out, err := runCommand(t, dir, "sprint", "start", "2w"); err != nil {
+		t.Fatalf("sprint start: %v\n%s", err, out)
+	}
+	today := time.Now().Format("2006-01-02")
+	sprintID := "sprints/" + today
+
+	if out, err := runCommand(t, dir, "sprint", "add", "fiber-a", "fiber-b"); err != nil {
+		t.Fatalf("sprint add: %v\n%s", err, out)
+	}
+	a, err := storage.Read("fiber-a")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if !a.HasTag("sprint:" + today) {
+		t.Fatalf("Tags = %v, want sprint:%s", a.Tags, today)
+	}
+	if strings.TrimSpace(a.Body) != "Body survives." {
+		t.Fatalf("Body = %q, sprint add must not drop the body", a.Body)
+	}
+
+	a.Status = felt.StatusClosed
+	closed := time.Now()
+	a.ClosedAt = &closed
+	if err := storage.Write(a); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	out, err := runCommand(t, dir, "sprint", "report")
+	if err != nil {
+		t.Fatalf("sprint report: %v\n%s", err, out)
+	}
+	for _, want := range []string{sprintID, "Completed 1 of 2 committed", "## Completed", "fiber-a", "## Remaining", "fiber-b"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("report missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCommand(t, dir, "-j", "sprint", "report")
+	if err != nil {
+		t.Fatalf("sprint report -j: %v\n%s", err, out)
+	}
+	var report struct {
+		Sprint    felt.Sprint       `json:"sprint"`
+		Remaining []json.RawMessage `json:"remaining"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("unmarshal report: %v\n%s", err, out)
+	}
+	if report.Sprint.ID != sprintID || len(report.Remaining) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestSprintStartRefusesOverlapAndCarriesSpillover(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	// A sprint that ended yesterday, with one unfinished member.
+	prevStart := time.Now().AddDate(0, 0, -7)
+	prevFelt, prev, err := felt.NewSprint(prevStart, 6*24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewSprint: %v", err)
+	}
+	if err := storage.Write(prevFelt); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{
+		ID:        "leftover",
+		Name:      "Leftover",
+		Status:    felt.StatusActive,
+		Tags:      []string{prev.Tag()},
+		CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z"),
+	}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	reset := saveSprintGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "sprint", "start", "1w")
+	if err != nil {
+		t.Fatalf("sprint start: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Carried 1 fiber from "+prev.ID) {
+		t.Fatalf("start output missing carry note:\n%s", out)
+	}
+	leftover, err := storage.Read("leftover")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	today := time.Now().Format("2006-01-02")
+	if !leftover.HasTag(prev.Tag()) || !leftover.HasTag("sprint:"+today) {
+		t.Fatalf("Tags = %v, want both the old and new sprint tags", leftover.Tags)
+	}
+
+	out, err = runCommand(t, dir, "sprint", "report", prev.Start)
+	if err != nil {
+		t.Fatalf("sprint report: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "## Spillover") || !strings.Contains(out, "(carried to sprints/"+today+")") {
+		t.Fatalf("ended report missing spillover carry:\n%s", out)
+	}
+
+	// A second start while the new sprint runs is refused.
+	if _, err := runCommand(t, dir, "sprint", "start", "1w"); err == nil || !strings.Contains(err.Error(), "still running") {
+		t.Fatalf("overlapping start err = %v, want still-running refusal", err)
+	}
+}
+
+func saveSprintGlobals() func() {
+	prevNoCarry := sprintStartNoCarry
+	prevForce := sprintStartForce
+	prevSprint := sprintAddSprint
+	prevJSON := jsonOutput
+
+	sprintStartNoCarry = false
+	sprintStartForce = false
+	sprintAddSprint = ""
+	jsonOutput = false
+
+	for _, name := range []string{"no-carry", "force"} {
+		if f := sprintStartCmd.Flags().Lookup(name); f != nil {
+			f.Changed = false
+		}
+	}
+	if f := sprintAddCmd.Flags().Lookup("sprint"); f != nil {
+		f.Changed = false
+	}
+
+	return func() {
+		sprintStartNoCarry = prevNoCarry
+		sprintStartForce = prevForce
+		sprintAddSprint = prevSprint
+		jsonOutput = prevJSON
+	}
+}
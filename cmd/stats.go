@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsHealth     bool
+	statsCost       bool
+	statsTrends     bool
+	statsEstimation bool
+	statsWeeks      int
+	statsTags       []string
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize the fiber store",
+	Long: `Prints fiber counts by status.
+
+--health adds a composite 0–100 health score for weekly review, averaged
+from four components, each shown with what it measured:
+  ready   open fibers whose data-flow inputs have all closed, vs blocked
+  stale   open and active fibers touched in the last 30 days
+  wip     active fibers against wip.limit in .felt/config.yaml (default 5)
+  cycles  inputs[].from loops, which keep every fiber in them blocked
+
+--cost rolls up the cost: field (a unit-to-amount mapping, or a shorthand
+like "12 gpu-hours" or "$40") in total and by tag. A fiber with several
+tags counts toward each.
+
+--trends adds a per-tag history over the last --weeks weeks (default 8):
+how many of the tag's fibers were open at the end of each week, oldest
+first, and how many closed per week on average. It is replayed from
+created-at and closed-at, so a reopened fiber reads as open throughout.
+
+--estimation compares each closed fiber's estimate: span with what the
+work took — its spent: span when recorded, else activated-at to closed-at
+— as the median of actual ÷ estimate, overall, by month closed, and by
+tag. A median of 1.5× says work ran half again as long as estimated: scale
+new estimates, and so felt forecast's inputs, by it. Fibers never marked
+active without a spent:, or estimated in points, are left out.
+
+-t drills down to one thread of work: every figure counts only fibers
+with the tag (a trailing colon matches a prefix, as in felt ls), and the
+trends compare the matching tags, implying --trends:
+  felt stats -t thread:       compare every thread:* tag`,
+	Example: `  felt stats --health
+  felt stats --trends --weeks 12
+  felt stats --estimation -t methods
+  felt stats -t thread: --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+
+		storage := felt.NewStorage(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+		if len(statsTags) > 0 {
+			felts = filterByTags(felts, statsTags)
+		}
+		counts := felt.CountStatuses(felts)
+
+		var match func(string) bool
+		if len(statsTags) > 0 {
+			match = func(tag string) bool {
+				return slices.ContainsFunc(statsTags, func(want string) bool { return tagMatches(tag, want) })
+			}
+		}
+		var trends *felt.Trends
+		if statsTrends || len(statsTags) > 0 {
+			if statsWeeks < 1 {
+				return fmt.Errorf("--weeks must be at least 1")
+			}
+			trends = felt.TagTrends(felts, match, statsWeeks, time.Now())
+		}
+
+		var estimation *felt.Estimation
+		if statsEstimation {
+			estimation = felt.EstimateAccuracy(felts, match, displayTime(time.Now()).Location())
+		}
+
+		var health *felt.Health
+		if statsHealth {
+			cfg, err := storage.LoadConfig()
+			if err != nil {
+				return err
+			}
+			health = felt.ProjectHealth(felts, cfg, time.Now())
+		}
+
+		var costs *felt.CostRollup
+		if statsCost {
+			var errs []error
+			costs, errs = felt.RollupCosts(felts)
+			for _, err := range errs {
+				fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			}
+		}
+
+		if jsonOutput {
+			return outputJSON(statsOutput{Counts: counts, Health: health, Cost: costs, Trends: trends, Estimation: estimation})
+		}
+		fmt.Print(renderStats(counts, health))
+		if costs != nil {
+			fmt.Print(renderCostRollup(costs))
+		}
+		if trends != nil {
+			fmt.Print(renderTrends(trends))
+		}
+		if estimation != nil {
+			fmt.Print(renderEstimation(estimation))
+		}
+		return nil
+	},
+}
+
+type statsOutput struct {
+	Counts     felt.StatusCounts `json:"counts"`
+	Health     *felt.Health      `json:"health,omitempty"`
+	Cost       *felt.CostRollup  `json:"cost,omitempty"`
+	Trends     *felt.Trends      `json:"trends,omitempty"`
+	Estimation *felt.Estimation  `json:"estimation,omitempty"`
+}
+
+func renderStats(counts felt.StatusCounts, health *felt.Health) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Fibers: %d (%d open, %d active, %d closed", counts.Total, counts.Open, counts.Active, counts.Closed)
+	if counts.Untracked > 0 {
+		fmt.Fprintf(&sb, ", %d untracked", counts.Untracked)
+	}
+	sb.WriteString(")\n")
+	if health == nil {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "\nHealth: %d/100\n", health.Score)
+	for _, c := range health.Components {
+		fmt.Fprintf(&sb, "  %-7s %3d  %s\n", c.Name, c.Score, c.Detail)
+		fmt.Fprintf(&sb, "               %s\n", c.Explanation)
+	}
+	return sb.String()
+}
+
+func renderCostRollup(r *felt.CostRollup) string {
+	if r.Fibers == 0 {
+		return "\nCost: none recorded\n"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\nCost: %s (%d %s)\n", r.Total, r.Fibers, pluralize(r.Fibers, "fiber", "fibers"))
+	tags := make([]string, 0, len(r.ByTag))
+	width := 0
+	for tag := range r.ByTag {
+		tags = append(tags, tag)
+		width = max(width, len(tag))
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		fmt.Fprintf(&sb, "  %-*s  %s\n", width, tag, r.ByTag[tag])
+	}
+	return sb.String()
+}
+
+func renderTrends(t *felt.Trends) string {
+	weeks := len(t.WeekEnds)
+	if len(t.Tags) == 0 {
+		return fmt.Sprintf("\nTrends: no tagged work in the last %d %s\n", weeks, pluralize(weeks, "week", "weeks"))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\nTrends over %d %s to %s (open at each week's end, oldest first):\n",
+		weeks, pluralize(weeks, "week", "weeks"), displayTime(t.WeekEnds[weeks-1]).Format("2 Jan"))
+	width, cell := 0, 1
+	for _, tr := range t.Tags {
+		width = max(width, len(tr.Tag))
+		for _, n := range tr.Open {
+			cell = max(cell, len(fmt.Sprint(n)))
+		}
+	}
+	for _, tr := range t.Tags {
+		fmt.Fprintf(&sb, "  %-*s  open", width, tr.Tag)
+		for _, n := range tr.Open {
+			fmt.Fprintf(&sb, " %*d", cell, n)
+		}
+		fmt.Fprintf(&sb, "  closed %.1f/wk\n", tr.ClosedPerWeek)
+	}
+	return sb.String()
+}
+
+func renderEstimation(e *felt.Estimation) string {
+	if e.All.Fibers == 0 {
+		return "\nEstimation: no closed fiber has both a span estimate and an actual\n"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\nEstimation (actual ÷ estimate): %s\n", formatEstimationAccuracy(e.All))
+	for _, m := range e.Months {
+		fmt.Fprintf(&sb, "  %s  %s\n", m.Month, formatEstimationAccuracy(m.EstimationAccuracy))
+	}
+	if len(e.Tags) == 0 {
+		return sb.String()
+	}
+	width := 0
+	for _, t := range e.Tags {
+		width = max(width, len(t.Tag))
+	}
+	sb.WriteString("By tag:\n")
+	for _, t := range e.Tags {
+		fmt.Fprintf(&sb, "  %-*s  %s", width, t.Tag, formatEstimationAccuracy(t.EstimationAccuracy))
+		if len(t.Months) > 1 {
+			months := make([]string, len(t.Months))
+			for i, m := range t.Months {
+				months[i] = fmt.Sprintf("%s %.1f×", m.Month, m.MedianRatio)
+			}
+			fmt.Fprintf(&sb, " (%s)", strings.Join(months, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// formatEstimationAccuracy renders "median 1.4× over 5 fibers, 2 within 25%".
+func formatEstimationAccuracy(a felt.EstimationAccuracy) string {
+	return fmt.Sprintf("median %.1f× over %d %s, %d within 25%%", a.MedianRatio, a.Fibers, pluralize(a.Fibers, "fiber", "fibers"), a.Within)
+}
+
+// tagMatches reports whether tag satisfies a -t filter: equal to it, or
+// prefixed by it when the filter ends in a colon — felt.Felt.HasTag's rule.
+func tagMatches(tag, filter string) bool {
+	if strings.HasSuffix(filter, ":") {
+		return strings.HasPrefix(tag, filter)
+	}
+	return tag == filter
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().BoolVar(&statsCost, "cost", false, "Roll up the cost: field in total and by tag")
+	statsCmd.Flags().BoolVar(&statsHealth, "health", false, "Add a composite health score with per-component explanations")
+	statsCmd.Flags().BoolVar(&statsTrends, "trends", false, "Add per-tag open counts and closure rate over recent weeks")
+	statsCmd.Flags().BoolVar(&statsEstimation, "estimation", false, "Add estimate accuracy (actual ÷ estimate) overall, by month, and by tag")
+	statsCmd.Flags().IntVar(&statsWeeks, "weeks", felt.DefaultTrendWeeks, "Weeks of history for --trends")
+	statsCmd.Flags().StringArrayVarP(&statsTags, "tag", "t", nil, "Only count fibers with this tag (repeatable, AND; trailing colon for prefix), and compare matching tags' trends")
+	registerTagCompletion(statsCmd, "tag")
+}
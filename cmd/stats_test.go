@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestStatsHealthExplainsComponents(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	now := time.Now()
+	for _, id := range []string{"one", "two", "three"} {
+		if err := storage.Write(&felt.Felt{ID: id, Name: id, Status: felt.StatusActive, CreatedAt: now}); err != nil {
+			t.Fatalf("Write(%s) error: %v", id, err)
+		}
+	}
+	if err := os.WriteFile(storage.ConfigPath(), []byte("wip:\n  limit: 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	reset := saveStatsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "stats")
+	if err != nil {
+		t.Fatalf("stats: %v\n%s", err, out)
+	}
+	if out != "Fibers: 3 (0 open, 3 active, 0 closed)\n" {
+		t.Fatalf("stats = %q", out)
+	}
+
+	out, err = runCommand(t, dir, "stats", "--health")
+	if err != nil {
+		t.Fatalf("stats --health: %v\n%s", err, out)
+	}
+	for _, want := range []string{"Health: 92/100", "wip      67  3 active, limit 2", "cycles  100  none"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("stats --health missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStatsCostRollsUpByTag(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	for _, c := range []struct{ id, tag, cost string }{
+		{"fit", "cosebis", "4 gpu-hours"},
+		{"plot", "cosebis", "$2"},
+		{"mock", "mocks", "10 gpu-hours"},
+	} {
+		f := &felt.Felt{ID: c.id, Name: c.id, Status: felt.StatusOpen, CreatedAt: time.Now(), Tags: []string{c.tag}}
+		if err := f.SetExtraField(felt.CostKey, c.cost); err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", c.id, err)
+		}
+	}
+
+	reset := saveStatsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "stats", "--cost")
+	if err != nil {
+		t.Fatalf("stats --cost: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"Cost: 14 gpu-hours, 2 usd (3 fibers)",
+		"  cosebis  4 gpu-hours, 2 usd\n",
+		"  mocks    10 gpu-hours\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("stats --cost missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStatsEstimationReportsRatioByTag(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	activated := mustParseTime(t, "2026-04-01T09:00:00Z")
+	for _, c := range []struct {
+		id, tag, estimate, spent string
+		took                     time.Duration
+	}{
+		{"fit", "methods", "2h", "3h", 0},
+		{"plot", "methods", "4h", "", 4 * time.Hour},
+		{"mock", "sims", "1h", "", 2 * time.Hour},
+		{"sized", "sims", "3", "", time.Hour}, // points: no comparable actual
+	} {
+		closed := activated.Add(c.took)
+		if c.spent != "" {
+			closed = activated.Add(24 * time.Hour)
+		}
+		f := &felt.Felt{ID: c.id, Name: c.id, Status: felt.StatusClosed, CreatedAt: activated, ActivatedAt: &activated, ClosedAt: &closed, Tags: []string{c.tag}}
+		if err := f.SetExtraField(felt.EstimateKey, c.estimate); err != nil {
+			t.Fatal(err)
+		}
+		if c.spent != "" {
+			if err := f.SetExtraField(felt.SpentKey, c.spent); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", c.id, err)
+		}
+	}
+
+	reset := saveStatsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "stats", "--estimation")
+	if err != nil {
+		t.Fatalf("stats --estimation: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"Estimation (actual ÷ estimate): median 1.5× over 3 fibers, 1 within 25%\n",
+		"  2026-04  median 1.5× over 3 fibers",
+		"  methods  median 1.2× over 2 fibers, 1 within 25%\n",
+		"  sims     median 2.0× over 1 fiber, 0 within 25%\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("stats --estimation missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStatsTagDrillDownComparesThreads(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	now := time.Now()
+	weeksAgo := func(w int) time.Time { return now.AddDate(0, 0, -7*w).Add(-time.Hour) }
+	closed := weeksAgo(1)
+	for _, f := range []*felt.Felt{
+		{ID: "shear", Name: "Shear", Status: felt.StatusOpen, Tags: []string{"thread:lensing"}, CreatedAt: weeksAgo(3)},
+		{ID: "psf", Name: "PSF", Status: felt.StatusClosed, Tags: []string{"thread:lensing"}, CreatedAt: weeksAgo(3), ClosedAt: &closed},
+		{ID: "bao", Name: "BAO", Status: felt.StatusActive, Tags: []string{"thread:bao", "fit"}, CreatedAt: weeksAgo(1)},
+		{ID: "misc", Name: "Misc", Status: felt.StatusOpen, Tags: []string{"chore"}, CreatedAt: weeksAgo(2)},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reset := saveStatsGlobals()
+	defer reset()
+
+	out, err := runCommand(t, dir, "stats", "-t", "thread:", "--weeks", "4")
+	if err != nil {
+		t.Fatalf("stats -t thread:: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"Fibers: 3 (1 open, 1 active, 1 closed)",
+		"Trends over 4 weeks",
+		"  thread:lensing  open 2 2 1 1  closed 0.2/wk",
+		"  thread:bao      open 0 0 1 1  closed 0.0/wk",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("stats -t thread: missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "chore") || strings.Contains(out, "fit") {
+		t.Fatalf("drill-down shows tags outside thread:*:\n%s", out)
+	}
+}
+
+func saveStatsGlobals() func() {
+	prevHealth, prevCost, prevJSON := statsHealth, statsCost, jsonOutput
+	prevTrends, prevWeeks, prevTags := statsTrends, statsWeeks, statsTags
+	prevEstimation := statsEstimation
+	statsHealth, statsCost, jsonOutput = false, false, false
+	statsTrends, statsWeeks, statsTags = false, felt.DefaultTrendWeeks, nil
+	statsEstimation = false
+	for _, name := range []string{"health", "cost", "trends", "weeks", "tag", "estimation"} {
+		if f := statsCmd.Flags().Lookup(name); f != nil {
+			f.Changed = false
+		}
+	}
+	return func() {
+		statsHealth, statsCost, jsonOutput = prevHealth, prevCost, prevJSON
+		statsTrends, statsWeeks, statsTags = prevTrends, prevWeeks, prevTags
+		statsEstimation = prevEstimation
+	}
+}
@@ -27,12 +27,16 @@ This migration pass:
 - rewrites frontmatter key title -> name
 - removes inert legacy depends-on frontmatter
 - strips leading MyST anchor lines like (slug)= from fiber bodies
+- lowercases fiber directories not in canonical case, which would collide
+  with a same-named fiber on a case-insensitive filesystem (macOS)
 
 Each migrated flat fiber lands at <slug>/<slug>.md, and any inputs.from
 references to migrated hex IDs are rewritten, and myst.yml is ensured.
 
 A single bare .md at .felt/ root is the entry-point fiber and is preserved —
-only multiple bare files are treated as orphaned legacy and migrated.`,
+only multiple bare files are treated as orphaned legacy and migrated. A
+directory whose lowercase name another fiber already holds is left alone
+and reported; felt doctor lists both.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		storage, err := resolveMigrationStorage(migrateDir)
@@ -44,21 +48,21 @@ only multiple bare files are treated as orphaned legacy and migrated.`,
 		if err != nil {
 			return err
 		}
-		if len(result.Entries) == 0 && len(result.TitleToNameIDs) == 0 && len(result.RemovedDependsOnIDs) == 0 && len(result.StrippedMystAnchorIDs) == 0 {
+		if len(result.Entries) == 0 && len(result.TitleToNameIDs) == 0 && len(result.RemovedDependsOnIDs) == 0 && len(result.StrippedMystAnchorIDs) == 0 && len(result.CaseEntries) == 0 && len(result.CaseCollisionIDs) == 0 {
 			fmt.Println("No migrations needed")
 			return nil
 		}
 
-		// One verb-parameterized pass over the four result slices; dry-run vs.
+		// One verb-parameterized pass over the result slices; dry-run vs.
 		// applied differs only in the verbs and summary line.
 		var migrateVerb, renameVerb, removeVerb, stripVerb string
 		var summary string
 		if migrateDryRun {
 			migrateVerb, renameVerb, removeVerb, stripVerb = "Would migrate", "Would rename", "Would remove", "Would strip"
-			summary = "Dry run: %d flat fibers, %d legacy title fields, %d legacy depends-on keys, %d legacy MyST anchors would migrate\n"
+			summary = "Dry run: %d flat fibers, %d legacy title fields, %d legacy depends-on keys, %d legacy MyST anchors, %d non-canonical ids would migrate\n"
 		} else {
 			migrateVerb, renameVerb, removeVerb, stripVerb = "Migrated", "Renamed", "Removed", "Stripped"
-			summary = "Migrated %d flat fibers, %d legacy title fields, %d legacy depends-on keys, %d legacy MyST anchors\n"
+			summary = "Migrated %d flat fibers, %d legacy title fields, %d legacy depends-on keys, %d legacy MyST anchors, %d non-canonical ids\n"
 		}
 
 		for _, entry := range result.Entries {
@@ -73,9 +77,15 @@ only multiple bare files are treated as orphaned legacy and migrated.`,
 		for _, id := range result.StrippedMystAnchorIDs {
 			fmt.Printf("%s legacy MyST anchor from %s\n", stripVerb, id)
 		}
+		for _, entry := range result.CaseEntries {
+			fmt.Printf("%s %s -> %s\n", renameVerb, entry.OldID, entry.NewID)
+		}
+		for _, id := range result.CaseCollisionIDs {
+			fmt.Printf("Skipped %s: %s is another fiber\n", id, felt.CanonicalID(id))
+		}
 		fmt.Printf(
 			summary,
-			len(result.Entries), len(result.TitleToNameIDs), len(result.RemovedDependsOnIDs), len(result.StrippedMystAnchorIDs),
+			len(result.Entries), len(result.TitleToNameIDs), len(result.RemovedDependsOnIDs), len(result.StrippedMystAnchorIDs), len(result.CaseEntries),
 		)
 
 		return nil
@@ -122,10 +132,11 @@ Replicas should inherit the committed ids rather than minting their own.`,
 }
 
 var nestCmd = &cobra.Command{
-	Use:   "nest <child> <parent>",
-	Short: "Move a fiber under another fiber",
-	Long:  `Moves an existing fiber subtree under a parent fiber, rewriting IDs and dependencies.`,
-	Args:  cobra.ExactArgs(2),
+	Use:               "nest <child> <parent>",
+	Short:             "Move a fiber under another fiber",
+	Long:              `Moves an existing fiber subtree under a parent fiber, rewriting IDs and dependencies.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeFiberIDs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := resolveProjectRoot()
 		if err != nil {
@@ -171,10 +182,11 @@ var nestCmd = &cobra.Command{
 }
 
 var unnestCmd = &cobra.Command{
-	Use:   "unnest <child>",
-	Short: "Promote a nested fiber to the top level",
-	Long:  `Moves a nested fiber subtree to the top level, rewriting IDs and dependencies.`,
-	Args:  cobra.ExactArgs(1),
+	Use:               "unnest <child>",
+	Short:             "Promote a nested fiber to the top level",
+	Long:              `Moves a nested fiber subtree to the top level, rewriting IDs and dependencies.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		root, err := resolveProjectRoot()
 		if err != nil {
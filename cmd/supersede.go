@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var supersedeOutcome string
+
+var supersedeCmd = &cobra.Command{
+	Use:   "supersede <old-id> <new-title>",
+	Short: "Replace a decision with a new one that revises it",
+	Long: `Creates a new decision beside the old one and links the pair: the old
+fiber gets superseded-by: <new-id>, the new one supersedes: <old-id>, and
+felt show on either names the other. The new fiber takes the old one's tags
+and opens with a link back, the old outcome, and the old body as context to
+edit down.
+
+It starts open, as a decision still being settled; --outcome records the new
+decision and closes it in one step. The old fiber keeps its status.`,
+	Example: `  felt supersede mask-choice "Use the DR2 star mask"
+  felt supersede prior-widths "Widen the Omega_m prior" -o "Flat [0.1, 0.5]"`,
+	Args:              cobra.MinimumNArgs(2),
+	ValidArgsFunction: completeFirstFiberID,
+	SilenceUsage:      true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		old, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+		if err != nil {
+			return err
+		}
+		cfg, err := storage.LoadConfig()
+		if err != nil {
+			return err
+		}
+
+		title := strings.Join(args[1:], " ")
+		slugTitle := title
+		if cfg.Slug.Transliterate {
+			slugTitle = felt.Transliterate(slugTitle)
+		}
+		base, err := felt.GenerateID(slugTitle)
+		if err != nil {
+			return err
+		}
+		if i := strings.LastIndex(old.ID, "/"); i >= 0 {
+			base = old.ID[:i] + "/" + base
+		}
+		id, err := storage.AvailableID(base)
+		if err != nil {
+			return err
+		}
+
+		f, err := storage.Supersede(old.ID, id, title, supersedeOutcome, time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Println(f.ID)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(supersedeCmd)
+	supersedeCmd.Flags().StringVarP(&supersedeOutcome, "outcome", "o", "", "Record the new decision and close it")
+}
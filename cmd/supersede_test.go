@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestSupersedeCreatesLinkedDecision(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	closedAt := time.Now().Add(-time.Hour)
+	old := &felt.Felt{ID: "prior-widths", Name: "Prior widths", Status: felt.StatusClosed, ClosedAt: &closedAt, CreatedAt: closedAt, Tags: []string{"decision"}}
+	if err := storage.Write(old); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { supersedeOutcome = "" }()
+
+	out, err := runCommand(t, dir, "supersede", "prior-widths", "Widen the Omega_m prior", "-o", "Flat [0.1, 0.5]")
+	if err != nil {
+		t.Fatalf("supersede: %v\n%s", err, out)
+	}
+	if strings.TrimSpace(out) != "widen-the-omega-m-prior" {
+		t.Fatalf("supersede printed %q, want the new id", out)
+	}
+	f, err := storage.Read("widen-the-omega-m-prior")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.IsClosed() || f.Outcome != "Flat [0.1, 0.5]" {
+		t.Fatalf("new decision = status %q outcome %q, want closed with outcome", f.Status, f.Outcome)
+	}
+
+	reset := saveShowGlobals()
+	defer reset()
+	out, err = runCommand(t, dir, "show", "prior-widths")
+	if err != nil {
+		t.Fatalf("show: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "Superseded by: widen-the-omega-m-prior") {
+		t.Fatalf("show missing superseded-by pointer:\n%s", out)
+	}
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var syncDryRun bool
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Synchronize a store with a peer outside git",
+}
+
+var syncPeerCmd = &cobra.Command{
+	Use:   "peer <remote>",
+	Short: "Two-way merge with another store, field by field",
+	Long: `Merges this store with a peer in both directions, for stores that are not
+kept in git. The remote is a local path (a project directory or its .felt
+directory) or an rsync/ssh location, host:path.
+
+Each field of each fiber merges on its own. A field changed on one side
+since the last sync takes that side's value; a field changed on both takes
+the value from the fiber with the later updated-at — last writer wins, and
+the clash is reported. A fiber deleted on one side is deleted on the other
+unless it was edited there since; deletions are kept as tombstones so a
+stale copy is not resurrected.
+
+The merge base lives in .felt/sync-state.json, per peer. The first sync has
+no base: fields present on one side are kept and differing fields take the
+newer fiber's value.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		local := felt.NewStorage(root)
+		remote := args[0]
+
+		peerRoot, peerKey, push, cleanup, err := openSyncPeer(remote)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		state, err := local.LoadSyncState()
+		if err != nil {
+			return err
+		}
+		peerState := state.Peers[peerKey]
+		if peerState == nil {
+			peerState = &felt.PeerSyncState{}
+			state.Peers[peerKey] = peerState
+		}
+		result, err := felt.SyncPeer(local, felt.NewStorage(peerRoot), peerState, time.Now().UTC().Truncate(time.Second), syncDryRun)
+		if err != nil {
+			return err
+		}
+		if !syncDryRun {
+			if len(result.Pushed) > 0 || len(result.Deleted) > 0 {
+				if err := push(); err != nil {
+					return err
+				}
+			}
+			if err := local.SaveSyncState(state); err != nil {
+				return err
+			}
+		}
+
+		if jsonOutput {
+			return outputJSON(result)
+		}
+		verb := ""
+		if syncDryRun {
+			verb = "would be "
+		}
+		for _, line := range []struct {
+			label string
+			ids   []string
+		}{
+			{"pulled", result.Pulled},
+			{"pushed", result.Pushed},
+			{"deleted", result.Deleted},
+		} {
+			for _, id := range line.ids {
+				fmt.Printf("%s%-8s %s\n", verb, line.label, id)
+			}
+		}
+		for _, c := range result.Conflicts {
+			fmt.Printf("conflict %s (later updated-at won)\n", c)
+		}
+		if len(result.Pulled)+len(result.Pushed)+len(result.Deleted) == 0 {
+			fmt.Printf("Already in sync with %s\n", remote)
+		}
+		return nil
+	},
+}
+
+// syncRsyncExcludes keeps each store's local bookkeeping out of transfers:
+// excluded files are neither copied nor removed by --delete.
+var syncRsyncExcludes = []string{
+	"--exclude=/" + felt.SyncStateName,
+	"--exclude=/" + felt.AccessLogName,
+	"--exclude=/" + felt.IndexName,
+	"--exclude=*.md.lock",
+	"--exclude=/.tx-*/",
+}
+
+// openSyncPeer prepares remote for merging. A local path is merged in place;
+// a host:path remote is pulled into a staging directory with rsync, and push
+// copies the merged staging store back. key identifies the peer in the
+// sync-state file.
+func openSyncPeer(remote string) (root, key string, push func() error, cleanup func(), err error) {
+	noop := func() error { return nil }
+	if isRsyncRemote(remote) {
+		staging, err := os.MkdirTemp("", "felt-sync-")
+		if err != nil {
+			return "", "", nil, nil, err
+		}
+		cleanup = func() { os.RemoveAll(staging) }
+		src := strings.TrimSuffix(remote, "/") + "/" + felt.DirName + "/"
+		dst := filepath.Join(staging, felt.DirName) + string(filepath.Separator)
+		if err := rsync(src, dst); err != nil {
+			cleanup()
+			return "", "", nil, nil, fmt.Errorf("pulling %s: %w", remote, err)
+		}
+		push = func() error {
+			if err := rsync(dst, src); err != nil {
+				return fmt.Errorf("pushing to %s: %w", remote, err)
+			}
+			return nil
+		}
+		return staging, remote, push, cleanup, nil
+	}
+
+	abs, err := filepath.Abs(remote)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	if filepath.Base(abs) == felt.DirName {
+		abs = filepath.Dir(abs)
+	}
+	root, ok, err := felt.ProjectRootAt(abs)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+	if !ok {
+		return "", "", nil, nil, fmt.Errorf("no .felt directory in %s", abs)
+	}
+	return root, root, noop, func() {}, nil
+}
+
+// isRsyncRemote reports whether remote is host:path rather than a local
+// path: a colon before any slash, and no local file of that name.
+func isRsyncRemote(remote string) bool {
+	colon := strings.Index(remote, ":")
+	if colon <= 0 {
+		return false
+	}
+	if slash := strings.Index(remote, "/"); slash >= 0 && slash < colon {
+		return false
+	}
+	_, err := os.Stat(remote)
+	return err != nil
+}
+
+func rsync(src, dst string) error {
+	args := append([]string{"-a", "--delete"}, syncRsyncExcludes...)
+	args = append(args, src, dst)
+	c := exec.Command("rsync", args...)
+	c.Stderr = os.Stderr
+	return c.Run()
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.AddCommand(syncPeerCmd)
+	syncPeerCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Report what would change without writing either side")
+}
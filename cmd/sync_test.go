@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestSyncPeerLocalPath(t *testing.T) {
+	localDir, peerDir := t.TempDir(), t.TempDir()
+	created := mustParseTime(t, "2026-04-10T09:00:00Z")
+	for _, store := range []struct {
+		dir string
+		f   *felt.Felt
+	}{
+		{localDir, &felt.Felt{ID: "mine", Name: "Mine", Status: felt.StatusOpen, CreatedAt: created, Body: "local notes"}},
+		{peerDir, &felt.Felt{ID: "theirs", Name: "Theirs", CreatedAt: created}},
+	} {
+		s := felt.NewStorage(store.dir)
+		if err := s.Init(); err != nil {
+			t.Fatalf("Init() error: %v", err)
+		}
+		if err := s.Write(store.f); err != nil {
+			t.Fatalf("Write(%s): %v", store.f.ID, err)
+		}
+	}
+	prevDry, prevJSON := syncDryRun, jsonOutput
+	defer func() { syncDryRun, jsonOutput = prevDry, prevJSON }()
+	syncDryRun, jsonOutput = false, false
+
+	out, err := runCommand(t, localDir, "sync", "peer", peerDir)
+	if err != nil {
+		t.Fatalf("sync peer: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "pulled   theirs") || !strings.Contains(out, "pushed   mine") {
+		t.Fatalf("first sync output:\n%s", out)
+	}
+	if f, err := felt.NewStorage(peerDir).Read("mine"); err != nil || f.Body != "local notes" {
+		t.Fatalf("peer copy of mine = %+v, %v", f, err)
+	}
+
+	// Round-tripped fibers are stable: syncing again changes nothing.
+	out, err = runCommand(t, localDir, "sync", "peer", peerDir)
+	if err != nil || !strings.Contains(out, "Already in sync") {
+		t.Fatalf("second sync = %v\n%s", err, out)
+	}
+	state, err := felt.NewStorage(localDir).LoadSyncState()
+	if err != nil || len(state.Peers) != 1 {
+		t.Fatalf("sync state = %+v, %v", state, err)
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var targetsFor string
+
+var targetsCmd = &cobra.Command{
+	Use:   "targets [id] [--for <rule>]",
+	Short: "Map fibers to the workflow rules they own",
+	Long: `Looks up the targets: field, which associates fibers with workflow rules —
+snakemake rule names, make targets, or glob patterns over them:
+
+  targets: [fit_cosebis, plots/cosebis_*.pdf]
+
+  felt targets <id>              the rules a fiber owns
+  felt targets --for <rule>      the fibers owning a rule, unfinished first
+                                 ("which open fiber owns this failing rule?")`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeFirstFiberID,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if (len(args) == 1) == (targetsFor != "") {
+			return fmt.Errorf("give either a fiber id or --for <rule>")
+		}
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+
+		if targetsFor == "" {
+			f, err := storage.FindMetadataInScope(resolveCommandScope(root), args[0])
+			if err != nil {
+				return err
+			}
+			targets := f.Targets()
+			if jsonOutput {
+				if targets == nil {
+					targets = []string{}
+				}
+				return outputJSON(targets)
+			}
+			for _, t := range targets {
+				fmt.Println(t)
+			}
+			return nil
+		}
+
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+		owners := felt.FibersForTarget(felts, targetsFor)
+		if jsonOutput {
+			if owners == nil {
+				owners = []*felt.Felt{}
+			}
+			return outputJSON(owners)
+		}
+		if len(owners) == 0 {
+			return fmt.Errorf("no fiber owns target %q", targetsFor)
+		}
+		for _, f := range owners {
+			fmt.Print(formatFeltTwoLine(f))
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(targetsCmd)
+	targetsCmd.Flags().StringVar(&targetsFor, "for", "", "List the fibers owning this rule or target")
+}
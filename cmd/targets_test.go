@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestTargetsLookupsBothWays(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	for _, c := range []struct {
+		id, status string
+		targets    any
+	}{
+		{"old-plots", felt.StatusClosed, "plots/*.pdf"},
+		{"plots", felt.StatusOpen, []string{"fit_cosebis", "plots/*.pdf"}},
+	} {
+		f := &felt.Felt{ID: c.id, Name: c.id, Status: c.status, CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}
+		if err := f.SetExtraField(felt.TargetsKey, c.targets); err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", c.id, err)
+		}
+	}
+
+	prevFor, prevJSON := targetsFor, jsonOutput
+	defer func() { targetsFor, jsonOutput = prevFor, prevJSON }()
+	targetsFor, jsonOutput = "", false
+
+	out, err := runCommand(t, dir, "targets", "plots")
+	if err != nil || out != "fit_cosebis\nplots/*.pdf\n" {
+		t.Fatalf("targets plots = %q, %v", out, err)
+	}
+
+	out, err = runCommand(t, dir, "targets", "--for", "plots/cosebis.pdf")
+	if err != nil {
+		t.Fatalf("targets --for: %v\n%s", err, out)
+	}
+	if open, closed := strings.Index(out, "○ plots"), strings.Index(out, "● old-plots"); open < 0 || closed < open {
+		t.Fatalf("want the open owner before the closed one:\n%s", out)
+	}
+	if _, err := runCommand(t, dir, "targets", "--for", "unknown_rule"); err == nil {
+		t.Fatalf("unowned rule should error")
+	}
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+// telemetryFileEnv overrides the telemetry file location.
+const telemetryFileEnv = "FELT_TELEMETRY_FILE"
+
+// telemetrySizeEvery is how often the store's size bucket is re-measured:
+// counting fibers costs a directory walk, so it is sampled, not taken on
+// every command.
+const telemetrySizeEvery = 24 * time.Hour
+
+// telemetryReport is the whole of what telemetry keeps: counts only. No
+// arguments, ids, paths, fiber content, or error text are ever recorded,
+// and nothing leaves the machine — `felt telemetry status --json` prints the
+// report for a user to share if they choose.
+type telemetryReport struct {
+	Enabled  bool           `json:"enabled"`
+	Since    time.Time      `json:"since,omitempty"`
+	Commands map[string]int `json:"commands,omitempty"`
+	Errors   map[string]int `json:"errors,omitempty"`
+	// RepoSizes counts sampled store sizes by bucket ("1-10", "11-100", ...).
+	RepoSizes map[string]int `json:"repo_sizes,omitempty"`
+	SizedAt   time.Time      `json:"sized_at,omitempty"`
+}
+
+// telemetryFilePath is FELT_TELEMETRY_FILE if set, else
+// <user config dir>/felt/telemetry.json.
+func telemetryFilePath() (string, error) {
+	if v := strings.TrimSpace(os.Getenv(telemetryFileEnv)); v != "" {
+		return v, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "felt", "telemetry.json"), nil
+}
+
+func loadTelemetry() (*telemetryReport, string, error) {
+	path, err := telemetryFilePath()
+	if err != nil {
+		return nil, "", err
+	}
+	report := &telemetryReport{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return report, path, nil
+	}
+	if err != nil {
+		return nil, path, err
+	}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, path, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return report, path, nil
+}
+
+func saveTelemetry(report *telemetryReport, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// recordTelemetry counts one finished command when telemetry is on. It is
+// strictly best-effort: a telemetry failure never surfaces to the user.
+func recordTelemetry(c *cobra.Command, runErr error, now time.Time) {
+	if c == nil || isTelemetryCommand(c) {
+		return
+	}
+	report, path, err := loadTelemetry()
+	if err != nil || !report.Enabled {
+		return
+	}
+	if report.Commands == nil {
+		report.Commands = make(map[string]int)
+	}
+	report.Commands[telemetryCommandName(c)]++
+	if runErr != nil {
+		if report.Errors == nil {
+			report.Errors = make(map[string]int)
+		}
+		report.Errors[telemetryErrorCategory(runErr)]++
+	}
+	if now.Sub(report.SizedAt) >= telemetrySizeEvery {
+		if root, err := resolveProjectRoot(); err == nil {
+			if felts, err := felt.NewStorage(root).ListMetadata(); err == nil {
+				if report.RepoSizes == nil {
+					report.RepoSizes = make(map[string]int)
+				}
+				report.RepoSizes[telemetrySizeBucket(len(felts))]++
+				report.SizedAt = now
+			}
+		}
+	}
+	_ = saveTelemetry(report, path)
+}
+
+func isTelemetryCommand(c *cobra.Command) bool {
+	for ; c != nil; c = c.Parent() {
+		if c == telemetryCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// telemetryCommandName is the command path below the root, e.g.
+// "shuttle status" — never its arguments.
+func telemetryCommandName(c *cobra.Command) string {
+	name := strings.TrimSpace(strings.TrimPrefix(c.CommandPath(), rootCmd.Name()))
+	if name == "" {
+		return rootCmd.Name()
+	}
+	return name
+}
+
+// telemetryErrorCategory buckets an error without recording its text.
+func telemetryErrorCategory(err error) string {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "not in a felt repository") || strings.Contains(msg, "no .felt directory"):
+		return "no-repo"
+	case strings.Contains(msg, "ambiguous"):
+		return "ambiguous-id"
+	case strings.Contains(msg, "not found") || strings.Contains(msg, "no fiber") || strings.Contains(msg, "no such file"):
+		return "not-found"
+	case strings.Contains(msg, "unknown command") || strings.Contains(msg, "unknown flag") ||
+		strings.Contains(msg, "accepts ") || strings.Contains(msg, "requires ") || strings.HasPrefix(msg, "usage"):
+		return "usage"
+	case strings.Contains(msg, "parsing") || strings.Contains(msg, "yaml"):
+		return "parse"
+	}
+	return "other"
+}
+
+func telemetrySizeBucket(n int) string {
+	switch {
+	case n == 0:
+		return "0"
+	case n <= 10:
+		return "1-10"
+	case n <= 100:
+		return "11-100"
+	case n <= 1000:
+		return "101-1000"
+	}
+	return "1000+"
+}
+
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Opt-in anonymous usage counts (off by default)",
+	Long: `Opt-in, local-only usage counts that help maintainers prioritize work.
+
+With telemetry on, each command adds one to a per-command count, failures
+add one to a coarse error category (no-repo, not-found, usage, ...), and
+the store's size is sampled daily as a bucket (1-10, 11-100, ...). Nothing
+else is recorded — no arguments, ids, paths, or content — and nothing is
+sent anywhere: status --json prints the report for you to share.
+
+  felt telemetry on        start counting
+  felt telemetry off       stop and delete everything collected
+  felt telemetry status    show whether it is on and what was collected`,
+}
+
+var telemetryOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Start recording anonymous usage counts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, path, err := loadTelemetry()
+		if err != nil {
+			return err
+		}
+		if !report.Enabled {
+			report.Enabled = true
+			report.Since = time.Now().UTC().Truncate(time.Second)
+		}
+		if err := saveTelemetry(report, path); err != nil {
+			return err
+		}
+		fmt.Printf("Telemetry on: counts are kept in %s\n", path)
+		return nil
+	},
+}
+
+var telemetryOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Stop recording and delete collected counts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := telemetryFilePath()
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		fmt.Println("Telemetry off: collected counts deleted")
+		return nil
+	},
+}
+
+var telemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show telemetry state and the collected report",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, _, err := loadTelemetry()
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return outputJSON(report)
+		}
+		if !report.Enabled {
+			fmt.Println("Telemetry off")
+			return nil
+		}
+		fmt.Printf("Telemetry on since %s\n", displayTime(report.Since).Format("2006-01-02"))
+		for _, section := range []struct {
+			title  string
+			counts map[string]int
+		}{
+			{"Commands", report.Commands},
+			{"Errors", report.Errors},
+			{"Repo sizes", report.RepoSizes},
+		} {
+			if len(section.counts) == 0 {
+				continue
+			}
+			fmt.Printf("\n%s:\n", section.title)
+			keys := make([]string, 0, len(section.counts))
+			for k := range section.counts {
+				keys = append(keys, k)
+			}
+			sort.Slice(keys, func(i, j int) bool {
+				if section.counts[keys[i]] != section.counts[keys[j]] {
+					return section.counts[keys[i]] > section.counts[keys[j]]
+				}
+				return keys[i] < keys[j]
+			})
+			for _, k := range keys {
+				fmt.Printf("  %6d  %s\n", section.counts[k], k)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(telemetryCmd)
+	telemetryCmd.AddCommand(telemetryOnCmd)
+	telemetryCmd.AddCommand(telemetryOffCmd)
+	telemetryCmd.AddCommand(telemetryStatusCmd)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestTelemetryOptInCountsWithoutDetail(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "secret-plan", Name: "Secret plan", CreatedAt: mustParseTime(t, "2026-04-10T09:00:00Z")}); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(telemetryFileEnv, filepath.Join(t.TempDir(), "telemetry.json"))
+	prevJSON, prevDir := jsonOutput, changeDir
+	defer func() { jsonOutput, changeDir = prevJSON, prevDir }()
+	jsonOutput = false
+	now := time.Now()
+
+	// Off by default: nothing is recorded.
+	recordTelemetry(showCmd, nil, now)
+	if report, _, err := loadTelemetry(); err != nil || report.Enabled || len(report.Commands) != 0 {
+		t.Fatalf("report before opt-in = %+v, %v", report, err)
+	}
+
+	if out, err := runCommand(t, dir, "telemetry", "on"); err != nil {
+		t.Fatalf("telemetry on: %v\n%s", err, out)
+	}
+	changeDir = dir
+	recordTelemetry(showCmd, errors.New(`fiber "secret-plan" not found`), now)
+	recordTelemetry(statusCmd, nil, now)
+	recordTelemetry(telemetryStatusCmd, nil, now)
+	changeDir = prevDir
+
+	report, path, err := loadTelemetry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Commands["show"] != 1 || report.Commands["shuttle status"] != 1 || len(report.Commands) != 2 {
+		t.Fatalf("commands = %v", report.Commands)
+	}
+	if report.Errors["not-found"] != 1 || report.RepoSizes["1-10"] != 1 {
+		t.Fatalf("errors = %v, sizes = %v", report.Errors, report.RepoSizes)
+	}
+
+	out, err := runCommand(t, dir, "telemetry", "status")
+	if err != nil || !strings.Contains(out, "shuttle status") || strings.Contains(out, "secret-plan") {
+		t.Fatalf("status = %v\n%s", err, out)
+	}
+
+	if _, err := runCommand(t, dir, "telemetry", "off"); err != nil {
+		t.Fatal(err)
+	}
+	if report, _, err := loadTelemetry(); err != nil || report.Enabled {
+		t.Fatalf("report after off = %+v, %v (file %s)", report, err, path)
+	}
+}
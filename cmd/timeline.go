@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var timelineDays int
+
+var timelineCmd = &cobra.Command{
+	Use:   "timeline",
+	Short: "Chronological feed of fiber activity",
+	Long: `Interleaves creations, activations, edits, and closures across all
+fibers into one chronological feed, grouped by day — a replay of what
+happened while you were away.
+
+Events come from frontmatter timestamps (created-at, activated-at,
+updated-at, closed-at), so they survive git sync. Only a fiber's latest
+activation is recorded, and an edit at the same moment as another event
+is folded into it.
+
+  felt timeline               last 14 days
+  felt timeline --days 3      last 3 days`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		if timelineDays < 1 {
+			return fmt.Errorf("--days must be at least 1")
+		}
+
+		storage := felt.NewStorage(root)
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		since := now.AddDate(0, 0, -timelineDays)
+		events := felt.Timeline(felts, since)
+		if jsonOutput {
+			if events == nil {
+				events = []felt.TimelineEvent{}
+			}
+			return outputJSON(events)
+		}
+		fmt.Print(renderTimeline(events, timelineDays))
+		return nil
+	},
+}
+
+func renderTimeline(events []felt.TimelineEvent, days int) string {
+	if len(events) == 0 {
+		return fmt.Sprintf("No activity in the last %d %s\n", days, pluralize(days, "day", "days"))
+	}
+	var sb strings.Builder
+	day := ""
+	for _, ev := range events {
+		at := displayTime(ev.At)
+		if d := at.Format("2006-01-02 Mon"); d != day {
+			if day != "" {
+				sb.WriteString("\n")
+			}
+			day = d
+			sb.WriteString(d + "\n")
+		}
+		fmt.Fprintf(&sb, "  %s  %s %-9s %s — %s", at.Format("15:04"), timelineIcon(ev.Kind), ev.Kind, ev.ID, ev.Name)
+		if ev.Detail != "" {
+			fmt.Fprintf(&sb, " → %s", ev.Detail)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// timelineIcon maps an event to the status glyph it moves the fiber into;
+// an edit keeps the neutral dot.
+func timelineIcon(kind string) string {
+	switch kind {
+	case felt.TimelineCreated:
+		return statusIcon(felt.StatusOpen)
+	case felt.TimelineActivated:
+		return statusIcon(felt.StatusActive)
+	case felt.TimelineClosed:
+		return statusIcon(felt.StatusClosed)
+	default:
+		return statusIcon("")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(timelineCmd)
+	timelineCmd.Flags().IntVar(&timelineDays, "days", 14, "How many days back to replay")
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestTimelineReplaysRecentActivity(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	now := time.Now()
+	closedAt := now.Add(-2 * time.Hour)
+	for _, f := range []*felt.Felt{
+		{ID: "ancient", Name: "Ancient", Status: felt.StatusOpen, CreatedAt: now.AddDate(0, 0, -40)},
+		{ID: "fresh", Name: "Fresh fit", Status: felt.StatusClosed, Outcome: "Chains converged", CreatedAt: now.AddDate(0, 0, -2), ClosedAt: &closedAt},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write(%s) error: %v", f.ID, err)
+		}
+	}
+
+	prevDays, prevJSON := timelineDays, jsonOutput
+	defer func() { timelineDays, jsonOutput = prevDays, prevJSON }()
+	timelineDays, jsonOutput = 14, false
+
+	out, err := runCommand(t, dir, "timeline")
+	if err != nil {
+		t.Fatalf("timeline: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "ancient") {
+		t.Fatalf("event outside --days window shown:\n%s", out)
+	}
+	created := strings.Index(out, "○ created   fresh — Fresh fit")
+	closed := strings.Index(out, "● closed    fresh — Fresh fit → Chains converged")
+	if created < 0 || closed < created {
+		t.Fatalf("want created then closed for fresh:\n%s", out)
+	}
+
+	out, err = runCommand(t, dir, "timeline", "--days", "1")
+	if err != nil {
+		t.Fatalf("timeline --days 1: %v\n%s", err, out)
+	}
+	if strings.Contains(out, "created") || !strings.Contains(out, "closed") {
+		t.Fatalf("--days 1 should keep only the close:\n%s", out)
+	}
+}
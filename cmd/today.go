@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "Show today's log fiber, creating it on first use",
+	Long: `Shows the day's log fiber, log/<date>, creating it if this is the day's
+first use. The log is a statusless note tagged daily-log and
+daily:<date>, so it sits beside the task graph without joining it, and
+felt ls -t daily: lists every day's.
+
+felt today log appends a timestamped entry. With hook.digest: true in
+.felt/config.yaml, each agent session that changed fibers also appends a
+section listing them (see felt hook session-end), so the journal and the
+work it touched read together.`,
+	Example: `  felt today
+  felt today log "Mocks look off at high ell; see [[mocks]]"
+  felt ls -t daily: -s all`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		now := time.Now()
+		f, err := storage.Read(felt.DailyLogID(now, displayTime(now).Location()))
+		if err != nil {
+			if f, err = updateDailyLog(storage, now, nil); err != nil {
+				return err
+			}
+		}
+		if jsonOutput {
+			return outputJSON(f)
+		}
+		fmt.Print(renderFelt(f, graphForBodyRefs(storage, f), DepthFull, nil, nil, upstreamNotes{}))
+		return nil
+	},
+}
+
+var todayLogCmd = &cobra.Command{
+	Use:   "log <text>",
+	Short: "Append a timestamped entry to today's log fiber",
+	Long: `Appends "- 15:04 <text>" to the day's log fiber, creating the fiber on
+the day's first entry. Wiki links like [[fiber-id]] in the text link the
+entry to the work it is about.`,
+	Example:      `  felt today log "Switched the fit to the Gaussian prior"`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		text := strings.TrimSpace(strings.Join(args, " "))
+		if text == "" {
+			return fmt.Errorf("log entry cannot be empty")
+		}
+		now := time.Now()
+		loc := displayTime(now).Location()
+		f, err := updateDailyLog(felt.NewStorage(root), now, func(f *felt.Felt) {
+			felt.AppendDailyLogEntry(f, text, now, loc)
+		})
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return outputJSON(f)
+		}
+		fmt.Printf("Logged to %s\n", f.ID)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(todayCmd)
+	todayCmd.AddCommand(todayLogCmd)
+}
+
+// updateDailyLog applies change to the log fiber of the day at falls on,
+// under its lock, creating the fiber on the day's first change. A nil
+// change only ensures the fiber exists.
+func updateDailyLog(storage *felt.Storage, at time.Time, change func(*felt.Felt)) (*felt.Felt, error) {
+	loc := displayTime(at).Location()
+	id := felt.DailyLogID(at, loc)
+	unlock, err := storage.LockFiber(id)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	f, err := storage.Read(id)
+	if err != nil {
+		if f, err = felt.NewDailyLog(at, loc); err != nil {
+			return nil, err
+		}
+		f.CreatedAt = at
+	} else if change == nil {
+		return f, nil // created meanwhile
+	}
+	// Logs written before the daily: tag existed pick it up on their next change.
+	f.AddTag(felt.DailyTag(at, loc))
+	if change != nil {
+		change(f)
+	}
+	f.Touch(at)
+	if err := storage.Write(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
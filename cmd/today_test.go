@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestTodayLogAppendsToTheDailyFiber(t *testing.T) {
+	jsonOutput = false
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, text := range []string{"Started [[fit]]", "Prior looks wrong"} {
+		out, err := runCommand(t, dir, "today", "log", text)
+		if err != nil || !strings.HasPrefix(out, "Logged to log/") {
+			t.Fatalf("today log: %v\n%s", err, out)
+		}
+	}
+	now := time.Now()
+	log, err := storage.Read(felt.DailyLogID(now, displayTime(now).Location()))
+	if err != nil {
+		t.Fatalf("reading daily log: %v", err)
+	}
+	if !log.HasTag(felt.DailyTag(now, displayTime(now).Location())) || log.HasStatus() {
+		t.Fatalf("daily log = tags %v status %q", log.Tags, log.Status)
+	}
+	if lines := strings.Split(log.Body, "\n"); len(lines) != 2 || !strings.HasSuffix(lines[0], " Started [[fit]]") || !strings.HasSuffix(lines[1], " Prior looks wrong") {
+		t.Fatalf("daily log body:\n%s", log.Body)
+	}
+
+	out, err := runCommand(t, dir, "today")
+	if err != nil || !strings.Contains(out, log.Name) || !strings.Contains(out, "Prior looks wrong") {
+		t.Fatalf("today: %v\n%s", err, out)
+	}
+}
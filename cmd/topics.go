@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	indexMinFibers int
+	indexTags      []string
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Write an alphabetical topic index of the store as markdown",
+	Long: `Builds an alphabetical topic index, the back-of-the-book kind, and writes
+it as markdown: a heading per letter, and under it one entry per tag and
+per word used in the names of several fibers (--min, default 2), each
+listing its fibers as [[id]] wiki links. Tags are set as code to tell
+them from title words. Common words and daily log fibers are left out.
+
+-t narrows the index to fibers with a tag (trailing colon for a prefix).`,
+	Example: `  felt index > site/index.md
+  felt index -t thread: --min 3`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		if indexMinFibers < 1 {
+			return fmt.Errorf("--min must be at least 1")
+		}
+		felts, err := felt.NewStorage(root).ListMetadata()
+		if err != nil {
+			return err
+		}
+		if len(indexTags) > 0 {
+			felts = filterByTags(felts, indexTags)
+		}
+		entries := felt.TopicIndex(felts, indexMinFibers)
+		if jsonOutput {
+			if entries == nil {
+				entries = []felt.TopicEntry{}
+			}
+			return outputJSON(entries)
+		}
+		fmt.Print(felt.TopicIndexMarkdown(entries))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+	indexCmd.Flags().IntVar(&indexMinFibers, "min", felt.DefaultTopicMinFibers, "List a title word once this many fibers' names use it")
+	indexCmd.Flags().StringArrayVarP(&indexTags, "tag", "t", nil, "Only index fibers with this tag (repeatable, AND; trailing colon for prefix)")
+	registerTagCompletion(indexCmd, "tag")
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestIndexWritesTopicsForTaggedFibers(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	now := time.Now()
+	for _, f := range []*felt.Felt{
+		{ID: "psf-fit", Name: "Fit the PSF", Tags: []string{"thread:psf"}, CreatedAt: now},
+		{ID: "psf-check", Name: "Check the PSF", Tags: []string{"thread:psf"}, CreatedAt: now.Add(time.Minute)},
+		{ID: "bao", Name: "BAO PSF aside", Tags: []string{"thread:bao"}, CreatedAt: now},
+	} {
+		if err := storage.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	defer func() { indexTags, indexMinFibers, jsonOutput = nil, felt.DefaultTopicMinFibers, false }()
+	jsonOutput = false
+
+	out, err := runCommand(t, dir, "index", "-t", "thread:psf")
+	if err != nil {
+		t.Fatalf("index: %v\n%s", err, out)
+	}
+	want := "# Index\n\n## P\n\n- psf — [[psf-fit]] Fit the PSF; [[psf-check]] Check the PSF\n\n" +
+		"## T\n\n- `thread:psf` — [[psf-fit]] Fit the PSF; [[psf-check]] Check the PSF\n"
+	if out != want {
+		t.Fatalf("index =\n%s\nwant\n%s", out, want)
+	}
+	if strings.Contains(out, "bao") {
+		t.Fatal("-t should leave other threads out")
+	}
+}
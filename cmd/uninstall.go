@@ -8,16 +8,18 @@ import (
 )
 
 // uninstallCmd is the inverse of `felt setup`: removes the felt plugin from
-// Claude Code and Codex (whichever are installed and have felt wired up).
+// Claude Code, Codex, and Gemini CLI (whichever are installed and have felt
+// wired up).
 // Doesn't touch the felt binary itself — removal of that depends on how it
 // was installed (brew, curl, go install), so we just print the relevant
 // hint instead of guessing.
 var uninstallCmd = &cobra.Command{
 	Use:   "uninstall",
-	Short: "Remove the felt agent plugins (Claude Code, Codex)",
-	Long: `Remove the felt plugin from Claude Code and Codex.
+	Short: "Remove the felt agent plugins (Claude Code, Codex, Gemini CLI)",
+	Long: `Remove the felt plugin from Claude Code, Codex, and Gemini CLI.
 
-The inverse of ` + "`felt setup claude`" + ` and ` + "`felt setup codex`" + `. Idempotent:
+The inverse of ` + "`felt setup claude`" + `, ` + "`felt setup codex`" + `, and
+` + "`felt setup gemini`" + `. Idempotent:
 running it when no plugins are installed is a no-op. Leaves the felt
 binary in place — to remove that:
 
@@ -55,6 +57,15 @@ func runFeltUninstall() {
 		fmt.Println()
 	}
 
+	if feltGeminiInstalled() {
+		fmt.Println("Removing Gemini CLI integration...")
+		if err := uninstallGemini(); err != nil {
+			fmt.Printf("warning: %v\n", err)
+		}
+		removedAnything = true
+		fmt.Println()
+	}
+
 	if !removedAnything {
 		fmt.Println("No felt agent plugins detected — nothing to remove.")
 		fmt.Println()
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+var watchFormat string
+
+// watchDebounce is how long watch waits after a file event before redrawing,
+// so one felt command's burst of writes (fiber file, lock sidecar, rename
+// into place) draws once.
+const watchDebounce = 150 * time.Millisecond
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Keep the ready list or graph on screen, redrawn as fibers change",
+	Long: `Draws the ready queue — open fibers whose data-flow inputs have all closed —
+and redraws it whenever a fiber file under .felt/ changes, for a terminal
+pane kept live while an agent works. Runs until interrupted.
+
+--format mermaid draws the data-flow graph as a Mermaid flowchart instead:
+every tracked fiber, with an edge from each upstream to the fiber that
+reads it. On a terminal each redraw replaces the last; piped, redraws are
+separated by a blank line.`,
+	Example: `  felt watch
+  felt watch --format mermaid > graph.mmd`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		render, err := watchRenderer(watchFormat)
+		if err != nil {
+			return err
+		}
+		clear := false
+		if stat, err := os.Stdout.Stat(); err == nil && (stat.Mode()&os.ModeCharDevice) != 0 {
+			clear = true
+		}
+		return runWatch(cmd.Context(), felt.NewStorage(root), render, os.Stdout, clear)
+	},
+}
+
+func init() {
+	watchCmd.Flags().StringVar(&watchFormat, "format", "text", "What to draw: text (the ready list) or mermaid (the data-flow graph)")
+	rootCmd.AddCommand(watchCmd)
+}
+
+func watchRenderer(format string) (func(*felt.Graph, time.Time) string, error) {
+	switch format {
+	case "text", "":
+		return renderWatchReady, nil
+	case "mermaid":
+		return func(g *felt.Graph, _ time.Time) string { return renderMermaidGraph(g) }, nil
+	}
+	return nil, fmt.Errorf("invalid --format %q (valid: text, mermaid)", format)
+}
+
+// runWatch draws render's view of the store to w, then redraws it after
+// each burst of fiber file changes until ctx ends. The graph is refreshed
+// by modification time, as felt serve does, so a redraw re-reads only the
+// fibers that changed.
+func runWatch(ctx context.Context, storage *felt.Storage, render func(*felt.Graph, time.Time) string, w io.Writer, clear bool) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("starting file watcher: %w", err)
+	}
+	defer watcher.Close()
+	if err := watchStoreDirs(watcher, filepath.Join(storage.ProjectRoot(), felt.DirName)); err != nil {
+		return err
+	}
+
+	felts, err := storage.ListMetadataWithModTime()
+	if err != nil {
+		return err
+	}
+	graph := felt.NewGraph(felts)
+	drawn := false
+	draw := func() {
+		switch {
+		case clear:
+			fmt.Fprint(w, "\033[H\033[2J")
+		case drawn:
+			fmt.Fprintln(w)
+		}
+		fmt.Fprint(w, render(graph, time.Now()))
+		drawn = true
+	}
+	draw()
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			refresh := strings.HasSuffix(ev.Name, felt.FileExt) || ev.Has(fsnotify.Remove) || ev.Has(fsnotify.Rename)
+			if ev.Has(fsnotify.Create) {
+				if info, err := os.Stat(ev.Name); err == nil && info.IsDir() {
+					if err := watchStoreDirs(watcher, ev.Name); err != nil {
+						return err
+					}
+					// A new fiber's file can land before its directory is
+					// watched, so the directory itself is the change.
+					refresh = true
+				}
+			}
+			if refresh {
+				pending = time.After(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			return fmt.Errorf("watching %s: %w", felt.DirName, err)
+		case <-pending:
+			pending = nil
+			changed, err := storage.RefreshGraph(graph)
+			if err != nil {
+				return err
+			}
+			if changed {
+				draw()
+			}
+		}
+	}
+}
+
+// watchStoreDirs watches dir and every directory below it, except the ones
+// felt keeps its own bookkeeping in: transaction staging and the hook log.
+// fsnotify watches are not recursive.
+func watchStoreDirs(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil // removed between the event and the walk
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if name := d.Name(); name != felt.DirName && (strings.HasPrefix(name, ".") || name == felt.HookLogDirName) {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// renderWatchReady renders the ready queue, oldest first, under a heading
+// that says when it was drawn.
+func renderWatchReady(g *felt.Graph, now time.Time) string {
+	ready := felt.ReadyFelts(g.Felts(), now)
+	sortFibersByCreatedAt(ready)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Ready: %d %s · %s\n", len(ready), pluralize(len(ready), "fiber", "fibers"), displayTime(now).Format("15:04:05"))
+	if len(ready) == 0 {
+		sb.WriteString("Nothing ready to pick up\n")
+	}
+	for _, f := range ready {
+		fmt.Fprintf(&sb, "%s %s — %s\n", fiberIcon(f), f.ID, f.DisplayName())
+	}
+	return sb.String()
+}
+
+// renderMermaidGraph renders the tracked fibers and their data-flow edges
+// as a Mermaid flowchart. Node names are positional, since fiber ids hold
+// characters Mermaid ids cannot; labels carry the id and name.
+func renderMermaidGraph(g *felt.Graph) string {
+	felts := g.Felts()
+	node := make(map[string]string)
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, f := range felts {
+		if !f.HasStatus() && len(g.Upstreams(f.ID)) == 0 && len(g.Downstreams(f.ID)) == 0 {
+			continue // an untracked note outside the data flow
+		}
+		name := fmt.Sprintf("n%d", len(node))
+		node[f.ID] = name
+		status := f.Status
+		if status == "" {
+			status = "note"
+		}
+		fmt.Fprintf(&sb, "  %s[\"%s — %s\"]:::%s\n", name, mermaidLabel(f.ID), mermaidLabel(f.DisplayName()), status)
+	}
+	for _, f := range felts {
+		for _, up := range g.Upstreams(f.ID) {
+			if node[up] != "" && node[f.ID] != "" {
+				fmt.Fprintf(&sb, "  %s --> %s\n", node[up], node[f.ID])
+			}
+		}
+	}
+	sb.WriteString("  classDef open stroke-dasharray: 4 2\n")
+	sb.WriteString("  classDef active stroke-width:3px\n")
+	sb.WriteString("  classDef closed fill:#e6e6e6,color:#666\n")
+	sb.WriteString("  classDef note stroke:#999\n")
+	return sb.String()
+}
+
+// mermaidLabel escapes text for a quoted Mermaid label.
+func mermaidLabel(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestRenderMermaidGraphDrawsDataFlowEdges(t *testing.T) {
+	fit := &felt.Felt{ID: "fit/model", Name: `Fit "the" model`, Status: felt.StatusActive}
+	if err := fit.AddDataFlowInput("prep"); err != nil {
+		t.Fatal(err)
+	}
+	g := felt.NewGraph([]*felt.Felt{
+		fit,
+		{ID: "prep", Name: "Prep", Status: felt.StatusClosed},
+		{ID: "aside", Name: "Aside"},
+	})
+
+	got := renderMermaidGraph(g)
+	for _, want := range []string{
+		"flowchart LR\n",
+		`  n0["fit/model — Fit #quot;the#quot; model"]:::active` + "\n",
+		`  n1["prep — Prep"]:::closed` + "\n",
+		"  n1 --> n0\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("mermaid missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "aside") {
+		t.Fatalf("an untracked note outside the data flow was drawn:\n%s", got)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe to read while runWatch writes it.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchRedrawsReadyListWhenAFiberChanges(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := storage.Write(&felt.Felt{ID: "prep", Name: "Prep", Status: felt.StatusOpen, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan error, 1)
+	go func() { done <- runWatch(ctx, storage, renderWatchReady, &out, false) }()
+	waitFor := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(out.String(), want) {
+			if time.Now().After(deadline) {
+				cancel()
+				t.Fatalf("watch output never showed %q:\n%s", want, out.String())
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	waitFor("Ready: 1 fiber")
+	waitFor("prep — Prep\n")
+
+	if err := storage.Write(&felt.Felt{ID: "fit", Name: "Fit", Status: felt.StatusOpen, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("Ready: 2 fibers")
+	waitFor("fit — Fit\n")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("runWatch: %v", err)
+	}
+}
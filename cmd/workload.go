@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/spf13/cobra"
+)
+
+var workloadCmd = &cobra.Command{
+	Use:   "workload [owner]",
+	Short: "Summarize open work per owner and flag who is overloaded",
+	Long: `Lists each owner's unfinished work: active and open fibers, the estimated
+effort they add up to, and the nearest due dates. Owners come from the
+owner: field (felt add/edit --owner); fibers nobody owns are grouped last.
+Naming an owner shows just theirs: "me" means $FELT_OWNER, else
+owner.default; "none" means unowned.
+
+An owner is flagged as overloaded when:
+  - any of their fibers is past due,
+  - they have more fibers active than owner.max-active, or
+  - their estimated work due by some date exceeds capacity.daily for each
+    day from today through it.
+
+The last two are checked only when set in .felt/config.yaml. Estimates are
+span estimate: fields; fibers estimated in points or not at all are counted
+as unestimated.`,
+	Example: `  felt workload
+  felt workload me
+  felt workload alice -j`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, err := resolveProjectRoot()
+		if err != nil {
+			return fmt.Errorf("not in a felt repository")
+		}
+		storage := felt.NewStorage(root)
+		cfg, err := storage.LoadConfig()
+		if err != nil {
+			return err
+		}
+		daily, _, err := cfg.DailyCapacity()
+		if err != nil {
+			return err
+		}
+		owner := ""
+		if len(args) == 1 {
+			if owner, err = resolveOwnerFlag(storage, args[0]); err != nil {
+				return err
+			}
+		}
+		felts, err := storage.ListMetadata()
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		workloads := felt.Workloads(felts, cfg.Owner.MaxActive, daily, now, displayTime(now).Location())
+		if owner != "" {
+			var mine []felt.Workload
+			for _, w := range workloads {
+				if (owner == "none" && w.Owner == "") || w.Owner == owner {
+					mine = append(mine, w)
+				}
+			}
+			workloads = mine
+		}
+		if jsonOutput {
+			if workloads == nil {
+				workloads = []felt.Workload{}
+			}
+			return outputJSON(workloads)
+		}
+		if len(workloads) == 0 {
+			fmt.Println("No open work")
+			return nil
+		}
+		fmt.Print(renderWorkloads(workloads))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(workloadCmd)
+}
+
+// renderWorkloads prints one block per owner: counts and estimated effort,
+// the nearest due dates, and what makes the owner overloaded.
+func renderWorkloads(workloads []felt.Workload) string {
+	var sb strings.Builder
+	for i, w := range workloads {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		owner := w.Owner
+		if owner == "" {
+			owner = "(unowned)"
+		}
+		fmt.Fprintf(&sb, "%s: %d active, %d open", owner, w.Active, w.Open)
+		if w.Estimated > 0 {
+			fmt.Fprintf(&sb, ", %s estimated", felt.FormatSpan(w.Estimated))
+		}
+		if w.Unestimated > 0 {
+			fmt.Fprintf(&sb, " (%d unestimated)", w.Unestimated)
+		}
+		if w.Overloaded() {
+			sb.WriteString("  OVERLOADED")
+		}
+		sb.WriteString("\n")
+		for _, d := range w.Due {
+			fmt.Fprintf(&sb, "  due %s  %s (%s)\n", d.Due, d.Name, d.ID)
+		}
+		if w.Overdue > 0 {
+			fmt.Fprintf(&sb, "  ! %d %s past due\n", w.Overdue, pluralize(w.Overdue, "fiber", "fibers"))
+		}
+		if w.OverActive {
+			fmt.Fprintf(&sb, "  ! %d active, more than owner.max-active\n", w.Active)
+		}
+		if c := w.Overcommit; c != nil {
+			fmt.Fprintf(&sb, "  ! %s estimated due by %s, %s of capacity\n", felt.FormatSpan(c.Estimated), c.By, felt.FormatSpan(c.Capacity))
+		}
+	}
+	return sb.String()
+}
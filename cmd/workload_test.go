@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+func TestWorkloadListsOwnersAndFlagsOverload(t *testing.T) {
+	dir := t.TempDir()
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := os.WriteFile(storage.ConfigPath(), []byte("capacity:\n  daily: 2h\nowner:\n  max-active: 3\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	soon, err := felt.ParseDateExpr("tomorrow", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func(id, owner, estimate string, due *time.Time) {
+		t.Helper()
+		f := &felt.Felt{ID: id, Name: id, Status: felt.StatusOpen, CreatedAt: time.Now(), Due: due}
+		if owner != "" {
+			if err := f.SetOwner(owner); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := f.SetExtraField(felt.EstimateKey, estimate); err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.Write(f); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	write("mocks", "ana", "1d", &soon)
+	write("review", "ben", "1h", nil)
+	write("loose", "", "30m", nil)
+
+	out, err := runCommand(t, dir, "workload")
+	if err != nil {
+		t.Fatalf("workload: %v\n%s", err, out)
+	}
+	for _, want := range []string{
+		"ana: 0 active, 1 open, 1d estimated  OVERLOADED\n",
+		"  due " + soon.Format("2006-01-02") + "  mocks (mocks)\n",
+		"  ! 1d estimated due by " + soon.Format("2006-01-02") + ", 4h of capacity\n",
+		"ben: 0 active, 1 open, 1h estimated\n",
+		"(unowned): 0 active, 1 open, 30m estimated\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("workload missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = runCommand(t, dir, "workload", "ben")
+	if err != nil {
+		t.Fatalf("workload ben: %v\n%s", err, out)
+	}
+	if !strings.Contains(out, "ben:") || strings.Contains(out, "ana:") || strings.Contains(out, "(unowned)") {
+		t.Fatalf("workload ben:\n%s", out)
+	}
+}
@@ -0,0 +1,108 @@
+package felt
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AcceptanceKey is the frontmatter key for a fiber's definition of done: a
+// list of criteria, each a quoted string, "[ ] text" until met and
+// "[x] text" after:
+//
+//	acceptance:
+//	  - "[ ] Covariance matches DES Y3"
+//	  - "[x] Figure 4 regenerated"
+//
+// The quotes are required: unquoted, `- [ ] text` is not valid YAML and the
+// fiber fails to parse. A criterion without a box counts as unmet.
+const AcceptanceKey = "acceptance"
+
+// Criterion is one acceptance criterion.
+type Criterion struct {
+	Text string `json:"text"`
+	Met  bool   `json:"met"`
+}
+
+// String renders c as it is written in frontmatter.
+func (c Criterion) String() string {
+	if c.Met {
+		return "[x] " + c.Text
+	}
+	return "[ ] " + c.Text
+}
+
+// parseCriterion reads a criterion, with or without its checkbox.
+func parseCriterion(s string) Criterion {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "[x]"), strings.HasPrefix(s, "[X]"):
+		return Criterion{Text: strings.TrimSpace(s[3:]), Met: true}
+	case strings.HasPrefix(s, "[ ]"):
+		return Criterion{Text: strings.TrimSpace(s[3:])}
+	}
+	return Criterion{Text: s}
+}
+
+// Acceptance returns f's acceptance criteria in order, or nil when the field
+// is absent or is not a list of strings; `felt check` reports the malformed
+// case.
+func (f *Felt) Acceptance() []Criterion {
+	node := extraFieldNode(f.ExtraFields, AcceptanceKey)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	out := make([]Criterion, 0, len(node.Content))
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return nil
+		}
+		out = append(out, parseCriterion(item.Value))
+	}
+	return out
+}
+
+// SetAcceptance replaces f's acceptance criteria; an empty list clears them.
+func (f *Felt) SetAcceptance(criteria []Criterion) error {
+	if len(criteria) == 0 {
+		return f.SetExtraField(AcceptanceKey, nil)
+	}
+	items := make([]string, len(criteria))
+	for i, c := range criteria {
+		items[i] = c.String()
+	}
+	return f.SetExtraField(AcceptanceKey, items)
+}
+
+// MarkAcceptance ticks (or, with met false, unticks) criterion n, counted
+// from 1 as felt show numbers them.
+func (f *Felt) MarkAcceptance(n int, met bool) error {
+	criteria := f.Acceptance()
+	if len(criteria) == 0 {
+		return fmt.Errorf("%s has no acceptance criteria", f.ID)
+	}
+	if n < 1 || n > len(criteria) {
+		return fmt.Errorf("%s has %d acceptance %s; there is no %d", f.ID, len(criteria), pluralCriteria(len(criteria)), n)
+	}
+	criteria[n-1].Met = met
+	return f.SetAcceptance(criteria)
+}
+
+// AcceptanceMet counts the met criteria.
+func AcceptanceMet(criteria []Criterion) int {
+	met := 0
+	for _, c := range criteria {
+		if c.Met {
+			met++
+		}
+	}
+	return met
+}
+
+func pluralCriteria(n int) string {
+	if n == 1 {
+		return "criterion"
+	}
+	return "criteria"
+}
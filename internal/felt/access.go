@@ -0,0 +1,143 @@
+package felt
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// AccessLogName is the opt-in read log, `.felt/access.log`: one JSON line per
+// `felt show`, appended when `access.log: true` is set in config.yaml. It is
+// local telemetry, not fiber content, so the store's .gitignore covers it.
+const AccessLogName = "access.log"
+
+// AccessStaleAfter is how long a fiber may go unread before `felt check`
+// warns about it (with the access log enabled).
+const AccessStaleAfter = 30 * 24 * time.Hour
+
+// AccessRecord is one access-log line: which fiber was read, when, and by
+// which session (FELT_SESSION_ID; empty outside an agent session).
+type AccessRecord struct {
+	ID      string    `json:"id"`
+	At      time.Time `json:"at"`
+	Session string    `json:"session,omitempty"`
+}
+
+// AccessLog is the folded access log: each fiber's latest read, and when
+// logging began (the earliest record), which bounds how far back
+// "never read" can be trusted.
+type AccessLog struct {
+	Last  map[string]AccessRecord
+	Since time.Time
+}
+
+// AccessLogPath returns the path of the store's access log.
+func (s *Storage) AccessLogPath() string {
+	return filepath.Join(s.root, AccessLogName)
+}
+
+// RecordAccess appends one record per id to the access log. Each write is a
+// single O_APPEND line, so concurrent readers do not interleave records.
+func (s *Storage) RecordAccess(ids []string, session string, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var buf []byte
+	for _, id := range ids {
+		line, err := json.Marshal(AccessRecord{ID: id, At: at.UTC(), Session: session})
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	ensureGitignoreCovers(s.root, AccessLogName)
+	file, err := os.OpenFile(s.AccessLogPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", AccessLogName, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(buf); err != nil {
+		return fmt.Errorf("writing %s: %w", AccessLogName, err)
+	}
+	return nil
+}
+
+// LoadAccessLog folds the access log into each fiber's latest read. A
+// missing log is an empty AccessLog; a malformed line is skipped, since a
+// torn write must not make every later read unreadable.
+func (s *Storage) LoadAccessLog() (*AccessLog, error) {
+	log := &AccessLog{Last: make(map[string]AccessRecord)}
+	file, err := os.Open(s.AccessLogPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", AccessLogName, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var rec AccessRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil || rec.ID == "" {
+			continue
+		}
+		if log.Since.IsZero() || rec.At.Before(log.Since) {
+			log.Since = rec.At
+		}
+		if prev, ok := log.Last[rec.ID]; !ok || rec.At.After(prev.At) {
+			log.Last[rec.ID] = rec
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", AccessLogName, err)
+	}
+	return log, nil
+}
+
+// LastRead returns when id was last read, if the log has seen it.
+func (l *AccessLog) LastRead(id string) (time.Time, bool) {
+	if l == nil {
+		return time.Time{}, false
+	}
+	rec, ok := l.Last[id]
+	return rec.At, ok
+}
+
+// CheckUnread warns about closed fibers — recorded decisions — that nobody
+// has read in AccessStaleAfter. A fiber only counts once both it and the
+// log are older than the window, so turning the log on does not flag the
+// whole store on day one.
+func CheckUnread(felts []*Felt, log *AccessLog, now time.Time) []CheckIssue {
+	if log == nil || log.Since.IsZero() || now.Sub(log.Since) < AccessStaleAfter {
+		return nil
+	}
+	days := int(AccessStaleAfter / (24 * time.Hour))
+	var issues []CheckIssue
+	for _, f := range felts {
+		if !f.IsClosed() || f.HasShuttleFacet() {
+			continue
+		}
+		since := f.CreatedAt
+		if f.ClosedAt != nil {
+			since = *f.ClosedAt
+		}
+		if now.Sub(since) < AccessStaleAfter {
+			continue
+		}
+		last, ok := log.LastRead(f.ID)
+		if ok && now.Sub(last) < AccessStaleAfter {
+			continue
+		}
+		msg := fmt.Sprintf("not read in %d days", days)
+		if ok {
+			msg = fmt.Sprintf("not read in %d days (last read %s)", days, last.Format("2006-01-02"))
+		}
+		issues = append(issues, CheckIssue{Level: CheckLevelWarning, FiberID: f.ID, Message: msg})
+	}
+	return issues
+}
@@ -0,0 +1,68 @@
+package felt
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAccessLogFoldsLatestRead(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	if err := s.RecordAccess([]string{"a", "b"}, "s1", day(2)); err != nil {
+		t.Fatalf("RecordAccess: %v", err)
+	}
+	if err := s.RecordAccess([]string{"a"}, "s2", day(5)); err != nil {
+		t.Fatalf("RecordAccess: %v", err)
+	}
+	// A torn line must not hide the records around it.
+	f, err := os.OpenFile(s.AccessLogPath(), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("{\"id\":\"c\",\"at\"\n")
+	f.Close()
+
+	log, err := s.LoadAccessLog()
+	if err != nil {
+		t.Fatalf("LoadAccessLog: %v", err)
+	}
+	if got := log.Last["a"]; !got.At.Equal(day(5)) || got.Session != "s2" {
+		t.Fatalf("latest read of a = %+v", got)
+	}
+	if !log.Since.Equal(day(2)) {
+		t.Fatalf("Since = %v, want %v", log.Since, day(2))
+	}
+	if _, ok := log.LastRead("c"); ok {
+		t.Fatalf("malformed line should be skipped")
+	}
+}
+
+func TestCheckUnreadWaitsForLogWindow(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	closedLongAgo := now.AddDate(0, -3, 0)
+	felts := []*Felt{
+		{ID: "read-decision", Name: "Read", Status: StatusClosed, CreatedAt: closedLongAgo, ClosedAt: &closedLongAgo},
+		{ID: "stale-decision", Name: "Stale", Status: StatusClosed, CreatedAt: closedLongAgo, ClosedAt: &closedLongAgo},
+		{ID: "open-task", Name: "Open", Status: StatusOpen, CreatedAt: closedLongAgo},
+	}
+	log := &AccessLog{
+		Last:  map[string]AccessRecord{"read-decision": {ID: "read-decision", At: now.AddDate(0, 0, -3)}},
+		Since: now.AddDate(0, 0, -3),
+	}
+	if issues := CheckUnread(felts, log, now); len(issues) != 0 {
+		t.Fatalf("a log younger than the window should not warn: %v", issues)
+	}
+
+	log.Since = now.AddDate(0, -2, 0)
+	issues := CheckUnread(felts, log, now)
+	if len(issues) != 1 || issues[0].FiberID != "stale-decision" || !strings.Contains(issues[0].Message, "not read in 30 days") {
+		t.Fatalf("issues = %v", issues)
+	}
+}
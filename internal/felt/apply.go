@@ -0,0 +1,330 @@
+package felt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Apply operations.
+const (
+	OpAdd    = "add"
+	OpEdit   = "edit"
+	OpClose  = "close"
+	OpReopen = "reopen"
+	OpTag    = "tag"
+	OpUntag  = "untag"
+	OpLink   = "link"
+	OpDefer  = "defer"
+)
+
+// ApplyOp is one operation in a `felt apply` document. ID names the fiber
+// (for add, the new fiber's id; empty derives it from Name under Parent);
+// IDs applies the same operation to several fibers. The other fields are
+// read by the operations that take them:
+//
+//	add     name, parent, status (empty is open, none a statusless
+//	        note), tags, outcome, body, created, closed
+//	edit    name, outcome, body (appended as a paragraph)
+//	close   outcome
+//	reopen  —
+//	tag     tags
+//	untag   tags
+//	link    from: the fiber (or fiber.output) id reads as an input
+//	defer   until
+type ApplyOp struct {
+	Op      string     `json:"op"`
+	ID      string     `json:"id,omitempty"`
+	IDs     []string   `json:"ids,omitempty"`
+	Name    string     `json:"name,omitempty"`
+	Parent  string     `json:"parent,omitempty"`
+	Status  string     `json:"status,omitempty"`
+	Tags    []string   `json:"tags,omitempty"`
+	Outcome string     `json:"outcome,omitempty"`
+	Body    string     `json:"body,omitempty"`
+	From    string     `json:"from,omitempty"`
+	Until   *time.Time `json:"until,omitempty"`
+	Created *time.Time `json:"created,omitempty"`
+	Closed  *time.Time `json:"closed,omitempty"`
+}
+
+// ApplyOps carries out ops in order as one batch: every operation is
+// applied to an in-memory copy of the store first, so a malformed or
+// conflicting operation anywhere in the list changes nothing on disk. The
+// changed fibers are then written in one transaction, so a failed write
+// puts every file back. It returns the ops as applied — IDs
+// expanded and every id resolved — so callers can report them.
+func (s *Storage) ApplyOps(ops []ApplyOp, now time.Time) ([]ApplyOp, error) {
+	felts, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	b := &applyBatch{
+		byID:    make(map[string]*Felt, len(felts)),
+		taken:   make(map[string]bool, len(felts)),
+		changed: make(map[string]bool),
+		now:     now,
+		cfg:     cfg,
+	}
+	for _, f := range felts {
+		b.byID[f.ID] = f
+		if f.UID != "" {
+			b.taken[strings.ToUpper(f.UID)] = true
+		}
+	}
+
+	var applied []ApplyOp
+	for i, op := range ops {
+		expanded, err := b.expand(op)
+		if err != nil {
+			return nil, fmt.Errorf("op %d (%s): %w", i+1, op.Op, err)
+		}
+		for _, one := range expanded {
+			done, err := b.apply(one)
+			if err != nil {
+				return nil, fmt.Errorf("op %d (%s %s): %w", i+1, one.Op, one.ID, err)
+			}
+			applied = append(applied, done)
+		}
+	}
+
+	tx, err := s.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	for _, id := range b.order {
+		f := b.byID[id]
+		f.Touch(now)
+		if err := tx.Write(f); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// applyBatch is the in-memory store an apply document runs against.
+type applyBatch struct {
+	byID    map[string]*Felt
+	taken   map[string]bool // upper-cased UIDs in use
+	changed map[string]bool
+	order   []string // changed ids, in first-change order
+	now     time.Time
+	cfg     *Config // status.transitions guards add and close; reopen is exempt
+}
+
+func (b *applyBatch) resolve(query string) (string, error) {
+	ids := make([]string, 0, len(b.byID))
+	for id := range b.byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ResolveScopedID(ids, "", query)
+}
+
+func (b *applyBatch) touch(id string) {
+	if !b.changed[id] {
+		b.changed[id] = true
+		b.order = append(b.order, id)
+	}
+}
+
+// expand splits an op naming several fibers into one op per fiber.
+func (b *applyBatch) expand(op ApplyOp) ([]ApplyOp, error) {
+	if len(op.IDs) == 0 {
+		return []ApplyOp{op}, nil
+	}
+	if op.Op == OpAdd {
+		return nil, fmt.Errorf("add takes one id, not ids")
+	}
+	if op.ID != "" {
+		return nil, fmt.Errorf("give id or ids, not both")
+	}
+	out := make([]ApplyOp, 0, len(op.IDs))
+	for _, id := range op.IDs {
+		one := op
+		one.ID, one.IDs = id, nil
+		out = append(out, one)
+	}
+	return out, nil
+}
+
+func (b *applyBatch) apply(op ApplyOp) (ApplyOp, error) {
+	if op.Op == OpAdd {
+		return b.add(op)
+	}
+	if strings.TrimSpace(op.ID) == "" {
+		return op, fmt.Errorf("needs an id")
+	}
+	id, err := b.resolve(op.ID)
+	if err != nil {
+		return op, err
+	}
+	op.ID = id
+	f := b.byID[id]
+
+	switch op.Op {
+	case OpEdit:
+		if op.Name == "" && op.Outcome == "" && op.Body == "" {
+			return op, fmt.Errorf("edit needs a name, outcome, or body")
+		}
+		if op.Name != "" {
+			f.Name = strings.TrimSpace(op.Name)
+		}
+		if op.Outcome != "" {
+			f.Outcome = op.Outcome
+		}
+		if strings.TrimSpace(op.Body) != "" {
+			f.Body = appendParagraph(f.Body, op.Body)
+		}
+	case OpClose:
+		if err := b.cfg.CheckStatusTransition(f.Status, StatusClosed); err != nil {
+			return op, err
+		}
+		if !f.IsClosed() {
+			prev := f.Status
+			f.Status = StatusClosed
+			closed := b.now
+			f.ClosedAt = &closed
+			f.NoteStatusChange(prev, b.now)
+		}
+		if op.Outcome != "" {
+			f.Outcome = op.Outcome
+		}
+	case OpReopen:
+		if !f.IsClosed() {
+			return op, fmt.Errorf("not closed")
+		}
+		f.Status = StatusOpen
+		f.ClosedAt = nil
+		f.NoteStatusChange(StatusClosed, b.now)
+	case OpTag, OpUntag:
+		if len(op.Tags) == 0 {
+			return op, fmt.Errorf("%s needs tags", op.Op)
+		}
+		for _, tag := range op.Tags {
+			if op.Op == OpTag {
+				f.AddTag(strings.TrimSpace(tag))
+			} else {
+				f.RemoveTag(strings.TrimSpace(tag))
+			}
+		}
+	case OpLink:
+		if strings.TrimSpace(op.From) == "" {
+			return op, fmt.Errorf("link needs from")
+		}
+		fromID, fragment := splitDataFlowRef(strings.TrimSpace(op.From))
+		resolved, err := b.resolve(fromID)
+		if err != nil {
+			return op, fmt.Errorf("from: %w", err)
+		}
+		if resolved == id {
+			return op, fmt.Errorf("a fiber cannot read from itself")
+		}
+		op.From = resolved
+		if fragment != "" {
+			op.From += "." + fragment
+		}
+		if err := f.AddDataFlowInput(op.From); err != nil {
+			return op, err
+		}
+	case OpDefer:
+		if op.Until == nil {
+			return op, fmt.Errorf("defer needs until")
+		}
+		if err := f.SetDeferUntil(*op.Until); err != nil {
+			return op, err
+		}
+	default:
+		return op, fmt.Errorf("unknown op %q (want add, edit, close, reopen, tag, untag, link, or defer)", op.Op)
+	}
+	b.touch(id)
+	return op, nil
+}
+
+func (b *applyBatch) add(op ApplyOp) (ApplyOp, error) {
+	name := strings.TrimSpace(op.Name)
+	if name == "" {
+		return op, fmt.Errorf("add needs a name")
+	}
+	id := strings.TrimSpace(op.ID)
+	if id == "" {
+		slug, err := GenerateID(name)
+		if err != nil {
+			return op, err
+		}
+		id = slug
+		if op.Parent != "" {
+			parent, err := b.resolve(op.Parent)
+			if err != nil {
+				return op, fmt.Errorf("parent: %w", err)
+			}
+			id = parent + "/" + slug
+		}
+	} else if op.Parent != "" {
+		return op, fmt.Errorf("give id or parent, not both")
+	}
+	f, err := New(id, name)
+	if err != nil {
+		return op, err
+	}
+	if _, exists := b.byID[f.ID]; exists {
+		return op, fmt.Errorf("fiber %q already exists", f.ID)
+	}
+	f.CreatedAt = b.now
+	if op.Created != nil {
+		f.CreatedAt = *op.Created
+	}
+	f.UID = mintAvailableUID(f.ID, b.taken)
+	b.taken[strings.ToUpper(f.UID)] = true
+	switch op.Status {
+	case "":
+		f.Status = StatusOpen
+	case statusNone:
+		f.Status = ""
+	case StatusOpen, StatusActive, StatusClosed:
+		f.Status = op.Status
+	default:
+		return op, fmt.Errorf("invalid status %q (valid: open, active, closed, none)", op.Status)
+	}
+	if err := b.cfg.CheckStatusTransition("", f.Status); err != nil {
+		return op, err
+	}
+	if f.Status == StatusClosed {
+		closed := b.now
+		if op.Closed != nil {
+			closed = *op.Closed
+		}
+		f.ClosedAt = &closed
+	} else if op.Closed != nil {
+		return op, fmt.Errorf("closed needs status closed")
+	}
+	f.NoteStatusChange("", f.CreatedAt)
+	for _, tag := range op.Tags {
+		f.AddTag(strings.TrimSpace(tag))
+	}
+	f.Outcome = op.Outcome
+	f.Body = strings.TrimSpace(op.Body)
+
+	b.byID[f.ID] = f
+	b.touch(f.ID)
+	op.ID, op.Parent = f.ID, ""
+	return op, nil
+}
+
+// appendParagraph adds text to body as a new trailing paragraph.
+func appendParagraph(body, text string) string {
+	text = strings.Trim(text, "\n")
+	if strings.TrimSpace(body) == "" {
+		return text
+	}
+	return strings.TrimRight(body, "\n") + "\n\n" + text
+}
@@ -0,0 +1,151 @@
+package felt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestApplyOpsRunsABatch(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, f := range []*Felt{
+		{ID: "plan", Name: "Plan", Status: StatusActive, CreatedAt: time.Now()},
+		{ID: "a", Name: "A", Status: StatusOpen, CreatedAt: time.Now()},
+		{ID: "b", Name: "B", Status: StatusOpen, CreatedAt: time.Now()},
+	} {
+		if err := s.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	applied, err := s.ApplyOps([]ApplyOp{
+		{Op: OpAdd, Name: "Fit the model", Parent: "plan", Tags: []string{"fit"}},
+		{Op: OpLink, ID: "plan/fit-the-model", From: "a"},
+		{Op: OpClose, IDs: []string{"a", "b"}, Outcome: "Done"},
+		{Op: OpEdit, ID: "plan", Body: "Fit comes next."},
+	}, now)
+	if err != nil {
+		t.Fatalf("ApplyOps: %v", err)
+	}
+	if len(applied) != 5 || applied[0].ID != "plan/fit-the-model" || applied[3].ID != "b" {
+		t.Fatalf("applied = %+v", applied)
+	}
+
+	fit, err := s.Read("plan/fit-the-model")
+	if err != nil || !fit.IsOpen() || !fit.HasTag("fit") || fit.UID == "" {
+		t.Fatalf("added fiber = %+v, %v", fit, err)
+	}
+	if in := fit.DataFlowInputs(); len(in) != 1 || in[0].From != "a" {
+		t.Fatalf("inputs = %+v, want one from a", in)
+	}
+	for _, id := range []string{"a", "b"} {
+		f, _ := s.Read(id)
+		if !f.IsClosed() || f.Outcome != "Done" || !f.ClosedAt.Equal(now) {
+			t.Fatalf("%s = %+v, want closed with outcome", id, f)
+		}
+	}
+	if plan, _ := s.Read("plan"); plan.Body != "Fit comes next." {
+		t.Fatalf("plan body = %q", plan.Body)
+	}
+}
+
+func TestApplyOpsIsAllOrNothing(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	a := &Felt{ID: "a", Name: "A", Status: StatusOpen, CreatedAt: time.Now()}
+	if err := s.Write(a); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.ReadFile(s.Path("a"))
+
+	// A bad op late in the list stops the batch before anything is written.
+	_, err := s.ApplyOps([]ApplyOp{
+		{Op: OpClose, ID: "a"},
+		{Op: OpAdd, Name: "New"},
+		{Op: OpTag, ID: "missing", Tags: []string{"x"}},
+	}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "op 3") {
+		t.Fatalf("ApplyOps = %v, want an error naming op 3", err)
+	}
+	if after, _ := os.ReadFile(s.Path("a")); string(after) != string(before) {
+		t.Fatalf("a changed by a failed batch:\n%s", after)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".felt", "new")); !os.IsNotExist(err) {
+		t.Fatalf("failed batch created a fiber: %v", err)
+	}
+
+	// A write that fails partway is rolled back: a plain file where the new
+	// fiber's directory would go makes its write fail after a's.
+	if err := os.WriteFile(filepath.Join(dir, ".felt", "blocker"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.ApplyOps([]ApplyOp{
+		{Op: OpClose, ID: "a"},
+		{Op: OpAdd, ID: "blocker/child", Name: "Child"},
+	}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "no changes kept") {
+		t.Fatalf("ApplyOps = %v, want a rolled-back write error", err)
+	}
+	if after, _ := os.ReadFile(s.Path("a")); string(after) != string(before) {
+		t.Fatalf("a not restored after rollback:\n%s", after)
+	}
+}
+
+func TestApplyOpsRefusesCaseVariantOfExistingFiber(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "Pure", Name: "Pure", Status: StatusOpen, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.ApplyOps([]ApplyOp{{Op: OpAdd, ID: "pure/eta", Name: "Eta"}}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "differs only in case from existing Pure") {
+		t.Fatalf("ApplyOps = %v, want case collision error", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".felt", "pure")); !os.IsNotExist(err) {
+		t.Fatalf("refused batch created .felt/pure: %v", err)
+	}
+}
+
+func TestApplyOpsEnforcesStatusTransitions(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := os.WriteFile(s.ConfigPath(), []byte("status:\n  transitions:\n    open: [active]\n    none: [open]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(&Felt{ID: "a", Name: "A", Status: StatusOpen, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.ApplyOps([]ApplyOp{{Op: OpClose, IDs: []string{"a"}, Outcome: "Done"}}, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "open → closed is not allowed") || !strings.Contains(err.Error(), "open may become active") {
+		t.Fatalf("closing an open fiber: err = %v", err)
+	}
+	if f, _ := s.Read("a"); f.Status != StatusOpen {
+		t.Fatalf("refused close still wrote status %q", f.Status)
+	}
+	if _, err := s.ApplyOps([]ApplyOp{{Op: OpAdd, ID: "b", Name: "B", Status: StatusActive}}, time.Now()); err == nil || !strings.Contains(err.Error(), "none → active is not allowed") {
+		t.Fatalf("adding an active fiber: err = %v", err)
+	}
+
+	bad := &Config{Status: StatusConfig{Transitions: map[string][]string{"open": {"done"}}}}
+	if err := bad.CheckStatusTransition(StatusOpen, StatusClosed); err == nil || !strings.Contains(err.Error(), `unknown status "done"`) {
+		t.Fatalf("unknown status in config: err = %v", err)
+	}
+}
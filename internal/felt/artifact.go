@@ -0,0 +1,132 @@
+package felt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ArtifactsKey is the frontmatter list of data files a fiber produced, so an
+// output can be traced back to the decision that made it:
+//
+//	artifacts:
+//	  - path: data/chains/cosebis.h5
+//	    sha256: 9f86d081884c7d65...
+//	    size: 1048576
+//	    recorded-at: 2026-04-10T09:00:00Z
+//
+// Paths are relative to the project root (the directory holding .felt/).
+// sha256 is optional: without it, verify only checks the file still exists.
+const ArtifactsKey = "artifacts"
+
+// Artifact is one `artifacts:` entry. Field order is the on-disk order.
+type Artifact struct {
+	Path       string    `yaml:"path" json:"path"`
+	SHA256     string    `yaml:"sha256,omitempty" json:"sha256,omitempty"`
+	Size       int64     `yaml:"size,omitempty" json:"size,omitempty"`
+	RecordedAt time.Time `yaml:"recorded-at" json:"recorded_at"`
+}
+
+// Artifacts returns f's registered artifacts.
+func (f *Felt) Artifacts() ([]Artifact, error) {
+	node := extraFieldNode(f.ExtraFields, ArtifactsKey)
+	if node == nil {
+		return nil, nil
+	}
+	var artifacts []Artifact
+	if err := node.Decode(&artifacts); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", f.ID, ArtifactsKey, err)
+	}
+	return artifacts, nil
+}
+
+// RegisterArtifact records a on f, replacing any entry for the same path so
+// re-registering a regenerated file updates its checksum in place.
+func (f *Felt) RegisterArtifact(a Artifact) error {
+	artifacts, err := f.Artifacts()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range artifacts {
+		if artifacts[i].Path == a.Path {
+			artifacts[i] = a
+			replaced = true
+		}
+	}
+	if !replaced {
+		artifacts = append(artifacts, a)
+	}
+	return f.SetExtraField(ArtifactsKey, artifacts)
+}
+
+// HashFile returns the hex SHA-256 and size of the file at path.
+func HashFile(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("hashing %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// Artifact verification outcomes.
+const (
+	ArtifactOK      = "ok"
+	ArtifactChanged = "changed"
+	ArtifactMissing = "missing"
+)
+
+// ArtifactStatus is the verification result for one registered artifact.
+// Actual is the file's current checksum when it differs from the record.
+type ArtifactStatus struct {
+	FiberID string `json:"fiber_id"`
+	Artifact
+	Status string `json:"status"`
+	Actual string `json:"actual,omitempty"`
+}
+
+// VerifyArtifacts checks every artifact registered on felts against the
+// files under projectRoot: missing when the file is gone, changed when its
+// checksum no longer matches. Artifacts registered without a checksum only
+// need to exist.
+func VerifyArtifacts(projectRoot string, felts []*Felt) ([]ArtifactStatus, error) {
+	var out []ArtifactStatus
+	for _, f := range felts {
+		artifacts, err := f.Artifacts()
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range artifacts {
+			st := ArtifactStatus{FiberID: f.ID, Artifact: a, Status: ArtifactOK}
+			path := filepath.Join(projectRoot, filepath.FromSlash(a.Path))
+			var sum string
+			if a.SHA256 == "" {
+				_, err = os.Stat(path)
+			} else {
+				sum, _, err = HashFile(path)
+			}
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				st.Status = ArtifactMissing
+			case err != nil:
+				return nil, err
+			case sum != a.SHA256:
+				st.Status = ArtifactChanged
+				st.Actual = sum
+			}
+			out = append(out, st)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,75 @@
+package felt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegisterArtifactReplacesSamePath(t *testing.T) {
+	f := &Felt{ID: "fit"}
+	at := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	if err := f.RegisterArtifact(Artifact{Path: "a.h5", SHA256: "old", RecordedAt: at}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.RegisterArtifact(Artifact{Path: "b.h5", RecordedAt: at}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.RegisterArtifact(Artifact{Path: "a.h5", SHA256: "new", RecordedAt: at}); err != nil {
+		t.Fatal(err)
+	}
+	artifacts, err := f.Artifacts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 2 || artifacts[0].Path != "a.h5" || artifacts[0].SHA256 != "new" || artifacts[1].Path != "b.h5" {
+		t.Fatalf("artifacts = %+v", artifacts)
+	}
+}
+
+func TestVerifyArtifacts(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("same.txt", "x")
+	write("edited.txt", "x")
+	write("unhashed.txt", "x")
+	sum, size, err := HashFile(filepath.Join(root, "same.txt"))
+	if err != nil || size != 1 {
+		t.Fatalf("HashFile = %q, %d, %v", sum, size, err)
+	}
+
+	f := &Felt{ID: "fit"}
+	for _, a := range []Artifact{
+		{Path: "same.txt", SHA256: sum},
+		{Path: "edited.txt", SHA256: sum},
+		{Path: "unhashed.txt"},
+		{Path: "gone.txt", SHA256: sum},
+	} {
+		if err := f.RegisterArtifact(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("edited.txt", "y")
+
+	statuses, err := VerifyArtifacts(root, []*Felt{f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{ArtifactOK, ArtifactChanged, ArtifactOK, ArtifactMissing}
+	if len(statuses) != len(want) {
+		t.Fatalf("statuses = %+v", statuses)
+	}
+	for i, st := range statuses {
+		if st.Status != want[i] || st.FiberID != "fit" {
+			t.Errorf("%s: status = %q, want %q", st.Path, st.Status, want[i])
+		}
+	}
+	if statuses[1].Actual == "" || statuses[1].Actual == sum {
+		t.Errorf("changed artifact Actual = %q", statuses[1].Actual)
+	}
+}
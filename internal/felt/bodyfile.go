@@ -0,0 +1,123 @@
+package felt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BodyFileKey is the frontmatter pointer to an externalized body:
+// `body-file: body.md` names a file beside the fiber's own `.md`, in the
+// fiber's directory, that holds the body in place of the inline markdown.
+// Metadata reads never open it, so listing a store full of pasted logs costs
+// no more than listing one without them, and the fiber file's diff stays
+// about frontmatter.
+const BodyFileKey = "body-file"
+
+// BodyFileName is the sidecar name Write uses when it externalizes a body.
+// Sidecars never shadow fibers: only `<dir>/<dir>.md` is read as a fiber.
+const BodyFileName = "body.md"
+
+// BodyExternalizeThreshold is the body size (in bytes) above which Write
+// moves the body into BodyFileName. Once a fiber has a body-file pointer it
+// keeps it, so the body does not flip between files as it grows and shrinks.
+const BodyExternalizeThreshold = 64 << 10
+
+// BodyFile returns the fiber's body-file pointer, or "" when the body is
+// inline.
+func (f *Felt) BodyFile() string {
+	node := extraFieldNode(f.ExtraFields, BodyFileKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(node.Value)
+}
+
+// validBodyFile reports whether name is a plain file name: the pointer may
+// only reach a sibling, never climb out of the fiber's directory.
+func validBodyFile(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// bodyFilePath resolves f's body-file pointer against the fiber file at
+// fiberPath. ok is false when the body is inline.
+func bodyFilePath(f *Felt, fiberPath string) (string, bool, error) {
+	name := f.BodyFile()
+	if name == "" {
+		return "", false, nil
+	}
+	path := filepath.Join(filepath.Dir(fiberPath), name)
+	if !validBodyFile(name) || path == filepath.Clean(fiberPath) {
+		return "", false, fmt.Errorf("%s: %s %q must name another file in the fiber's directory", f.ID, BodyFileKey, name)
+	}
+	return path, true, nil
+}
+
+// loadBodyFile fills f.Body from its body-file sidecar. Inline text left in
+// the fiber file (e.g. added by hand) is kept after the sidecar content so
+// the next Write folds it in rather than dropping it. A missing sidecar
+// leaves the inline body as-is.
+func loadBodyFile(f *Felt, fiberPath string) error {
+	path, ok, err := bodyFilePath(f, fiberPath)
+	if err != nil || !ok {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading body file %s: %w", path, err)
+	}
+	external := strings.TrimSpace(string(data))
+	switch {
+	case f.Body == "":
+		f.Body = external
+	case external != "":
+		f.Body = external + "\n\n" + f.Body
+	}
+	return nil
+}
+
+// fileWrite is one file a fiber write produces.
+type fileWrite struct {
+	path string
+	data []byte
+}
+
+// fiberFileWrites returns the files writing f at fiberPath produces: the
+// body sidecar, when f has a body-file pointer or its body has outgrown
+// BodyExternalizeThreshold, then the fiber file itself with the body left
+// out of it. A newly externalized body gets the pointer set on f.
+func fiberFileWrites(f *Felt, fiberPath string) ([]fileWrite, error) {
+	inline := f
+	var writes []fileWrite
+	if f.BodyFile() != "" || len(f.Body) > BodyExternalizeThreshold {
+		if f.BodyFile() == "" {
+			if err := f.SetExtraField(BodyFileKey, BodyFileName); err != nil {
+				return nil, err
+			}
+		}
+		path, _, err := bodyFilePath(f, fiberPath)
+		if err != nil {
+			return nil, err
+		}
+		var data []byte
+		if f.Body != "" {
+			data = []byte(f.Body + "\n")
+		}
+		writes = append(writes, fileWrite{path: path, data: data})
+		copied := *f
+		copied.Body = ""
+		inline = &copied
+	}
+	data, err := inline.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append(writes, fileWrite{path: fiberPath, data: data}), nil
+}
@@ -0,0 +1,106 @@
+package felt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStorageExternalizesLargeBody(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	log := strings.TrimSpace(strings.Repeat("step ok\n", BodyExternalizeThreshold/8+1))
+	f := &Felt{ID: "run-log", Name: "Run log", Status: StatusOpen, CreatedAt: time.Now(), Body: log}
+	if err := s.Write(f); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if f.BodyFile() != BodyFileName {
+		t.Fatalf("BodyFile() = %q, want %q", f.BodyFile(), BodyFileName)
+	}
+
+	sidecar := filepath.Join(filepath.Dir(s.Path(f.ID)), BodyFileName)
+	data, err := os.ReadFile(s.Path(f.ID))
+	if err != nil {
+		t.Fatalf("reading fiber file: %v", err)
+	}
+	if strings.Contains(string(data), "step ok") || !strings.Contains(string(data), "body-file: body.md") {
+		t.Fatalf("fiber file should hold only the pointer:\n%s", data)
+	}
+	if _, err := os.Stat(sidecar); err != nil {
+		t.Fatalf("sidecar missing: %v", err)
+	}
+
+	meta, err := s.ReadMetadata(f.ID)
+	if err != nil {
+		t.Fatalf("ReadMetadata: %v", err)
+	}
+	if meta.Body != "" {
+		t.Fatalf("metadata read loaded the body")
+	}
+	full, err := s.Read(f.ID)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if full.Body != log {
+		t.Fatalf("full read body has %d bytes, want %d", len(full.Body), len(log))
+	}
+	felts, err := s.List()
+	if err != nil || len(felts) != 1 || felts[0].Body != log {
+		t.Fatalf("List() should load the sidecar and not list it as a fiber: %v, %d fibers", err, len(felts))
+	}
+
+	// The pointer sticks once set, even when the body shrinks.
+	full.Body = "Trimmed."
+	if err := s.Write(full); err != nil {
+		t.Fatalf("Write (shrunk): %v", err)
+	}
+	if got, _ := os.ReadFile(sidecar); string(got) != "Trimmed.\n" {
+		t.Fatalf("sidecar = %q", got)
+	}
+
+	if err := s.Delete(f.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(sidecar)); !os.IsNotExist(err) {
+		t.Fatalf("fiber directory should be pruned with its sidecar, stat err = %v", err)
+	}
+}
+
+func TestBodyFileKeepsHandAddedInlineText(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	path := filepath.Join(s.root, "notes", "notes.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("---\nname: Notes\nbody-file: log.md\n---\n\nAdded inline.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "log.md"), []byte("From the sidecar.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := s.Read("notes")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if f.Body != "From the sidecar.\n\nAdded inline." {
+		t.Fatalf("Body = %q", f.Body)
+	}
+
+	if err := os.WriteFile(path, []byte("---\nname: Notes\nbody-file: ../escape.md\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Read("notes"); err == nil {
+		t.Fatalf("a body-file pointer outside the fiber directory should be rejected")
+	}
+}
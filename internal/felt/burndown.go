@@ -0,0 +1,128 @@
+package felt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBurndownDays is how many days `felt report burndown` charts.
+const DefaultBurndownDays = 14
+
+// Burndown units: the estimate: spans, in hours; the estimate: bare
+// numbers, as points; or, when nothing is estimated, a count of fibers.
+const (
+	BurndownHours  = "hours"
+	BurndownPoints = "points"
+	BurndownFibers = "fibers"
+)
+
+// EstimatePoints returns f's `estimate:` when it is a bare positive number —
+// story points rather than a span. ok is false otherwise.
+func (f *Felt) EstimatePoints() (float64, bool) {
+	node := extraFieldNode(f.ExtraFields, EstimateKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(node.Value), 64)
+	if err != nil || v <= 0 {
+		return 0, false
+	}
+	return v, true
+}
+
+// BurndownDay is the effort left at the end of one day — or at the time of
+// the report, for today — and the scope created by then.
+type BurndownDay struct {
+	Day       time.Time `json:"day"`
+	Remaining float64   `json:"remaining"`
+	Scope     float64   `json:"scope"`
+}
+
+// Burndown is the daily series behind `felt report burndown`. Fibers counts
+// the tracked fibers charted; Unestimated the ones left out for having no
+// estimate in Unit.
+type Burndown struct {
+	Unit        string        `json:"unit"`
+	Fibers      int           `json:"fibers"`
+	Unestimated int           `json:"unestimated"`
+	Days        []BurndownDay `json:"days"`
+}
+
+// BuildBurndown replays the tracked fibers among felts over the days days
+// up to now, in loc, from their created-at and closed-at: a fiber is scope
+// from its creation and remaining until it closes. Each weighs its
+// estimate — hours for a span, points for a bare number — or 1 when no
+// fiber is estimated. A closed fiber with no closed-at was never
+// remaining, and, as with stats trends, a reopen leaves no date behind.
+// Estimates mixing spans and points are an error: they have no common unit.
+func BuildBurndown(felts []*Felt, days int, now time.Time, loc *time.Location) (*Burndown, error) {
+	if days < 1 {
+		days = 1
+	}
+	var tracked []*Felt
+	var spanned, pointed *Felt
+	for _, f := range felts {
+		if !f.HasStatus() {
+			continue
+		}
+		tracked = append(tracked, f)
+		if _, ok := f.Estimate(); ok && spanned == nil {
+			spanned = f
+		}
+		if _, ok := f.EstimatePoints(); ok && pointed == nil {
+			pointed = f
+		}
+	}
+	if spanned != nil && pointed != nil {
+		return nil, fmt.Errorf("estimates mix spans (%s) and points (%s); burndown needs one unit", spanned.ID, pointed.ID)
+	}
+
+	b := &Burndown{Unit: BurndownFibers}
+	weight := func(*Felt) (float64, bool) { return 1, true }
+	switch {
+	case spanned != nil:
+		b.Unit = BurndownHours
+		weight = func(f *Felt) (float64, bool) {
+			d, ok := f.Estimate()
+			return d.Hours(), ok
+		}
+	case pointed != nil:
+		b.Unit = BurndownPoints
+		weight = (*Felt).EstimatePoints
+	}
+
+	now = now.In(loc)
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	b.Days = make([]BurndownDay, days)
+	for i := range b.Days {
+		b.Days[i].Day = today.AddDate(0, 0, i-(days-1))
+	}
+	for _, f := range tracked {
+		w, ok := weight(f)
+		if !ok {
+			b.Unestimated++
+			continue
+		}
+		b.Fibers++
+		for i := range b.Days {
+			end := b.Days[i].Day.AddDate(0, 0, 1)
+			if i == days-1 {
+				end = now
+			}
+			if f.CreatedAt.After(end) {
+				continue
+			}
+			b.Days[i].Scope += w
+			closed := f.IsClosed() && (f.ClosedAt == nil || !f.ClosedAt.After(end))
+			if !closed {
+				b.Days[i].Remaining += w
+			}
+		}
+	}
+	return b, nil
+}
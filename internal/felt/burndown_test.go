@@ -0,0 +1,73 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildBurndownReplaysRemainingEffort(t *testing.T) {
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return time.Date(2026, 3, n, 10, 0, 0, 0, time.UTC) }
+	closedOn := day(4)
+	felts := []*Felt{
+		{ID: "mocks", Status: StatusClosed, CreatedAt: day(1), ClosedAt: &closedOn},
+		{ID: "fit", Status: StatusOpen, CreatedAt: day(1)},
+		{ID: "late", Status: StatusActive, CreatedAt: day(5)},
+		{ID: "bare", Status: StatusOpen, CreatedAt: day(1)},
+		{ID: "note", CreatedAt: day(1)},
+	}
+	for id, estimate := range map[string]string{"mocks": "4h", "fit": "1d", "late": "90m", "note": "2h"} {
+		for _, f := range felts {
+			if f.ID == id {
+				if err := f.SetExtraField(EstimateKey, estimate); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	b, err := BuildBurndown(felts, 3, now, time.UTC)
+	if err != nil {
+		t.Fatalf("BuildBurndown: %v", err)
+	}
+	if b.Unit != BurndownHours || b.Fibers != 3 || b.Unestimated != 1 {
+		t.Fatalf("burndown = %+v", b)
+	}
+	want := []BurndownDay{
+		{Day: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), Remaining: 28, Scope: 28},
+		{Day: time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), Remaining: 24, Scope: 28},
+		{Day: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), Remaining: 25.5, Scope: 29.5},
+	}
+	for i := range want {
+		if !b.Days[i].Day.Equal(want[i].Day) || b.Days[i].Remaining != want[i].Remaining || b.Days[i].Scope != want[i].Scope {
+			t.Fatalf("Days[%d] = %+v, want %+v", i, b.Days[i], want[i])
+		}
+	}
+}
+
+func TestBuildBurndownUnits(t *testing.T) {
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC)
+	pointed := &Felt{ID: "pointed", Status: StatusOpen, CreatedAt: now.Add(-time.Hour)}
+	if err := pointed.SetExtraField(EstimateKey, 3); err != nil {
+		t.Fatal(err)
+	}
+	plain := &Felt{ID: "plain", Status: StatusOpen, CreatedAt: now.Add(-time.Hour)}
+
+	b, err := BuildBurndown([]*Felt{pointed, plain}, 1, now, time.UTC)
+	if err != nil || b.Unit != BurndownPoints || b.Days[0].Remaining != 3 || b.Unestimated != 1 {
+		t.Fatalf("points burndown = %+v, %v", b, err)
+	}
+	b, err = BuildBurndown([]*Felt{plain}, 1, now, time.UTC)
+	if err != nil || b.Unit != BurndownFibers || b.Days[0].Remaining != 1 {
+		t.Fatalf("unestimated burndown = %+v, %v", b, err)
+	}
+
+	spanned := &Felt{ID: "spanned", Status: StatusOpen, CreatedAt: now}
+	if err := spanned.SetExtraField(EstimateKey, "2h"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := BuildBurndown([]*Felt{pointed, spanned}, 1, now, time.UTC); err == nil || !strings.Contains(err.Error(), "mix") {
+		t.Fatalf("mixed units err = %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package felt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Calendar entry kinds.
+const (
+	CalendarDue   = "due"
+	CalendarWakes = "wakes" // a snoozed fiber's defer-until
+)
+
+// CalendarEntry is one fiber's mark on a calendar day. Day is the civil
+// date as midnight UTC, the form ParseDateExpr and an ISO due: use.
+type CalendarEntry struct {
+	Day   time.Time `json:"day"`
+	Kind  string    `json:"kind"`
+	ID    string    `json:"id"`
+	Name  string    `json:"name"`
+	Fiber *Felt     `json:"-"`
+}
+
+// CalendarEntries collects the due dates and snooze wake days of felts
+// that fall within [first, last], ordered by day, due before wakes, then
+// id. Closed fibers are left out: their dates no longer ask for anything.
+// A due date is read as written; a wake instant is placed on its day in
+// loc.
+func CalendarEntries(felts []*Felt, first, last time.Time, loc *time.Location) []CalendarEntry {
+	var out []CalendarEntry
+	add := func(f *Felt, y int, m time.Month, d int, kind string) {
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		if day.Before(first) || day.After(last) {
+			return
+		}
+		out = append(out, CalendarEntry{Day: day, Kind: kind, ID: f.ID, Name: f.DisplayName(), Fiber: f})
+	}
+	for _, f := range felts {
+		if f.IsClosed() {
+			continue
+		}
+		if f.Due != nil {
+			y, m, d := f.Due.Date()
+			add(f, y, m, d, CalendarDue)
+		}
+		if until, ok := f.DeferUntil(); ok {
+			y, m, d := until.In(loc).Date()
+			add(f, y, m, d, CalendarWakes)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].Day.Equal(out[j].Day) {
+			return out[i].Day.Before(out[j].Day)
+		}
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind == CalendarDue
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// ParseMonth reads a calendar month relative to now, returning its first
+// day as midnight UTC:
+//
+//	2026-11            a year and month
+//	november, nov      that month of now's year
+//	this, next, last   now's month, or the one after or before it
+func ParseMonth(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	y, m, _ := now.Date()
+	switch s {
+	case "", "this":
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC), nil
+	case "next":
+		return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC), nil
+	case "last", "prev":
+		return time.Date(y, m-1, 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	if t, err := time.Parse("2006-01", s); err == nil {
+		return t, nil
+	}
+	for month := time.January; month <= time.December; month++ {
+		if name := strings.ToLower(month.String()); s == name || s == name[:3] {
+			return time.Date(y, month, 1, 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized month %q (use 2026-11, november, nov, next, or last)", s)
+}
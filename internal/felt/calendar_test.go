@@ -0,0 +1,42 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseMonth(t *testing.T) {
+	now := time.Date(2026, 12, 14, 15, 0, 0, 0, time.UTC)
+	for in, want := range map[string]string{
+		"":         "2026-12",
+		"next":     "2027-01",
+		"last":     "2026-11",
+		"2025-03":  "2025-03",
+		"February": "2026-02",
+		"sep":      "2026-09",
+	} {
+		got, err := ParseMonth(in, now)
+		if err != nil || got.Format("2006-01-02") != want+"-01" {
+			t.Errorf("ParseMonth(%q) = %v, %v; want %s-01", in, got, err, want)
+		}
+	}
+	if _, err := ParseMonth("someday", now); err == nil {
+		t.Error("ParseMonth(someday) should fail")
+	}
+}
+
+func TestCalendarEntriesPlacesWakesInTheDisplayZone(t *testing.T) {
+	f := &Felt{ID: "late", Name: "Late", Status: StatusOpen}
+	if err := f.SetDeferUntil(time.Date(2026, 10, 31, 23, 30, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	oct := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	nov := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	if got := CalendarEntries([]*Felt{f}, oct, nov.AddDate(0, 0, -1), time.UTC); len(got) != 1 || got[0].Kind != CalendarWakes {
+		t.Fatalf("UTC entries = %+v", got)
+	}
+	// An hour east, the same instant wakes on the 1st of November.
+	if got := CalendarEntries([]*Felt{f}, oct, nov.AddDate(0, 0, -1), time.FixedZone("CET", 3600)); len(got) != 0 {
+		t.Fatalf("CET entries = %+v, want none in October", got)
+	}
+}
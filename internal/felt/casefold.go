@@ -0,0 +1,207 @@
+package felt
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CanonicalID returns id in the case felt stores ids in: lower case. New
+// slugs are lowercased already; directories made by hand, or prefix
+// segments typed in capitals ("felt add Pure_EB/x"), need not be.
+func CanonicalID(id string) string {
+	return strings.ToLower(id)
+}
+
+// CaseCollision is a group of fiber ids that differ only in case. On a
+// case-insensitive filesystem (macOS, Windows) they name one file, so
+// whichever was written last silently replaced the others.
+type CaseCollision struct {
+	IDs []string `json:"ids"`
+}
+
+// CaseCollisions returns every group of felts whose ids match when case is
+// ignored, sorted. Only a case-sensitive filesystem can hold such a group;
+// syncing it to a case-insensitive one loses all but one of each.
+func CaseCollisions(felts []*Felt) []CaseCollision {
+	byFold := make(map[string][]string)
+	for _, f := range felts {
+		key := CanonicalID(f.ID)
+		byFold[key] = append(byFold[key], f.ID)
+	}
+	var out []CaseCollision
+	for _, ids := range byFold {
+		if len(ids) > 1 {
+			sort.Strings(ids)
+			out = append(out, CaseCollision{IDs: ids})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IDs[0] < out[j].IDs[0] })
+	return out
+}
+
+// NonCanonicalIDs returns the ids of felts not in canonical case, sorted.
+func NonCanonicalIDs(felts []*Felt) []string {
+	var ids []string
+	for _, f := range felts {
+		if f.ID != CanonicalID(f.ID) {
+			ids = append(ids, f.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// caseCollision returns the existing entry target would silently share on a
+// case-insensitive filesystem: the first entry along target's path below the
+// store root whose name matches a segment only when case is ignored. It is
+// "" when each existing segment matches exactly, or target is new.
+func (s *Storage) caseCollision(target string) (string, error) {
+	rel, err := filepath.Rel(s.root, target)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", nil
+	}
+	dir := s.root
+	for _, seg := range strings.Split(rel, string(filepath.Separator)) {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("reading %s: %w", dir, err)
+		}
+		exact, folded := false, ""
+		for _, e := range entries {
+			if e.Name() == seg {
+				if !e.IsDir() {
+					return "", nil // the write itself fails on a file where a directory goes
+				}
+				exact = true
+				break
+			}
+			if folded == "" && strings.EqualFold(e.Name(), seg) {
+				folded = e.Name()
+			}
+		}
+		if !exact {
+			if folded != "" {
+				return filepath.Join(dir, folded), nil
+			}
+			return "", nil
+		}
+		dir = filepath.Join(dir, seg)
+	}
+	return "", nil
+}
+
+// refuseCaseCollision is the error for writing target, named name in the
+// message, when caseCollision finds an entry it would share.
+func (s *Storage) refuseCaseCollision(target, name string) error {
+	clash, err := s.caseCollision(target)
+	if err != nil || clash == "" {
+		return err
+	}
+	rel, _ := filepath.Rel(s.root, clash)
+	return fmt.Errorf("%s differs only in case from existing %s, and would share its file on a case-insensitive filesystem; use the existing case", name, filepath.ToSlash(rel))
+}
+
+// NormalizeIDCase renames every fiber directory whose id is not in
+// canonical case, outermost first, rewriting data-flow references as nest
+// does. entries maps each renamed fiber's old id to its new one. A
+// directory whose lowercase name another entry already holds is left in
+// place, and the fibers under it are returned in collisions: merging them
+// is a decision for a person (felt doctor lists them). The bare entry-point
+// file at the store root keeps its name.
+func (s *Storage) NormalizeIDCase(dryRun bool) (entries []MigrationEntry, collisions []string, err error) {
+	if err := s.refuseUnpatchable("renaming fibers"); !dryRun && err != nil {
+		return nil, nil, err
+	}
+	felts, err := s.ListMetadata()
+	if err != nil {
+		return nil, nil, err
+	}
+	var ids []string
+	for _, f := range felts {
+		if !f.EntryPoint {
+			ids = append(ids, f.ID)
+		}
+	}
+	sort.Strings(ids)
+	original := append([]string(nil), ids...)
+
+	// Each rename moves one directory: the outermost segment not yet in
+	// canonical case. Renaming it first means every inner rename sees its
+	// parent already lowercased.
+	blocked := make(map[string]bool)
+	for {
+		prefix := nextNonCanonicalPrefix(ids, blocked)
+		if prefix == "" {
+			break
+		}
+		canonical := path.Join(path.Dir(prefix), CanonicalID(path.Base(prefix)))
+		oldRoot := filepath.Join(s.root, filepath.FromSlash(prefix))
+		newRoot := filepath.Join(s.root, filepath.FromSlash(canonical))
+		if distinctEntryExists(newRoot, oldRoot) {
+			blocked[prefix] = true
+			continue
+		}
+		if !dryRun {
+			if err := s.MoveSubtree(prefix, canonical); err != nil {
+				return nil, nil, err
+			}
+		}
+		for i, id := range ids {
+			if moved, ok := remapIDPrefix(id, prefix, canonical); ok {
+				ids[i] = moved
+			}
+		}
+	}
+
+	for i, id := range ids {
+		switch {
+		case id != original[i]:
+			entries = append(entries, MigrationEntry{OldID: original[i], NewID: id})
+		case id != CanonicalID(id):
+			collisions = append(collisions, id)
+		}
+	}
+	return entries, collisions, nil
+}
+
+// nextNonCanonicalPrefix returns the shortest id prefix among ids whose
+// last segment is not in canonical case and which is not blocked, or "".
+func nextNonCanonicalPrefix(ids []string, blocked map[string]bool) string {
+	best := ""
+	for _, id := range ids {
+		segs := strings.Split(id, "/")
+		for i, seg := range segs {
+			if seg == CanonicalID(seg) {
+				continue
+			}
+			prefix := strings.Join(segs[:i+1], "/")
+			if blocked[prefix] {
+				break
+			}
+			if best == "" || len(prefix) < len(best) || (len(prefix) == len(best) && prefix < best) {
+				best = prefix
+			}
+			break
+		}
+	}
+	return best
+}
+
+// distinctEntryExists reports whether target exists as an entry of its own,
+// distinct from source — on a case-insensitive filesystem a case-only
+// rename's target is source itself.
+func distinctEntryExists(target, source string) bool {
+	ti, err := os.Stat(target)
+	if err != nil {
+		return false
+	}
+	si, err := os.Stat(source)
+	return err != nil || !os.SameFile(ti, si)
+}
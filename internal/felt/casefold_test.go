@@ -0,0 +1,93 @@
+package felt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteRefusesCaseVariantOfExistingFiber(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	if err := s.Write(&Felt{ID: "Pure_EB", Name: "Pure E/B", CreatedAt: created}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "Pure_EB/x", Name: "Kept case", CreatedAt: created}); err != nil {
+		t.Fatalf("Write under the existing case: %v", err)
+	}
+	err := s.Write(&Felt{ID: "pure_eb/y", Name: "Lowercased", CreatedAt: created})
+	if err == nil || !strings.Contains(err.Error(), "differs only in case from existing Pure_EB") {
+		t.Fatalf("Write of a case variant = %v, want case collision error", err)
+	}
+}
+
+func TestNormalizeIDCaseLowercasesAndRewritesInputs(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeRawFiber(t, s, "Pure_EB/Pure_EB.md", "---\nname: Pure E/B\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	writeRawFiber(t, s, "Pure_EB/Mocks/Mocks.md", "---\nname: Mocks\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	writeRawFiber(t, s, "paper/paper.md", "---\nname: Paper\ncreated-at: 2026-04-10T09:00:00Z\ninputs:\n  - id: mocks\n    from: Pure_EB/Mocks\n---\n")
+
+	dry, _, err := s.NormalizeIDCase(true)
+	if err != nil {
+		t.Fatalf("NormalizeIDCase dry run: %v", err)
+	}
+	if _, err := s.Read("Pure_EB/Mocks"); err != nil {
+		t.Fatalf("dry run moved fibers: %v", err)
+	}
+
+	entries, collisions, err := s.NormalizeIDCase(false)
+	if err != nil {
+		t.Fatalf("NormalizeIDCase: %v", err)
+	}
+	want := []MigrationEntry{{OldID: "Pure_EB", NewID: "pure_eb"}, {OldID: "Pure_EB/Mocks", NewID: "pure_eb/mocks"}}
+	if !reflect.DeepEqual(entries, want) || !reflect.DeepEqual(dry, want) || len(collisions) != 0 {
+		t.Fatalf("NormalizeIDCase = %+v, %v (dry run %+v), want %+v", entries, collisions, dry, want)
+	}
+	if _, err := s.Read("pure_eb/mocks"); err != nil {
+		t.Fatalf("Read(pure_eb/mocks): %v", err)
+	}
+	felts, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := DanglingInputs(felts); len(got) != 0 {
+		t.Fatalf("paper's input was not redirected: %+v", got)
+	}
+}
+
+func TestNormalizeIDCaseSkipsCollisions(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeRawFiber(t, s, "mocks/mocks.md", "---\nname: Mocks\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	writeRawFiber(t, s, "Mocks/Mocks.md", "---\nname: Mocks too\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	if !distinctEntryExists(s.Path("mocks"), s.Path("Mocks")) {
+		t.Skip("case-insensitive filesystem cannot hold a collision")
+	}
+
+	entries, collisions, err := s.NormalizeIDCase(false)
+	if err != nil {
+		t.Fatalf("NormalizeIDCase: %v", err)
+	}
+	if len(entries) != 0 || !reflect.DeepEqual(collisions, []string{"Mocks"}) {
+		t.Fatalf("NormalizeIDCase = %+v, %v, want no renames and [Mocks] skipped", entries, collisions)
+	}
+	felts, err := s.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := CaseCollisions(felts); !reflect.DeepEqual(got, []CaseCollision{{IDs: []string{"Mocks", "mocks"}}}) {
+		t.Fatalf("CaseCollisions = %+v", got)
+	}
+	if got := NonCanonicalIDs(felts); !reflect.DeepEqual(got, []string{"Mocks"}) {
+		t.Fatalf("NonCanonicalIDs = %v", got)
+	}
+}
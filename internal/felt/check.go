@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -41,6 +43,10 @@ func Check(felts []*Felt) []CheckIssue {
 	issues := checkNativeMetadata(felts)
 	issues = append(issues, checkRelationshipIntegrity(felts)...)
 	issues = append(issues, checkPinnedOrphans(felts)...)
+	issues = append(issues, checkConfidence(felts)...)
+	issues = append(issues, checkImpact(felts)...)
+	issues = append(issues, checkAcceptance(felts)...)
+	issues = append(issues, checkSupersession(felts)...)
 
 	sort.Slice(issues, func(i, j int) bool {
 		if issues[i].FiberID != issues[j].FiberID {
@@ -229,6 +235,98 @@ func checkPinnedOrphans(felts []*Felt) []CheckIssue {
 	return issues
 }
 
+// checkConfidence warns on a `confidence:` value that is not a level or a
+// number from 0 to 1, which would otherwise be silently ignored.
+func checkConfidence(felts []*Felt) []CheckIssue {
+	var issues []CheckIssue
+	for _, f := range felts {
+		node := extraFieldNode(f.ExtraFields, ConfidenceKey)
+		if node == nil {
+			continue
+		}
+		if node.Kind == yaml.ScalarNode {
+			if _, err := ParseConfidence(node.Value); err == nil {
+				continue
+			}
+		}
+		issues = append(issues, CheckIssue{
+			Level:   CheckLevelWarning,
+			FiberID: f.ID,
+			Path:    "frontmatter." + ConfidenceKey,
+			Message: "confidence must be low, medium, high, or a number from 0 to 1",
+		})
+	}
+	return issues
+}
+
+// checkImpact warns on an `impact:` value that is not a level, which would
+// otherwise be silently ignored.
+func checkImpact(felts []*Felt) []CheckIssue {
+	var issues []CheckIssue
+	for _, f := range felts {
+		node := extraFieldNode(f.ExtraFields, ImpactKey)
+		if node == nil {
+			continue
+		}
+		if node.Kind == yaml.ScalarNode {
+			if _, err := ParseImpact(node.Value); err == nil {
+				continue
+			}
+		}
+		issues = append(issues, CheckIssue{
+			Level:   CheckLevelWarning,
+			FiberID: f.ID,
+			Path:    "frontmatter." + ImpactKey,
+			Message: "impact must be low, medium, high, or critical",
+		})
+	}
+	return issues
+}
+
+// checkAcceptance warns on an `acceptance:` value that is not a list of
+// strings, which would otherwise be silently ignored.
+func checkAcceptance(felts []*Felt) []CheckIssue {
+	var issues []CheckIssue
+	for _, f := range felts {
+		node := extraFieldNode(f.ExtraFields, AcceptanceKey)
+		if node == nil || f.Acceptance() != nil {
+			continue
+		}
+		issues = append(issues, CheckIssue{
+			Level:   CheckLevelWarning,
+			FiberID: f.ID,
+			Path:    "frontmatter." + AcceptanceKey,
+			Message: `acceptance must be a list of criteria ("[ ] text" or "[x] text")`,
+		})
+	}
+	return issues
+}
+
+// checkSupersession warns on a superseded-by or supersedes pointer naming a
+// fiber that no longer exists, as after a rename outside felt.
+func checkSupersession(felts []*Felt) []CheckIssue {
+	ids := make(map[string]bool, len(felts))
+	for _, f := range felts {
+		ids[f.ID] = true
+	}
+	var issues []CheckIssue
+	for _, f := range felts {
+		for _, key := range []string{SupersededByKey, SupersedesKey} {
+			target := extraFieldString(f, key)
+			if target == "" || ids[target] {
+				continue
+			}
+			issues = append(issues, CheckIssue{
+				Level:   CheckLevelWarning,
+				FiberID: f.ID,
+				Path:    "frontmatter." + key,
+				Message: fmt.Sprintf("%s points at missing fiber %q", key, target),
+			})
+		}
+	}
+	return issues
+}
+
 func checkRelationshipIntegrity(felts []*Felt) []CheckIssue {
 	ids := make([]string, 0, len(felts))
 	byID := make(map[string]*Felt, len(felts))
@@ -0,0 +1,108 @@
+package felt
+
+import (
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CommitsKey is the frontmatter list the git post-commit hook appends to:
+// one entry per commit whose message carries a `Felt: <id>` trailer naming
+// the fiber, so the fiber links back to the code that moved it.
+const CommitsKey = "commits"
+
+// CommitTrailer is the git trailer key that names a commit's fibers.
+const CommitTrailer = "Felt"
+
+// Commit is one `commits:` entry. Field order is the on-disk order.
+type Commit struct {
+	SHA     string    `yaml:"sha" json:"sha"`
+	Subject string    `yaml:"subject" json:"subject"`
+	At      time.Time `yaml:"at" json:"at"`
+}
+
+// Commits returns f's recorded commits, oldest first. Malformed entries are
+// skipped.
+func (f *Felt) Commits() []Commit {
+	node := extraFieldNode(f.ExtraFields, CommitsKey)
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var out []Commit
+	for _, item := range node.Content {
+		var c Commit
+		if item.Kind != yaml.MappingNode || item.Decode(&c) != nil || c.SHA == "" {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// AddCommit appends c to f's commits. It reports false, changing nothing,
+// when a commit with the same SHA is already recorded, so a hook that runs
+// twice for one commit leaves a single entry.
+func (f *Felt) AddCommit(c Commit) (bool, error) {
+	commits := f.Commits()
+	for _, have := range commits {
+		if have.SHA == c.SHA {
+			return false, nil
+		}
+	}
+	c.At = c.At.UTC()
+	if err := f.SetExtraField(CommitsKey, append(commits, c)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RecordCommit appends c to the commits of the fiber id under its write
+// lock. It reports whether the fiber changed.
+func (s *Storage) RecordCommit(id string, c Commit) (bool, error) {
+	unlock, err := s.LockFiber(id)
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+	f, err := s.Read(id)
+	if err != nil {
+		return false, err
+	}
+	added, err := f.AddCommit(c)
+	if err != nil || !added {
+		return false, err
+	}
+	f.Touch(c.At)
+	if err := s.Write(f); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CommitTrailerIDs returns the fiber ids named by `Felt:` trailers in a
+// commit message's final paragraph, in order and without repeats. A trailer
+// may list several ids separated by commas or spaces; a parenthesised note
+// after them, as the prepare-commit-msg suggestions carry, is ignored.
+func CommitTrailerIDs(message string) []string {
+	paragraphs := strings.Split(strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n")), "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+	var ids []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(paragraphs[len(paragraphs)-1], "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), CommitTrailer) {
+			continue
+		}
+		value, _, _ = strings.Cut(value, "(")
+		for _, id := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,58 @@
+package felt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCommitTrailerIDs(t *testing.T) {
+	cases := []struct {
+		message string
+		want    []string
+	}{
+		{"Fix the fit\n\nFelt: fit/prep\n", []string{"fit/prep"}},
+		{"Fix the fit\n\nSome body.\n\nFelt: a, b\nfelt: c (Tune the fit)\nSigned-off-by: x\nFelt: a\n", []string{"a", "b", "c"}},
+		{"Felt: only-a-subject\n", nil},
+		{"Fix\n\nFelt: early\n\nBody after.\n", nil},
+	}
+	for _, tc := range cases {
+		if got := CommitTrailerIDs(tc.message); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("CommitTrailerIDs(%q) = %v, want %v", tc.message, got, tc.want)
+		}
+	}
+}
+
+func TestRecordCommit(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "tune", Name: "Tune", Status: StatusActive, CreatedAt: time.Now(), Body: "Notes."}); err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	first := Commit{SHA: "1111111aaaa", Subject: "Fit the model", At: at}
+	for i := 0; i < 2; i++ {
+		added, err := s.RecordCommit("tune", first)
+		if err != nil || added != (i == 0) {
+			t.Fatalf("RecordCommit #%d = %v, %v", i+1, added, err)
+		}
+	}
+	if _, err := s.RecordCommit("tune", Commit{SHA: "2222222bbbb", Subject: "Refit", At: at.Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := s.Read("tune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commits := f.Commits()
+	if len(commits) != 2 || commits[0] != first || commits[1].SHA != "2222222bbbb" {
+		t.Fatalf("Commits = %+v", commits)
+	}
+	if f.Body != "Notes." || f.UpdatedAt == nil || !f.UpdatedAt.Equal(at.Add(time.Hour)) {
+		t.Fatalf("fiber after RecordCommit = %+v", f)
+	}
+}
@@ -0,0 +1,110 @@
+package felt
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfidenceKey is the conventional frontmatter key for how solid a decision
+// is: a level (low, medium, high) or a number from 0 to 1.
+const ConfidenceKey = "confidence"
+
+// Confidence levels.
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// ConfidenceLevels lists the levels from least to most confident.
+var ConfidenceLevels = []string{ConfidenceLow, ConfidenceMedium, ConfidenceHigh}
+
+// Confidence is a parsed `confidence:` value. Level is always set; Value is
+// the number as written, or the level's midpoint (0.2, 0.55, 0.85) so
+// worded and numeric confidences order together. Numeric reports whether a
+// number was written.
+type Confidence struct {
+	Level   string
+	Value   float64
+	Numeric bool
+}
+
+// String renders the level, with the number when one was written:
+// "medium", or "medium (0.4)".
+func (c Confidence) String() string {
+	if c.Numeric {
+		return c.Level + " (" + strconv.FormatFloat(c.Value, 'f', -1, 64) + ")"
+	}
+	return c.Level
+}
+
+// ParseConfidence reads a level or a number in [0, 1]. Numbers below 0.4 are
+// low, below 0.7 medium, and the rest high.
+func ParseConfidence(s string) (Confidence, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case ConfidenceLow:
+		return Confidence{Level: s, Value: 0.2}, nil
+	case ConfidenceMedium:
+		return Confidence{Level: s, Value: 0.55}, nil
+	case ConfidenceHigh:
+		return Confidence{Level: s, Value: 0.85}, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 0 || v > 1 {
+		return Confidence{}, fmt.Errorf("invalid confidence %q (use low, medium, high, or a number from 0 to 1)", s)
+	}
+	c := Confidence{Level: ConfidenceHigh, Value: v, Numeric: true}
+	switch {
+	case v < 0.4:
+		c.Level = ConfidenceLow
+	case v < 0.7:
+		c.Level = ConfidenceMedium
+	}
+	return c, nil
+}
+
+// Confidence returns f's `confidence:` value. ok is false when the field is
+// absent or malformed; `felt check` reports the malformed case.
+func (f *Felt) Confidence() (Confidence, bool) {
+	node := extraFieldNode(f.ExtraFields, ConfidenceKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return Confidence{}, false
+	}
+	c, err := ParseConfidence(node.Value)
+	if err != nil {
+		return Confidence{}, false
+	}
+	return c, true
+}
+
+// ShakyUpstreams returns the transitive data-flow ancestors of id that carry
+// a confidence below high, least confident first (ties by id) — the ground
+// worth revisiting before building further on id.
+func ShakyUpstreams(felts []*Felt, id string) []*Felt {
+	byID := make(map[string]*Felt, len(felts))
+	for _, f := range felts {
+		byID[f.ID] = f
+	}
+	var shaky []*Felt
+	for _, next := range walkDataFlow(DataFlowUpstreams(felts), id) {
+		if f := byID[next]; f != nil {
+			if c, ok := f.Confidence(); ok && c.Level != ConfidenceHigh {
+				shaky = append(shaky, f)
+			}
+		}
+	}
+	sort.SliceStable(shaky, func(i, j int) bool {
+		ci, _ := shaky[i].Confidence()
+		cj, _ := shaky[j].Confidence()
+		if ci.Value != cj.Value {
+			return ci.Value < cj.Value
+		}
+		return shaky[i].ID < shaky[j].ID
+	})
+	return shaky
+}
@@ -0,0 +1,63 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfidence(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"low", "low"},
+		{" High ", "high"},
+		{"0.2", "low (0.2)"},
+		{"0.4", "medium (0.4)"},
+		{"0.7", "high (0.7)"},
+		{"1", "high (1)"},
+	}
+	for _, c := range cases {
+		got, err := ParseConfidence(c.in)
+		if err != nil {
+			t.Fatalf("ParseConfidence(%q): %v", c.in, err)
+		}
+		if got.String() != c.want {
+			t.Errorf("ParseConfidence(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+	for _, bad := range []string{"", "sure", "1.5", "-0.1"} {
+		if _, err := ParseConfidence(bad); err == nil {
+			t.Errorf("ParseConfidence(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestShakyUpstreamsOrdersLeastConfidentFirst(t *testing.T) {
+	prior := &Felt{ID: "prior", Tags: []string{"decision"}}
+	mustExtra(t, prior, ConfidenceKey, "medium")
+	mask := &Felt{ID: "mask", Tags: []string{"decision"}}
+	mustExtra(t, mask, ConfidenceKey, 0.3)
+	mustExtra(t, mask, "inputs", []map[string]any{{"id": "prior", "from": "prior"}})
+	binning := &Felt{ID: "binning", Tags: []string{"decision"}}
+	mustExtra(t, binning, ConfidenceKey, "high")
+	fit := &Felt{ID: "fit"}
+	mustExtra(t, fit, "inputs", []map[string]any{{"id": "mask", "from": "mask"}, {"id": "bins", "from": "binning"}})
+
+	var got []string
+	for _, f := range ShakyUpstreams([]*Felt{prior, mask, binning, fit}, "fit") {
+		got = append(got, f.ID)
+	}
+	if strings.Join(got, ",") != "mask,prior" {
+		t.Fatalf("ShakyUpstreams(fit) = %v, want [mask prior]", got)
+	}
+}
+
+func TestCheckMalformedConfidence(t *testing.T) {
+	f := &Felt{ID: "choice", Name: "Choice"}
+	mustExtra(t, f, ConfidenceKey, "pretty sure")
+	issues := Check([]*Felt{f})
+	if len(issues) != 1 || issues[0].Path != "frontmatter.confidence" {
+		t.Fatalf("Check() = %+v, want one frontmatter.confidence issue", issues)
+	}
+}
@@ -0,0 +1,266 @@
+package felt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigName is the optional per-store settings file, `.felt/config.yaml`.
+// It holds planning knobs only — never fiber content — and every setting has
+// a usable zero value, so a store without the file behaves exactly as before.
+const ConfigName = "config.yaml"
+
+// Config is the decoded `.felt/config.yaml`.
+type Config struct {
+	Capacity CapacityConfig `yaml:"capacity,omitempty"`
+	WIP      WIPConfig      `yaml:"wip,omitempty"`
+	Access   AccessConfig   `yaml:"access,omitempty"`
+	Hook     HookConfig     `yaml:"hook,omitempty"`
+	Display  DisplayConfig  `yaml:"display,omitempty"`
+	Slug     SlugConfig     `yaml:"slug,omitempty"`
+	Owner    OwnerConfig    `yaml:"owner,omitempty"`
+	Status   StatusConfig   `yaml:"status,omitempty"`
+}
+
+// CapacityConfig describes how much estimated work fits in a day. Daily is a
+// span (e.g. "6h"); empty means no capacity is configured.
+type CapacityConfig struct {
+	Daily string `yaml:"daily,omitempty"`
+}
+
+// WIPConfig tunes work-in-progress alerts. MaxAge is a span (e.g. "7d"):
+// active fibers active longer than it are flagged at session start. Limit
+// is how many fibers may be active at once before `felt stats --health`
+// counts WIP against the project.
+type WIPConfig struct {
+	MaxAge string `yaml:"max-age,omitempty"`
+	Limit  int    `yaml:"limit,omitempty"`
+}
+
+// AccessConfig turns on the read log. With Log set, `felt show` records
+// each read in AccessLogName, feeding `felt ls --sort last-read` and the
+// unread-decision warning in `felt check`.
+type AccessConfig struct {
+	Log bool `yaml:"log,omitempty"`
+}
+
+// HookConfig tunes the hooks. With IncludeParents set, a store nested
+// inside another felt project (a paper repo inside its project repo) also
+// lists the in-flight fibers of every enclosing store, each under its own
+// labelled section, instead of showing the nearest store alone. With Log
+// set, every payload a hook reads is saved under HookLogDirName, keeping
+// the newest LogKeep files. With Digest set, the SessionEnd hook records
+// what each session changed in the day's log fiber (DailyLogContainerID).
+// Quotas caps how many fibers per tag the session context lists, in-flight
+// and ready alike (see ApplyTagQuotas): `"thread:": 3` keeps any one thread
+// to three, so the rest of the work still shows. Stop picks what the Stop
+// hook does with active fibers when the agent ends its turn: HookStopRemind
+// (the default), HookStopBlock, or HookStopOff. Transcript configures the
+// transcript-mining hook (see TranscriptHookConfig).
+type HookConfig struct {
+	IncludeParents bool                 `yaml:"include-parents,omitempty"`
+	Log            bool                 `yaml:"log,omitempty"`
+	LogKeep        int                  `yaml:"log-keep,omitempty"`
+	Digest         bool                 `yaml:"digest,omitempty"`
+	Quotas         map[string]int       `yaml:"quotas,omitempty"`
+	Stop           string               `yaml:"stop,omitempty"`
+	Transcript     TranscriptHookConfig `yaml:"transcript,omitempty"`
+}
+
+// TranscriptHookConfig tunes `felt hook transcript`. With Mine set, the
+// hook files session decisions and open questions as fibers, beneath Under
+// when it names one. Decisions and Questions are extra regular expressions
+// for each kind, matched against every line of the session's text; a
+// pattern's first capture group, if it has one, names the fiber.
+type TranscriptHookConfig struct {
+	Mine      bool     `yaml:"mine,omitempty"`
+	Under     string   `yaml:"under,omitempty"`
+	Decisions []string `yaml:"decisions,omitempty"`
+	Questions []string `yaml:"questions,omitempty"`
+}
+
+// Stop hook modes for HookConfig.Stop.
+const (
+	HookStopRemind = "remind"
+	HookStopBlock  = "block"
+	HookStopOff    = "off"
+)
+
+// OwnerConfig sets the owner new fibers are given, and that `--owner me`
+// names, when FELT_OWNER (OwnerEnv) is unset. MaxActive is how many fibers
+// one owner may have active before `felt workload` flags them as
+// overloaded; zero means no limit.
+type OwnerConfig struct {
+	Default   string `yaml:"default,omitempty"`
+	MaxActive int    `yaml:"max-active,omitempty"`
+}
+
+// StatusConfig constrains status changes. Transitions maps a status (open,
+// active, closed, or none for untracked fibers) to the statuses it may
+// become: `closed: []` keeps closed work closed. A status without an entry
+// may become anything, as every status may when no transitions are set.
+// Reopening on purpose — felt edit --reopen, an apply reopen op, felt
+// invalidate — is always allowed.
+type StatusConfig struct {
+	Transitions map[string][]string `yaml:"transitions,omitempty"`
+}
+
+// DisplayConfig tunes text rendering. ASCII replaces the unicode status
+// icons with bracketed words, as --ascii does for one invocation. Icons
+// remaps status icons by status (open, active, closed, or none for
+// untracked fibers) and wins over ASCII; TagIcons adds a glyph after the
+// status icon of fibers carrying the tag; Headers retitles session
+// sections by their default title ("Active / Open", "Waking Today",
+// "Recently Touched", "Aging WIP", "Attention", "Parent Project"). Timezone is an IANA zone name
+// (e.g. "Europe/Paris") timestamps are shown in; empty means the machine's
+// local zone. Timestamps are always stored in UTC.
+type DisplayConfig struct {
+	ASCII    bool              `yaml:"ascii,omitempty"`
+	Icons    map[string]string `yaml:"icons,omitempty"`
+	TagIcons map[string]string `yaml:"tag-icons,omitempty"`
+	Headers  map[string]string `yaml:"headers,omitempty"`
+	Timezone string            `yaml:"timezone,omitempty"`
+}
+
+// SlugConfig tunes slugs derived from user text. With Transliterate set,
+// accented, Greek, and Cyrillic letters are romanized ("Größe" → "grosse")
+// instead of kept as unicode; letters of other scripts are kept either way.
+type SlugConfig struct {
+	Transliterate bool `yaml:"transliterate,omitempty"`
+}
+
+// DefaultWIPMaxAge is the aging threshold when wip.max-age is unset.
+const DefaultWIPMaxAge = 7 * 24 * time.Hour
+
+// DefaultWIPLimit is the concurrent-active limit when wip.limit is unset.
+const DefaultWIPLimit = 5
+
+// DefaultHookLogKeep is how many payloads the hook log keeps when
+// hook.log-keep is unset.
+const DefaultHookLogKeep = 200
+
+// ConfigPath returns the path of the store's config file.
+func (s *Storage) ConfigPath() string {
+	return filepath.Join(s.root, ConfigName)
+}
+
+// LoadConfig reads `.felt/config.yaml`. A missing file yields the zero Config.
+func (s *Storage) LoadConfig() (*Config, error) {
+	data, err := os.ReadFile(s.ConfigPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ConfigName, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ConfigName, err)
+	}
+	return &cfg, nil
+}
+
+// DailyCapacity returns the configured daily capacity. ok is false when none
+// is configured; a malformed value is an error rather than silently ignored.
+func (c *Config) DailyCapacity() (d time.Duration, ok bool, err error) {
+	if c == nil || c.Capacity.Daily == "" {
+		return 0, false, nil
+	}
+	d, err = ParseSpan(c.Capacity.Daily)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s capacity.daily: %w", ConfigName, err)
+	}
+	return d, true, nil
+}
+
+// WIPMaxAge returns the configured aging threshold, or DefaultWIPMaxAge.
+func (c *Config) WIPMaxAge() (time.Duration, error) {
+	if c == nil || c.WIP.MaxAge == "" {
+		return DefaultWIPMaxAge, nil
+	}
+	d, err := ParseSpan(c.WIP.MaxAge)
+	if err != nil {
+		return 0, fmt.Errorf("%s wip.max-age: %w", ConfigName, err)
+	}
+	return d, nil
+}
+
+// DisplayLocation returns the zone display.timezone names, or nil when none
+// is configured (show local time).
+func (c *Config) DisplayLocation() (*time.Location, error) {
+	if c == nil || c.Display.Timezone == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(c.Display.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%s display.timezone: %w", ConfigName, err)
+	}
+	return loc, nil
+}
+
+// WIPLimit returns the configured concurrent-active limit, or
+// DefaultWIPLimit.
+func (c *Config) WIPLimit() int {
+	if c == nil || c.WIP.Limit <= 0 {
+		return DefaultWIPLimit
+	}
+	return c.WIP.Limit
+}
+
+// HookLogKeep returns how many payload files the hook log keeps.
+func (c *Config) HookLogKeep() int {
+	if c == nil || c.Hook.LogKeep <= 0 {
+		return DefaultHookLogKeep
+	}
+	return c.Hook.LogKeep
+}
+
+// CheckStatusTransition returns an error when status.transitions forbids a
+// fiber moving from one status to another, naming what is allowed.
+// Staying put is always allowed.
+func (c *Config) CheckStatusTransition(from, to string) error {
+	if c == nil || from == to || len(c.Status.Transitions) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(c.Status.Transitions))
+	for key := range c.Status.Transitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		for _, status := range append([]string{key}, c.Status.Transitions[key]...) {
+			switch status {
+			case StatusOpen, StatusActive, StatusClosed, statusNone:
+			default:
+				return fmt.Errorf("%s status.transitions: unknown status %q (use open, active, closed, or none)", ConfigName, status)
+			}
+		}
+	}
+	allowed, ok := c.Status.Transitions[statusLabel(from)]
+	if !ok || slices.Contains(allowed, statusLabel(to)) {
+		return nil
+	}
+	next := "nothing else"
+	if len(allowed) > 0 {
+		next = strings.Join(allowed, ", ")
+	}
+	return fmt.Errorf("status %s → %s is not allowed by status.transitions in %s (%s may become %s)", statusLabel(from), statusLabel(to), ConfigName, statusLabel(from), next)
+}
+
+// statusNone names the untracked status in config, where "" cannot be a key.
+const statusNone = "none"
+
+func statusLabel(status string) string {
+	if status == "" {
+		return statusNone
+	}
+	return status
+}
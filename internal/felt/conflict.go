@@ -0,0 +1,195 @@
+package felt
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// syncConflictPatterns match the names file-sync tools give the second copy
+// of a file edited on two machines at once:
+//
+//	fiber (conflicted copy).md                     Dropbox, Nextcloud
+//	fiber (Ada's conflicted copy 2026-04-10).md    Dropbox
+//	fiber.sync-conflict-20260410-090000-ABC123.md  Syncthing
+var syncConflictPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i) \([^()]*conflicted copy[^()]*\)`),
+	regexp.MustCompile(`\.sync-conflict-\d{8}-\d{6}(-[A-Za-z0-9]+)?`),
+}
+
+// IsSyncConflictName reports whether a file or directory name is a sync
+// tool's conflicted copy. Listing skips these, so a conflicted copy is never
+// read as a fiber or reported as a broken one; `felt doctor` surfaces them.
+func IsSyncConflictName(name string) bool {
+	return syncConflictOriginalName(name) != name
+}
+
+// syncConflictOriginalName strips the conflict marker from name.
+func syncConflictOriginalName(name string) string {
+	for _, re := range syncConflictPatterns {
+		name = re.ReplaceAllString(name, "")
+	}
+	return name
+}
+
+// SyncConflict is one conflicted copy inside .felt/. Path and Original are
+// relative to the store root; FiberID is the fiber the copy belongs to, ""
+// when the original is not inside a fiber. IsFiber is true when the copy is
+// of the fiber's own file — the case `felt doctor` can merge field by field.
+type SyncConflict struct {
+	Path     string `json:"path"`
+	Original string `json:"original"`
+	FiberID  string `json:"fiber_id,omitempty"`
+	IsDir    bool   `json:"is_dir,omitempty"`
+	IsFiber  bool   `json:"is_fiber,omitempty"`
+}
+
+// Conflict resolutions for ResolveSyncConflict.
+const (
+	ConflictKeep  = "keep"  // keep the original, discard the copy
+	ConflictTake  = "take"  // replace the original with the copy
+	ConflictMerge = "merge" // field-level merge of a fiber copy into the original
+)
+
+// FindSyncConflicts walks the store for conflicted copies. A conflicted
+// directory is reported once, not file by file.
+func (s *Storage) FindSyncConflicts() ([]SyncConflict, error) {
+	root, err := filepath.EvalSymlinks(s.root)
+	if err != nil {
+		return nil, fmt.Errorf("resolving .felt path: %w", err)
+	}
+	var conflicts []SyncConflict
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == root || !IsSyncConflictName(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		original := filepath.Join(filepath.Dir(rel), syncConflictOriginalName(d.Name()))
+		c := SyncConflict{Path: filepath.ToSlash(rel), Original: filepath.ToSlash(original), IsDir: d.IsDir()}
+		if id, _, ok := fiberIDFromRelativePath(original); ok && !d.IsDir() {
+			c.FiberID, c.IsFiber = id, true
+		} else if c.IsDir {
+			c.FiberID = c.Original
+		} else if dir := filepath.Dir(original); dir != "." {
+			c.FiberID = filepath.ToSlash(dir)
+		}
+		conflicts = append(conflicts, c)
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking .felt directory: %w", err)
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Path < conflicts[j].Path })
+	return conflicts, nil
+}
+
+// SyncConflictFields lists the top-level fields (and "body") in which a
+// fiber copy differs from its original, so a resolver can see what is at
+// stake before choosing.
+func (s *Storage) SyncConflictFields(c SyncConflict) ([]string, error) {
+	current, copied, err := s.syncConflictVersions(c)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]struct{})
+	for k := range current.fields {
+		keys[k] = struct{}{}
+	}
+	for k := range copied.fields {
+		keys[k] = struct{}{}
+	}
+	var differ []string
+	for k := range keys {
+		cv, cok := current.fields[k]
+		pv, pok := copied.fields[k]
+		if cok != pok || cv != pv {
+			if k == syncBodyField {
+				k = "body"
+			}
+			differ = append(differ, k)
+		}
+	}
+	sort.Strings(differ)
+	return differ, nil
+}
+
+// ResolveSyncConflict applies resolution to c and removes the copy. Merge
+// uses the first-sync rule of SyncPeer: fields present in only one version
+// are kept and differing fields take the version with the later updated-at.
+func (s *Storage) ResolveSyncConflict(c SyncConflict, resolution string) error {
+	copyPath := filepath.Join(s.root, filepath.FromSlash(c.Path))
+	originalPath := filepath.Join(s.root, filepath.FromSlash(c.Original))
+	switch resolution {
+	case ConflictKeep:
+		return os.RemoveAll(copyPath)
+	case ConflictTake:
+		if c.IsDir {
+			if err := os.RemoveAll(originalPath); err != nil {
+				return err
+			}
+		}
+		return os.Rename(copyPath, originalPath)
+	case ConflictMerge:
+		if !c.IsFiber {
+			return fmt.Errorf("%s: only a fiber's own file can be merged; keep or take it", c.Path)
+		}
+		current, copied, err := s.syncConflictVersions(c)
+		if err != nil {
+			return err
+		}
+		merged, _ := mergeSyncFields(current, copied, nil, false)
+		if err := syncWrite(s, c.FiberID, merged, slices.Concat(current.order, copied.order), false); err != nil {
+			return err
+		}
+		return os.Remove(copyPath)
+	}
+	return fmt.Errorf("unknown conflict resolution %q", resolution)
+}
+
+func (s *Storage) syncConflictVersions(c SyncConflict) (current, copied syncFiber, err error) {
+	if !c.IsFiber {
+		return current, copied, fmt.Errorf("%s is not a copy of a fiber file", c.Path)
+	}
+	f, err := s.Read(c.FiberID)
+	if err != nil {
+		return current, copied, err
+	}
+	data, err := os.ReadFile(filepath.Join(s.root, filepath.FromSlash(c.Path)))
+	if err != nil {
+		return current, copied, err
+	}
+	g, err := Parse(c.FiberID, data)
+	if err != nil {
+		return current, copied, fmt.Errorf("%s: %w", c.Path, err)
+	}
+	for _, v := range []struct {
+		f   *Felt
+		out *syncFiber
+	}{{f, &current}, {g, &copied}} {
+		fields, err := syncFields(v.f)
+		if err != nil {
+			return current, copied, err
+		}
+		*v.out = syncFiber{fields: fields, updated: v.f.RecencyAnchor(), order: v.f.ExtraFieldOrder}
+	}
+	return current, copied, nil
+}
+
+// String renders c for listings.
+func (c SyncConflict) String() string {
+	return fmt.Sprintf("%s (conflicted copy of %s)", c.Path, strings.TrimPrefix(c.Original, "./"))
+}
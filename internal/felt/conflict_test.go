@@ -0,0 +1,100 @@
+package felt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsSyncConflictName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"fit (conflicted copy).md":                     true,
+		"fit (Ada's conflicted copy 2026-04-10).md":    true,
+		"fit.sync-conflict-20260410-090000-ABCDEFG.md": true,
+		"fit (conflicted copy 2026-04-10 090000)":      true,
+		"fit.md":           false,
+		"notes (draft).md": false,
+		"sync-conflict.md": false,
+	} {
+		if got := IsSyncConflictName(name); got != want {
+			t.Errorf("IsSyncConflictName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSyncConflictsSkippedByListAndMerged(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	t0 := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	current := &Felt{ID: "fit", Name: "Fit", Status: StatusOpen, CreatedAt: t0, Body: "current"}
+	if err := s.Write(current); err != nil {
+		t.Fatal(err)
+	}
+	// The copy came from a machine that later closed the fiber and tagged it.
+	copied := &Felt{ID: "fit", Name: "Fit", Status: StatusClosed, Tags: []string{"done"}, CreatedAt: t0, Body: "current"}
+	copied.Touch(t0.Add(time.Hour))
+	data, err := copied.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	copyPath := filepath.Join(dir, DirName, "fit", "fit (conflicted copy).md")
+	if err := os.WriteFile(copyPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, DirName, "fit.sync-conflict-20260410-090000-ABC"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	felts, err := s.List()
+	if err != nil || len(felts) != 1 || felts[0].ID != "fit" {
+		t.Fatalf("List() = %v, %v; conflicted copies should be skipped", felts, err)
+	}
+
+	conflicts, err := s.FindSyncConflicts()
+	if err != nil || len(conflicts) != 2 {
+		t.Fatalf("FindSyncConflicts() = %+v, %v", conflicts, err)
+	}
+	// Sorted by path: "fit.sync-conflict-…" sorts before "fit/…".
+	dirCopy, fileCopy := conflicts[0], conflicts[1]
+	if !fileCopy.IsFiber || fileCopy.FiberID != "fit" || fileCopy.Original != "fit/fit.md" {
+		t.Fatalf("file conflict = %+v", fileCopy)
+	}
+	if !dirCopy.IsDir || dirCopy.Original != "fit" {
+		t.Fatalf("dir conflict = %+v", dirCopy)
+	}
+
+	fields, err := s.SyncConflictFields(fileCopy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 3 || fields[0] != "status" || fields[1] != "tags" || fields[2] != "updated-at" {
+		t.Fatalf("differing fields = %v", fields)
+	}
+
+	if err := s.ResolveSyncConflict(fileCopy, ConflictMerge); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	got, err := s.Read("fit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusClosed || len(got.Tags) != 1 || got.Body != "current" {
+		t.Fatalf("merged fiber = status %q tags %v body %q", got.Status, got.Tags, got.Body)
+	}
+	if _, err := os.Stat(copyPath); !os.IsNotExist(err) {
+		t.Fatalf("merged copy should be removed, stat err = %v", err)
+	}
+	if err := s.ResolveSyncConflict(dirCopy, ConflictMerge); err == nil {
+		t.Fatal("merging a directory copy should fail")
+	}
+	if err := s.ResolveSyncConflict(dirCopy, ConflictKeep); err != nil {
+		t.Fatal(err)
+	}
+	if conflicts, _ := s.FindSyncConflicts(); len(conflicts) != 0 {
+		t.Fatalf("conflicts left = %+v", conflicts)
+	}
+}
@@ -0,0 +1,150 @@
+package felt
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CostKey is the conventional frontmatter key for what a fiber spent. It is
+// either a mapping of unit to amount,
+//
+//	cost:
+//	  gpu-hours: 12
+//	  usd: 40
+//
+// or a scalar shorthand for one unit: "12 gpu-hours", "$40" (usd).
+const CostKey = "cost"
+
+// Cost is spend per unit. Units are compared lowercased.
+type Cost map[string]float64
+
+// Cost returns f's `cost:` field. A fiber without one returns nil; a
+// malformed one is an error naming the fiber.
+func (f *Felt) Cost() (Cost, error) {
+	node := extraFieldNode(f.ExtraFields, CostKey)
+	if node == nil {
+		return nil, nil
+	}
+	cost, err := parseCostNode(node)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", f.ID, CostKey, err)
+	}
+	return cost, nil
+}
+
+func parseCostNode(node *yaml.Node) (Cost, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		unit, amount, err := parseCostScalar(node.Value)
+		if err != nil {
+			return nil, err
+		}
+		return Cost{unit: amount}, nil
+	case yaml.MappingNode:
+		cost := Cost{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			unit := strings.ToLower(strings.TrimSpace(node.Content[i].Value))
+			value := node.Content[i+1]
+			if unit == "" || value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("want unit: amount pairs")
+			}
+			amount, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(value.Value), "$"), 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: amount %q is not a number", unit, value.Value)
+			}
+			cost[unit] += amount
+		}
+		return cost, nil
+	default:
+		return nil, fmt.Errorf("want a mapping of unit to amount or \"<amount> <unit>\"")
+	}
+}
+
+// parseCostScalar reads "<amount> <unit>" or "$<amount>".
+func parseCostScalar(s string) (string, float64, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "$"); ok {
+		amount, err := strconv.ParseFloat(strings.TrimSpace(rest), 64)
+		if err != nil {
+			return "", 0, fmt.Errorf("amount %q is not a number", rest)
+		}
+		return "usd", amount, nil
+	}
+	amountStr, unit, ok := strings.Cut(s, " ")
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	if !ok || unit == "" {
+		return "", 0, fmt.Errorf("%q: want \"<amount> <unit>\" or \"$<amount>\"", s)
+	}
+	amount, err := strconv.ParseFloat(amountStr, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("amount %q is not a number", amountStr)
+	}
+	return unit, amount, nil
+}
+
+// Add accumulates other into c.
+func (c Cost) Add(other Cost) {
+	for unit, amount := range other {
+		c[unit] += amount
+	}
+}
+
+// String renders c as "12 gpu-hours, 40 usd", units sorted.
+func (c Cost) String() string {
+	units := make([]string, 0, len(c))
+	for unit := range c {
+		units = append(units, unit)
+	}
+	sort.Strings(units)
+	parts := make([]string, 0, len(units))
+	for _, unit := range units {
+		parts = append(parts, strconv.FormatFloat(c[unit], 'f', -1, 64)+" "+unit)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// UntaggedCostGroup labels spend on fibers without tags in a CostRollup.
+const UntaggedCostGroup = "(untagged)"
+
+// CostRollup is spend across a set of fibers: the total, how many fibers
+// reported any, and the same split by tag. A fiber with several tags counts
+// toward each, so the per-tag rows can sum past the total.
+type CostRollup struct {
+	Total  Cost            `json:"total"`
+	Fibers int             `json:"fibers"`
+	ByTag  map[string]Cost `json:"by_tag"`
+}
+
+// RollupCosts sums `cost:` over felts. Malformed cost fields are returned as
+// errors alongside the rollup of every fiber that parsed.
+func RollupCosts(felts []*Felt) (*CostRollup, []error) {
+	r := &CostRollup{Total: Cost{}, ByTag: map[string]Cost{}}
+	var errs []error
+	for _, f := range felts {
+		cost, err := f.Cost()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(cost) == 0 {
+			continue
+		}
+		r.Fibers++
+		r.Total.Add(cost)
+		tags := f.Tags
+		if len(tags) == 0 {
+			tags = []string{UntaggedCostGroup}
+		}
+		for _, tag := range tags {
+			if r.ByTag[tag] == nil {
+				r.ByTag[tag] = Cost{}
+			}
+			r.ByTag[tag].Add(cost)
+		}
+	}
+	return r, errs
+}
@@ -0,0 +1,30 @@
+package felt
+
+import (
+	"testing"
+)
+
+func TestRollupCostsByTag(t *testing.T) {
+	mapped := &Felt{ID: "train", Name: "Train", Tags: []string{"cosebis", "gpu"}}
+	mustExtraField(t, mapped, CostKey, map[string]any{"GPU-hours": 12, "usd": 30.5})
+	dollars := &Felt{ID: "api", Name: "API", Tags: []string{"cosebis"}}
+	mustExtraField(t, dollars, CostKey, "$9.5")
+	units := &Felt{ID: "sweep", Name: "Sweep"}
+	mustExtraField(t, units, CostKey, "3 gpu-hours")
+	bad := &Felt{ID: "bad", Name: "Bad"}
+	mustExtraField(t, bad, CostKey, "lots")
+
+	r, errs := RollupCosts([]*Felt{mapped, dollars, units, bad, {ID: "free", Name: "Free"}})
+	if len(errs) != 1 {
+		t.Fatalf("errs = %v, want the malformed cost reported", errs)
+	}
+	if r.Fibers != 3 || r.Total.String() != "15 gpu-hours, 40 usd" {
+		t.Fatalf("total = %s over %d fibers", r.Total, r.Fibers)
+	}
+	if got := r.ByTag["cosebis"].String(); got != "12 gpu-hours, 40 usd" {
+		t.Fatalf("cosebis = %s", got)
+	}
+	if got := r.ByTag[UntaggedCostGroup].String(); got != "3 gpu-hours" {
+		t.Fatalf("untagged = %s", got)
+	}
+}
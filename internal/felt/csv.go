@@ -0,0 +1,287 @@
+package felt
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CSVColumns are the columns of felt's spreadsheet interchange format, in
+// the order `felt ls --csv` writes them and `felt ingest csv` reads them:
+//
+//	id          the fiber id
+//	title       the fiber's name
+//	status      open, active, or closed; empty is a statusless note,
+//	            as felt add makes without -s
+//	tags        tags, separated by ";"
+//	depends_on  the fibers it reads (inputs[].from), separated by ";"
+//	created     created-at, RFC 3339 (a bare 2006-01-02 date on import)
+//	closed      closed-at, the same; empty while open
+//	outcome     the outcome
+//
+// The same columns, tab-separated, are the TSV format.
+var CSVColumns = []string{"id", "title", "status", "tags", "depends_on", "created", "closed", "outcome"}
+
+// csvListSep separates the values of the tags and depends_on columns.
+const csvListSep = ";"
+
+// WriteCSV writes felts as CSVColumns rows under a header line, separated
+// by comma (',' for CSV, '\t' for TSV).
+func WriteCSV(w io.Writer, felts []*Felt, comma rune) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	if err := cw.Write(CSVColumns); err != nil {
+		return err
+	}
+	for _, f := range felts {
+		var deps []string
+		for _, in := range f.DataFlowInputs() {
+			if in.From != "" {
+				deps = append(deps, in.From)
+			}
+		}
+		closed := ""
+		if f.ClosedAt != nil {
+			closed = f.ClosedAt.UTC().Format(time.RFC3339)
+		}
+		row := []string{
+			f.ID,
+			f.DisplayName(),
+			f.Status,
+			strings.Join(f.Tags, csvListSep),
+			strings.Join(deps, csvListSep),
+			f.CreatedAt.UTC().Format(time.RFC3339),
+			closed,
+			f.Outcome,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVRow is one data row of an import file. ID and DependsOn are as the
+// file wrote them: ids in the source, not yet fibers in this store.
+type CSVRow struct {
+	Line      int        `json:"line"`
+	ID        string     `json:"id,omitempty"`
+	Title     string     `json:"title"`
+	Status    string     `json:"status,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	DependsOn []string   `json:"depends_on,omitempty"`
+	Created   *time.Time `json:"created,omitempty"`
+	Closed    *time.Time `json:"closed,omitempty"`
+	Outcome   string     `json:"outcome,omitempty"`
+}
+
+// ReadCSV parses an import file separated by comma. The header line names
+// the columns, in any order and any case; title is required, the other
+// CSVColumns are optional, and any other column is an error rather than
+// data silently dropped.
+func ReadCSV(r io.Reader, comma rune) ([]CSVRow, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("empty file: want a header line naming the columns")
+	}
+	if err != nil {
+		return nil, err
+	}
+	col := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		known := false
+		for _, c := range CSVColumns {
+			known = known || c == name
+		}
+		if !known {
+			return nil, fmt.Errorf("line 1: unknown column %q (known: %s)", name, strings.Join(CSVColumns, ", "))
+		}
+		if _, dup := col[name]; dup {
+			return nil, fmt.Errorf("line 1: column %q appears twice", name)
+		}
+		col[name] = i
+	}
+	if _, ok := col["title"]; !ok {
+		return nil, fmt.Errorf("line 1: missing the title column")
+	}
+
+	var rows []CSVRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			i, ok := col[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if strings.Join(record, "") == "" {
+			continue // a blank line of separators
+		}
+		row := CSVRow{
+			Line:      line,
+			ID:        field("id"),
+			Title:     field("title"),
+			Status:    strings.ToLower(field("status")),
+			Tags:      splitCSVList(field("tags")),
+			DependsOn: splitCSVList(field("depends_on")),
+			Outcome:   field("outcome"),
+		}
+		if row.Title == "" {
+			return nil, fmt.Errorf("line %d: title is empty", line)
+		}
+		if row.Created, err = parseCSVTime(field("created")); err != nil {
+			return nil, fmt.Errorf("line %d: created: %w", line, err)
+		}
+		if row.Closed, err = parseCSVTime(field("closed")); err != nil {
+			return nil, fmt.Errorf("line %d: closed: %w", line, err)
+		}
+		switch row.Status {
+		case "", StatusOpen, StatusActive, StatusClosed:
+		default:
+			return nil, fmt.Errorf("line %d: invalid status %q (valid: open, active, closed)", line, row.Status)
+		}
+		if row.Closed != nil {
+			if row.Status == "" {
+				row.Status = StatusClosed
+			} else if row.Status != StatusClosed {
+				return nil, fmt.Errorf("line %d: closed is set but status is %s", line, row.Status)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func splitCSVList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, csvListSep) {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func parseCSVTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not an RFC 3339 time or a 2006-01-02 date", s)
+	}
+	return &t, nil
+}
+
+// CSVImportOps turns rows into an apply batch for ApplyOps: an add per row,
+// then a link per dependency. A row keeps its id when that is a valid,
+// unused fiber id whose parent exists; otherwise its id is generated from
+// its title, disambiguated against the store and the rest of the file. A
+// depends_on value naming another row's source id reads that row's new
+// fiber; any other resolves against the store's existing fibers.
+func (s *Storage) CSVImportOps(rows []CSVRow) ([]ApplyOp, error) {
+	felts, err := s.ListMetadata()
+	if err != nil {
+		return nil, err
+	}
+	existing := make([]string, 0, len(felts))
+	reserved := make(map[string]struct{}, len(felts)+len(rows))
+	for _, f := range felts {
+		existing = append(existing, f.ID)
+		reserved[f.ID] = struct{}{}
+	}
+	sort.Strings(existing)
+
+	assigned := make(map[string]string, len(rows)) // source id -> new id
+	ops := make([]ApplyOp, 0, len(rows))
+	for _, row := range rows {
+		id := ""
+		if row.ID != "" && SlugifyPath(row.ID) == row.ID {
+			_, taken := reserved[row.ID]
+			_, parentOK := reserved[path.Dir(row.ID)]
+			if !taken && (!strings.Contains(row.ID, "/") || parentOK) {
+				id = row.ID
+			}
+		}
+		if id == "" {
+			base, err := GenerateID(row.Title)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", row.Line, err)
+			}
+			if id, err = s.nextAvailableMigrationID(base, reserved); err != nil {
+				return nil, err
+			}
+		}
+		reserved[id] = struct{}{}
+		if row.ID != "" {
+			if _, dup := assigned[row.ID]; dup {
+				return nil, fmt.Errorf("line %d: id %q appears twice", row.Line, row.ID)
+			}
+			assigned[row.ID] = id
+		}
+		status := row.Status
+		if status == "" {
+			status = statusNone // a note, where add alone would make it open
+		}
+		ops = append(ops, ApplyOp{
+			Op:      OpAdd,
+			ID:      id,
+			Name:    row.Title,
+			Status:  status,
+			Tags:    row.Tags,
+			Outcome: row.Outcome,
+			Created: row.Created,
+			Closed:  row.Closed,
+		})
+	}
+
+	for i, row := range rows {
+		for _, dep := range row.DependsOn {
+			from, err := resolveCSVDependency(dep, assigned, existing)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: depends_on %q: %w", row.Line, dep, err)
+			}
+			ops = append(ops, ApplyOp{Op: OpLink, ID: ops[i].ID, From: from})
+		}
+	}
+	return ops, nil
+}
+
+func resolveCSVDependency(dep string, assigned map[string]string, existing []string) (string, error) {
+	if id, ok := assigned[dep]; ok {
+		return id, nil
+	}
+	fiber, fragment := splitDataFlowRef(dep)
+	id, ok := assigned[fiber]
+	if !ok {
+		var err error
+		if id, err = ResolveScopedID(existing, "", fiber); err != nil {
+			return "", fmt.Errorf("neither a row in the file nor an existing fiber")
+		}
+	}
+	if fragment != "" {
+		id += "." + fragment
+	}
+	return id, nil
+}
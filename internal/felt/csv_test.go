@@ -0,0 +1,127 @@
+package felt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSVRoundTripsThroughImport(t *testing.T) {
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	closed := created.Add(48 * time.Hour)
+	mocks := &Felt{ID: "mocks", Name: "Run the mocks", Status: StatusClosed, Tags: []string{"sim", "cluster"}, CreatedAt: created, ClosedAt: &closed, Outcome: "500 realisations, see the plots"}
+	fit := &Felt{ID: "fit", Name: "Fit, then compare", Status: StatusOpen, CreatedAt: created}
+	if err := fit.AddDataFlowInput("mocks"); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, []*Felt{mocks, fit}, ','); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	want := "id,title,status,tags,depends_on,created,closed,outcome\n" +
+		"mocks,Run the mocks,closed,sim;cluster,,2026-03-02T09:00:00Z,2026-03-04T09:00:00Z,\"500 realisations, see the plots\"\n" +
+		"fit,\"Fit, then compare\",open,,mocks,2026-03-02T09:00:00Z,,\n"
+	if buf.String() != want {
+		t.Fatalf("WriteCSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	rows, err := ReadCSV(strings.NewReader(buf.String()), ',')
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Line != 2 || !rows[0].Closed.Equal(closed) || rows[1].DependsOn[0] != "mocks" || rows[0].Tags[1] != "cluster" {
+		t.Fatalf("rows = %+v", rows)
+	}
+
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	// An existing fiber already holds "mocks", so that row gets an id of its
+	// own, and fit's dependency follows it.
+	if err := s.Write(&Felt{ID: "mocks", Name: "Older mocks", CreatedAt: created}); err != nil {
+		t.Fatal(err)
+	}
+	ops, err := s.CSVImportOps(rows)
+	if err != nil {
+		t.Fatalf("CSVImportOps: %v", err)
+	}
+	if _, err := s.ApplyOps(ops, closed.Add(time.Hour)); err != nil {
+		t.Fatalf("ApplyOps: %v", err)
+	}
+	imported, err := s.Read("run-the-mocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !imported.IsClosed() || !imported.CreatedAt.Equal(created) || !imported.ClosedAt.Equal(closed) || imported.Outcome != mocks.Outcome || !imported.HasTag("cluster") {
+		t.Fatalf("imported mocks = %+v", imported)
+	}
+	kept, err := s.Read("fit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if in := kept.DataFlowInputs(); len(in) != 1 || in[0].From != "run-the-mocks" {
+		t.Fatalf("fit inputs = %+v, want one from run-the-mocks", in)
+	}
+}
+
+func TestReadCSVRejectsBadFiles(t *testing.T) {
+	for name, tc := range map[string]struct {
+		input string
+		comma rune
+		want  string
+	}{
+		"no title column": {"id,status\na,open\n", ',', "missing the title column"},
+		"unknown column":  {"title,priority\nA,high\n", ',', `unknown column "priority"`},
+		"bad status":      {"title\tstatus\nA\tblocked\n", '\t', `line 2: invalid status "blocked"`},
+		"bad time":        {"title,created\nA,last week\n", ',', "line 2: created:"},
+		"open but closed": {"title,status,closed\nA,open,2026-03-02\n", ',', "closed is set but status is open"},
+		"empty title":     {"title,id\n,a\n", ',', "line 2: title is empty"},
+	} {
+		if _, err := ReadCSV(strings.NewReader(tc.input), tc.comma); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestCSVImportOpsResolvesDependencies(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "survey", Name: "Survey", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := ReadCSV(strings.NewReader("ID,Title,Depends_On\nPROJ-1,Calibrate,survey\nPROJ-2,Calibrate,PROJ-1.table\n,Report,PROJ-2;PROJ-1\n"), ',')
+	if err != nil {
+		t.Fatalf("ReadCSV: %v", err)
+	}
+	ops, err := s.CSVImportOps(rows)
+	if err != nil {
+		t.Fatalf("CSVImportOps: %v", err)
+	}
+	var got []string
+	for _, op := range ops {
+		got = append(got, op.Op+" "+op.ID+" "+op.From)
+	}
+	want := []string{
+		"add calibrate ",
+		"add calibrate-2 ",
+		"add report ",
+		"link calibrate survey",
+		"link calibrate-2 calibrate.table",
+		"link report calibrate-2",
+		"link report calibrate",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("ops =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	rows, _ = ReadCSV(strings.NewReader("title,depends_on\nReport,nowhere\n"), ',')
+	if _, err := s.CSVImportOps(rows); err == nil || !strings.Contains(err.Error(), `line 2: depends_on "nowhere"`) {
+		t.Fatalf("unknown dependency err = %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package felt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateExprHelp lists the forms ParseDateExpr accepts, for error messages.
+const dateExprHelp = "use YYYY-MM-DD, today, tomorrow, eod, eow, friday, next friday, next week, next month, in 3 days, or 2w"
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ParseDateExpr reads a calendar date written the way people (and agents)
+// say it, relative to now in now's zone:
+//
+//	2026-05-01         an ISO date
+//	today, eod         today; tomorrow, yesterday
+//	eow                this week's Friday (today if it is Friday)
+//	friday, fri        the next Friday after today
+//	next friday        the Friday after that
+//	next week          next Monday; next month: the 1st of next month
+//	in 3 days, 3d, 2w  today plus a span (days, weeks, months)
+//
+// The result is midnight UTC of the named date, the same value an ISO
+// `due:` parses to, so dates compare and render as before.
+func ParseDateExpr(s string, now time.Time) (time.Time, error) {
+	raw := s
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	switch s {
+	case "today", "eod", "tonight":
+		return today, nil
+	case "tomorrow", "tmrw":
+		return today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "eow", "end of week", "this week":
+		return nextWeekday(today, time.Friday, true), nil
+	case "next week":
+		return nextWeekday(today, time.Monday, false), nil
+	case "next month":
+		return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC), nil
+	case "eom", "end of month":
+		return time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC), nil
+	}
+	if wd, ok := weekdayNames[strings.TrimPrefix(s, "this ")]; ok {
+		return nextWeekday(today, wd, false), nil
+	}
+	if rest, ok := strings.CutPrefix(s, "next "); ok {
+		if wd, ok := weekdayNames[rest]; ok {
+			return nextWeekday(today, wd, false).AddDate(0, 0, 7), nil
+		}
+	}
+	if t, ok := parseRelativeDate(strings.TrimPrefix(s, "in "), today); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date %q (%s)", raw, dateExprHelp)
+}
+
+// nextWeekday returns the first wd after today, or today itself when
+// inclusive and today is wd.
+func nextWeekday(today time.Time, wd time.Weekday, inclusive bool) time.Time {
+	days := (int(wd) - int(today.Weekday()) + 7) % 7
+	if days == 0 && !inclusive {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// parseRelativeDate reads "3 days", "2 weeks", "1 month", or a day-or-longer
+// span ("3d", "2w").
+func parseRelativeDate(s string, today time.Time) (time.Time, bool) {
+	if fields := strings.Fields(s); len(fields) == 2 {
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n < 0 {
+			return time.Time{}, false
+		}
+		switch strings.TrimSuffix(fields[1], "s") {
+		case "day":
+			return today.AddDate(0, 0, n), true
+		case "week":
+			return today.AddDate(0, 0, 7*n), true
+		case "month":
+			return today.AddDate(0, n, 0), true
+		}
+		return time.Time{}, false
+	}
+	d, err := ParseSpan(s)
+	if err != nil || d < 24*time.Hour {
+		return time.Time{}, false
+	}
+	return today.Add(d.Truncate(24 * time.Hour)), true
+}
@@ -0,0 +1,178 @@
+package felt
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldChange is one top-level frontmatter field, or the body (Field
+// "body"), that differs between two versions of a fiber. Old and New are
+// the field's YAML, or the body text, and "" when absent. When either side
+// is a sequence — tags, inputs — Added and Removed list the items, as
+// one-line YAML, that only the new or only the old version has.
+type FieldChange struct {
+	Field   string   `json:"field"`
+	Old     string   `json:"old,omitempty"`
+	New     string   `json:"new,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// DiffFelts lists the fields that differ from old to new: frontmatter keys
+// sorted, the body last. A nil old is a fiber that did not exist before, so
+// every field it has is a change.
+func DiffFelts(old, new *Felt) ([]FieldChange, error) {
+	before, after := map[string]string{}, map[string]string{}
+	var err error
+	if old != nil {
+		if before, err = syncFields(old); err != nil {
+			return nil, err
+		}
+	}
+	if after, err = syncFields(new); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]struct{})
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		if k != syncBodyField {
+			sorted = append(sorted, k)
+		}
+	}
+	sort.Strings(sorted)
+
+	var changes []FieldChange
+	for _, k := range sorted {
+		b, a := strings.TrimSpace(before[k]), strings.TrimSpace(after[k])
+		if a == b {
+			continue
+		}
+		c := FieldChange{Field: k, Old: b, New: a}
+		oldItems, oldSeq := sequenceItems(b)
+		newItems, newSeq := sequenceItems(a)
+		if oldSeq || newSeq {
+			c.Added = missingFrom(newItems, oldItems)
+			c.Removed = missingFrom(oldItems, newItems)
+		}
+		changes = append(changes, c)
+	}
+	if b, a := strings.TrimSpace(before[syncBodyField]), strings.TrimSpace(after[syncBodyField]); a != b {
+		changes = append(changes, FieldChange{Field: "body", Old: b, New: a})
+	}
+	return changes, nil
+}
+
+// FieldBlame attributes one field of a fiber, or its body (Field "body"),
+// to the version that gave it its current value. Version indexes the
+// history BlameFields was given; -1 means the value differs from the
+// newest version, as an uncommitted edit does.
+type FieldBlame struct {
+	Field   string
+	Version int
+}
+
+// BlameFields attributes each field current has, frontmatter keys sorted
+// and the body last, to the oldest version in history (newest first) from
+// which the field has held its current value without a break. A nil entry
+// in history is a version without the fiber.
+func BlameFields(current *Felt, history []*Felt) ([]FieldBlame, error) {
+	now, err := syncFields(current)
+	if err != nil {
+		return nil, err
+	}
+	past := make([]map[string]string, len(history))
+	for i, f := range history {
+		if f == nil {
+			continue
+		}
+		if past[i], err = syncFields(f); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]string, 0, len(now))
+	for k := range now {
+		if k != syncBodyField {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if strings.TrimSpace(now[syncBodyField]) != "" {
+		keys = append(keys, syncBodyField)
+	}
+	out := make([]FieldBlame, 0, len(keys))
+	for _, k := range keys {
+		value := strings.TrimSpace(now[k])
+		version := -1
+		for i := range past {
+			if strings.TrimSpace(past[i][k]) != value {
+				break
+			}
+			version = i
+		}
+		field := k
+		if k == syncBodyField {
+			field = "body"
+		}
+		out = append(out, FieldBlame{Field: field, Version: version})
+	}
+	return out, nil
+}
+
+// sequenceItems renders each item of a YAML sequence on one line in flow
+// style. ok is false when value is not a sequence; an absent value is an
+// empty one.
+func sequenceItems(value string) ([]string, bool) {
+	if value == "" {
+		return nil, false
+	}
+	var node yaml.Node
+	if yaml.Unmarshal([]byte(value), &node) != nil || len(node.Content) == 0 || node.Content[0].Kind != yaml.SequenceNode {
+		return nil, false
+	}
+	var items []string
+	for _, item := range node.Content[0].Content {
+		setFlowStyle(item)
+		out, err := yaml.Marshal(item)
+		if err != nil {
+			return nil, false
+		}
+		items = append(items, strings.TrimSpace(string(out)))
+	}
+	return items, true
+}
+
+func setFlowStyle(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode {
+		n.Style = yaml.FlowStyle
+	}
+	for _, child := range n.Content {
+		setFlowStyle(child)
+	}
+}
+
+// missingFrom returns the items of xs that ys lacks, in xs order.
+func missingFrom(xs, ys []string) []string {
+	have := make(map[string]int, len(ys))
+	for _, y := range ys {
+		have[y]++
+	}
+	var out []string
+	for _, x := range xs {
+		if have[x] > 0 {
+			have[x]--
+			continue
+		}
+		out = append(out, x)
+	}
+	return out
+}
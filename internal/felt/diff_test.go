@@ -0,0 +1,34 @@
+package felt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffFelts(t *testing.T) {
+	created := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	old := &Felt{ID: "fit", Name: "Fit", Status: StatusOpen, Tags: []string{"a", "b"}, CreatedAt: created, Body: "Same."}
+	new := &Felt{ID: "fit", Name: "Fit", Status: StatusActive, Tags: []string{"b", "c"}, CreatedAt: created, Body: "Changed."}
+
+	changes, err := DiffFelts(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FieldChange{
+		{Field: "status", Old: "open", New: "active"},
+		{Field: "tags", Old: "- a\n- b", New: "- b\n- c", Added: []string{"c"}, Removed: []string{"a"}},
+		{Field: "body", Old: "Same.", New: "Changed."},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Fatalf("DiffFelts = %+v\nwant %+v", changes, want)
+	}
+
+	if changes, _ := DiffFelts(old, old); len(changes) != 0 {
+		t.Fatalf("identical fibers differ: %+v", changes)
+	}
+	fresh, err := DiffFelts(nil, new)
+	if err != nil || len(fresh) != 5 || fresh[0].Field != "created-at" || fresh[0].Old != "" {
+		t.Fatalf("DiffFelts(nil, new) = %+v, %v", fresh, err)
+	}
+}
@@ -0,0 +1,153 @@
+package felt
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DailyLogContainerID is the parent path of the daily log: one fiber per
+// day, log/<2006-01-02>, holding `felt today log` entries and, from `felt
+// hook session-end`, one section per agent session that changed something.
+const DailyLogContainerID = "log"
+
+// DailyLogTag marks daily log fibers.
+const DailyLogTag = "daily-log"
+
+// DailyTagPrefix starts the tag naming a daily log's day, daily:2006-01-02,
+// so `felt ls -t daily:` lists the journal.
+const DailyTagPrefix = "daily:"
+
+// SessionDigest is what one session did to the store: the fibers created,
+// closed, and otherwise touched between Start and End. A fiber counts once,
+// in the first of those that applies; daily log fibers are left out.
+type SessionDigest struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Opened  []*Felt   `json:"opened"`
+	Closed  []*Felt   `json:"closed"`
+	Touched []*Felt   `json:"touched"`
+}
+
+// BuildSessionDigest collects the fibers in felts that changed within
+// [start, end], each list ordered by when the change happened.
+func BuildSessionDigest(felts []*Felt, start, end time.Time) *SessionDigest {
+	d := &SessionDigest{Start: start, End: end}
+	within := func(t *time.Time) bool {
+		return t != nil && !t.Before(start) && !t.After(end)
+	}
+	for _, f := range felts {
+		if path.Dir(f.ID) == DailyLogContainerID {
+			continue
+		}
+		created := f.CreatedAt
+		switch {
+		case within(&created):
+			d.Opened = append(d.Opened, f)
+		case f.IsClosed() && within(f.ClosedAt):
+			d.Closed = append(d.Closed, f)
+		case within(f.UpdatedAt):
+			d.Touched = append(d.Touched, f)
+		}
+	}
+	sort.SliceStable(d.Opened, func(i, j int) bool { return d.Opened[i].CreatedAt.Before(d.Opened[j].CreatedAt) })
+	sort.SliceStable(d.Closed, func(i, j int) bool { return d.Closed[i].ClosedAt.Before(*d.Closed[j].ClosedAt) })
+	sort.SliceStable(d.Touched, func(i, j int) bool { return d.Touched[i].UpdatedAt.Before(*d.Touched[j].UpdatedAt) })
+	return d
+}
+
+// Empty reports whether the session changed no fibers.
+func (d *SessionDigest) Empty() bool {
+	return len(d.Opened) == 0 && len(d.Closed) == 0 && len(d.Touched) == 0
+}
+
+// Section renders the digest as a daily log section, with times in loc.
+// session, when set, is shortened into the heading so two sessions in the
+// same minute stay distinct.
+func (d *SessionDigest) Section(session string, loc *time.Location) string {
+	var sb strings.Builder
+	heading := fmt.Sprintf("## Session %s–%s", d.Start.In(loc).Format("15:04"), d.End.In(loc).Format("15:04"))
+	if len(session) > 8 {
+		session = session[:8]
+	}
+	if session != "" {
+		heading += " (" + session + ")"
+	}
+	sb.WriteString(heading + "\n")
+	list := func(title string, felts []*Felt, withOutcome bool) {
+		if len(felts) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s:\n", title)
+		for _, f := range felts {
+			line := fmt.Sprintf("- [[%s]] %s", f.ID, f.DisplayName())
+			if withOutcome && f.Outcome != "" {
+				line += " — " + firstLine(f.Outcome)
+			}
+			sb.WriteString(line + "\n")
+		}
+	}
+	list("Opened", d.Opened, false)
+	list("Closed", d.Closed, true)
+	list("Touched", d.Touched, false)
+	return sb.String()
+}
+
+// DailyLogID returns the id of the daily log fiber for the day t falls on
+// in loc.
+func DailyLogID(t time.Time, loc *time.Location) string {
+	return path.Join(DailyLogContainerID, t.In(loc).Format("2006-01-02"))
+}
+
+// DailyTag returns the daily:<date> tag for the day t falls on in loc.
+func DailyTag(t time.Time, loc *time.Location) string {
+	return DailyTagPrefix + t.In(loc).Format("2006-01-02")
+}
+
+// NewDailyLog builds an empty daily log fiber for the day t falls on in loc.
+// It is a statusless note: a record, not work.
+func NewDailyLog(t time.Time, loc *time.Location) (*Felt, error) {
+	day := t.In(loc)
+	f, err := New(DailyLogID(t, loc), "Log "+day.Format("Monday 2 January 2006"))
+	if err != nil {
+		return nil, err
+	}
+	f.AddTag(DailyLogTag)
+	f.AddTag(DailyTag(t, loc))
+	return f, nil
+}
+
+// AppendDailyLogEntry adds text to the daily log f as a "- 15:04 text"
+// bullet stamped with at in loc. Consecutive entries share one list; a
+// multi-line entry's later lines are indented under its bullet.
+func AppendDailyLogEntry(f *Felt, text string, at time.Time, loc *time.Location) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	entry := "- " + at.In(loc).Format("15:04") + " " + lines[0]
+	for _, line := range lines[1:] {
+		entry += "\n  " + line
+	}
+	body := strings.TrimRight(f.Body, "\n")
+	last := body
+	if i := strings.LastIndex(body, "\n\n"); i >= 0 {
+		last = body[i+2:]
+	}
+	switch {
+	case body == "":
+		f.Body = entry
+	case dailyLogEntryLine.MatchString(last):
+		f.Body = body + "\n" + entry
+	default:
+		f.Body = body + "\n\n" + entry
+	}
+}
+
+// dailyLogEntryLine matches a paragraph that is a list of log entries.
+var dailyLogEntryLine = regexp.MustCompile(`^- \d\d:\d\d `)
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
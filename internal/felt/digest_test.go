@@ -0,0 +1,58 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildSessionDigestSortsChangesIntoOpenedClosedTouched(t *testing.T) {
+	start := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	at := func(d time.Duration) *time.Time { t := start.Add(d); return &t }
+	felts := []*Felt{
+		{ID: "new", Name: "New", Status: StatusOpen, CreatedAt: *at(30 * time.Minute)},
+		{ID: "done", Name: "Done", Status: StatusClosed, Outcome: "Worked\nin detail", CreatedAt: start.AddDate(0, 0, -3), ClosedAt: at(time.Hour)},
+		{ID: "edited", Name: "Edited", Status: StatusActive, CreatedAt: start.AddDate(0, 0, -3), UpdatedAt: at(90 * time.Minute)},
+		{ID: "stale", Name: "Stale", Status: StatusOpen, CreatedAt: start.AddDate(0, 0, -3), UpdatedAt: at(-time.Hour)},
+		{ID: "log/2026-10-13", Name: "Log", CreatedAt: *at(time.Minute)},
+	}
+
+	d := BuildSessionDigest(felts, start, end)
+	if ids(d.Opened) != "new" || ids(d.Closed) != "done" || ids(d.Touched) != "edited" {
+		t.Fatalf("digest = opened %s, closed %s, touched %s", ids(d.Opened), ids(d.Closed), ids(d.Touched))
+	}
+	section := d.Section("0123456789abcdef", time.UTC)
+	for _, want := range []string{
+		"## Session 09:00–11:00 (01234567)\n",
+		"\nOpened:\n- [[new]] New\n",
+		"\nClosed:\n- [[done]] Done — Worked\n",
+		"\nTouched:\n- [[edited]] Edited\n",
+	} {
+		if !strings.Contains(section, want) {
+			t.Fatalf("section missing %q:\n%s", want, section)
+		}
+	}
+	if !BuildSessionDigest(felts, end, end.Add(time.Hour)).Empty() {
+		t.Fatal("a later window with no changes should be empty")
+	}
+}
+
+func TestAppendDailyLogEntryKeepsEntriesInOneList(t *testing.T) {
+	at := time.Date(2026, 10, 14, 9, 5, 0, 0, time.UTC)
+	f, err := NewDailyLog(at, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.HasTag(DailyLogTag) || !f.HasTag("daily:2026-10-14") {
+		t.Fatalf("tags = %v", f.Tags)
+	}
+	AppendDailyLogEntry(f, "Started the fit", at, time.UTC)
+	AppendDailyLogEntry(f, "Prior looks wrong\nsee [[fit]]", at.Add(time.Hour), time.UTC)
+	f.Body += "\n\n## Session 10:00–11:00\n\nTouched:\n- [[fit]] Fit"
+	AppendDailyLogEntry(f, "Done for today", at.Add(8*time.Hour), time.UTC)
+	want := "- 09:05 Started the fit\n- 10:05 Prior looks wrong\n  see [[fit]]\n\n## Session 10:00–11:00\n\nTouched:\n- [[fit]] Fit\n\n- 17:05 Done for today"
+	if f.Body != want {
+		t.Fatalf("body =\n%s\nwant\n%s", f.Body, want)
+	}
+}
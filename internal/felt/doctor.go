@@ -0,0 +1,239 @@
+package felt
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MalformedFiber is a fiber file whose frontmatter does not parse. Listings
+// skip it with a warning, so the fiber is silently missing from every view.
+type MalformedFiber struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
+
+// MisfiledFiber is a markdown file with fiber frontmatter sitting where no
+// fiber is read from: in a directory with no <dir>/<dir>.md of its own, as
+// after a directory is renamed without its file. Want is the path the
+// directory's fiber would be read from. Both paths are relative to .felt/.
+type MisfiledFiber struct {
+	Path string `json:"path"`
+	Want string `json:"want"`
+}
+
+// DuplicateName is a name shared by two or more tracked fibers, sorted by id.
+type DuplicateName struct {
+	Name string   `json:"name"`
+	IDs  []string `json:"ids"`
+}
+
+// DanglingInput is an inputs[].from that resolves to no fiber.
+type DanglingInput struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+}
+
+// zonedTimestampKeys are the frontmatter instants felt writes in RFC 3339.
+// due: is a day, not an instant, and is left out.
+var zonedTimestampKeys = []string{"created-at", "updated-at", "activated-at", "closed-at"}
+
+// FindMalformedFibers returns every fiber file whose frontmatter fails to
+// parse, sorted by path.
+func (s *Storage) FindMalformedFibers() ([]MalformedFiber, error) {
+	files, err := s.listFiberFiles()
+	if err != nil {
+		return nil, err
+	}
+	var out []MalformedFiber
+	for _, file := range files {
+		frontmatter, _, err := readFiberFile(file.path, ParseMetadataOnly)
+		if err == nil {
+			_, err = parseFrontmatter(file.id, frontmatter)
+		}
+		if err != nil && !isEvictedFileError(err) {
+			out = append(out, MalformedFiber{Path: s.relPath(file.path), Error: err.Error()})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// FindZonelessTimestamps returns the ids of fibers with an instant written
+// without a zone ("2026-04-10 09:00"), sorted. felt reads such a time as UTC,
+// which is only right if whoever wrote it meant UTC.
+func (s *Storage) FindZonelessTimestamps() ([]string, error) {
+	files, err := s.listFiberFiles()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, file := range files {
+		frontmatter, _, err := readFiberFile(file.path, ParseMetadataOnly)
+		if err != nil {
+			continue
+		}
+		var doc map[string]yaml.Node
+		if err := yaml.Unmarshal(frontmatter, &doc); err != nil {
+			continue
+		}
+		for _, key := range zonedTimestampKeys {
+			node, ok := doc[key]
+			if !ok || node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+				continue
+			}
+			if _, err := time.Parse(time.RFC3339Nano, node.Value); err != nil {
+				ids = append(ids, file.id)
+				break
+			}
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// FindMisfiledFibers returns the markdown files under .felt/ that carry a
+// fiber's frontmatter (a name:) but sit in a directory without a fiber file,
+// sorted by path. Sidecar notes beside a real fiber are not reported.
+func (s *Storage) FindMisfiledFibers() ([]MisfiledFiber, error) {
+	var out []MisfiledFiber
+	root := s.resolvedRoot()
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if p != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		name := d.Name()
+		if !strings.HasSuffix(name, FileExt) || name == BodyFileName || IsSyncConflictName(name) {
+			return nil
+		}
+		rel := filepath.ToSlash(s.relPath(p))
+		if _, _, ok := fiberIDFromRelativePath(rel); ok {
+			return nil
+		}
+		dir := path.Dir(rel)
+		want := path.Join(dir, path.Base(dir)+FileExt)
+		if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(want))); err == nil {
+			return nil
+		}
+		frontmatter, err := readFrontmatterFile(p)
+		if err != nil {
+			return nil
+		}
+		if f, err := parseFrontmatter(dir, frontmatter); err == nil && strings.TrimSpace(f.Name) != "" {
+			out = append(out, MisfiledFiber{Path: rel, Want: want})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// RestoreCreatedAt gives the fiber at id a created-at from its file's
+// modification time — the best evidence left of when it was written — and
+// returns it. It is for fibers with none; one that has a created-at keeps it.
+func (s *Storage) RestoreCreatedAt(id string) (time.Time, error) {
+	f, err := s.Read(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !f.CreatedAt.IsZero() {
+		return f.CreatedAt, nil
+	}
+	info, err := os.Stat(s.Path(id))
+	if err != nil {
+		return time.Time{}, err
+	}
+	f.CreatedAt = info.ModTime().UTC().Truncate(time.Second)
+	if err := s.Write(f); err != nil {
+		return time.Time{}, err
+	}
+	return f.CreatedAt, nil
+}
+
+// MissingCreatedAt returns the ids of felts with no created-at, sorted.
+func MissingCreatedAt(felts []*Felt) []string {
+	var ids []string
+	for _, f := range felts {
+		if f.CreatedAt.IsZero() {
+			ids = append(ids, f.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DuplicateNames returns the names shared by two or more tracked fibers,
+// compared case- and space-insensitively — usually a fiber filed twice.
+// Statusless notes are left out: many of them share a name on purpose.
+func DuplicateNames(felts []*Felt) []DuplicateName {
+	byName := make(map[string][]*Felt)
+	for _, f := range felts {
+		if !f.HasStatus() || strings.TrimSpace(f.Name) == "" {
+			continue
+		}
+		key := strings.ToLower(strings.Join(strings.Fields(f.Name), " "))
+		byName[key] = append(byName[key], f)
+	}
+	var out []DuplicateName
+	for _, group := range byName {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		dup := DuplicateName{Name: group[0].Name}
+		for _, f := range group {
+			dup.IDs = append(dup.IDs, f.ID)
+		}
+		out = append(out, dup)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].IDs[0] < out[j].IDs[0] })
+	return out
+}
+
+// DanglingInputs returns every inputs[].from among felts that resolves to
+// no fiber, in id order.
+func DanglingInputs(felts []*Felt) []DanglingInput {
+	var out []DanglingInput
+	_ = iterRefs(felts, sortedFeltIDs(felts), func(ref resolvedRef) error {
+		if ref.Kind == refKindDataFlow && ref.ResolveErr != nil {
+			out = append(out, DanglingInput{ID: ref.Source.ID, From: ref.Label})
+		}
+		return nil
+	})
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// resolvedRoot is the store root with symlinks resolved, as listFiberFiles
+// walks it, or the root as given when it cannot be resolved.
+func (s *Storage) resolvedRoot() string {
+	if root, err := filepath.EvalSymlinks(s.root); err == nil {
+		return root
+	}
+	return s.root
+}
+
+// relPath returns p, a path under the resolved root, relative to it with
+// forward slashes, or p itself when it lies outside it.
+func (s *Storage) relPath(p string) string {
+	rel, err := filepath.Rel(s.resolvedRoot(), p)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return p
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,78 @@
+package felt
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeRawFiber(t *testing.T, s *Storage, rel, content string) {
+	t.Helper()
+	p := filepath.Join(s.root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDoctorFindersReportStoreDamage(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeRawFiber(t, s, "good/good.md", "---\nname: Good\nstatus: open\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	writeRawFiber(t, s, "good/notes.md", "Sidecar notes without frontmatter.\n")
+	writeRawFiber(t, s, "broken/broken.md", "---\nname: [unclosed\n---\n")
+	writeRawFiber(t, s, "renamed/old-name.md", "---\nname: Renamed\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	writeRawFiber(t, s, "local/local.md", "---\nname: Local\ncreated-at: 2026-04-10 09:00:00\n---\n")
+
+	malformed, err := s.FindMalformedFibers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(malformed) != 1 || malformed[0].Path != "broken/broken.md" || malformed[0].Error == "" {
+		t.Fatalf("FindMalformedFibers = %+v", malformed)
+	}
+	misfiled, err := s.FindMisfiledFibers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []MisfiledFiber{{Path: "renamed/old-name.md", Want: "renamed/renamed.md"}}; !reflect.DeepEqual(misfiled, want) {
+		t.Fatalf("FindMisfiledFibers = %+v, want %+v", misfiled, want)
+	}
+	zoneless, err := s.FindZonelessTimestamps()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(zoneless, []string{"local"}) {
+		t.Fatalf("FindZonelessTimestamps = %v, want [local]", zoneless)
+	}
+}
+
+func TestDuplicateNamesAndDanglingInputs(t *testing.T) {
+	created := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	a := &Felt{ID: "a", Name: "Fix the  build", Status: StatusOpen, CreatedAt: created}
+	b := &Felt{ID: "b", Name: "fix the build", Status: StatusActive, CreatedAt: created}
+	note := &Felt{ID: "note", Name: "Fix the build", CreatedAt: created}
+	if err := b.AddDataFlowInput("gone"); err != nil {
+		t.Fatal(err)
+	}
+	if err := note.AddDataFlowInput("a"); err != nil {
+		t.Fatal(err)
+	}
+	felts := []*Felt{a, b, note, {ID: "undated", Name: "Undated"}}
+
+	if got, want := DuplicateNames(felts), []DuplicateName{{Name: "Fix the  build", IDs: []string{"a", "b"}}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("DuplicateNames = %+v, want %+v", got, want)
+	}
+	if got, want := DanglingInputs(felts), []DanglingInput{{ID: "b", From: "gone"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("DanglingInputs = %+v, want %+v", got, want)
+	}
+	if got := MissingCreatedAt(felts); !reflect.DeepEqual(got, []string{"undated"}) {
+		t.Fatalf("MissingCreatedAt = %v, want [undated]", got)
+	}
+}
@@ -0,0 +1,158 @@
+package felt
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpentKey is the conventional frontmatter key for the effort a fiber
+// actually took, a span like estimate ("3h", "1d4h").
+const SpentKey = "spent"
+
+// Spent returns f's `spent:` span. ok is false when the field is absent or
+// does not parse as a span.
+func (f *Felt) Spent() (time.Duration, bool) {
+	node := extraFieldNode(f.ExtraFields, SpentKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return 0, false
+	}
+	d, err := ParseSpan(node.Value)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// EstimationAccuracy summarizes how far actual effort strayed from the
+// estimate over a set of closed fibers. MedianRatio is the median of
+// actual ÷ estimate — 1.5 means work ran half again as long as planned,
+// and is the factor to scale new estimates by. Within counts the fibers
+// that landed within 25% of their estimate; Spent counts those whose
+// actual came from `spent:` rather than cycle time.
+type EstimationAccuracy struct {
+	Fibers      int     `json:"fibers"`
+	MedianRatio float64 `json:"median_ratio"`
+	Within      int     `json:"within_25pct"`
+	Spent       int     `json:"spent"`
+}
+
+// EstimationMonth is the accuracy of the fibers closed in one month,
+// "2006-01".
+type EstimationMonth struct {
+	Month string `json:"month"`
+	EstimationAccuracy
+}
+
+// TagEstimation is one tag's accuracy, overall and month by month.
+type TagEstimation struct {
+	Tag string `json:"tag"`
+	EstimationAccuracy
+	Months []EstimationMonth `json:"months"`
+}
+
+// Estimation is the report behind `felt stats --estimation`.
+type Estimation struct {
+	All    EstimationAccuracy `json:"all"`
+	Months []EstimationMonth  `json:"months"`
+	Tags   []TagEstimation    `json:"tags"`
+}
+
+type estimationSample struct {
+	ratio  float64
+	spent  bool
+	month  string
+	fiber  *Felt
+	closed time.Time
+}
+
+// EstimateAccuracy compares each closed fiber's span estimate with its
+// actual effort: `spent:` when recorded, else its cycle time from
+// activated-at to closed-at. A fiber never marked active, or estimated in
+// points, has no comparable actual and is left out. Months are calendar
+// months of closed-at in loc, oldest first; tags are those match accepts
+// (every tag when match is nil), most sampled first, then by name.
+func EstimateAccuracy(felts []*Felt, match func(tag string) bool, loc *time.Location) *Estimation {
+	var samples []estimationSample
+	for _, f := range felts {
+		if !f.IsClosed() || f.ClosedAt == nil {
+			continue
+		}
+		estimate, ok := f.Estimate()
+		if !ok {
+			continue
+		}
+		s := estimationSample{fiber: f, closed: *f.ClosedAt, month: f.ClosedAt.In(loc).Format("2006-01")}
+		actual, spent := f.Spent()
+		if !spent {
+			if f.ActivatedAt == nil || !f.ClosedAt.After(*f.ActivatedAt) {
+				continue
+			}
+			actual = f.ClosedAt.Sub(*f.ActivatedAt)
+		}
+		s.ratio, s.spent = float64(actual)/float64(estimate), spent
+		samples = append(samples, s)
+	}
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].closed.Before(samples[j].closed) })
+
+	e := &Estimation{All: estimationOf(samples), Months: estimationMonths(samples), Tags: []TagEstimation{}}
+	byTag := make(map[string][]estimationSample)
+	for _, s := range samples {
+		for _, tag := range s.fiber.Tags {
+			if match == nil || match(tag) {
+				byTag[tag] = append(byTag[tag], s)
+			}
+		}
+	}
+	for tag, tagged := range byTag {
+		e.Tags = append(e.Tags, TagEstimation{Tag: tag, EstimationAccuracy: estimationOf(tagged), Months: estimationMonths(tagged)})
+	}
+	sort.Slice(e.Tags, func(i, j int) bool {
+		if e.Tags[i].Fibers != e.Tags[j].Fibers {
+			return e.Tags[i].Fibers > e.Tags[j].Fibers
+		}
+		return e.Tags[i].Tag < e.Tags[j].Tag
+	})
+	return e
+}
+
+// estimationMonths groups samples, already in closing order, by month.
+func estimationMonths(samples []estimationSample) []EstimationMonth {
+	months := []EstimationMonth{}
+	for start := 0; start < len(samples); {
+		end := start
+		for end < len(samples) && samples[end].month == samples[start].month {
+			end++
+		}
+		months = append(months, EstimationMonth{Month: samples[start].month, EstimationAccuracy: estimationOf(samples[start:end])})
+		start = end
+	}
+	return months
+}
+
+func estimationOf(samples []estimationSample) EstimationAccuracy {
+	a := EstimationAccuracy{Fibers: len(samples)}
+	if len(samples) == 0 {
+		return a
+	}
+	ratios := make([]float64, len(samples))
+	for i, s := range samples {
+		ratios[i] = s.ratio
+		if math.Abs(s.ratio-1) <= 0.25 {
+			a.Within++
+		}
+		if s.spent {
+			a.Spent++
+		}
+	}
+	sort.Float64s(ratios)
+	mid := len(ratios) / 2
+	if len(ratios)%2 == 1 {
+		a.MedianRatio = ratios[mid]
+	} else {
+		a.MedianRatio = (ratios[mid-1] + ratios[mid]) / 2
+	}
+	return a
+}
@@ -0,0 +1,41 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstimateAccuracyGroupsByMonthClosed(t *testing.T) {
+	at := func(s string) *time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &v
+	}
+	fiber := func(id, estimate, activated, closed string) *Felt {
+		f := &Felt{ID: id, Status: StatusClosed, Tags: []string{"fit"}, ClosedAt: at(closed)}
+		if activated != "" {
+			f.ActivatedAt = at(activated)
+		}
+		if err := f.SetExtraField(EstimateKey, estimate); err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	felts := []*Felt{
+		fiber("may", "1h", "2026-05-02T09:00:00Z", "2026-05-02T10:00:00Z"),
+		fiber("april", "1h", "2026-04-02T09:00:00Z", "2026-04-02T12:00:00Z"),
+		fiber("never-active", "1h", "", "2026-04-03T09:00:00Z"),
+	}
+	e := EstimateAccuracy(felts, nil, time.UTC)
+	if e.All.Fibers != 2 || e.All.MedianRatio != 2 || e.All.Within != 1 {
+		t.Fatalf("All = %+v", e.All)
+	}
+	if len(e.Months) != 2 || e.Months[0].Month != "2026-04" || e.Months[0].MedianRatio != 3 || e.Months[1].MedianRatio != 1 {
+		t.Fatalf("Months = %+v", e.Months)
+	}
+	if len(e.Tags) != 1 || e.Tags[0].Tag != "fit" || len(e.Tags[0].Months) != 2 {
+		t.Fatalf("Tags = %+v", e.Tags)
+	}
+}
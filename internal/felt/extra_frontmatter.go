@@ -2,6 +2,7 @@ package felt
 
 import (
 	"fmt"
+	"path"
 	"sort"
 	"strings"
 
@@ -117,6 +118,44 @@ func (f *Felt) DataFlowInputs() []DataFlowInputRef {
 	return out
 }
 
+// AddDataFlowInput appends an `inputs:` entry reading from ref ("fiber" or
+// "fiber.output"), keeping existing entries and any keys they carry. The
+// input id is the output name when ref has one, else the fiber's basename.
+func (f *Felt) AddDataFlowInput(ref string) error {
+	fiberID, fragment := splitDataFlowRef(ref)
+	if fiberID == "" {
+		return fmt.Errorf("empty input ref")
+	}
+	inputID := fragment
+	if inputID == "" {
+		inputID = path.Base(fiberID)
+	}
+	for _, in := range f.DataFlowInputs() {
+		if in.InputID == inputID {
+			return fmt.Errorf("input %q already exists", inputID)
+		}
+	}
+	node := extraFieldNode(f.ExtraFields, "inputs")
+	if node == nil {
+		if err := f.SetExtraField("inputs", []any{}); err != nil {
+			return err
+		}
+		node = f.ExtraFields["inputs"]
+		node.Style = 0
+	}
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("inputs is not a list")
+	}
+	item := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for _, kv := range [][2]string{{"id", inputID}, {"from", strings.TrimSpace(ref)}} {
+		item.Content = append(item.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: kv[0]},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: kv[1]})
+	}
+	node.Content = append(node.Content, item)
+	return nil
+}
+
 // HasDataFlowOutput reports whether an opaque top-level `outputs:` sequence has
 // an item with the requested id.
 func (f *Felt) HasDataFlowOutput(id string) bool {
@@ -4,8 +4,10 @@ package felt
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"os"
 	"path"
 	"reflect"
 	"regexp"
@@ -13,6 +15,7 @@ import (
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/oklog/ulid/v2"
 	"gopkg.in/yaml.v3"
@@ -60,14 +63,23 @@ type Felt struct {
 	// Pointer + omitempty:
 	// absent on fibers never touched since the field shipped, where recency
 	// falls back to created-at.
-	UpdatedAt   *time.Time `yaml:"updated-at,omitempty" json:"updated_at,omitempty"`
+	UpdatedAt *time.Time `yaml:"updated-at,omitempty" json:"updated_at,omitempty"`
+	// ActivatedAt is when the fiber last entered status active — the start of
+	// its current work-in-progress stretch. Stamped by felt's own status
+	// writes, cleared when the fiber drops back to open or untracked, and kept
+	// through close so activated-at → closed-at is the fiber's cycle time.
+	ActivatedAt *time.Time `yaml:"activated-at,omitempty" json:"activated_at,omitempty"`
 	ClosedAt    *time.Time `yaml:"closed-at,omitempty" json:"closed_at,omitempty"`
 	Outcome     string     `yaml:"outcome,omitempty" json:"outcome,omitempty"`
 	Due         *time.Time `yaml:"due,omitempty" json:"due,omitempty"`
 	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
-	// ExtraFields holds all non-native top-level frontmatter keys. felt does
-	// not parse or validate their semantics; it preserves them on round-trip
-	// and surfaces them in JSON so downstream tools can own their contracts.
+	// ExtraFields holds all non-native top-level frontmatter keys. felt
+	// preserves them on round-trip and surfaces them in JSON so downstream
+	// tools can own their contracts. A few conventional keys — estimate,
+	// owner, confidence and the other *Key constants — felt also reads or
+	// writes through accessors, but they stay extra fields rather than
+	// native ones: optional, untouched by a round-trip, and free for another
+	// tool to read the same way.
 	ExtraFields map[string]*yaml.Node `yaml:"-" json:"-"`
 	// ExtraFieldOrder records the document order of ExtraFields keys so Marshal
 	// can replay it. A map alone has no stable iteration order, so without this
@@ -95,6 +107,11 @@ type Felt struct {
 	// Distinguishes the root from top-level folder fibers; both have
 	// unslashed IDs, only EntryPoint tells them apart.
 	EntryPoint bool `yaml:"-" json:"entry_point,omitempty"`
+	// Readiness explains an open fiber's place in the ready queue — which
+	// upstreams have closed, which block it, which refs are missing. Attached
+	// by `felt ls --ready/--blocked --json` from ExplainReadiness; nil
+	// elsewhere, and never persisted.
+	Readiness *Readiness `yaml:"-" json:"readiness,omitempty"`
 	// resolvedShuttle holds the resolved view of the shuttle: facet — the flat
 	// block plus a `resolved` sub-key — attached by AttachShuttleResolution on
 	// the JSON read paths (felt show -j / ls --json). Unexported: never marshaled
@@ -195,8 +212,35 @@ func (f *Felt) RecencyAnchor() time.Time {
 	return anchor
 }
 
+// NoteStatusChange maintains activated-at across a status transition from
+// prev to the fiber's current status: entering active stamps it at now,
+// dropping back to open or untracked clears it, and closing keeps it.
+func (f *Felt) NoteStatusChange(prev string, now time.Time) {
+	switch {
+	case f.Status == StatusActive && prev != StatusActive:
+		t := now
+		f.ActivatedAt = &t
+	case f.Status == StatusOpen || f.Status == "":
+		f.ActivatedAt = nil
+	}
+}
+
+// ActiveSince returns when the fiber's current active stretch began:
+// activated-at when stamped, else created-at for fibers that went active
+// before felt recorded the transition. ok is false for a non-active fiber.
+func (f *Felt) ActiveSince() (time.Time, bool) {
+	if !f.IsActive() {
+		return time.Time{}, false
+	}
+	if f.ActivatedAt != nil {
+		return *f.ActivatedAt, true
+	}
+	return f.CreatedAt, !f.CreatedAt.IsZero()
+}
+
 // New creates a new Felt from a slug and name.
-// The slug is slugified silently if it contains spaces or uppercase.
+// The slug is slugified silently if it contains spaces or uppercase, and
+// shortened to MaxSlugBytes if it is longer.
 // Fibers have no status by default — status is opt-in for tracked work.
 func New(slug string, name string) (*Felt, error) {
 	slug = strings.TrimSpace(slug)
@@ -208,6 +252,7 @@ func New(slug string, name string) (*Felt, error) {
 	if id == "" {
 		return nil, fmt.Errorf("slug must contain at least one alphanumeric character")
 	}
+	id = limitIDSlug(id)
 
 	name = strings.TrimSpace(name)
 	if name == "" {
@@ -216,7 +261,7 @@ func New(slug string, name string) (*Felt, error) {
 
 	return &Felt{
 		ID:        id,
-		UID:       NewULID(),
+		UID:       NewUID(id),
 		Name:      name,
 		CreatedAt: time.Now(),
 	}, nil
@@ -227,11 +272,29 @@ func NewULID() string {
 	return ulid.MustNew(ulid.Now(), rand.Reader).String()
 }
 
+// IDSeedEnv makes minted intrinsic ids reproducible for imports, tests, and
+// pipelines that must produce the same store on every run.
+const IDSeedEnv = "FELT_ID_SEED"
+
+// NewUID mints the intrinsic id for the fiber at slug: a random ULID, or,
+// when FELT_ID_SEED is set, one derived from the seed and the slug so the
+// same seed always yields the same id for the same fiber. Seeded ids carry a
+// zero timestamp (they start "0000000000"), which marks them as seeded and
+// keeps them from posing as creation times.
+func NewUID(slug string) string {
+	seed := os.Getenv(IDSeedEnv)
+	if seed == "" {
+		return NewULID()
+	}
+	sum := sha256.Sum256([]byte(seed + "\x00" + slug))
+	return ulid.MustNew(0, bytes.NewReader(sum[:])).String()
+}
+
 // GenerateID creates a slug-based ID from a title string.
 // Used by migration and legacy paths that derive slugs from titles.
 func GenerateID(title string) (string, error) {
 	slug := Slugify(title)
-	if len(slug) > 32 {
+	if utf8.RuneCountInString(slug) > 32 {
 		// Truncate at word boundary
 		slug = truncateAtWord(slug, 32)
 	}
@@ -298,18 +361,22 @@ func stripBracketedTags(s string) string {
 	return strings.TrimSpace(result)
 }
 
-// truncateAtWord truncates s to at most maxLen, breaking at word boundary.
+// truncateAtWord truncates s to at most maxLen runes, breaking at a hyphen
+// when one falls inside the limit. Counting runes rather than bytes keeps a
+// multi-byte letter from being split into invalid UTF-8.
 func truncateAtWord(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
 
 	// Find last hyphen before maxLen
-	lastHyphen := strings.LastIndex(s[:maxLen], "-")
+	head := string(runes[:maxLen])
+	lastHyphen := strings.LastIndex(head, "-")
 	if lastHyphen > 0 {
-		return s[:lastHyphen]
+		return head[:lastHyphen]
 	}
-	return s[:maxLen]
+	return head
 }
 
 // bracketPattern matches [tag] at the start of titles.
@@ -432,6 +499,7 @@ type nativeFrontmatter struct {
 	Tags        []string   `yaml:"tags,omitempty"`
 	CreatedAt   time.Time  `yaml:"created-at"`
 	UpdatedAt   *time.Time `yaml:"updated-at,omitempty"`
+	ActivatedAt *time.Time `yaml:"activated-at,omitempty"`
 	ClosedAt    *time.Time `yaml:"closed-at,omitempty"`
 	Outcome     string     `yaml:"outcome,omitempty"`
 	Due         *time.Time `yaml:"due,omitempty"`
@@ -485,6 +553,13 @@ func IsNativeFrontmatterKey(key string) bool {
 }
 
 func parseFrontmatter(id string, frontmatter []byte) (*Felt, error) {
+	f, _, err := parseFrontmatterKeys(id, frontmatter)
+	return f, err
+}
+
+// parseFrontmatterKeys is parseFrontmatter that also returns every top-level
+// key in document order, native and extra alike, for the metadata index.
+func parseFrontmatterKeys(id string, frontmatter []byte) (*Felt, []string, error) {
 	// nativeFrontmatter carries the written fields; LegacyTitle is the
 	// read-only `title` inbound alias, parsed here but never marshalled.
 	var fm struct {
@@ -492,7 +567,7 @@ func parseFrontmatter(id string, frontmatter []byte) (*Felt, error) {
 		LegacyTitle       string `yaml:"title"`
 	}
 	if err := yaml.Unmarshal(frontmatter, &fm); err != nil {
-		return nil, fmt.Errorf("parsing YAML frontmatter: %w", err)
+		return nil, nil, fmt.Errorf("parsing YAML frontmatter: %w", err)
 	}
 	name := strings.TrimSpace(fm.Name)
 	if name == "" {
@@ -506,6 +581,7 @@ func parseFrontmatter(id string, frontmatter []byte) (*Felt, error) {
 		Tags:        fm.Tags,
 		CreatedAt:   fm.CreatedAt,
 		UpdatedAt:   fm.UpdatedAt,
+		ActivatedAt: fm.ActivatedAt,
 		ClosedAt:    fm.ClosedAt,
 		Outcome:     fm.Outcome,
 		Due:         fm.Due,
@@ -513,6 +589,7 @@ func parseFrontmatter(id string, frontmatter []byte) (*Felt, error) {
 	}
 
 	// Capture unknown top-level keys so Marshal can round-trip them.
+	var keys []string
 	var node yaml.Node
 	if err := yaml.Unmarshal(frontmatter, &node); err == nil && len(node.Content) > 0 {
 		mapping := node.Content[0]
@@ -521,6 +598,7 @@ func parseFrontmatter(id string, frontmatter []byte) (*Felt, error) {
 			var order []string
 			for i := 0; i+1 < len(mapping.Content); i += 2 {
 				key := mapping.Content[i].Value
+				keys = append(keys, key)
 				if _, known := knownFrontmatterKeys[key]; !known {
 					if _, seen := extra[key]; !seen {
 						order = append(order, key)
@@ -536,7 +614,7 @@ func parseFrontmatter(id string, frontmatter []byte) (*Felt, error) {
 	}
 
 	f.canonicalizeName()
-	return f, nil
+	return f, keys, nil
 }
 
 func normalizeLegacyFrontmatter(frontmatter []byte) ([]byte, bool, bool, error) {
@@ -716,15 +794,18 @@ func (f *Felt) Marshal() ([]byte, error) {
 	// Build frontmatter struct for controlled field ordering. The shared
 	// nativeFrontmatter type guarantees Marshal writes exactly the fields
 	// parse reads — minus the read-only `title` alias, which has no field
-	// here and is therefore never emitted.
+	// here and is therefore never emitted. Instants are written in UTC so a
+	// store edited from machines in different zones stays uniform; `due` is
+	// a calendar date and keeps the zone it was written with.
 	fm := nativeFrontmatter{
 		UID:         f.UID,
 		Name:        f.Name,
 		Status:      f.Status,
 		Tags:        f.Tags,
-		CreatedAt:   f.CreatedAt,
-		UpdatedAt:   f.UpdatedAt,
-		ClosedAt:    f.ClosedAt,
+		CreatedAt:   f.CreatedAt.UTC(),
+		UpdatedAt:   utcTimePtr(f.UpdatedAt),
+		ActivatedAt: utcTimePtr(f.ActivatedAt),
+		ClosedAt:    utcTimePtr(f.ClosedAt),
 		Outcome:     f.Outcome,
 		Due:         f.Due,
 		Description: f.Description,
@@ -874,6 +955,32 @@ func LooksLikeUID(query string) bool {
 	return err == nil
 }
 
+// maxUIDLen bounds an explicitly assigned intrinsic id.
+const maxUIDLen = 64
+
+// ValidateUID checks an explicitly assigned intrinsic id (`felt add --id`).
+// Minted ids are ULIDs; assigned ones may be any short token of letters,
+// digits, and . _ : - so migration tools can carry identifiers over from
+// another system. Slashes are refused because ids must never read as slug
+// paths.
+func ValidateUID(uid string) error {
+	if uid == "" {
+		return fmt.Errorf("id cannot be empty")
+	}
+	if len(uid) > maxUIDLen {
+		return fmt.Errorf("id %q is longer than %d characters", uid, maxUIDLen)
+	}
+	for _, r := range uid {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.' || r == '_' || r == ':' || r == '-':
+		default:
+			return fmt.Errorf("id %q contains %q (use letters, digits, and . _ : -)", uid, r)
+		}
+	}
+	return nil
+}
+
 // MatchesUID returns true if the query is an exact (case-insensitive) match for
 // this felt's UID.
 func (f *Felt) MatchesUID(query string) bool {
@@ -94,6 +94,46 @@ func TestNewPreservesLongExplicitSlug(t *testing.T) {
 	}
 }
 
+func TestNewUIDSeeded(t *testing.T) {
+	t.Setenv(IDSeedEnv, "")
+	if a, b := NewUID("alpha"), NewUID("alpha"); a == b {
+		t.Fatalf("unseeded NewUID repeated %s", a)
+	}
+
+	t.Setenv(IDSeedEnv, "pipeline-1")
+	a, err := New("alpha", "Alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := New("alpha", "Alpha again")
+	if a.UID != b.UID {
+		t.Fatalf("seeded UIDs differ: %s vs %s", a.UID, b.UID)
+	}
+	if !LooksLikeUID(a.UID) || !strings.HasPrefix(a.UID, "0000000000") {
+		t.Fatalf("seeded UID = %s, want a zero-timestamp ULID", a.UID)
+	}
+	if other := NewUID("beta"); other == a.UID {
+		t.Fatalf("different slugs share seeded UID %s", other)
+	}
+	t.Setenv(IDSeedEnv, "pipeline-2")
+	if other := NewUID("alpha"); other == a.UID {
+		t.Fatalf("different seeds share UID %s", other)
+	}
+}
+
+func TestValidateUID(t *testing.T) {
+	for _, uid := range []string{"01KTC9C1G1CBJ84H6WB92J8A13", "jira:PROJ-123", "legacy_id.v2"} {
+		if err := ValidateUID(uid); err != nil {
+			t.Errorf("ValidateUID(%q) = %v, want nil", uid, err)
+		}
+	}
+	for _, uid := range []string{"", "a/b", "has space", strings.Repeat("x", maxUIDLen+1)} {
+		if err := ValidateUID(uid); err == nil {
+			t.Errorf("ValidateUID(%q) = nil, want error", uid)
+		}
+	}
+}
+
 func TestBodyStartLine(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -157,6 +197,8 @@ func TestGenerateID(t *testing.T) {
 		{"Special!@#Characters", "special-characters"},
 		{"  Extra   Spaces  ", "extra-spaces"},
 		{"This is a very long title that should be truncated at word boundary", "this-is-a-very-long-title-that"},
+		{"Überprüfung der Kovarianzschätzung für alle Bins", "überprüfung-der"},
+		{"共分散推定の方法を比較する長いタイトルです共分散推定の方法を比較する", "共分散推定の方法を比較する長いタイトルです共分散推定の方法を比較"},
 	}
 
 	for _, tt := range tests {
@@ -189,6 +231,8 @@ func TestSlugify(t *testing.T) {
 		{"Special!@#$%", "special"},
 		{"  Trim Me  ", "trim-me"},
 		{"CamelCase", "camelcase"},
+		{"Größe der Fehler", "größe-der-fehler"},
+		{"共分散", "共分散"},
 		{"", ""},
 	}
 
@@ -200,6 +244,27 @@ func TestSlugify(t *testing.T) {
 	}
 }
 
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Größe der Fehler", "grosse-der-fehler"},
+		{"Élan vital, œuvre", "elan-vital-oeuvre"},
+		{"Ковариация", "kovariatsiya"},
+		{"Θεωρία", "theoria"},
+		{"Łódź 共分散", "lodz-共分散"},
+	}
+	for _, tt := range tests {
+		if got := Slugify(Transliterate(tt.input)); got != tt.want {
+			t.Errorf("Slugify(Transliterate(%q)) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+	if got := TransliteratePath("Äpfel/Größe"); got != "Äpfel/grosse" {
+		t.Errorf("TransliteratePath kept prefix wrong: %q", got)
+	}
+}
+
 func TestParse(t *testing.T) {
 	content := []byte(`---
 name: Test Task
@@ -419,6 +484,35 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestMarshalWritesInstantsInUTC(t *testing.T) {
+	paris := time.FixedZone("CEST", 2*3600)
+	created := time.Date(2026, 4, 10, 11, 0, 0, 0, paris)
+	due := time.Date(2026, 5, 1, 0, 0, 0, 0, paris)
+	f := &Felt{ID: "zoned", Name: "Zoned", CreatedAt: created, Due: &due}
+	f.Touch(created.Add(time.Hour))
+
+	data, err := f.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{"created-at: 2026-04-10T09:00:00Z", "updated-at: 2026-04-10T10:00:00Z", "due: 2026-05-01T00:00:00+02:00"} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("Marshal() missing %q:\n%s", want, content)
+		}
+	}
+	if !f.HasNonUTCTimestamps() {
+		t.Fatal("HasNonUTCTimestamps() = false for a fiber stamped in +02:00")
+	}
+	parsed, err := Parse(f.ID, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.CreatedAt.Equal(created) || parsed.HasNonUTCTimestamps() {
+		t.Fatalf("round trip created-at = %v (non-UTC %v), want %v in UTC", parsed.CreatedAt, parsed.HasNonUTCTimestamps(), created)
+	}
+}
+
 func TestUpdatedAtRoundTrips(t *testing.T) {
 	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
 	updated := time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC)
@@ -1454,3 +1548,42 @@ func looksLikeULID(value string) bool {
 	}
 	return true
 }
+
+func TestParseDateExpr(t *testing.T) {
+	// Wednesday evening in a zone behind UTC: "today" is the local date,
+	// not the UTC date it already is.
+	now := time.Date(2026, 5, 13, 18, 30, 0, 0, time.FixedZone("PDT", -7*3600))
+	cases := map[string]string{
+		"2026-06-01":   "2026-06-01",
+		"today":        "2026-05-13",
+		"EOD":          "2026-05-13",
+		"tomorrow":     "2026-05-14",
+		"eow":          "2026-05-15",
+		"friday":       "2026-05-15",
+		"fri":          "2026-05-15",
+		"wednesday":    "2026-05-20",
+		"next friday":  "2026-05-22",
+		"next week":    "2026-05-18",
+		"next month":   "2026-06-01",
+		"eom":          "2026-05-31",
+		"in 3 days":    "2026-05-16",
+		"in 1 week":    "2026-05-20",
+		"in  2 months": "2026-07-13",
+		"3d":           "2026-05-16",
+		"in 2w":        "2026-05-27",
+	}
+	for in, want := range cases {
+		got, err := ParseDateExpr(in, now)
+		if err != nil {
+			t.Fatalf("ParseDateExpr(%q): %v", in, err)
+		}
+		if got.Format(time.RFC3339) != want+"T00:00:00Z" {
+			t.Fatalf("ParseDateExpr(%q) = %s, want %s", in, got.Format(time.RFC3339), want)
+		}
+	}
+	for _, bad := range []string{"", "someday", "next fortnight", "in 3 hours", "4h", "2026-13-01"} {
+		if _, err := ParseDateExpr(bad, now); err == nil {
+			t.Fatalf("ParseDateExpr(%q) succeeded, want error", bad)
+		}
+	}
+}
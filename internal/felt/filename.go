@@ -0,0 +1,117 @@
+package felt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxSlugBytes caps an id segment's encoded length. Most filesystems
+// limit a filename to 255 bytes; the cap leaves room for ".md" and for the
+// suffixes sync tools add to conflicted copies, so a fiber titled with a
+// pasted paragraph still gets a file that can be opened, synced, and copied.
+const MaxSlugBytes = 200
+
+// counterSuffixRe matches the "-2", "-3", … counter disambiguateID gives a
+// colliding id, which truncation keeps at the end where it was.
+var counterSuffixRe = regexp.MustCompile(`-[0-9]+$`)
+
+// LimitSlug shortens slug to at most MaxSlugBytes, cutting at a hyphen where
+// it can. The cut slug ends in a short hash of the whole one, so titles that
+// differ only past the limit still get different ids, then any counter
+// suffix. A slug within the limit is returned as is.
+func LimitSlug(slug string) string {
+	if len(slug) <= MaxSlugBytes {
+		return slug
+	}
+	suffix := counterSuffixRe.FindString(slug)
+	head := strings.TrimSuffix(slug, suffix)
+	sum := sha256.Sum256([]byte(slug))
+	tag := "-" + hex.EncodeToString(sum[:])[:8]
+	limit := MaxSlugBytes - len(tag) - len(suffix)
+	cut := 0
+	for i, r := range head {
+		if i+utf8.RuneLen(r) > limit {
+			break
+		}
+		cut = i + utf8.RuneLen(r)
+	}
+	head = head[:cut]
+	if i := strings.LastIndex(head, "-"); i > 0 {
+		head = head[:i]
+	}
+	return strings.TrimRight(head, "-") + tag + suffix
+}
+
+// limitIDSlug applies LimitSlug to id's last segment; prefix segments name
+// directories that already exist.
+func limitIDSlug(id string) string {
+	dir, slug := path.Split(id)
+	return dir + LimitSlug(slug)
+}
+
+// FilenameIssue is a fiber whose last id segment — its directory and file
+// name — is not one felt would create: over MaxSlugBytes, or carrying
+// characters a slug never holds. Want is the id felt would give it.
+type FilenameIssue struct {
+	ID     string `json:"id"`
+	Want   string `json:"want"`
+	Reason string `json:"reason"`
+}
+
+// NonCanonicalFilenames returns the felts whose filenames are not in the
+// form felt writes, sorted by id. Only the last segment is judged, as felt
+// keeps prefix directories as typed. Case and underscores are left alone:
+// hand-made directories like pure_eb are common, and felt doctor reports
+// case separately.
+func NonCanonicalFilenames(felts []*Felt) []FilenameIssue {
+	var out []FilenameIssue
+	for _, f := range felts {
+		dir, slug := path.Split(f.ID)
+		var reason string
+		switch {
+		case len(slug) > MaxSlugBytes:
+			reason = "longer than the filename limit"
+		case strings.IndexFunc(slug, notSlugRune) >= 0:
+			reason = "has characters a slug never holds"
+		default:
+			continue
+		}
+		out = append(out, FilenameIssue{ID: f.ID, Want: dir + LimitSlug(tidySlug(slug)), Reason: reason})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// notSlugRune reports whether r is a rune no slug holds: felt's slugs are
+// letters, digits, and hyphens, and hand-made directories add underscores.
+func notSlugRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+}
+
+// tidySlug collapses each run of runes no slug holds into one hyphen, as
+// Slugify does, but keeps case and underscores.
+func tidySlug(slug string) string {
+	var b strings.Builder
+	prevHyphen := false
+	for _, r := range slug {
+		if notSlugRune(r) || r == '-' {
+			if !prevHyphen {
+				b.WriteRune('-')
+			}
+			prevHyphen = true
+			continue
+		}
+		b.WriteRune(r)
+		prevHyphen = false
+	}
+	if tidy := strings.Trim(b.String(), "-"); tidy != "" {
+		return tidy
+	}
+	return slug
+}
@@ -0,0 +1,62 @@
+package felt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestLimitSlugKeepsLongTitlesApart(t *testing.T) {
+	prefix := strings.Repeat("covariance-", 30)
+	a, b := LimitSlug(prefix+"north"), LimitSlug(prefix+"south")
+	if a == b {
+		t.Fatalf("LimitSlug gave both titles %q", a)
+	}
+	for _, got := range []string{a, b} {
+		if len(got) > MaxSlugBytes || !strings.HasPrefix(got, "covariance-covariance-") || strings.Contains(got, "--") {
+			t.Fatalf("LimitSlug = %q (%d bytes)", got, len(got))
+		}
+	}
+	if again := LimitSlug(prefix + "north"); again != a {
+		t.Fatalf("LimitSlug is not stable: %q then %q", a, again)
+	}
+	counted := LimitSlug(prefix + "north-2")
+	if len(counted) > MaxSlugBytes || !strings.HasSuffix(counted, "-2") {
+		t.Fatalf("LimitSlug dropped the counter: %q", counted)
+	}
+	if got := LimitSlug("mocks-2"); got != "mocks-2" {
+		t.Fatalf("LimitSlug shortened a short slug: %q", got)
+	}
+	wide := LimitSlug(strings.Repeat("é", 150))
+	if len(wide) > MaxSlugBytes || !utf8.ValidString(wide) {
+		t.Fatalf("LimitSlug split a rune: %q", wide)
+	}
+}
+
+func TestNewShortensLongSlug(t *testing.T) {
+	f, err := New("pure_eb/"+strings.Repeat("a very long title ", 20), "Long")
+	if err != nil {
+		t.Fatal(err)
+	}
+	slug := strings.TrimPrefix(f.ID, "pure_eb/")
+	if len(slug) > MaxSlugBytes || !strings.HasPrefix(f.ID, "pure_eb/a-very-long-title-") || strings.HasSuffix(slug, "-") {
+		t.Fatalf("New id = %q", f.ID)
+	}
+}
+
+func TestNonCanonicalFilenames(t *testing.T) {
+	felts := []*Felt{
+		{ID: "pure_eb/covariance"},
+		{ID: "notes/meeting notes.v2"},
+		{ID: strings.Repeat("x", MaxSlugBytes+1)},
+	}
+	got := NonCanonicalFilenames(felts)
+	want := []FilenameIssue{
+		{ID: "notes/meeting notes.v2", Want: "notes/meeting-notes-v2", Reason: "has characters a slug never holds"},
+		{ID: strings.Repeat("x", MaxSlugBytes+1), Want: LimitSlug(strings.Repeat("x", MaxSlugBytes+1)), Reason: "longer than the filename limit"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NonCanonicalFilenames = %+v, want %+v", got, want)
+	}
+}
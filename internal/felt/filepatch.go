@@ -0,0 +1,233 @@
+package felt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EmitPatchEnv switches a Storage to review mode: instead of changing fiber
+// files, it prints each change to stderr as a git-style unified diff with
+// project-relative paths. `felt --emit-patch` sets it; `felt apply --patch`
+// (Storage.ApplyPatch) applies the saved output later.
+const EmitPatchEnv = "FELT_EMIT_PATCH"
+
+// devNull is the diff header name of the missing side of a created or
+// deleted file.
+const devNull = "/dev/null"
+
+// emitPatch prints the change that writing data to path (or, when !exists,
+// removing it) would make. An unchanged file prints nothing.
+func (s *Storage) emitPatch(path string, data []byte, exists bool) error {
+	old, err := os.ReadFile(path)
+	existed := err == nil
+	if err != nil && !os.IsNotExist(err) && !isNotDirError(err) {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	name, err := filepath.Rel(s.ProjectRoot(), path)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(s.patches, filePatch(filepath.ToSlash(name), string(old), string(data), existed, exists))
+	return err
+}
+
+// emitDeletePatch prints the removal of the fiber id and its body sidecar,
+// as Storage.Delete would make it.
+func (s *Storage) emitDeletePatch(id, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("deleting file %s: %w", path, err)
+	}
+	if f, err := readMetadataFile(path, id); err == nil {
+		if bodyPath, ok, err := bodyFilePath(f, path); err == nil && ok {
+			if err := s.emitPatch(bodyPath, nil, false); err != nil {
+				return err
+			}
+		}
+	}
+	return s.emitPatch(path, nil, false)
+}
+
+// refuseUnpatchable fails an operation that moves files wholesale, which a
+// unified diff cannot carry, when the store is only emitting patches.
+func (s *Storage) refuseUnpatchable(what string) error {
+	if s.patches != nil {
+		return fmt.Errorf("%s cannot be emitted as a patch; unset %s to run it", what, EmitPatchEnv)
+	}
+	return nil
+}
+
+// filePatch renders one file's change as a git-style diff section. Contents
+// lose their final newline before diffing, so a fiber file's last line is
+// an ordinary line rather than a trailing empty one.
+func filePatch(name, old, new string, existed, exists bool) string {
+	old, new = strings.TrimSuffix(old, "\n"), strings.TrimSuffix(new, "\n")
+	if existed == exists && old == new {
+		return ""
+	}
+	from, to := "a/"+name, "b/"+name
+	if !existed {
+		from = devNull
+	}
+	if !exists {
+		to = devNull
+	}
+	return "--- " + from + "\n+++ " + to + "\n" + UnifiedDiff(old, new)
+}
+
+// fileDiff is one file's section of a multi-file patch. OldName or NewName
+// is "" on the side where the file does not exist.
+type fileDiff struct {
+	OldName string
+	NewName string
+	Hunks   string
+}
+
+// splitFilePatch cuts a multi-file unified diff, as `felt --emit-patch` or
+// `git diff` writes it, into its files. Hunk line counts are tracked, so a
+// removed line that happens to start with "--" is not taken for a header.
+func splitFilePatch(patch string) ([]fileDiff, error) {
+	var files []fileDiff
+	var hunks strings.Builder
+	flush := func() {
+		if len(files) > 0 {
+			files[len(files)-1].Hunks = hunks.String()
+		}
+		hunks.Reset()
+	}
+	lines := strings.Split(strings.TrimRight(patch, "\n"), "\n")
+	oldLeft, newLeft := 0, 0
+	for n := 0; n < len(lines); n++ {
+		line := strings.TrimSuffix(lines[n], "\r")
+		inHunk := oldLeft > 0 || newLeft > 0
+		switch {
+		case !inHunk && strings.HasPrefix(line, "--- ") && n+1 < len(lines) && strings.HasPrefix(lines[n+1], "+++ "):
+			flush()
+			files = append(files, fileDiff{
+				OldName: patchFileName(line[len("--- "):], "a/"),
+				NewName: patchFileName(strings.TrimSuffix(lines[n+1], "\r")[len("+++ "):], "b/"),
+			})
+			n++
+			continue
+		case !inHunk && strings.HasPrefix(line, "@@"):
+			if len(files) == 0 {
+				return nil, fmt.Errorf("patch line %d: hunk before any --- / +++ file header", n+1)
+			}
+			_, oldCount, newCount, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("patch line %d: %w", n+1, err)
+			}
+			oldLeft, newLeft = oldCount, newCount
+		case inHunk && line != `\ No newline at end of file`:
+			switch {
+			case line == "" || line[0] == ' ':
+				oldLeft--
+				newLeft--
+			case line[0] == '-':
+				oldLeft--
+			case line[0] == '+':
+				newLeft--
+			}
+		}
+		hunks.WriteString(line + "\n")
+	}
+	flush()
+	if len(files) == 0 {
+		return nil, fmt.Errorf("patch names no files (expected --- / +++ headers)")
+	}
+	return files, nil
+}
+
+// patchFileName strips a header name's a/ or b/ prefix and any tab-separated
+// timestamp; /dev/null becomes "".
+func patchFileName(header, prefix string) string {
+	name, _, _ := strings.Cut(header, "\t")
+	if name == devNull {
+		return ""
+	}
+	return strings.TrimPrefix(name, prefix)
+}
+
+// ApplyPatch applies a multi-file unified diff to the store in one
+// transaction: every file's hunks must apply, and every fiber file must
+// still parse, or nothing changes. Paths are relative to the project root
+// and must lie inside the store. It returns the files changed.
+func (s *Storage) ApplyPatch(patch string) ([]string, error) {
+	files, err := splitFilePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := s.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var changed []string
+	for _, fd := range files {
+		name := fd.NewName
+		if name == "" {
+			name = fd.OldName
+		}
+		if name == "" {
+			return nil, fmt.Errorf("patch has a file that is %s on both sides", devNull)
+		}
+		path := filepath.Join(s.ProjectRoot(), filepath.FromSlash(name))
+		if !s.pathInStore(path) {
+			return nil, fmt.Errorf("%s: outside the felt store", name)
+		}
+		if fd.OldName != "" && fd.NewName != "" && fd.OldName != fd.NewName {
+			return nil, fmt.Errorf("%s: renames are not supported", name)
+		}
+
+		old, err := os.ReadFile(path)
+		switch {
+		case err == nil && fd.OldName == "":
+			return nil, fmt.Errorf("%s: patch creates it, but it already exists", name)
+		case err != nil && !os.IsNotExist(err):
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		case err != nil && fd.OldName != "":
+			return nil, fmt.Errorf("%s: does not exist", name)
+		}
+		result, err := ApplyUnifiedPatch(strings.TrimSuffix(string(old), "\n"), fd.Hunks)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		if fd.NewName == "" {
+			if result != "" {
+				return nil, fmt.Errorf("%s: patch deletes it but leaves content behind", name)
+			}
+			if err := tx.Remove(path); err != nil {
+				return nil, err
+			}
+		} else {
+			data := []byte(result + "\n")
+			if s.isFiberFilePath(path) {
+				if _, err := Parse(strings.TrimSuffix(filepath.Base(path), FileExt), data); err != nil {
+					return nil, fmt.Errorf("%s: patched file does not parse: %w", name, err)
+				}
+			}
+			if err := tx.WriteFile(path, data); err != nil {
+				return nil, err
+			}
+		}
+		changed = append(changed, name)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// isFiberFilePath reports whether path is where a fiber file lives — a
+// <slug>/<slug>.md, or a .md entry point at the store root — rather than a
+// body sidecar or other file beside one.
+func (s *Storage) isFiberFilePath(path string) bool {
+	dir, base := filepath.Dir(path), filepath.Base(path)
+	if !strings.HasSuffix(base, FileExt) {
+		return false
+	}
+	return filepath.Clean(dir) == filepath.Clean(s.root) || base == filepath.Base(dir)+FileExt
+}
@@ -0,0 +1,134 @@
+package felt
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEmittedPatchAppliesBack(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for _, f := range []*Felt{
+		{ID: "prep", Name: "Prep", Status: StatusActive, Body: "Line one.\n\n--- not a header", CreatedAt: created},
+		{ID: "gone", Name: "Gone", CreatedAt: created},
+	} {
+		if err := s.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before := func(id string) []byte {
+		data, err := os.ReadFile(s.Path(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	prepBefore := before("prep")
+
+	var patch bytes.Buffer
+	review := NewStorage(dir)
+	review.patches = &patch
+	prep, err := review.Read("prep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prep.Outcome = "Prepped"
+	prep.Body = "Line one, revised.\n\n--- not a header"
+	if err := review.Write(prep); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := review.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Write(&Felt{ID: "prep/sub", Name: "Sub", CreatedAt: created}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Delete("gone"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(before("prep"), prepBefore) {
+		t.Fatal("emitting a patch changed the fiber file")
+	}
+	if _, err := os.Stat(s.Path("prep/sub")); !os.IsNotExist(err) {
+		t.Fatalf("emitting a transaction created its fiber (stat err %v)", err)
+	}
+	for _, want := range []string{
+		"--- a/.felt/prep/prep.md\n+++ b/.felt/prep/prep.md\n",
+		"--- /dev/null\n+++ b/.felt/prep/sub/sub.md\n",
+		"--- a/.felt/gone/gone.md\n+++ /dev/null\n",
+		"-Line one.\n+Line one, revised.\n",
+	} {
+		if !strings.Contains(patch.String(), want) {
+			t.Fatalf("patch missing %q:\n%s", want, patch.String())
+		}
+	}
+
+	changed, err := s.ApplyPatch(patch.String())
+	if err != nil {
+		t.Fatalf("ApplyPatch: %v\n%s", err, patch.String())
+	}
+	if len(changed) != 3 {
+		t.Fatalf("changed = %v, want 3 files", changed)
+	}
+	got, err := s.Read("prep")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Outcome != "Prepped" || got.Body != prep.Body {
+		t.Fatalf("prep after patch = outcome %q body %q", got.Outcome, got.Body)
+	}
+	if _, err := s.Read("prep/sub"); err != nil {
+		t.Fatalf("patch did not create prep/sub: %v", err)
+	}
+	if _, err := os.Stat(s.Path("gone")); !os.IsNotExist(err) {
+		t.Fatalf("patch did not delete gone (stat err %v)", err)
+	}
+}
+
+func TestApplyPatchIsAllOrNothing(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "a", Name: "A", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(s.Path("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patch := "--- a/.felt/a/a.md\n+++ b/.felt/a/a.md\n@@ -3 +3 @@\n-name: A\n+name: Renamed\n" +
+		"--- a/.felt/b/b.md\n+++ b/.felt/b/b.md\n@@ -1 +1 @@\n----\n+---\n"
+	if _, err := s.ApplyPatch(patch); err == nil || !strings.Contains(err.Error(), "does not exist") {
+		t.Fatalf("ApplyPatch with a missing file = %v, want error", err)
+	}
+	if data, _ := os.ReadFile(s.Path("a")); !bytes.Equal(data, original) {
+		t.Fatal("a failed patch still changed an earlier file")
+	}
+
+	broken := "--- a/.felt/a/a.md\n+++ b/.felt/a/a.md\n@@ -1 +1 @@\n----\n+--\n"
+	if _, err := s.ApplyPatch(broken); err == nil || !strings.Contains(err.Error(), "does not parse") {
+		t.Fatalf("ApplyPatch breaking frontmatter = %v, want parse error", err)
+	}
+	outside := "--- /dev/null\n+++ b/notes.md\n@@ -0,0 +1 @@\n+hi\n"
+	if _, err := s.ApplyPatch(outside); err == nil || !strings.Contains(err.Error(), "outside the felt store") {
+		t.Fatalf("ApplyPatch outside the store = %v, want error", err)
+	}
+	if len(txStagingDirs(t, s)) != 0 {
+		t.Fatal("failed patches left staging directories behind")
+	}
+}
@@ -0,0 +1,133 @@
+package felt
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// MinForecastHistory is the fewest historical cycle times a forecast will
+// sample from; below it the percentiles would just echo one or two fibers.
+const MinForecastHistory = 3
+
+// CycleTimes returns how long each closed fiber took: activated-at (else
+// created-at) to closed-at. Fibers without a usable span are skipped.
+func CycleTimes(felts []*Felt) []time.Duration {
+	var out []time.Duration
+	for _, f := range felts {
+		if !f.IsClosed() || f.ClosedAt == nil {
+			continue
+		}
+		start := f.CreatedAt
+		if f.ActivatedAt != nil {
+			start = *f.ActivatedAt
+		}
+		if start.IsZero() || !f.ClosedAt.After(start) {
+			continue
+		}
+		out = append(out, f.ClosedAt.Sub(start))
+	}
+	return out
+}
+
+// Forecast is a Monte Carlo completion estimate: P50 and P85 are durations
+// from now by which the remaining work finished in half and 85% of trials.
+type Forecast struct {
+	Remaining int           `json:"remaining"`
+	History   int           `json:"history"`
+	Trials    int           `json:"trials"`
+	P50       time.Duration `json:"p50"`
+	P85       time.Duration `json:"p85"`
+}
+
+// ForecastCompletion simulates finishing targets. Each trial draws a cycle
+// time per unfinished fiber from history and lets a fiber start only once
+// its open data-flow upstreams (transitively, including ones outside
+// targets) have finished, so a trial's completion is its longest dependency
+// chain. Independent chains run in parallel. Active fibers count their time
+// already spent against the draw.
+func ForecastCompletion(targets, all []*Felt, history []time.Duration, trials int, rng *rand.Rand, now time.Time) (*Forecast, error) {
+	if len(history) < MinForecastHistory {
+		return nil, fmt.Errorf("forecast needs at least %d closed fibers with timestamps, have %d", MinForecastHistory, len(history))
+	}
+	if trials < 1 {
+		return nil, fmt.Errorf("trials must be at least 1")
+	}
+
+	byID := make(map[string]*Felt, len(all))
+	for _, f := range all {
+		byID[f.ID] = f
+	}
+	upstreams := DataFlowUpstreams(all)
+
+	// The work to simulate: unfinished targets plus their unfinished upstream
+	// closure, in dependency order.
+	var order []*Felt
+	state := map[string]int{} // 1 visiting, 2 done
+	var visit func(f *Felt)
+	visit = func(f *Felt) {
+		if f.IsClosed() || state[f.ID] != 0 {
+			return // closed, already placed, or a cycle back-edge
+		}
+		state[f.ID] = 1
+		for _, id := range upstreams[f.ID] {
+			if up := byID[id]; up != nil {
+				visit(up)
+			}
+		}
+		state[f.ID] = 2
+		order = append(order, f)
+	}
+	for _, f := range targets {
+		visit(f)
+	}
+
+	fc := &Forecast{Remaining: len(order), History: len(history), Trials: trials}
+	if len(order) == 0 {
+		return fc, nil
+	}
+
+	results := make([]time.Duration, trials)
+	finish := make(map[string]time.Duration, len(order))
+	for t := range results {
+		var last time.Duration
+		for _, f := range order {
+			var start time.Duration
+			for _, id := range upstreams[f.ID] {
+				if end, ok := finish[id]; ok && end > start {
+					start = end
+				}
+			}
+			d := history[rng.Intn(len(history))]
+			if f.IsActive() && f.ActivatedAt != nil {
+				d -= now.Sub(*f.ActivatedAt)
+				if d < 0 {
+					d = 0
+				}
+			}
+			finish[f.ID] = start + d
+			if start+d > last {
+				last = start + d
+			}
+		}
+		results[t] = last
+		clear(finish)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+	fc.P50 = percentile(results, 0.50)
+	fc.P85 = percentile(results, 0.85)
+	return fc, nil
+}
+
+// percentile returns the nearest-rank p-quantile of sorted.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	i := int(float64(len(sorted))*p+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
@@ -0,0 +1,40 @@
+package felt
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestForecastFollowsDependencyChains(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	var felts []*Felt
+	// Three closed fibers that each took exactly two days.
+	for _, id := range []string{"h1", "h2", "h3"} {
+		start, end := now.Add(-10*day), now.Add(-8*day)
+		felts = append(felts, &Felt{ID: id, Name: id, Status: StatusClosed, CreatedAt: start, ClosedAt: &end})
+	}
+	up := &Felt{ID: "up", Name: "Up", Status: StatusOpen, CreatedAt: now}
+	down := &Felt{ID: "down", Name: "Down", Status: StatusOpen, CreatedAt: now, Tags: []string{"paper"}}
+	mustExtraField(t, down, "inputs", []map[string]any{{"id": "x", "from": "up"}})
+	side := &Felt{ID: "side", Name: "Side", Status: StatusOpen, CreatedAt: now, Tags: []string{"paper"}}
+	felts = append(felts, up, down, side)
+
+	history := CycleTimes(felts)
+	if len(history) != 3 {
+		t.Fatalf("history = %v", history)
+	}
+	fc, err := ForecastCompletion([]*Felt{down, side}, felts, history, 100, rand.New(rand.NewSource(1)), now)
+	if err != nil {
+		t.Fatalf("ForecastCompletion: %v", err)
+	}
+	// up → down is a four-day chain; side runs alongside it.
+	if fc.Remaining != 3 || fc.P50 != 4*day || fc.P85 != 4*day {
+		t.Fatalf("forecast = %+v", fc)
+	}
+
+	if _, err := ForecastCompletion([]*Felt{side}, felts, history[:2], 10, rand.New(rand.NewSource(1)), now); err == nil {
+		t.Fatalf("thin history should be refused")
+	}
+}
@@ -0,0 +1,135 @@
+package felt
+
+import "sort"
+
+// Graph is an in-memory view of the store — every fiber by id plus the
+// resolved data-flow edges between them — for long-running consumers (felt
+// serve, the shuttle daemon) that would otherwise re-list and re-resolve the
+// whole store on every request. Build it with NewGraph, then patch it with
+// Update and Remove, or let RefreshGraph find what changed by file mtime.
+type Graph struct {
+	Nodes map[string]*Felt
+	// upstreams maps a fiber id to the resolved, sorted ids its
+	// `inputs[].from` entries name, as DataFlowUpstreams does.
+	upstreams map[string][]string
+}
+
+// NewGraph builds the graph of felts.
+func NewGraph(felts []*Felt) *Graph {
+	g := &Graph{Nodes: make(map[string]*Felt, len(felts))}
+	for _, f := range felts {
+		g.Nodes[f.ID] = f
+	}
+	g.upstreams = DataFlowUpstreams(felts)
+	return g
+}
+
+// Felts returns the graph's fibers ordered by id.
+func (g *Graph) Felts() []*Felt {
+	out := make([]*Felt, 0, len(g.Nodes))
+	for _, id := range g.ids() {
+		out = append(out, g.Nodes[id])
+	}
+	return out
+}
+
+// Upstreams returns the fibers id reads from, by id.
+func (g *Graph) Upstreams(id string) []string {
+	return append([]string(nil), g.upstreams[id]...)
+}
+
+// Downstreams returns the fibers that read from id directly, by id.
+func (g *Graph) Downstreams(id string) []string {
+	var out []string
+	for consumer, ups := range g.upstreams {
+		for _, up := range ups {
+			if up == id {
+				out = append(out, consumer)
+				break
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Update replaces or adds each changed fiber. Only the changed fibers'
+// inputs are re-resolved, unless one is new: scoped resolution depends on
+// the whole id set, so a new id re-resolves every edge.
+func (g *Graph) Update(changed []*Felt) {
+	added := false
+	for _, f := range changed {
+		if _, ok := g.Nodes[f.ID]; !ok {
+			added = true
+		}
+		g.Nodes[f.ID] = f
+	}
+	if added {
+		g.upstreams = DataFlowUpstreams(g.Felts())
+		return
+	}
+	resolver := newScopedIDResolver(g.ids())
+	for _, f := range changed {
+		delete(g.upstreams, f.ID)
+		seen := make(map[string]bool)
+		_ = iterRefsResolved([]*Felt{f}, resolver, func(r resolvedRef) error {
+			if r.Kind != refKindDataFlow || r.ResolveErr != nil || r.ResolvedID == f.ID || seen[r.ResolvedID] {
+				return nil
+			}
+			seen[r.ResolvedID] = true
+			g.upstreams[f.ID] = append(g.upstreams[f.ID], r.ResolvedID)
+			return nil
+		})
+		sort.Strings(g.upstreams[f.ID])
+	}
+}
+
+// Remove drops the fibers ids. Like an added id, a removed one can change
+// how other fibers' refs resolve, so every edge is re-resolved.
+func (g *Graph) Remove(ids ...string) {
+	if len(ids) == 0 {
+		return
+	}
+	for _, id := range ids {
+		delete(g.Nodes, id)
+	}
+	g.upstreams = DataFlowUpstreams(g.Felts())
+}
+
+func (g *Graph) ids() []string {
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// RefreshGraph brings g up to date with the store: fibers whose file
+// modification time moved since g last saw them are updated, new ones
+// added, and vanished ones removed. g's fibers must carry ModifiedAt, as a
+// graph built from ListMetadataWithModTime does. It reports whether
+// anything changed.
+func (s *Storage) RefreshGraph(g *Graph) (bool, error) {
+	felts, err := s.ListMetadataWithModTime()
+	if err != nil {
+		return false, err
+	}
+	var changed []*Felt
+	present := make(map[string]bool, len(felts))
+	for _, f := range felts {
+		present[f.ID] = true
+		if old, ok := g.Nodes[f.ID]; !ok || !old.ModifiedAt.Equal(f.ModifiedAt) {
+			changed = append(changed, f)
+		}
+	}
+	var removed []string
+	for id := range g.Nodes {
+		if !present[id] {
+			removed = append(removed, id)
+		}
+	}
+	g.Update(changed)
+	g.Remove(removed...)
+	return len(changed) > 0 || len(removed) > 0, nil
+}
@@ -0,0 +1,104 @@
+package felt
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGraphUpdateAndRemove(t *testing.T) {
+	data := &Felt{ID: "data", Name: "Data"}
+	fit := &Felt{ID: "fit", Name: "Fit"}
+	mustExtra(t, fit, "inputs", []map[string]any{{"id": "data", "from": "data"}})
+	paper := &Felt{ID: "paper", Name: "Paper"}
+	g := NewGraph([]*Felt{data, fit, paper})
+	if got := g.Upstreams("fit"); !reflect.DeepEqual(got, []string{"data"}) {
+		t.Fatalf("Upstreams(fit) = %v", got)
+	}
+
+	// An edit re-resolves only the edited fiber.
+	edited := &Felt{ID: "paper", Name: "Paper"}
+	mustExtra(t, edited, "inputs", []map[string]any{{"id": "fit", "from": "fit"}, {"id": "data", "from": "data"}})
+	g.Update([]*Felt{edited})
+	if got := g.Upstreams("paper"); !reflect.DeepEqual(got, []string{"data", "fit"}) {
+		t.Fatalf("Upstreams(paper) = %v", got)
+	}
+	if got := g.Downstreams("data"); !reflect.DeepEqual(got, []string{"fit", "paper"}) {
+		t.Fatalf("Downstreams(data) = %v", got)
+	}
+	if g.Nodes["paper"] != edited {
+		t.Fatal("Update did not replace the node")
+	}
+
+	// A new fiber can change what an existing ref resolves to: step's
+	// input names a prep that does not exist until a later update.
+	step := &Felt{ID: "fit/step", Name: "Step"}
+	mustExtra(t, step, "inputs", []map[string]any{{"id": "prep", "from": "prep"}})
+	g.Update([]*Felt{step})
+	if got := g.Upstreams("fit/step"); len(got) != 0 {
+		t.Fatalf("Upstreams(fit/step) = %v before prep exists", got)
+	}
+	g.Update([]*Felt{{ID: "fit/prep", Name: "Prep"}})
+	if got := g.Upstreams("fit/step"); !reflect.DeepEqual(got, []string{"fit/prep"}) {
+		t.Fatalf("Upstreams(fit/step) = %v, want the new prep", got)
+	}
+
+	g.Remove("fit/prep")
+	if got := g.Upstreams("fit/step"); len(got) != 0 {
+		t.Fatalf("Upstreams(fit/step) after Remove = %v", got)
+	}
+	if _, ok := g.Nodes["fit/prep"]; ok || len(g.Felts()) != 4 {
+		t.Fatalf("Remove left %d nodes", len(g.Felts()))
+	}
+}
+
+func TestRefreshGraphFollowsMtimes(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	for _, f := range []*Felt{{ID: "a", Name: "A", CreatedAt: old}, {ID: "b", Name: "B", CreatedAt: old}} {
+		if err := s.Write(f); err != nil {
+			t.Fatal(err)
+		}
+		os.Chtimes(s.Path(f.ID), old, old)
+	}
+	felts, err := s.ListMetadataWithModTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGraph(felts)
+	if changed, err := s.RefreshGraph(g); err != nil || changed {
+		t.Fatalf("RefreshGraph on an unchanged store = %v, %v", changed, err)
+	}
+
+	b := &Felt{ID: "b", Name: "B", CreatedAt: old}
+	mustExtra(t, b, "inputs", []map[string]any{{"id": "a", "from": "a"}})
+	if err := s.Write(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(&Felt{ID: "c", Name: "C", CreatedAt: old}); err != nil {
+		t.Fatal(err)
+	}
+	if changed, err := s.RefreshGraph(g); err != nil || !changed {
+		t.Fatalf("RefreshGraph = %v, %v, want changed", changed, err)
+	}
+	var ids []string
+	for _, f := range g.Felts() {
+		ids = append(ids, f.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"b", "c"}) {
+		t.Fatalf("graph fibers = %v", ids)
+	}
+	if _, ok := g.Nodes["b"].ExtraFields["inputs"]; !ok {
+		t.Fatal("edited fiber was not re-read")
+	}
+	if got := g.Upstreams("b"); len(got) != 0 {
+		t.Fatalf("Upstreams(b) = %v, want none once a is gone", got)
+	}
+}
@@ -0,0 +1,264 @@
+package felt
+
+import (
+	"encoding/xml"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GraphEdge is one typed edge between exported fibers. Kind is "data_flow"
+// for an inputs[].from entry, running from the upstream to the fiber that
+// reads it, or "reference" for a [[wiki link]], running from the citing
+// fiber to the cited one. Input names the data-flow input.
+type GraphEdge struct {
+	Source string
+	Target string
+	Kind   string
+	Input  string
+}
+
+// GraphEdges resolves the references and data-flow inputs among felts into
+// edges, sorted and without duplicates. Refs that leave the set, or point a
+// fiber at itself, are dropped. Reference edges need the fibers' bodies.
+func GraphEdges(felts []*Felt) []GraphEdge {
+	seen := make(map[GraphEdge]bool)
+	var out []GraphEdge
+	_ = iterRefs(felts, sortedFeltIDs(felts), func(r resolvedRef) error {
+		if r.ResolveErr != nil || r.ResolvedID == r.Source.ID {
+			return nil
+		}
+		e := GraphEdge{Source: r.Source.ID, Target: r.ResolvedID, Kind: r.Kind}
+		if r.Kind == refKindDataFlow {
+			e = GraphEdge{Source: r.ResolvedID, Target: r.Source.ID, Kind: r.Kind, Input: r.InputID}
+		}
+		if !seen[e] {
+			seen[e] = true
+			out = append(out, e)
+		}
+		return nil
+	})
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Input < b.Input
+	})
+	return out
+}
+
+// graphNodeAttrs are the fiber attributes the graph exports carry, in
+// order, and graphEdgeAttrs the edge ones.
+var (
+	graphNodeAttrs = []string{"name", "status", "tags", "created", "closed", "due", "outcome", "owner"}
+	graphEdgeAttrs = []string{"kind", "input"}
+)
+
+// graphNodeValues returns f's graphNodeAttrs values; unset ones are "".
+// Tags are joined by ";", times are RFC 3339.
+func graphNodeValues(f *Felt) map[string]string {
+	stamp := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	return map[string]string{
+		"name":    f.DisplayName(),
+		"status":  f.Status,
+		"tags":    strings.Join(f.Tags, csvListSep),
+		"created": stamp(&f.CreatedAt),
+		"closed":  stamp(f.ClosedAt),
+		"due":     stamp(f.Due),
+		"outcome": f.Outcome,
+		"owner":   f.Owner(),
+	}
+}
+
+func graphEdgeValues(e GraphEdge) map[string]string {
+	return map[string]string{"kind": e.Kind, "input": e.Input}
+}
+
+type graphMLDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes felts and the GraphEdges among them as a directed
+// GraphML graph, for Gephi, NetworkX (read_graphml), or yEd. Nodes are
+// keyed by fiber id; unset attributes are left out.
+func WriteGraphML(w io.Writer, felts []*Felt) error {
+	doc := graphMLDoc{XMLNS: "http://graphml.graphdrawing.org/xmlns", Graph: graphMLGraph{ID: "felt", EdgeDefault: "directed"}}
+	for _, name := range graphNodeAttrs {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: name, For: "node", Name: name, Type: "string"})
+	}
+	for _, name := range graphEdgeAttrs {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: name, For: "edge", Name: name, Type: "string"})
+	}
+	for _, f := range sortedByID(felts) {
+		n := graphMLNode{ID: f.ID}
+		values := graphNodeValues(f)
+		for _, name := range graphNodeAttrs {
+			if v := values[name]; v != "" {
+				n.Data = append(n.Data, graphMLData{Key: name, Value: v})
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, n)
+	}
+	for i, e := range GraphEdges(felts) {
+		edge := graphMLEdge{ID: graphEdgeID(i), Source: e.Source, Target: e.Target}
+		values := graphEdgeValues(e)
+		for _, name := range graphEdgeAttrs {
+			if v := values[name]; v != "" {
+				edge.Data = append(edge.Data, graphMLData{Key: name, Value: v})
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+	return writeXML(w, doc)
+}
+
+type gexfDoc struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID     string         `xml:"id,attr"`
+	Label  string         `xml:"label,attr"`
+	Values []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID     string         `xml:"id,attr"`
+	Source string         `xml:"source,attr"`
+	Target string         `xml:"target,attr"`
+	Label  string         `xml:"label,attr"`
+	Values []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// WriteGEXF writes the same graph as WriteGraphML in GEXF 1.3, Gephi's
+// native format. Node labels are fiber names and edge labels their kinds.
+func WriteGEXF(w io.Writer, felts []*Felt) error {
+	doc := gexfDoc{XMLNS: "http://gexf.net/1.3", Version: "1.3", Graph: gexfGraph{DefaultEdgeType: "directed"}}
+	nodeAttrs := gexfAttributes{Class: "node"}
+	for _, name := range graphNodeAttrs {
+		nodeAttrs.Attributes = append(nodeAttrs.Attributes, gexfAttribute{ID: name, Title: name, Type: "string"})
+	}
+	edgeAttrs := gexfAttributes{Class: "edge"}
+	for _, name := range graphEdgeAttrs {
+		edgeAttrs.Attributes = append(edgeAttrs.Attributes, gexfAttribute{ID: name, Title: name, Type: "string"})
+	}
+	doc.Graph.Attributes = []gexfAttributes{nodeAttrs, edgeAttrs}
+	for _, f := range sortedByID(felts) {
+		n := gexfNode{ID: f.ID, Label: f.DisplayName()}
+		values := graphNodeValues(f)
+		for _, name := range graphNodeAttrs {
+			if v := values[name]; v != "" {
+				n.Values = append(n.Values, gexfAttValue{For: name, Value: v})
+			}
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, n)
+	}
+	for i, e := range GraphEdges(felts) {
+		edge := gexfEdge{ID: graphEdgeID(i), Source: e.Source, Target: e.Target, Label: e.Kind}
+		values := graphEdgeValues(e)
+		for _, name := range graphEdgeAttrs {
+			if v := values[name]; v != "" {
+				edge.Values = append(edge.Values, gexfAttValue{For: name, Value: v})
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+	return writeXML(w, doc)
+}
+
+func graphEdgeID(i int) string {
+	return "e" + strconv.Itoa(i)
+}
+
+func sortedByID(felts []*Felt) []*Felt {
+	out := append([]*Felt(nil), felts...)
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
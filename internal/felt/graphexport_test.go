@@ -0,0 +1,67 @@
+package felt
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func graphExportFixture(t *testing.T) []*Felt {
+	t.Helper()
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	mocks := &Felt{ID: "mocks", Name: "Run the mocks", Status: StatusClosed, Tags: []string{"sim"}, CreatedAt: created, ClosedAt: &created}
+	fit := &Felt{ID: "fit", Name: "Fit <then> compare", Status: StatusOpen, CreatedAt: created, Body: "Compare with [[notes]] and [[notes]]; see [[elsewhere]]."}
+	if err := fit.AddDataFlowInput("mocks"); err != nil {
+		t.Fatal(err)
+	}
+	notes := &Felt{ID: "notes", Name: "Notes", CreatedAt: created, Body: "Back to [[fit]], and to [[notes]] itself."}
+	return []*Felt{mocks, fit, notes}
+}
+
+func TestGraphEdgesAreTypedAndDirected(t *testing.T) {
+	edges := GraphEdges(graphExportFixture(t))
+	want := []GraphEdge{
+		{Source: "fit", Target: "notes", Kind: "reference"},
+		{Source: "mocks", Target: "fit", Kind: "data_flow", Input: "mocks"},
+		{Source: "notes", Target: "fit", Kind: "reference"},
+	}
+	if len(edges) != len(want) {
+		t.Fatalf("edges = %+v, want %+v", edges, want)
+	}
+	for i := range want {
+		if edges[i] != want[i] {
+			t.Fatalf("edges[%d] = %+v, want %+v", i, edges[i], want[i])
+		}
+	}
+}
+
+func TestWriteGraphMLAndGEXFAreWellFormed(t *testing.T) {
+	felts := graphExportFixture(t)
+	for name, write := range map[string]func(*bytes.Buffer) error{
+		"graphml": func(b *bytes.Buffer) error { return WriteGraphML(b, felts) },
+		"gexf":    func(b *bytes.Buffer) error { return WriteGEXF(b, felts) },
+	} {
+		var buf bytes.Buffer
+		if err := write(&buf); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out := buf.String()
+		dec := xml.NewDecoder(strings.NewReader(out))
+		for {
+			if _, err := dec.Token(); err != nil {
+				if err != io.EOF {
+					t.Fatalf("%s is not well-formed XML: %v\n%s", name, err, out)
+				}
+				break
+			}
+		}
+		for _, want := range []string{`source="mocks" target="fit"`, `Fit &lt;then&gt; compare`, "data_flow", "reference", "2026-03-02T09:00:00Z"} {
+			if !strings.Contains(out, want) {
+				t.Fatalf("%s missing %q:\n%s", name, want, out)
+			}
+		}
+	}
+}
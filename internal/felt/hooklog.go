@@ -0,0 +1,129 @@
+package felt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HookDebugLogName is the hook debug log, `.felt/hook-debug.log`: one JSON
+// line per hook payload that failed validation under `felt hook --strict`.
+// Hooks otherwise pass silently on a payload they cannot read, so this is
+// where a harness schema change becomes visible. It is local diagnostics,
+// so the store's .gitignore covers it.
+const HookDebugLogName = "hook-debug.log"
+
+// HookProblem is one debug-log line: the hook event, what was wrong with
+// its payload, and the envelope version it declared.
+type HookProblem struct {
+	At       time.Time `json:"at"`
+	Event    string    `json:"event"`
+	Version  int       `json:"version"`
+	Problems []string  `json:"problems"`
+	Replay   string    `json:"replay,omitempty"`
+}
+
+// HookDebugLogPath returns the path of the store's hook debug log.
+func (s *Storage) HookDebugLogPath() string {
+	return filepath.Join(s.root, HookDebugLogName)
+}
+
+// RecordHookProblem appends p to the hook debug log as a single O_APPEND
+// line.
+func (s *Storage) RecordHookProblem(p HookProblem) error {
+	line, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	ensureGitignoreCovers(s.root, HookDebugLogName)
+	file, err := os.OpenFile(s.HookDebugLogPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", HookDebugLogName, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing %s: %w", HookDebugLogName, err)
+	}
+	return nil
+}
+
+// HookLogDirName is the opt-in raw hook payload log, `.felt/hook-log/`: one
+// file per payload a hook read, written when `hook.log: true` is set in
+// config.yaml, so a hook bug can be reproduced offline with `felt hook
+// replay <file>`. Like the debug log, the store's .gitignore covers it.
+const HookLogDirName = "hook-log"
+
+// HookLogMaxPayload is the largest payload the hook log saves; a bigger one
+// (a Write of a large file, say) is skipped rather than truncated, since a
+// truncated payload cannot be replayed.
+const HookLogMaxPayload = 1 << 20
+
+// HookLogMaxTotal caps the hook log's size. Recording a payload prunes the
+// oldest files until the log is within both this and its file count.
+const HookLogMaxTotal = 16 << 20
+
+// HookLogDir returns the directory of the store's hook payload log.
+func (s *Storage) HookLogDir() string {
+	return filepath.Join(s.root, HookLogDirName)
+}
+
+// RecordHookPayload saves payload as the hook log's newest file, named by
+// at and event so the directory lists oldest first, then prunes the log to
+// keep files and HookLogMaxTotal bytes. It returns the saved file's path,
+// or "" for a payload over HookLogMaxPayload.
+func (s *Storage) RecordHookPayload(event string, payload []byte, at time.Time, keep int) (string, error) {
+	if len(payload) > HookLogMaxPayload {
+		return "", nil
+	}
+	dir := s.HookLogDir()
+	ensureGitignoreCovers(s.root, HookLogDirName+"/")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", HookLogDirName, err)
+	}
+	if event == "" {
+		event = "unknown"
+	}
+	name := at.UTC().Format("20060102T150405.000000000Z") + "-" + SlugifyPath(event) + ".json"
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, payload, 0644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", HookLogDirName, err)
+	}
+	return path, pruneHookLog(dir, keep)
+}
+
+// pruneHookLog removes the oldest payload files in dir until at most keep
+// remain and together they fit in HookLogMaxTotal.
+func pruneHookLog(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", HookLogDirName, err)
+	}
+	type logFile struct {
+		name string
+		size int64
+	}
+	var files []logFile
+	var total int64
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, logFile{e.Name(), info.Size()})
+		total += info.Size()
+	}
+	// ReadDir sorts by name, and names start with their timestamp.
+	for len(files) > 1 && (len(files) > keep || total > HookLogMaxTotal) {
+		if err := os.Remove(filepath.Join(dir, files[0].name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("pruning %s: %w", HookLogDirName, err)
+		}
+		total -= files[0].size
+		files = files[1:]
+	}
+	return nil
+}
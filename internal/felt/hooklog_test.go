@@ -0,0 +1,44 @@
+package felt
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordHookPayloadRotatesAndSkipsOversizedPayloads(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	at := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	var saved []string
+	for i := 0; i < 4; i++ {
+		path, err := s.RecordHookPayload("PostToolUse", []byte(fmt.Sprintf(`{"n": %d}`, i)), at.Add(time.Duration(i)*time.Second), 3)
+		if err != nil {
+			t.Fatalf("RecordHookPayload: %v", err)
+		}
+		saved = append(saved, path)
+	}
+	if !strings.HasSuffix(saved[0], "20261014T090000.000000000Z-posttooluse.json") {
+		t.Fatalf("saved as %s", saved[0])
+	}
+	entries, err := os.ReadDir(s.HookLogDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 || entries[0].Name() != filepath.Base(saved[1]) {
+		t.Fatalf("log after rotation = %v, want the newest 3", entries)
+	}
+
+	big := make([]byte, HookLogMaxPayload+1)
+	if path, err := s.RecordHookPayload("PostToolUse", big, at.Add(time.Minute), 3); err != nil || path != "" {
+		t.Fatalf("oversized payload = %q, %v; want skipped", path, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(s.root, GitignoreName)); !strings.Contains(string(data), "\nhook-log/\n") {
+		t.Fatalf(".gitignore does not cover the hook log:\n%s", data)
+	}
+}
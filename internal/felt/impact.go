@@ -0,0 +1,66 @@
+package felt
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImpactKey is the frontmatter key for how much a problem hurts — a bug's
+// severity, not when it should be worked — so bug-like fibers can sit
+// alongside planned work and still stand out.
+const ImpactKey = "impact"
+
+// Impact levels.
+const (
+	ImpactLow      = "low"
+	ImpactMedium   = "medium"
+	ImpactHigh     = "high"
+	ImpactCritical = "critical"
+)
+
+// ImpactLevels lists the levels from least to most severe.
+var ImpactLevels = []string{ImpactLow, ImpactMedium, ImpactHigh, ImpactCritical}
+
+// ImpactRank returns level's position in ImpactLevels, or -1 when it is not
+// a level.
+func ImpactRank(level string) int {
+	return slices.Index(ImpactLevels, level)
+}
+
+// ParseImpact normalizes an impact level, rejecting anything else.
+func ParseImpact(s string) (string, error) {
+	level := strings.ToLower(strings.TrimSpace(s))
+	if ImpactRank(level) < 0 {
+		return "", fmt.Errorf("invalid impact %q (use %s)", s, strings.Join(ImpactLevels, ", "))
+	}
+	return level, nil
+}
+
+// Impact returns f's `impact:` level, or "" when the field is absent or
+// malformed; `felt check` reports the malformed case.
+func (f *Felt) Impact() string {
+	node := extraFieldNode(f.ExtraFields, ImpactKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	level, err := ParseImpact(node.Value)
+	if err != nil {
+		return ""
+	}
+	return level
+}
+
+// SetImpact sets f's impact level; "" clears it.
+func (f *Felt) SetImpact(level string) error {
+	if strings.TrimSpace(level) == "" {
+		return f.SetExtraField(ImpactKey, nil)
+	}
+	level, err := ParseImpact(level)
+	if err != nil {
+		return err
+	}
+	return f.SetExtraField(ImpactKey, level)
+}
@@ -0,0 +1,34 @@
+package felt
+
+import "testing"
+
+func TestImpactRoundTripAndCheck(t *testing.T) {
+	f := &Felt{ID: "crash"}
+	if err := f.SetImpact(" High "); err != nil {
+		t.Fatalf("SetImpact: %v", err)
+	}
+	if got := f.Impact(); got != ImpactHigh {
+		t.Fatalf("Impact() = %q, want high", got)
+	}
+	if err := f.SetImpact("severe"); err == nil {
+		t.Fatal("SetImpact(severe) should fail")
+	}
+	if err := f.SetImpact(""); err != nil {
+		t.Fatalf("SetImpact clear: %v", err)
+	}
+	if extraFieldNode(f.ExtraFields, ImpactKey) != nil {
+		t.Fatal(`SetImpact("") should remove the field`)
+	}
+
+	bad := &Felt{ID: "bad"}
+	if err := bad.SetExtraField(ImpactKey, "urgent"); err != nil {
+		t.Fatal(err)
+	}
+	if bad.Impact() != "" {
+		t.Fatalf("malformed impact read as %q", bad.Impact())
+	}
+	issues := checkImpact([]*Felt{f, bad})
+	if len(issues) != 1 || issues[0].FiberID != "bad" || issues[0].Level != CheckLevelWarning {
+		t.Fatalf("checkImpact = %+v, want one warning on bad", issues)
+	}
+}
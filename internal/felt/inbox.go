@@ -0,0 +1,63 @@
+package felt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// InboxTag marks a quick capture awaiting triage. `felt in` files a thought
+// with it and nothing else — no status, no other tags — so capturing never
+// asks a question; `felt triage` later removes the tag as it files or
+// discards each capture.
+const InboxTag = "inbox"
+
+// Capture files thought as a new top-level inbox fiber named by it, under a
+// slug derived from it (disambiguated with -2, -3, … when taken). The slug
+// follows slug.transliterate like `felt add`.
+func (s *Storage) Capture(thought string, now time.Time) (*Felt, error) {
+	thought = strings.Join(strings.Fields(thought), " ")
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	title := thought
+	if cfg.Slug.Transliterate {
+		title = Transliterate(title)
+	}
+	base, err := GenerateID(title)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.AvailableID(base)
+	if err != nil {
+		return nil, err
+	}
+	f, err := New(id, thought)
+	if err != nil {
+		return nil, err
+	}
+	f.CreatedAt = now
+	f.AddTag(InboxTag)
+	if err := s.EnsureAvailableUID(f); err != nil {
+		return nil, err
+	}
+	f.Touch(now)
+	if err := s.Write(f); err != nil {
+		return nil, fmt.Errorf("capture %s: %w", id, err)
+	}
+	return f, nil
+}
+
+// InboxFelts returns the fibers tagged InboxTag, oldest capture first.
+func InboxFelts(felts []*Felt) []*Felt {
+	var inbox []*Felt
+	for _, f := range felts {
+		if f.HasTag(InboxTag) {
+			inbox = append(inbox, f)
+		}
+	}
+	sort.SliceStable(inbox, func(i, j int) bool { return inbox[i].CreatedAt.Before(inbox[j].CreatedAt) })
+	return inbox
+}
@@ -0,0 +1,326 @@
+package felt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IndexName is the metadata cache, `.felt/index.json`: every fiber file's
+// parsed frontmatter, keyed by on-disk path and trusted only while the file's
+// size and mtime are unchanged. Listing the store re-parses just the files
+// that changed since the last walk instead of every file. It is a pure cache
+// — deleting it costs one full re-parse — and, since paths and mtimes are per
+// machine, it is gitignored and never synced.
+const IndexName = "index.json"
+
+// indexVersion is bumped whenever the cached shape changes; an index written
+// by another version is discarded whole.
+const indexVersion = 1
+
+// indexRacyWindow guards against a write landing in the same mtime tick as
+// the parse that cached it (coarse-mtime filesystems tick once a second): a
+// file modified this recently is parsed but not cached.
+const indexRacyWindow = 2 * time.Second
+
+type fiberIndex struct {
+	Version int                   `json:"version"`
+	Entries map[string]indexEntry `json:"entries"`
+	dirty   bool                  // an entry was (re)parsed since load
+}
+
+// indexEntry is one cached file: its stat key, the top-level frontmatter keys
+// (for the has-field prefilter), and the parse result minus the body.
+type indexEntry struct {
+	ID      string          `json:"id"`
+	Size    int64           `json:"size"`
+	ModTime int64           `json:"mtime"`
+	Keys    []string        `json:"keys,omitempty"`
+	Native  indexNative     `json:"native"`
+	Extra   []indexExtraKey `json:"extra,omitempty"`
+}
+
+type indexNative struct {
+	UID         string     `json:"uid,omitempty"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status,omitempty"`
+	Tags        []string   `json:"tags,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	Outcome     string     `json:"outcome,omitempty"`
+	Due         *time.Time `json:"due,omitempty"`
+	Description string     `json:"description,omitempty"`
+}
+
+type indexExtraKey struct {
+	Key   string     `json:"key"`
+	Value *indexNode `json:"value"`
+}
+
+// indexNode is a yaml.Node with short JSON keys, so an extra field comes back
+// as parsed — style and comments included — without a YAML parse. Source
+// positions are dropped; Marshal never reads them.
+type indexNode struct {
+	Kind        yaml.Kind    `json:"k"`
+	Style       yaml.Style   `json:"s,omitempty"`
+	Tag         string       `json:"t,omitempty"`
+	Value       string       `json:"v,omitempty"`
+	Anchor      string       `json:"a,omitempty"`
+	HeadComment string       `json:"hc,omitempty"`
+	LineComment string       `json:"lc,omitempty"`
+	FootComment string       `json:"fc,omitempty"`
+	Content     []*indexNode `json:"n,omitempty"`
+}
+
+func (s *Storage) indexPath() string {
+	return filepath.Join(s.root, IndexName)
+}
+
+// loadIndex reads the metadata cache. A missing, unreadable, or
+// other-version index is an empty one.
+func (s *Storage) loadIndex() *fiberIndex {
+	empty := &fiberIndex{Version: indexVersion, Entries: map[string]indexEntry{}}
+	data, err := os.ReadFile(s.indexPath())
+	if err != nil {
+		return empty
+	}
+	var idx fiberIndex
+	if err := json.Unmarshal(data, &idx); err != nil || idx.Version != indexVersion || idx.Entries == nil {
+		return empty
+	}
+	return &idx
+}
+
+// saveIndex writes idx best-effort: through a temp file and rename, so a
+// concurrent reader sees the old index or the new one, never half of one.
+// Failing to write a cache must never fail the read that built it.
+func (s *Storage) saveIndex(idx *fiberIndex) {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return
+	}
+	ensureGitignoreCovers(s.root, IndexName)
+	tmp, err := os.CreateTemp(s.root, IndexName+".*.tmp")
+	if err != nil {
+		return
+	}
+	_ = tmp.Chmod(0644)
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil || os.Rename(tmp.Name(), s.indexPath()) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// readIndexed reads one walked file through the index. A fresh entry in idx
+// stands in for the YAML parse (a full read still reads the body); anything
+// else is parsed from disk. Either way the current entry is recorded in next,
+// so entries for vanished files drop out. f is nil when fields is set and the
+// file lacks one of them. info is the stat the entry was checked against.
+func (s *Storage) readIndexed(idx, next *fiberIndex, file fiberFile, mode ParseMode, fields []string, now time.Time) (*Felt, os.FileInfo, error) {
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading file %s: %w", file.path, err)
+	}
+
+	var f *Felt
+	var body string
+	entry, hit := idx.lookup(file, info)
+	if hit {
+		next.Entries[file.path] = entry
+		if len(fields) > 0 && !entry.hasKeys(fields) {
+			return nil, info, nil
+		}
+		f = entry.felt()
+		if mode == ParseFull {
+			data, err := os.ReadFile(file.path)
+			if err != nil {
+				return nil, nil, fmt.Errorf("reading file %s: %w", file.path, err)
+			}
+			if _, body, err = splitFrontmatter(data, true); err != nil {
+				return nil, nil, err
+			}
+		}
+	} else {
+		frontmatter, rest, err := readFiberFile(file.path, mode)
+		if err != nil {
+			return nil, nil, err
+		}
+		var keys []string
+		if f, keys, err = parseFrontmatterKeys(file.id, frontmatter); err != nil {
+			return nil, nil, err
+		}
+		body = rest
+		if now.Sub(info.ModTime()) > indexRacyWindow {
+			if entry, ok := newIndexEntry(f, keys, info); ok {
+				next.Entries[file.path] = entry
+				next.dirty = true
+			}
+		}
+		if len(fields) > 0 && !(indexEntry{Keys: keys}).hasKeys(fields) {
+			return nil, info, nil
+		}
+	}
+
+	if mode == ParseFull {
+		f.Body = strings.TrimSpace(body)
+		if err := loadBodyFile(f, file.path); err != nil {
+			return nil, nil, err
+		}
+	}
+	f.Path = file.path
+	if resolved, err := filepath.EvalSymlinks(file.path); err == nil {
+		f.Path = resolved
+	}
+	return f, info, nil
+}
+
+// readFiberFile returns the frontmatter of the fiber file at path and, for
+// ParseFull, its inline body. Otherwise the read stops at the closing ---.
+func readFiberFile(path string, mode ParseMode) ([]byte, string, error) {
+	if mode != ParseFull {
+		frontmatter, err := readFrontmatterFile(path)
+		return frontmatter, "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading file %s: %w", path, err)
+	}
+	return splitFrontmatter(data, true)
+}
+
+// lookup returns the cached entry for file when it is still fresh against
+// info.
+func (idx *fiberIndex) lookup(file fiberFile, info os.FileInfo) (indexEntry, bool) {
+	e, ok := idx.Entries[file.path]
+	if !ok || e.ID != file.id || e.Size != info.Size() || e.ModTime != info.ModTime().UnixNano() {
+		return indexEntry{}, false
+	}
+	return e, true
+}
+
+// hasKeys reports whether every field is among the entry's top-level keys,
+// the cached answer to fileFrontmatterHasTopLevelFields.
+func (e indexEntry) hasKeys(fields []string) bool {
+	for _, field := range fields {
+		found := false
+		for _, key := range e.Keys {
+			if key == field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// felt rebuilds the metadata-only parse result the entry was made from.
+func (e indexEntry) felt() *Felt {
+	n := e.Native
+	f := &Felt{
+		ID:          e.ID,
+		UID:         n.UID,
+		Name:        n.Name,
+		Status:      n.Status,
+		Tags:        n.Tags,
+		CreatedAt:   n.CreatedAt,
+		UpdatedAt:   n.UpdatedAt,
+		ActivatedAt: n.ActivatedAt,
+		ClosedAt:    n.ClosedAt,
+		Outcome:     n.Outcome,
+		Due:         n.Due,
+		Description: n.Description,
+	}
+	if len(e.Extra) > 0 {
+		f.ExtraFields = make(map[string]*yaml.Node, len(e.Extra))
+		for _, x := range e.Extra {
+			f.ExtraFields[x.Key] = x.Value.yamlNode()
+			f.ExtraFieldOrder = append(f.ExtraFieldOrder, x.Key)
+		}
+	}
+	return f
+}
+
+// newIndexEntry records f's metadata against info. ok is false when f cannot
+// be cached faithfully: an extra field using a YAML alias, whose target the
+// cache does not carry.
+func newIndexEntry(f *Felt, keys []string, info os.FileInfo) (indexEntry, bool) {
+	e := indexEntry{
+		ID:      f.ID,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Keys:    keys,
+		Native: indexNative{
+			UID:         f.UID,
+			Name:        f.Name,
+			Status:      f.Status,
+			Tags:        f.Tags,
+			CreatedAt:   f.CreatedAt,
+			UpdatedAt:   f.UpdatedAt,
+			ActivatedAt: f.ActivatedAt,
+			ClosedAt:    f.ClosedAt,
+			Outcome:     f.Outcome,
+			Due:         f.Due,
+			Description: f.Description,
+		},
+	}
+	for _, key := range f.ExtraFieldOrder {
+		node, ok := newIndexNode(f.ExtraFields[key])
+		if !ok {
+			return indexEntry{}, false
+		}
+		e.Extra = append(e.Extra, indexExtraKey{Key: key, Value: node})
+	}
+	return e, true
+}
+
+func newIndexNode(n *yaml.Node) (*indexNode, bool) {
+	if n == nil || n.Kind == yaml.AliasNode || n.Alias != nil {
+		return nil, false
+	}
+	out := &indexNode{
+		Kind:        n.Kind,
+		Style:       n.Style,
+		Tag:         n.Tag,
+		Value:       n.Value,
+		Anchor:      n.Anchor,
+		HeadComment: n.HeadComment,
+		LineComment: n.LineComment,
+		FootComment: n.FootComment,
+	}
+	for _, child := range n.Content {
+		c, ok := newIndexNode(child)
+		if !ok {
+			return nil, false
+		}
+		out.Content = append(out.Content, c)
+	}
+	return out, true
+}
+
+func (n *indexNode) yamlNode() *yaml.Node {
+	out := &yaml.Node{
+		Kind:        n.Kind,
+		Style:       n.Style,
+		Tag:         n.Tag,
+		Value:       n.Value,
+		Anchor:      n.Anchor,
+		HeadComment: n.HeadComment,
+		LineComment: n.LineComment,
+		FootComment: n.FootComment,
+	}
+	for _, child := range n.Content {
+		out.Content = append(out.Content, child.yamlNode())
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+package felt
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeAgedFiber writes f and backdates its file past the racy window so the
+// next listing may cache it.
+func writeAgedFiber(t *testing.T, s *Storage, f *Felt, age time.Duration) {
+	t.Helper()
+	if err := s.Write(f); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	old := time.Now().Add(-age)
+	if err := os.Chtimes(s.Path(f.ID), old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+}
+
+func readIndexFile(t *testing.T, s *Storage) fiberIndex {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(s.root, IndexName))
+	if err != nil {
+		t.Fatalf("reading index: %v", err)
+	}
+	var idx fiberIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		t.Fatalf("parsing index: %v", err)
+	}
+	return idx
+}
+
+func TestIndexServesUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	created := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	f := &Felt{ID: "tune", Name: "Tune the fit", Status: StatusOpen, Tags: []string{"fit"}, CreatedAt: created, Body: "Notes here."}
+	if err := f.SetExtraField("shuttle", map[string]interface{}{"every": "1d", "cmd": "make fit"}); err != nil {
+		t.Fatal(err)
+	}
+	writeAgedFiber(t, s, f, time.Hour)
+	want, err := os.ReadFile(s.Path(f.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.List(); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	idx := readIndexFile(t, s)
+	path, _ := filepath.EvalSymlinks(s.Path(f.ID))
+	entry, ok := idx.Entries[path]
+	if !ok || entry.Native.Name != "Tune the fit" {
+		t.Fatalf("index entries = %+v, want one for %s", idx.Entries, path)
+	}
+
+	// Doctor the cached name: an unchanged file must be served from the
+	// index, not re-parsed.
+	entry.Native.Name = "From the index"
+	idx.Entries[path] = entry
+	data, _ := json.Marshal(idx)
+	if err := os.WriteFile(filepath.Join(s.root, IndexName), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	felts, err := s.List()
+	if err != nil || len(felts) != 1 {
+		t.Fatalf("List: %v, %d fibers", err, len(felts))
+	}
+	got := felts[0]
+	if got.Name != "From the index" {
+		t.Fatalf("Name = %q, want the cached name", got.Name)
+	}
+	if got.Body != "Notes here." || got.Path != path {
+		t.Fatalf("hit should still carry body and path: %+v", got)
+	}
+	got.Name = "Tune the fit"
+	if out, _ := got.Marshal(); string(out) != string(want) {
+		t.Fatalf("cached fiber does not round-trip:\n%s\nwant:\n%s", out, want)
+	}
+	picked, err := s.ListMetadataHavingFrontmatterFields([]string{"shuttle"})
+	if err != nil || len(picked) != 1 {
+		t.Fatalf("field filter over the index: %v, %d fibers", err, len(picked))
+	}
+	if none, _ := s.ListMetadataHavingFrontmatterFields([]string{"tempered"}); len(none) != 0 {
+		t.Fatalf("field filter matched a fiber without the field")
+	}
+
+	// Any edit changes the stat key, and the file is parsed again.
+	f.Name = "Tune the fit again"
+	writeAgedFiber(t, s, f, 30*time.Minute)
+	felts, err = s.ListMetadata()
+	if err != nil || len(felts) != 1 || felts[0].Name != "Tune the fit again" {
+		t.Fatalf("edited fiber served stale: %v, %+v", err, felts)
+	}
+}
+
+func TestIndexPrunesAndRecovers(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, id := range []string{"keep", "drop"} {
+		writeAgedFiber(t, s, &Felt{ID: id, Name: id, CreatedAt: time.Now()}, time.Hour)
+	}
+	fresh := &Felt{ID: "fresh", Name: "fresh", CreatedAt: time.Now()}
+	if err := s.Write(fresh); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ListMetadata(); err != nil {
+		t.Fatalf("ListMetadata: %v", err)
+	}
+	if n := len(readIndexFile(t, s).Entries); n != 2 {
+		t.Fatalf("index has %d entries, want 2 (a just-written file is not cached)", n)
+	}
+
+	if err := s.Delete("drop"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ListMetadata(); err != nil {
+		t.Fatalf("ListMetadata: %v", err)
+	}
+	if n := len(readIndexFile(t, s).Entries); n != 1 {
+		t.Fatalf("index has %d entries after delete, want 1", n)
+	}
+
+	if err := os.WriteFile(filepath.Join(s.root, IndexName), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	felts, err := s.ListMetadata()
+	if err != nil || len(felts) != 2 {
+		t.Fatalf("corrupt index should be rebuilt: %v, %d fibers", err, len(felts))
+	}
+	if n := len(readIndexFile(t, s).Entries); n != 1 {
+		t.Fatalf("rebuilt index has %d entries, want 1", n)
+	}
+}
@@ -0,0 +1,169 @@
+package felt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InvalidatedKey is the frontmatter mapping `felt invalidate` writes on a
+// closed decision that turned out wrong. The fiber stays closed — its
+// outcome is still what was decided — but the work downstream of it is
+// flagged for a second look.
+const InvalidatedKey = "invalidated"
+
+// Invalidation is the `invalidated:` entry. Field order is the on-disk order.
+type Invalidation struct {
+	Reason string    `yaml:"reason" json:"reason"`
+	At     time.Time `yaml:"at" json:"at"`
+}
+
+// Invalidation returns f's invalidation. ok is false when the fiber was
+// never invalidated or the entry is malformed.
+func (f *Felt) Invalidation() (Invalidation, bool) {
+	node := extraFieldNode(f.ExtraFields, InvalidatedKey)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return Invalidation{}, false
+	}
+	var inv Invalidation
+	if err := node.Decode(&inv); err != nil {
+		return Invalidation{}, false
+	}
+	return inv, true
+}
+
+// DataFlowDownstreams returns the fibers that transitively consume id's
+// outputs through `inputs[].from`, sorted by id.
+func DataFlowDownstreams(felts []*Felt, id string) []*Felt {
+	byID := make(map[string]*Felt, len(felts))
+	consumers := make(map[string][]string)
+	for source, ups := range DataFlowUpstreams(felts) {
+		for _, up := range ups {
+			consumers[up] = append(consumers[up], source)
+		}
+	}
+	for _, f := range felts {
+		byID[f.ID] = f
+	}
+	var out []*Felt
+	for _, next := range walkDataFlow(consumers, id) {
+		if f := byID[next]; f != nil {
+			out = append(out, f)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// InvalidatedUpstreams returns the transitive data-flow ancestors of id that
+// carry an invalidation, sorted by id.
+func InvalidatedUpstreams(felts []*Felt, id string) []*Felt {
+	byID := make(map[string]*Felt, len(felts))
+	for _, f := range felts {
+		byID[f.ID] = f
+	}
+	var out []*Felt
+	for _, next := range walkDataFlow(DataFlowUpstreams(felts), id) {
+		if f := byID[next]; f != nil {
+			if _, ok := f.Invalidation(); ok {
+				out = append(out, f)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// walkDataFlow returns every id reachable from id along edges, breadth
+// first, excluding id itself.
+func walkDataFlow(edges map[string][]string, id string) []string {
+	seen := map[string]bool{id: true}
+	var out []string
+	queue := append([]string(nil), edges[id]...)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		out = append(out, next)
+		queue = append(queue, edges[next]...)
+	}
+	return out
+}
+
+// InvalidatedDownstream is one fiber `felt invalidate` flagged, and whether
+// it was reopened because it had already closed on the bad decision.
+type InvalidatedDownstream struct {
+	Felt     *Felt `json:"fiber"`
+	Reopened bool  `json:"reopened"`
+}
+
+// Invalidate marks the closed fiber id as invalidated for reason and returns
+// its transitive data-flow consumers. With reopen, consumers that already
+// closed are set back to open, where status.transitions allows it; the rest
+// are left as they are and flagged by the invalidation upstream of them.
+func (s *Storage) Invalidate(id, reason string, now time.Time, reopen bool) ([]InvalidatedDownstream, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("invalidating %s needs a reason", id)
+	}
+	f, err := s.Read(id)
+	if err != nil {
+		return nil, err
+	}
+	if !f.IsClosed() {
+		return nil, fmt.Errorf("%s is not closed: only a closed decision can be invalidated", id)
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.SetExtraField(InvalidatedKey, Invalidation{Reason: reason, At: now.UTC()}); err != nil {
+		return nil, err
+	}
+	f.Touch(now)
+	tx, err := s.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if err := tx.Write(f); err != nil {
+		return nil, err
+	}
+
+	felts, err := s.ListMetadata()
+	if err != nil {
+		return nil, err
+	}
+	var affected []InvalidatedDownstream
+	for _, meta := range DataFlowDownstreams(felts, id) {
+		if !reopen || !meta.IsClosed() {
+			affected = append(affected, InvalidatedDownstream{Felt: meta})
+			continue
+		}
+		if err := cfg.CheckStatusTransition(StatusClosed, StatusOpen); err != nil {
+			return nil, fmt.Errorf("reopening %s: %w", meta.ID, err)
+		}
+		d, err := s.Read(meta.ID)
+		if err != nil {
+			return nil, err
+		}
+		d.Status = StatusOpen
+		d.ClosedAt = nil
+		d.NoteStatusChange(StatusClosed, now)
+		d.Touch(now)
+		if err := tx.Write(d); err != nil {
+			return nil, err
+		}
+		affected = append(affected, InvalidatedDownstream{Felt: d, Reopened: true})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return affected, nil
+}
@@ -0,0 +1,100 @@
+package felt
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInvalidateReopensClosedDownstream(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	closedAt := time.Now().Add(-time.Hour)
+	mask := &Felt{ID: "mask", Name: "Mask", Status: StatusClosed, ClosedAt: &closedAt, Tags: []string{"decision"}}
+	fit := &Felt{ID: "fit", Name: "Fit", Status: StatusClosed, ClosedAt: &closedAt}
+	mustExtra(t, fit, "inputs", []map[string]any{{"id": "mask", "from": "mask"}})
+	paper := &Felt{ID: "paper", Name: "Paper", Status: StatusOpen}
+	mustExtra(t, paper, "inputs", []map[string]any{{"id": "fit", "from": "fit"}})
+	other := &Felt{ID: "other", Name: "Other", Status: StatusClosed, ClosedAt: &closedAt}
+	for _, f := range []*Felt{mask, fit, paper, other} {
+		if err := s.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := s.Invalidate("paper", "wrong", time.Now(), true); err == nil || !strings.Contains(err.Error(), "not closed") {
+		t.Fatalf("Invalidate(open fiber) error = %v, want not closed", err)
+	}
+
+	affected, err := s.Invalidate("mask", "misses bright halos", time.Now(), true)
+	if err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+	var got []string
+	for _, d := range affected {
+		line := d.Felt.ID
+		if d.Reopened {
+			line += " reopened"
+		}
+		got = append(got, line)
+	}
+	if strings.Join(got, ",") != "fit reopened,paper" {
+		t.Fatalf("affected = %v, want [fit reopened, paper]", got)
+	}
+
+	m, err := s.Read("mask")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv, ok := m.Invalidation(); !ok || inv.Reason != "misses bright halos" || !m.IsClosed() {
+		t.Fatalf("mask = status %q invalidation %+v %v, want closed and invalidated", m.Status, inv, ok)
+	}
+	f, err := s.Read("fit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.IsOpen() || f.ClosedAt != nil {
+		t.Fatalf("fit status = %q closed-at %v, want reopened", f.Status, f.ClosedAt)
+	}
+
+	felts, err := s.ListMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up := InvalidatedUpstreams(felts, "paper"); len(up) != 1 || up[0].ID != "mask" {
+		t.Fatalf("InvalidatedUpstreams(paper) = %v, want [mask]", up)
+	}
+}
+
+func TestInvalidateReopenChecksStatusTransitions(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.ConfigPath(), []byte("status:\n  transitions:\n    closed: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	closedAt := time.Now().Add(-time.Hour)
+	mask := &Felt{ID: "mask", Name: "Mask", Status: StatusClosed, ClosedAt: &closedAt}
+	fit := &Felt{ID: "fit", Name: "Fit", Status: StatusClosed, ClosedAt: &closedAt}
+	mustExtra(t, fit, "inputs", []map[string]any{{"id": "mask", "from": "mask"}})
+	for _, f := range []*Felt{mask, fit} {
+		if err := s.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := s.Invalidate("mask", "wrong", time.Now(), true); err == nil || !strings.Contains(err.Error(), "reopening fit") {
+		t.Fatalf("Invalidate --reopen past closed: [] = %v", err)
+	}
+	f, err := s.Read("mask")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.Invalidation(); ok {
+		t.Fatal("refused invalidation was still written")
+	}
+}
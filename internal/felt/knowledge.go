@@ -0,0 +1,135 @@
+package felt
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// KnowledgeHit is one closed fiber whose outcome matched a knowledge
+// search. Matched counts the query terms its outcome contains; Score
+// weighs them by how rare each is across the store's outcomes.
+type KnowledgeHit struct {
+	Fiber   *Felt   `json:"fiber"`
+	Matched int     `json:"matched"`
+	Score   float64 `json:"score"`
+}
+
+// KnowledgeFibers returns the closed fibers that recorded an outcome — the
+// store's distilled conclusions — most recently closed first.
+func KnowledgeFibers(felts []*Felt) []*Felt {
+	var out []*Felt
+	for _, f := range felts {
+		if f.IsClosed() && strings.TrimSpace(f.Outcome) != "" {
+			out = append(out, f)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return closedAfter(out[i], out[j]) })
+	return out
+}
+
+// SearchKnowledge ranks the outcomes of KnowledgeFibers(felts) against
+// query. A query term matches an outcome word it is a prefix of, so "fit"
+// finds "fitting". Hits matching more terms rank first, then by score —
+// each matched term's occurrences weighted by its inverse document
+// frequency — then by most recent close.
+func SearchKnowledge(felts []*Felt, query string) []KnowledgeHit {
+	terms := slices.Compact(slices.Sorted(slices.Values(knowledgeTerms(query))))
+	if len(terms) == 0 {
+		return nil
+	}
+	docs := KnowledgeFibers(felts)
+	words := make([][]string, len(docs))
+	df := make(map[string]int, len(terms))
+	for i, f := range docs {
+		words[i] = knowledgeTerms(f.Outcome)
+		for _, term := range terms {
+			if countPrefixed(words[i], term) > 0 {
+				df[term]++
+			}
+		}
+	}
+
+	var hits []KnowledgeHit
+	for i, f := range docs {
+		hit := KnowledgeHit{Fiber: f}
+		for _, term := range terms {
+			n := countPrefixed(words[i], term)
+			if n == 0 {
+				continue
+			}
+			hit.Matched++
+			idf := math.Log(1 + float64(len(docs))/float64(df[term]))
+			hit.Score += (1 + math.Log(float64(n))) * idf
+		}
+		if hit.Matched > 0 {
+			hit.Score = math.Round(hit.Score*1000) / 1000
+			hits = append(hits, hit)
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Matched != hits[j].Matched {
+			return hits[i].Matched > hits[j].Matched
+		}
+		return hits[i].Score > hits[j].Score
+	})
+	return hits
+}
+
+// KnowledgeFAQ renders fibers as a FAQ-style markdown document: each
+// fiber's name is a question heading, its outcome the answer, followed by
+// where and when it was settled.
+func KnowledgeFAQ(fibers []*Felt) string {
+	var sb strings.Builder
+	sb.WriteString("# Knowledge\n\n")
+	fmt.Fprintf(&sb, "Conclusions recorded on %d closed %s, most recent first.\n", len(fibers), pluralFibers(len(fibers)))
+	for _, f := range fibers {
+		fmt.Fprintf(&sb, "\n## %s\n\n%s\n\n", f.DisplayName(), strings.TrimSpace(f.Outcome))
+		source := fmt.Sprintf("[[%s]]", f.ID)
+		if f.ClosedAt != nil {
+			source += ", closed " + f.ClosedAt.Local().Format("2006-01-02")
+		}
+		if len(f.Tags) > 0 {
+			source += " · " + strings.Join(f.Tags, ", ")
+		}
+		fmt.Fprintf(&sb, "*%s*\n", source)
+	}
+	return sb.String()
+}
+
+// knowledgeTerms lower-cases s and splits it into words of letters and
+// digits, dropping single characters.
+func knowledgeTerms(s string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(w)) > 1 {
+			out = append(out, w)
+		}
+	}
+	return out
+}
+
+func countPrefixed(words []string, term string) int {
+	n := 0
+	for _, w := range words {
+		if strings.HasPrefix(w, term) {
+			n++
+		}
+	}
+	return n
+}
+
+func closedAfter(a, b *Felt) bool {
+	switch {
+	case a.ClosedAt == nil:
+		return false
+	case b.ClosedAt == nil:
+		return true
+	}
+	return a.ClosedAt.After(*b.ClosedAt)
+}
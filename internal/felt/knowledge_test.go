@@ -0,0 +1,47 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchKnowledgeRanksClosedOutcomes(t *testing.T) {
+	day := func(d int) *time.Time { t := time.Date(2026, 5, d, 12, 0, 0, 0, time.UTC); return &t }
+	felts := []*Felt{
+		{ID: "psf-model", Name: "Which PSF model?", Status: StatusClosed, ClosedAt: day(3), Outcome: "Use the PSFEx model; shapelets overfit the stars."},
+		{ID: "psf-prior", Name: "PSF prior", Status: StatusClosed, ClosedAt: day(9), Outcome: "A Gaussian prior on the PSF size is enough."},
+		{ID: "prior-width", Name: "Prior width", Status: StatusClosed, ClosedAt: day(5), Outcome: "Priors wider than 2 sigma change nothing."},
+		{ID: "open-psf", Name: "Open PSF work", Status: StatusOpen, Outcome: "PSF model prior draft"},
+		{ID: "no-outcome", Name: "PSF", Status: StatusClosed, ClosedAt: day(10)},
+	}
+	hits := SearchKnowledge(felts, "psf prior")
+	var got []string
+	for _, h := range hits {
+		got = append(got, h.Fiber.ID)
+	}
+	// psf-prior matches both words. The single matches tie on score, since
+	// each word appears in two outcomes, so the more recent close ranks first.
+	if strings.Join(got, ",") != "psf-prior,prior-width,psf-model" {
+		t.Fatalf("hits = %v", got)
+	}
+	if hits[0].Matched != 2 || hits[1].Matched != 1 {
+		t.Fatalf("matched = %d, %d", hits[0].Matched, hits[1].Matched)
+	}
+	if SearchKnowledge(felts, "?") != nil {
+		t.Fatal("a query without words should match nothing")
+	}
+
+	faq := KnowledgeFAQ(KnowledgeFibers(felts))
+	for _, want := range []string{
+		"# Knowledge\n\nConclusions recorded on 3 closed fibers, most recent first.\n",
+		"\n## PSF prior\n\nA Gaussian prior on the PSF size is enough.\n\n*[[psf-prior]], closed 2026-05-09*\n",
+	} {
+		if !strings.Contains(faq, want) {
+			t.Fatalf("FAQ missing %q:\n%s", want, faq)
+		}
+	}
+	if strings.Index(faq, "PSF prior") > strings.Index(faq, "Which PSF model?") {
+		t.Fatalf("FAQ not most recent first:\n%s", faq)
+	}
+}
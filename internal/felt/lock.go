@@ -185,6 +185,12 @@ const feltGitignoreHeader = "# Generated by felt"
 // Errors are swallowed — failing to tidy .gitignore must never block the lock
 // acquisition it's called from.
 func ensureGitignoreCoversLocks(root string) {
+	ensureGitignoreCovers(root, lockGitignoreLine)
+}
+
+// ensureGitignoreCovers appends pattern to a felt-generated .gitignore that
+// lacks it, under the same best-effort rules as ensureGitignoreCoversLocks.
+func ensureGitignoreCovers(root, pattern string) {
 	path := filepath.Join(root, GitignoreName)
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -195,13 +201,13 @@ func ensureGitignoreCoversLocks(root string) {
 		return
 	}
 	for _, line := range strings.Split(content, "\n") {
-		if strings.TrimSpace(line) == lockGitignoreLine {
+		if strings.TrimSpace(line) == pattern {
 			return
 		}
 	}
 	if !strings.HasSuffix(content, "\n") {
 		content += "\n"
 	}
-	content += lockGitignoreLine + "\n"
+	content += pattern + "\n"
 	_ = os.WriteFile(path, []byte(content), 0644)
 }
@@ -0,0 +1,58 @@
+package felt
+
+// DependencyMatrix is a design-structure matrix over a set of fibers: row i
+// reads column j — an inputs[].from edge — when Cells[i][j] is 1. Fibers
+// are in data-flow order, so every mark falls below the diagonal except the
+// ones a cycle leaves above it; fibers on or downstream of a cycle come
+// last.
+type DependencyMatrix struct {
+	IDs    []string `json:"fibers"`
+	Cells  [][]int  `json:"cells"`
+	Fibers []*Felt  `json:"-"`
+}
+
+// NewDependencyMatrix builds the matrix of the fibers in felts that keep
+// accepts (every fiber when keep is nil), resolving inputs against the
+// whole of felts. Statusless notes are left out unless they read, or are
+// read by, another kept fiber.
+func NewDependencyMatrix(felts []*Felt, keep func(*Felt) bool) *DependencyMatrix {
+	upstreams := DataFlowUpstreams(felts)
+	kept := make(map[string]bool)
+	for _, f := range felts {
+		if keep == nil || keep(f) {
+			kept[f.ID] = true
+		}
+	}
+	linked := make(map[string]bool)
+	for id, ups := range upstreams {
+		for _, up := range ups {
+			if kept[id] && kept[up] && id != up {
+				linked[id], linked[up] = true, true
+			}
+		}
+	}
+	var subset []*Felt
+	for _, f := range felts {
+		if kept[f.ID] && (f.HasStatus() || linked[f.ID]) {
+			subset = append(subset, f)
+		}
+	}
+
+	ordered, cyclic := dataFlowOrder(subset, upstreams)
+	m := &DependencyMatrix{Fibers: append(ordered, cyclic...)}
+	index := make(map[string]int, len(m.Fibers))
+	for i, f := range m.Fibers {
+		index[f.ID] = i
+		m.IDs = append(m.IDs, f.ID)
+	}
+	m.Cells = make([][]int, len(m.Fibers))
+	for i, f := range m.Fibers {
+		m.Cells[i] = make([]int, len(m.Fibers))
+		for _, up := range upstreams[f.ID] {
+			if j, ok := index[up]; ok && j != i {
+				m.Cells[i][j] = 1
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,30 @@
+package felt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDependencyMatrixOrdersRowsByDataFlow(t *testing.T) {
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	plot := &Felt{ID: "plot", Name: "Plot", Status: StatusOpen, Tags: []string{"sim"}, CreatedAt: created}
+	fit := &Felt{ID: "fit", Name: "Fit", Status: StatusClosed, Tags: []string{"sim"}, CreatedAt: created.Add(time.Hour)}
+	mocks := &Felt{ID: "mocks", Name: "Mocks", Status: StatusOpen, Tags: []string{"sim"}, CreatedAt: created.Add(2 * time.Hour)}
+	note := &Felt{ID: "note", Name: "Loose note", Tags: []string{"sim"}, CreatedAt: created}
+	other := &Felt{ID: "other", Name: "Other", Status: StatusOpen, CreatedAt: created}
+	for _, link := range [][2]*Felt{{plot, fit}, {plot, other}, {fit, mocks}} {
+		if err := link[0].AddDataFlowInput(link[1].ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewDependencyMatrix([]*Felt{plot, fit, mocks, note, other}, func(f *Felt) bool { return f.HasTag("sim") })
+	if want := []string{"mocks", "fit", "plot"}; !reflect.DeepEqual(m.IDs, want) {
+		t.Fatalf("IDs = %v, want %v", m.IDs, want)
+	}
+	want := [][]int{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}}
+	if !reflect.DeepEqual(m.Cells, want) {
+		t.Fatalf("Cells = %v, want %v", m.Cells, want)
+	}
+}
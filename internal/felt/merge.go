@@ -0,0 +1,232 @@
+package felt
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MergeResult reports what Merge changed beyond the kept fiber itself.
+type MergeResult struct {
+	Kept *Felt
+	// Redirected lists the fibers whose references to the duplicate now
+	// point at the kept fiber, sorted.
+	Redirected []string
+	// SkippedInputs lists the duplicate's input ids left out because the
+	// kept fiber already has an input of that id reading something else.
+	SkippedInputs []string
+}
+
+// Merge folds the fiber dupID into keepID and deletes it: the duplicate's
+// body is appended to keepID's as a section, its tags and inputs are added
+// to keepID's, the earlier created-at is kept, and every input and body
+// link elsewhere that named dupID names keepID instead. Everything lands in
+// one transaction. A duplicate with fibers nested under it is refused, as
+// deleting it would orphan them.
+func (s *Storage) Merge(keepID, dupID string, now time.Time) (*MergeResult, error) {
+	if keepID == dupID {
+		return nil, fmt.Errorf("cannot merge %s into itself", keepID)
+	}
+	if strings.HasPrefix(keepID, dupID+"/") {
+		return nil, fmt.Errorf("cannot merge %s into %s, which is nested under it", dupID, keepID)
+	}
+	felts, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	var keep, dup *Felt
+	for _, f := range felts {
+		switch {
+		case f.ID == keepID:
+			keep = f
+		case f.ID == dupID:
+			dup = f
+		case strings.HasPrefix(f.ID, dupID+"/"):
+			return nil, fmt.Errorf("%s has nested fibers (%s); move them before merging it", dupID, f.ID)
+		}
+	}
+	if keep == nil {
+		return nil, fmt.Errorf("no felt found at %s", keepID)
+	}
+	if dup == nil {
+		return nil, fmt.Errorf("no felt found at %s", dupID)
+	}
+
+	result := &MergeResult{Kept: keep}
+	for _, tag := range dup.Tags {
+		keep.AddTag(tag)
+	}
+	if !dup.CreatedAt.IsZero() && (keep.CreatedAt.IsZero() || dup.CreatedAt.Before(keep.CreatedAt)) {
+		keep.CreatedAt = dup.CreatedAt
+	}
+	if result.SkippedInputs, err = mergeInputs(keep, dup); err != nil {
+		return nil, err
+	}
+	if section := mergedSection(dup); section != "" {
+		if body := strings.TrimRight(keep.Body, "\n"); body != "" {
+			keep.Body = body + "\n\n" + section
+		} else {
+			keep.Body = section
+		}
+	}
+	redirect(keep, dupID, keepID)
+	keep.Touch(now)
+
+	tx, err := s.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if err := tx.Write(keep); err != nil {
+		return nil, err
+	}
+	for _, f := range felts {
+		if f == keep || f == dup || !redirect(f, dupID, keepID) {
+			continue
+		}
+		f.Touch(now)
+		if err := tx.Write(f); err != nil {
+			return nil, err
+		}
+		result.Redirected = append(result.Redirected, f.ID)
+	}
+	if err := tx.Delete(dupID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// mergedSection renders dup's outcome and body as a section for the fiber it
+// is merged into, or "" when it has neither.
+func mergedSection(dup *Felt) string {
+	outcome := strings.TrimSpace(dup.Outcome)
+	body := strings.TrimSpace(dup.Body)
+	if outcome == "" && body == "" {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Merged from %s (%s)", path.Base(dup.ID), dup.DisplayName())
+	if outcome != "" {
+		fmt.Fprintf(&b, "\n\nOutcome: %s", outcome)
+	}
+	if body != "" {
+		fmt.Fprintf(&b, "\n\n%s", body)
+	}
+	return b.String()
+}
+
+// mergeInputs adds dup's inputs to keep's, by input id. Inputs reading
+// either fiber are dropped, as after the merge they would read keep itself;
+// an id keep already uses for another source stays keep's, and is returned.
+func mergeInputs(keep, dup *Felt) ([]string, error) {
+	selfRef := func(item *yaml.Node) bool {
+		target, _ := splitDataFlowRef(mappingScalar(item, "from"))
+		return target == keep.ID || target == dup.ID
+	}
+	merged := &yaml.Node{Kind: yaml.SequenceNode}
+	have := make(map[string]string)
+	if node := extraFieldNode(keep.ExtraFields, "inputs"); node != nil && node.Kind == yaml.SequenceNode {
+		merged.Style = node.Style
+		for _, item := range node.Content {
+			if item != nil && item.Kind == yaml.MappingNode && selfRef(item) {
+				continue
+			}
+			merged.Content = append(merged.Content, item)
+			if item != nil && item.Kind == yaml.MappingNode {
+				have[strings.TrimSpace(mappingScalar(item, "id"))] = strings.TrimSpace(mappingScalar(item, "from"))
+			}
+		}
+	}
+	var skipped []string
+	if node := extraFieldNode(dup.ExtraFields, "inputs"); node != nil && node.Kind == yaml.SequenceNode {
+		for _, item := range node.Content {
+			if item == nil || item.Kind != yaml.MappingNode || selfRef(item) {
+				continue
+			}
+			id := strings.TrimSpace(mappingScalar(item, "id"))
+			from := strings.TrimSpace(mappingScalar(item, "from"))
+			if existing, ok := have[id]; ok {
+				if existing != from {
+					skipped = append(skipped, id)
+				}
+				continue
+			}
+			have[id] = from
+			merged.Content = append(merged.Content, item)
+		}
+	}
+	if len(merged.Content) == 0 {
+		return skipped, keep.SetExtraField("inputs", nil)
+	}
+	return skipped, keep.SetExtraField("inputs", merged)
+}
+
+// redirect points f's inputs and body links that name oldID at newID, and
+// reports whether anything changed. Links inside code are left alone, as
+// ExtractBodyRefs ignores them.
+func redirect(f *Felt, oldID, newID string) bool {
+	changed := f.RewriteDataFlowRefs(func(ref string) (string, bool) {
+		target, fragment := splitDataFlowRef(ref)
+		if target != oldID {
+			return ref, false
+		}
+		if fragment == "" {
+			return newID, true
+		}
+		return newID + "." + fragment, true
+	})
+	body := rewriteOutsideCode(f.Body, func(text string) string {
+		text = wikiLinkRe.ReplaceAllStringFunc(text, func(link string) string {
+			m := wikiLinkRe.FindStringSubmatch(link)
+			if strings.TrimSpace(m[1]) != oldID {
+				return link
+			}
+			return strings.Replace(link, m[1], newID, 1)
+		})
+		return bodyLinkRe.ReplaceAllStringFunc(text, func(link string) string {
+			m := bodyLinkRe.FindStringSubmatch(link)
+			ref, ok := parseBodyRefTarget(m[1], "")
+			if !ok || ref.Target != oldID {
+				return link
+			}
+			return strings.Replace(link, "("+m[1]+")", "("+strings.Replace(m[1], oldID, newID, 1)+")", 1)
+		})
+	})
+	if body != f.Body {
+		f.Body = body
+		changed = true
+	}
+	return changed
+}
+
+// rewriteOutsideCode applies rewrite to the stretches of body outside
+// fenced code blocks and inline code spans.
+func rewriteOutsideCode(body string, rewrite func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range codeBlockRe.FindAllStringIndex(body, -1) {
+		b.WriteString(rewriteOutsideSpans(body[last:loc[0]], rewrite))
+		b.WriteString(body[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(rewriteOutsideSpans(body[last:], rewrite))
+	return b.String()
+}
+
+func rewriteOutsideSpans(text string, rewrite func(string) string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range codeSpanRe.FindAllStringIndex(text, -1) {
+		b.WriteString(rewrite(text[last:loc[0]]))
+		b.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	b.WriteString(rewrite(text[last:]))
+	return b.String()
+}
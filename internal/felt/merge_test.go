@@ -0,0 +1,77 @@
+package felt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMergeFoldsDuplicateAndRedirectsReferences(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeRawFiber(t, s, "mocks/mocks.md", "---\nname: Mocks\nstatus: open\ntags: [sim]\ncreated-at: 2026-04-12T09:00:00Z\ninputs:\n  - id: catalog\n    from: catalog\n---\nKept notes.\n")
+	writeRawFiber(t, s, "mocks-again/mocks-again.md", "---\nname: Mocks again\nstatus: open\ntags: [sim, cosebis]\ncreated-at: 2026-04-10T09:00:00Z\ninputs:\n  - id: catalog\n    from: other-catalog\n  - id: mask\n    from: mask\n  - id: mocks\n    from: mocks\n---\nDuplicate notes.\n")
+	writeRawFiber(t, s, "catalog/catalog.md", "---\nname: Catalog\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	writeRawFiber(t, s, "mask/mask.md", "---\nname: Mask\ncreated-at: 2026-04-10T09:00:00Z\n---\n")
+	writeRawFiber(t, s, "paper/paper.md", "---\nname: Paper\ncreated-at: 2026-04-10T09:00:00Z\ninputs:\n  - id: m\n    from: mocks-again.chi2\n---\nSee [[mocks-again]] and [the mocks](mocks-again), not `[[mocks-again]]`.\n")
+
+	now := time.Date(2026, 4, 20, 9, 0, 0, 0, time.UTC)
+	result, err := s.Merge("mocks", "mocks-again", now)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if !reflect.DeepEqual(result.Redirected, []string{"paper"}) || !reflect.DeepEqual(result.SkippedInputs, []string{"catalog"}) {
+		t.Fatalf("Merge = redirected %v, skipped %v", result.Redirected, result.SkippedInputs)
+	}
+	if _, err := s.Read("mocks-again"); err == nil {
+		t.Fatal("duplicate was not deleted")
+	}
+
+	kept, err := s.Read("mocks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(kept.Tags, []string{"sim", "cosebis"}) {
+		t.Fatalf("tags = %v", kept.Tags)
+	}
+	if want := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC); !kept.CreatedAt.Equal(want) {
+		t.Fatalf("created-at = %v, want %v", kept.CreatedAt, want)
+	}
+	if want := []DataFlowInputRef{{InputID: "catalog", From: "catalog"}, {InputID: "mask", From: "mask"}}; !reflect.DeepEqual(kept.DataFlowInputs(), want) {
+		t.Fatalf("inputs = %+v, want %+v", kept.DataFlowInputs(), want)
+	}
+	if want := "Kept notes.\n\n## Merged from mocks-again (Mocks again)\n\nDuplicate notes."; kept.Body != want {
+		t.Fatalf("body = %q, want %q", kept.Body, want)
+	}
+
+	paper, err := s.Read("paper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []DataFlowInputRef{{InputID: "m", From: "mocks.chi2"}}; !reflect.DeepEqual(paper.DataFlowInputs(), want) {
+		t.Fatalf("paper inputs = %+v, want %+v", paper.DataFlowInputs(), want)
+	}
+	if want := "See [[mocks]] and [the mocks](mocks), not `[[mocks-again]]`."; paper.Body != want {
+		t.Fatalf("paper body = %q, want %q", paper.Body, want)
+	}
+}
+
+func TestMergeRefusesDuplicateWithNestedFibers(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	writeRawFiber(t, s, "a/a.md", "---\nname: A\n---\n")
+	writeRawFiber(t, s, "b/b.md", "---\nname: B\n---\n")
+	writeRawFiber(t, s, "b/child/child.md", "---\nname: Child\n---\n")
+
+	if _, err := s.Merge("a", "b", time.Now()); err == nil || !strings.Contains(err.Error(), "nested fibers (b/child)") {
+		t.Fatalf("Merge = %v, want nested-fiber refusal", err)
+	}
+	if _, err := s.Merge("b/child", "b", time.Now()); err == nil {
+		t.Fatal("Merge into a fiber nested under the duplicate should fail")
+	}
+}
@@ -0,0 +1,183 @@
+package felt
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MilestoneKey is the top-level frontmatter scalar that assigns a fiber to a
+// milestone, by the milestone's slug:
+//
+//	milestone: bmodes-paper
+//
+// Like the sprint tag, membership lives on the member; the milestone fiber
+// itself only names and describes the goal.
+const MilestoneKey = "milestone"
+
+// MilestoneContainerID is the parent path under which `felt milestone
+// create` files milestone fibers. Every direct child of it is a milestone.
+const MilestoneContainerID = "milestones"
+
+// Milestone is the decoded view of a milestone fiber.
+type Milestone struct {
+	ID   string `json:"id"`
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// Milestone returns the slug f's milestone: field names, or "".
+func (f *Felt) Milestone() string {
+	node := extraFieldNode(f.ExtraFields, MilestoneKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(node.Value)
+}
+
+// SetMilestone assigns f to the milestone slug, replacing any earlier
+// assignment; an empty slug clears it.
+func (f *Felt) SetMilestone(slug string) error {
+	if slug == "" {
+		return f.SetExtraField(MilestoneKey, nil)
+	}
+	return f.SetExtraField(MilestoneKey, slug)
+}
+
+// MilestoneFromFelt returns the milestone f records. ok is false unless f is
+// a direct child of MilestoneContainerID.
+func MilestoneFromFelt(f *Felt) (*Milestone, bool) {
+	if path.Dir(f.ID) != MilestoneContainerID {
+		return nil, false
+	}
+	return &Milestone{ID: f.ID, Slug: path.Base(f.ID), Name: f.DisplayName()}, true
+}
+
+// NewMilestone builds the fiber for a milestone named name, filed at
+// milestones/<slug>.
+func NewMilestone(slug, name string) (*Felt, *Milestone, error) {
+	slug = SlugifyPath(strings.TrimPrefix(strings.TrimSpace(slug), MilestoneContainerID+"/"))
+	if slug == "" || strings.Contains(slug, "/") {
+		return nil, nil, fmt.Errorf("milestone slug must be a single path segment")
+	}
+	f, err := New(path.Join(MilestoneContainerID, slug), name)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.AddTag("milestone")
+	m, _ := MilestoneFromFelt(f)
+	return f, m, nil
+}
+
+// Milestones returns every milestone recorded in felts, by id.
+func Milestones(felts []*Felt) []*Milestone {
+	var out []*Milestone
+	for _, f := range felts {
+		if m, ok := MilestoneFromFelt(f); ok {
+			out = append(out, m)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// MilestoneProgress is how far along a milestone's work is. The work is the
+// transitive closure of its assigned fibers: each assigned fiber, its nested
+// children, and every fiber reachable upstream through inputs[].from — what
+// has to close before the milestone can. Statusless notes are not work and
+// are left out. Percent is Closed over Total, rounded down.
+type MilestoneProgress struct {
+	Milestone *Milestone `json:"milestone"`
+	Assigned  []string   `json:"assigned"`
+	Total     int        `json:"total"`
+	Closed    int        `json:"closed"`
+	Active    int        `json:"active"`
+	Open      int        `json:"open"`
+	Percent   int        `json:"percent"`
+	Remaining []*Felt    `json:"remaining"`
+}
+
+// BuildMilestoneProgress computes m's progress over felts. upstreams is
+// DataFlowUpstreams(felts), passed in so a listing of every milestone
+// resolves references once.
+func BuildMilestoneProgress(m *Milestone, felts []*Felt, upstreams map[string][]string) *MilestoneProgress {
+	p := &MilestoneProgress{Milestone: m}
+	var members []*Felt
+	p.Assigned, members = milestoneClosure(m, felts, upstreams)
+	for _, f := range members {
+		switch {
+		case f.IsClosed():
+			p.Closed++
+		case f.IsActive():
+			p.Active++
+			p.Remaining = append(p.Remaining, f)
+		default:
+			p.Open++
+			p.Remaining = append(p.Remaining, f)
+		}
+		p.Total++
+	}
+	if p.Total > 0 {
+		p.Percent = p.Closed * 100 / p.Total
+	}
+	return p
+}
+
+// MilestoneFibers returns the tracked fibers m's progress counts — the
+// closure BuildMilestoneProgress describes — ordered by id.
+func MilestoneFibers(m *Milestone, felts []*Felt, upstreams map[string][]string) []*Felt {
+	_, members := milestoneClosure(m, felts, upstreams)
+	return members
+}
+
+// milestoneClosure returns the ids of the fibers assigned to m, sorted, and
+// the tracked fibers of their closure, ordered by id.
+func milestoneClosure(m *Milestone, felts []*Felt, upstreams map[string][]string) (assigned []string, members []*Felt) {
+	byID := make(map[string]*Felt, len(felts))
+	for _, f := range felts {
+		byID[f.ID] = f
+	}
+
+	in := make(map[string]bool)
+	var queue []string
+	visit := func(id string) {
+		if !in[id] && id != m.ID && byID[id] != nil {
+			in[id] = true
+			queue = append(queue, id)
+		}
+	}
+	for _, f := range felts {
+		if f.ID != m.ID && f.Milestone() == m.Slug {
+			assigned = append(assigned, f.ID)
+			visit(f.ID)
+			for _, child := range felts {
+				if strings.HasPrefix(child.ID, f.ID+"/") {
+					visit(child.ID)
+				}
+			}
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, up := range upstreams[id] {
+			visit(up)
+		}
+	}
+	sort.Strings(assigned)
+
+	ids := make([]string, 0, len(in))
+	for id := range in {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if f := byID[id]; f.HasStatus() {
+			members = append(members, f)
+		}
+	}
+	return assigned, members
+}
@@ -0,0 +1,91 @@
+package felt
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBuildMilestoneProgressCoversTransitiveClosure(t *testing.T) {
+	closedAt := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	m, mf := mustMilestone(t, "bmodes-paper", "B-modes paper")
+	paper := &Felt{ID: "paper", Name: "Paper", Status: StatusActive}
+	fit := &Felt{ID: "paper/fit", Name: "Fit", Status: StatusClosed, ClosedAt: &closedAt}
+	notes := &Felt{ID: "paper/notes", Name: "Notes"}
+	sims := &Felt{ID: "sims", Name: "Sims", Status: StatusClosed, ClosedAt: &closedAt}
+	mocks := &Felt{ID: "mocks", Name: "Mocks", Status: StatusOpen}
+	other := &Felt{ID: "other", Name: "Other", Status: StatusOpen}
+	if err := paper.SetMilestone(m.Slug); err != nil {
+		t.Fatal(err)
+	}
+	if err := other.SetMilestone("another"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fit.AddDataFlowInput("sims"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sims.AddDataFlowInput("mocks"); err != nil {
+		t.Fatal(err)
+	}
+	felts := []*Felt{mf, paper, fit, notes, sims, mocks, other}
+
+	p := BuildMilestoneProgress(m, felts, DataFlowUpstreams(felts))
+	if !slices.Equal(p.Assigned, []string{"paper"}) {
+		t.Fatalf("Assigned = %v, want [paper]", p.Assigned)
+	}
+	// paper, paper/fit, and upstream sims and mocks; the note and the
+	// unrelated fiber are not work toward it.
+	if p.Total != 4 || p.Closed != 2 || p.Active != 1 || p.Open != 1 || p.Percent != 50 {
+		t.Fatalf("progress = %+v, want 2 of 4 closed (50%%)", p)
+	}
+	var remaining []string
+	for _, f := range p.Remaining {
+		remaining = append(remaining, f.ID)
+	}
+	if !slices.Equal(remaining, []string{"mocks", "paper"}) {
+		t.Fatalf("Remaining = %v", remaining)
+	}
+}
+
+func TestMilestoneFieldRoundTrips(t *testing.T) {
+	f := &Felt{ID: "paper", Name: "Paper", CreatedAt: time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)}
+	if err := f.SetMilestone("bmodes-paper"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := f.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse("paper", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.Milestone(); got != "bmodes-paper" {
+		t.Fatalf("Milestone() = %q after round trip\n%s", got, data)
+	}
+	if err := parsed.SetMilestone(""); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Milestone() != "" {
+		t.Fatal("SetMilestone(\"\") left the field set")
+	}
+
+	if _, ok := MilestoneFromFelt(&Felt{ID: "milestones/x/sub"}); ok {
+		t.Fatal("a fiber nested inside a milestone is not itself one")
+	}
+	if _, _, err := NewMilestone("a/b", "Nested"); err == nil {
+		t.Fatal("NewMilestone accepted a multi-segment slug")
+	}
+}
+
+func mustMilestone(t *testing.T, slug, name string) (*Milestone, *Felt) {
+	t.Helper()
+	f, m, err := NewMilestone(slug, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != "milestones/"+slug || m.Slug != slug {
+		t.Fatalf("NewMilestone = %+v", m)
+	}
+	return m, f
+}
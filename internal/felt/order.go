@@ -0,0 +1,77 @@
+package felt
+
+import "sort"
+
+// WorkOrder returns the open and active fibers in felts in an order that
+// respects data flow: every fiber comes after the fibers its inputs[].from
+// entries name. Among fibers free to go next, the earlier-created goes first,
+// then the lower id. Closed work and statusless notes are left out, and an
+// upstream outside the result imposes nothing.
+//
+// Fibers on a data-flow cycle, or downstream of one, can never be placed;
+// they are returned separately, in creation order, rather than failing the
+// whole ordering. DataFlowCycles names the loops themselves.
+func WorkOrder(felts []*Felt) (ordered, cyclic []*Felt) {
+	var work []*Felt
+	for _, f := range felts {
+		if f.HasStatus() && !f.IsClosed() {
+			work = append(work, f)
+		}
+	}
+	return dataFlowOrder(work, DataFlowUpstreams(felts))
+}
+
+// dataFlowOrder orders felts so each comes after the upstreams it reads
+// among them, taking the earliest-created available fiber at each step;
+// the ones a cycle leaves unplaced are returned separately, in creation
+// order. Upstreams outside felts impose nothing.
+func dataFlowOrder(felts []*Felt, upstreams map[string][]string) (ordered, cyclic []*Felt) {
+	in := make(map[string]bool, len(felts))
+	for _, f := range felts {
+		in[f.ID] = true
+	}
+	waiting := make(map[string]int, len(felts))
+	downstreams := make(map[string][]string)
+	for _, f := range felts {
+		for _, up := range upstreams[f.ID] {
+			if in[up] {
+				waiting[f.ID]++
+				downstreams[up] = append(downstreams[up], f.ID)
+			}
+		}
+	}
+
+	// Kahn's algorithm, taking the earliest available fiber at each step.
+	// Task lists are small enough that a linear scan beats keeping a heap.
+	placed := make(map[string]bool, len(felts))
+	for len(ordered) < len(felts) {
+		var next *Felt
+		for _, f := range felts {
+			if !placed[f.ID] && waiting[f.ID] == 0 && (next == nil || orderedBefore(f, next)) {
+				next = f
+			}
+		}
+		if next == nil {
+			break
+		}
+		placed[next.ID] = true
+		ordered = append(ordered, next)
+		for _, down := range downstreams[next.ID] {
+			waiting[down]--
+		}
+	}
+	for _, f := range felts {
+		if !placed[f.ID] {
+			cyclic = append(cyclic, f)
+		}
+	}
+	sort.Slice(cyclic, func(i, j int) bool { return orderedBefore(cyclic[i], cyclic[j]) })
+	return ordered, cyclic
+}
+
+func orderedBefore(a, b *Felt) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
@@ -0,0 +1,43 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkOrderPutsInputsFirstAndBreaksTiesByCreation(t *testing.T) {
+	at := func(day int) time.Time { return time.Date(2026, 5, day, 9, 0, 0, 0, time.UTC) }
+	closedAt := at(2)
+	felts := []*Felt{
+		{ID: "paper", Name: "Paper", Status: StatusOpen, CreatedAt: at(1)},
+		{ID: "fit", Name: "Fit", Status: StatusActive, CreatedAt: at(2)},
+		{ID: "sims", Name: "Sims", Status: StatusOpen, CreatedAt: at(5)},
+		{ID: "mocks", Name: "Mocks", Status: StatusClosed, ClosedAt: &closedAt, CreatedAt: at(1)},
+		{ID: "notes", Name: "Notes", CreatedAt: at(1)},
+		{ID: "b-loop", Name: "B", Status: StatusOpen, CreatedAt: at(4)},
+		{ID: "a-loop", Name: "A", Status: StatusOpen, CreatedAt: at(4)},
+		{ID: "after-loop", Name: "After", Status: StatusOpen, CreatedAt: at(1)},
+	}
+	byID := make(map[string]*Felt)
+	for _, f := range felts {
+		byID[f.ID] = f
+	}
+	for _, edge := range [][2]string{
+		{"paper", "fit"}, {"fit", "sims"}, {"fit", "mocks"},
+		{"a-loop", "b-loop"}, {"b-loop", "a-loop"}, {"after-loop", "a-loop"},
+	} {
+		if err := byID[edge[0]].AddDataFlowInput(edge[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ordered, cyclic := WorkOrder(felts)
+	// The closed input and the note take no place; sims, created last,
+	// still comes first because fit waits on it.
+	if got, want := ids(ordered), "sims,fit,paper"; got != want {
+		t.Fatalf("ordered = %v, want %v", got, want)
+	}
+	if got, want := ids(cyclic), "after-loop,a-loop,b-loop"; got != want {
+		t.Fatalf("cyclic = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package felt
+
+import (
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OwnerKey is the frontmatter key naming who is responsible for a fiber —
+// a person, or an agent — so a store shared between several can split its
+// ready queue.
+const OwnerKey = "owner"
+
+// OwnerEnv names the invoking user's owner, winning over owner.default in
+// config.yaml: a store shared between collaborators commits one config,
+// while each shell (or agent harness) says who it is.
+const OwnerEnv = "FELT_OWNER"
+
+// Owner returns f's `owner:`, or "" when it has none.
+func (f *Felt) Owner() string {
+	node := extraFieldNode(f.ExtraFields, OwnerKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(node.Value)
+}
+
+// SetOwner sets f's owner; "" clears it.
+func (f *Felt) SetOwner(owner string) error {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return f.SetExtraField(OwnerKey, nil)
+	}
+	return f.SetExtraField(OwnerKey, owner)
+}
+
+// DefaultOwner returns the owner new fibers get and `--owner me` means:
+// $FELT_OWNER when set, else owner.default. It is "" when neither is.
+func (c *Config) DefaultOwner() string {
+	if v := strings.TrimSpace(os.Getenv(OwnerEnv)); v != "" {
+		return v
+	}
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Owner.Default)
+}
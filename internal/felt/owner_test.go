@@ -0,0 +1,34 @@
+package felt
+
+import "testing"
+
+func TestOwnerRoundTrip(t *testing.T) {
+	f := &Felt{ID: "fiber"}
+	if f.Owner() != "" {
+		t.Fatalf("Owner() = %q, want empty", f.Owner())
+	}
+	if err := f.SetOwner(" ana "); err != nil {
+		t.Fatalf("SetOwner: %v", err)
+	}
+	if f.Owner() != "ana" {
+		t.Fatalf("Owner() = %q, want ana", f.Owner())
+	}
+	if err := f.SetOwner(""); err != nil {
+		t.Fatalf("SetOwner clear: %v", err)
+	}
+	if f.Owner() != "" || extraFieldNode(f.ExtraFields, OwnerKey) != nil {
+		t.Fatalf("SetOwner(\"\") should remove the field, got %q", f.Owner())
+	}
+}
+
+func TestDefaultOwnerPrefersEnv(t *testing.T) {
+	cfg := &Config{Owner: OwnerConfig{Default: "ana"}}
+	t.Setenv(OwnerEnv, "")
+	if got := cfg.DefaultOwner(); got != "ana" {
+		t.Fatalf("DefaultOwner() = %q, want ana", got)
+	}
+	t.Setenv(OwnerEnv, "ben")
+	if got := cfg.DefaultOwner(); got != "ben" {
+		t.Fatalf("DefaultOwner() with %s = %q, want ben", OwnerEnv, got)
+	}
+}
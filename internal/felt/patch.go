@@ -0,0 +1,326 @@
+package felt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// patchHunk is one `@@ -a,b +c,d @@` section of a unified diff: the old
+// lines it expects (context and removals) and the new lines that replace
+// them (context and additions).
+type patchHunk struct {
+	oldStart int // 1-based; 0 for a hunk against an empty file
+	oldLines []string
+	newLines []string
+}
+
+// patchFuzz is how far (in lines, either direction) a hunk may have drifted
+// from its stated position and still apply — enough to absorb an earlier
+// hunk or a concurrent append shifting the body, small enough that a hunk
+// never lands on an unrelated repeat of its context.
+const patchFuzz = 50
+
+// ApplyUnifiedPatch applies a unified diff to text and returns the result.
+// File headers (---/+++, diff --git, index) are ignored: the patch is always
+// against the one text given. Every hunk must match exactly (context lines
+// included) at or near its stated line; the first hunk that does not is an
+// error and text is left untouched, so a stale patch never half-applies.
+func ApplyUnifiedPatch(text, patch string) (string, error) {
+	hunks, err := parseUnifiedPatch(patch)
+	if err != nil {
+		return "", err
+	}
+	if len(hunks) == 0 {
+		return "", fmt.Errorf("patch contains no hunks")
+	}
+
+	var lines []string
+	if text != "" {
+		lines = strings.Split(text, "\n")
+	}
+	offset := 0 // cumulative line delta from hunks already applied
+	for i, h := range hunks {
+		// A pure insertion (`-a,0`) goes after old line a; anything else
+		// starts at old line a.
+		want := h.oldStart - 1 + offset
+		if len(h.oldLines) == 0 {
+			want = h.oldStart + offset
+		}
+		at, ok := locateHunk(lines, h.oldLines, want)
+		if !ok {
+			return "", fmt.Errorf("hunk %d (@@ -%d) does not apply: body has changed since the patch was made", i+1, h.oldStart)
+		}
+		next := make([]string, 0, len(lines)-len(h.oldLines)+len(h.newLines))
+		next = append(next, lines[:at]...)
+		next = append(next, h.newLines...)
+		next = append(next, lines[at+len(h.oldLines):]...)
+		lines = next
+		offset += len(h.newLines) - len(h.oldLines) + (at - want)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// locateHunk finds where old matches lines, trying the stated position first
+// and then alternating outward up to patchFuzz lines.
+func locateHunk(lines, old []string, want int) (int, bool) {
+	matches := func(at int) bool {
+		if at < 0 || at+len(old) > len(lines) {
+			return false
+		}
+		for i, l := range old {
+			if lines[at+i] != l {
+				return false
+			}
+		}
+		return true
+	}
+	for d := 0; d <= patchFuzz; d++ {
+		if matches(want - d) {
+			return want - d, true
+		}
+		if d > 0 && matches(want+d) {
+			return want + d, true
+		}
+	}
+	return 0, false
+}
+
+func parseUnifiedPatch(patch string) ([]patchHunk, error) {
+	var hunks []patchHunk
+	var cur *patchHunk
+	oldLeft, newLeft := 0, 0
+	for n, line := range strings.Split(strings.TrimRight(patch, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.HasPrefix(line, "@@") {
+			if cur != nil && (oldLeft > 0 || newLeft > 0) {
+				return nil, fmt.Errorf("patch line %d: previous hunk is short", n+1)
+			}
+			h, oldCount, newCount, err := parseHunkHeader(line)
+			if err != nil {
+				return nil, fmt.Errorf("patch line %d: %w", n+1, err)
+			}
+			hunks = append(hunks, h)
+			cur = &hunks[len(hunks)-1]
+			oldLeft, newLeft = oldCount, newCount
+			continue
+		}
+		if cur == nil || (oldLeft == 0 && newLeft == 0) {
+			// Headers before the first hunk, or trailing noise between hunks.
+			continue
+		}
+		if line == `\ No newline at end of file` {
+			continue
+		}
+		if line == "" {
+			// Some tools strip the leading space from blank context lines.
+			line = " "
+		}
+		body := line[1:]
+		switch line[0] {
+		case ' ':
+			cur.oldLines = append(cur.oldLines, body)
+			cur.newLines = append(cur.newLines, body)
+			oldLeft--
+			newLeft--
+		case '-':
+			cur.oldLines = append(cur.oldLines, body)
+			oldLeft--
+		case '+':
+			cur.newLines = append(cur.newLines, body)
+			newLeft--
+		default:
+			return nil, fmt.Errorf("patch line %d: unexpected %q inside a hunk", n+1, line)
+		}
+		if oldLeft < 0 || newLeft < 0 {
+			return nil, fmt.Errorf("patch line %d: hunk is longer than its header says", n+1)
+		}
+	}
+	if cur != nil && (oldLeft > 0 || newLeft > 0) {
+		return nil, fmt.Errorf("patch ends inside a hunk")
+	}
+	return hunks, nil
+}
+
+// parseHunkHeader reads `@@ -a[,b] +c[,d] @@ ...`.
+func parseHunkHeader(line string) (patchHunk, int, int, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || fields[0] != "@@" || fields[3] != "@@" ||
+		!strings.HasPrefix(fields[1], "-") || !strings.HasPrefix(fields[2], "+") {
+		return patchHunk{}, 0, 0, fmt.Errorf("malformed hunk header %q", line)
+	}
+	oldStart, oldCount, err := parseHunkRange(fields[1][1:])
+	if err != nil {
+		return patchHunk{}, 0, 0, fmt.Errorf("malformed hunk header %q: %w", line, err)
+	}
+	_, newCount, err := parseHunkRange(fields[2][1:])
+	if err != nil {
+		return patchHunk{}, 0, 0, fmt.Errorf("malformed hunk header %q: %w", line, err)
+	}
+	return patchHunk{oldStart: oldStart}, oldCount, newCount, nil
+}
+
+func parseHunkRange(s string) (start, count int, err error) {
+	startStr, countStr, hasCount := strings.Cut(s, ",")
+	if start, err = strconv.Atoi(startStr); err != nil {
+		return 0, 0, err
+	}
+	count = 1
+	if hasCount {
+		if count, err = strconv.Atoi(countStr); err != nil {
+			return 0, 0, err
+		}
+	}
+	return start, count, nil
+}
+
+// diffContext is how many unchanged lines UnifiedDiff keeps around each
+// change, as diff -u does.
+const diffContext = 3
+
+// diffMaxCells bounds the line-matching table UnifiedDiff builds. Past it,
+// the differing middle of the two texts is reported as one replacement:
+// still a correct patch, just not a minimal one.
+const diffMaxCells = 4 << 20
+
+// UnifiedDiff returns the hunks of a unified diff turning old into new,
+// splitting lines as ApplyUnifiedPatch does, so the result applies back onto
+// old. It returns "" when the texts are equal. Callers add file headers.
+func UnifiedDiff(old, new string) string {
+	if old == new {
+		return ""
+	}
+	var a, b []string
+	if old != "" {
+		a = strings.Split(old, "\n")
+	}
+	if new != "" {
+		b = strings.Split(new, "\n")
+	}
+	ops := diffLines(a, b)
+
+	var sb strings.Builder
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		// A hunk runs from diffContext lines before this change to
+		// diffContext lines after its last change, absorbing any change
+		// that starts within 2*diffContext unchanged lines.
+		start := max(i-diffContext, 0)
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		end = min(end+diffContext, len(ops))
+
+		oldStart, newStart := ops[start].a+1, ops[start].b+1
+		oldCount, newCount := 0, 0
+		var body strings.Builder
+		for _, op := range ops[start:end] {
+			body.WriteByte(op.kind)
+			body.WriteString(op.line)
+			body.WriteByte('\n')
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		sb.WriteString(body.String())
+		i = end
+	}
+	return sb.String()
+}
+
+// diffOp is one line of an edit script: kept (' '), removed ('-'), or added
+// ('+'). a and b are how many old and new lines precede it.
+type diffOp struct {
+	kind byte
+	line string
+	a, b int
+}
+
+// diffLines returns an edit script from a to b through a longest common
+// subsequence of their lines, after trimming the common prefix and suffix.
+func diffLines(a, b []string) []diffOp {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	ia, ib := 0, 0
+	emit := func(kind byte, line string) {
+		ops = append(ops, diffOp{kind: kind, line: line, a: ia, b: ib})
+		if kind != '+' {
+			ia++
+		}
+		if kind != '-' {
+			ib++
+		}
+	}
+	for _, line := range a[:pre] {
+		emit(' ', line)
+	}
+	if len(ma)*len(mb) > diffMaxCells {
+		for _, line := range ma {
+			emit('-', line)
+		}
+		for _, line := range mb {
+			emit('+', line)
+		}
+	} else {
+		// lcs[i][j] is the common-subsequence length of ma[i:] and mb[j:].
+		lcs := make([][]int, len(ma)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(mb)+1)
+		}
+		for i := len(ma) - 1; i >= 0; i-- {
+			for j := len(mb) - 1; j >= 0; j-- {
+				if ma[i] == mb[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(ma) || j < len(mb) {
+			switch {
+			case i < len(ma) && j < len(mb) && ma[i] == mb[j]:
+				emit(' ', ma[i])
+				i++
+				j++
+			case j < len(mb) && (i == len(ma) || lcs[i][j+1] > lcs[i+1][j]):
+				emit('+', mb[j])
+				j++
+			default:
+				emit('-', ma[i])
+				i++
+			}
+		}
+	}
+	for _, line := range a[len(a)-suf:] {
+		emit(' ', line)
+	}
+	return ops
+}
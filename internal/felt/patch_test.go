@@ -0,0 +1,89 @@
+package felt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestApplyUnifiedPatchMultiHunkAndInsert(t *testing.T) {
+	text := "one\ntwo\nthree\nfour\nfive\nsix"
+	patch := `@@ -1,2 +1,2 @@
+-one
++ONE
+ two
+@@ -4,0 +5,2 @@
++four-a
++four-b
+@@ -6 +7 @@
+-six
++SIX
+`
+	got, err := ApplyUnifiedPatch(text, patch)
+	if err != nil {
+		t.Fatalf("ApplyUnifiedPatch: %v", err)
+	}
+	want := "ONE\ntwo\nthree\nfour\nfour-a\nfour-b\nfive\nSIX"
+	if got != want {
+		t.Fatalf("patched = %q, want %q", got, want)
+	}
+}
+
+func TestApplyUnifiedPatchToleratesDrift(t *testing.T) {
+	// The body gained a preamble after the diff was taken.
+	text := "preamble\n\none\ntwo"
+	got, err := ApplyUnifiedPatch(text, "@@ -2 +2 @@\n-two\n+TWO\n")
+	if err != nil {
+		t.Fatalf("ApplyUnifiedPatch: %v", err)
+	}
+	if got != "preamble\n\none\nTWO" {
+		t.Fatalf("patched = %q", got)
+	}
+}
+
+func TestApplyUnifiedPatchRejectsMismatchAndMalformed(t *testing.T) {
+	if _, err := ApplyUnifiedPatch("a\nb", "@@ -1 +1 @@\n-x\n+y\n"); err == nil || !strings.Contains(err.Error(), "does not apply") {
+		t.Fatalf("mismatch err = %v", err)
+	}
+	if _, err := ApplyUnifiedPatch("a", "no hunks here"); err == nil {
+		t.Fatalf("hunkless patch should be rejected")
+	}
+	if _, err := ApplyUnifiedPatch("a", "@@ -1,2 +1,2 @@\n a\n"); err == nil {
+		t.Fatalf("truncated hunk should be rejected")
+	}
+}
+
+func TestUnifiedDiffAppliesBack(t *testing.T) {
+	base := make([]string, 30)
+	for i := range base {
+		base[i] = fmt.Sprintf("line %d", i+1)
+	}
+	edit := func(f func([]string) []string) string {
+		return strings.Join(f(append([]string(nil), base...)), "\n")
+	}
+	old := strings.Join(base, "\n")
+	cases := map[string]string{
+		"change":   edit(func(l []string) []string { l[4] = "LINE 5"; return l }),
+		"two hunk": edit(func(l []string) []string { l[0] = "first"; l[25] = "late"; return l }),
+		"insert":   edit(func(l []string) []string { return append(l[:10], append([]string{"new a", "new b"}, l[10:]...)...) }),
+		"delete":   edit(func(l []string) []string { return append(l[:3], l[9:]...) }),
+		"append":   edit(func(l []string) []string { return append(l, "tail") }),
+		"to empty": "",
+	}
+	for name, new := range cases {
+		patch := UnifiedDiff(old, new)
+		got, err := ApplyUnifiedPatch(old, patch)
+		if err != nil || got != new {
+			t.Errorf("%s: round trip = %v\n%s\npatch:\n%s", name, err, got, patch)
+		}
+	}
+	if got, err := ApplyUnifiedPatch("", UnifiedDiff("", "fresh\nbody")); err != nil || got != "fresh\nbody" {
+		t.Errorf("from empty: %q, %v", got, err)
+	}
+	if UnifiedDiff(old, old) != "" {
+		t.Error("equal texts should diff to nothing")
+	}
+	if patch := UnifiedDiff("a\nb\nc", "a\nB\nc"); patch != "@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n" {
+		t.Errorf("patch = %q", patch)
+	}
+}
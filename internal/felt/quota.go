@@ -0,0 +1,66 @@
+package felt
+
+import (
+	"sort"
+	"strings"
+)
+
+// ApplyTagQuotas walks felts in order and holds back each fiber that would
+// put a tag over its quota, so one prolific workstream cannot fill a short
+// list. A quota key is a tag, or a prefix ending in ":" that caps each tag
+// under it separately — "thread:": 3 allows three fibers per thread:<name>.
+// A fiber counts against every quota it matches and is held back if any is
+// full. Quotas below 1 are ignored.
+func ApplyTagQuotas(felts []*Felt, quotas map[string]int) (kept, held []*Felt) {
+	if len(quotas) == 0 {
+		return felts, nil
+	}
+	keys := make([]string, 0, len(quotas))
+	for key, n := range quotas {
+		if n >= 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	used := make(map[string]int)
+	for _, f := range felts {
+		groups := quotaGroups(f, keys)
+		full := false
+		for _, g := range groups {
+			if used[g[1]] >= quotas[g[0]] {
+				full = true
+				break
+			}
+		}
+		if full {
+			held = append(held, f)
+			continue
+		}
+		for _, g := range groups {
+			used[g[1]]++
+		}
+		kept = append(kept, f)
+	}
+	return kept, held
+}
+
+// quotaGroups pairs each quota key f falls under with the tag it is counted
+// against: the key itself for a plain tag, f's matching tag for a prefix.
+func quotaGroups(f *Felt, keys []string) [][2]string {
+	var out [][2]string
+	for _, key := range keys {
+		if !strings.HasSuffix(key, ":") {
+			if f.HasTag(key) {
+				out = append(out, [2]string{key, key})
+			}
+			continue
+		}
+		for _, tag := range f.Tags {
+			if strings.HasPrefix(tag, key) {
+				out = append(out, [2]string{key, tag})
+			}
+		}
+	}
+	return out
+}
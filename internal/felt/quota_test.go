@@ -0,0 +1,36 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyTagQuotasCapsEachTagUnderAPrefix(t *testing.T) {
+	var felts []*Felt
+	for _, spec := range []struct{ id, tags string }{
+		{"a1", "thread:a"}, {"a2", "thread:a"}, {"a3", "thread:a,urgent"},
+		{"b1", "thread:b"}, {"u1", "urgent"}, {"loose", ""},
+	} {
+		f := &Felt{ID: spec.id}
+		if spec.tags != "" {
+			f.Tags = strings.Split(spec.tags, ",")
+		}
+		felts = append(felts, f)
+	}
+
+	kept, held := ApplyTagQuotas(felts, map[string]int{"thread:": 2, "urgent": 1, "ignored": 0})
+	if got := ids(kept); got != "a1,a2,b1,u1,loose" {
+		t.Fatalf("kept = %s", got)
+	}
+	if got := ids(held); got != "a3" {
+		t.Fatalf("held = %s", got)
+	}
+
+	kept, held = ApplyTagQuotas(felts, map[string]int{"urgent": 1})
+	if got := ids(kept); got != "a1,a2,a3,b1,loose" || ids(held) != "u1" {
+		t.Fatalf("urgent quota: kept %s, held %s", got, ids(held))
+	}
+	if kept, held := ApplyTagQuotas(felts, nil); len(kept) != len(felts) || held != nil {
+		t.Fatalf("no quotas should keep everything, got %d held", len(held))
+	}
+}
@@ -0,0 +1,296 @@
+package felt
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EstimateKey is the conventional frontmatter key for a fiber's expected
+// effort, written as a span ("90m", "4h", "1d") or, for teams that size work
+// in story points, a bare number (see EstimatePoints).
+const EstimateKey = "estimate"
+
+// Estimate returns f's `estimate:` span. ok is false when the field is absent
+// or does not parse as a span.
+func (f *Felt) Estimate() (time.Duration, bool) {
+	node := extraFieldNode(f.ExtraFields, EstimateKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return 0, false
+	}
+	d, err := ParseSpan(node.Value)
+	if err != nil {
+		return 0, false
+	}
+	return d, true
+}
+
+// DataFlowUpstreams maps each fiber id to the resolved ids of the fibers its
+// `inputs[].from` entries name, deduplicated and sorted. Unresolvable refs are
+// dropped here; `felt check` is where they surface.
+func DataFlowUpstreams(felts []*Felt) map[string][]string {
+	upstreams := make(map[string][]string)
+	seen := make(map[string]map[string]bool)
+	_ = iterRefs(felts, sortedFeltIDs(felts), func(r resolvedRef) error {
+		if r.Kind != refKindDataFlow || r.ResolveErr != nil || r.ResolvedID == r.Source.ID {
+			return nil
+		}
+		if seen[r.Source.ID] == nil {
+			seen[r.Source.ID] = make(map[string]bool)
+		}
+		if seen[r.Source.ID][r.ResolvedID] {
+			return nil
+		}
+		seen[r.Source.ID][r.ResolvedID] = true
+		upstreams[r.Source.ID] = append(upstreams[r.Source.ID], r.ResolvedID)
+		return nil
+	})
+	for id := range upstreams {
+		sort.Strings(upstreams[id])
+	}
+	return upstreams
+}
+
+// ReadyFelts returns the open fibers whose data-flow upstreams have all
+// closed — the work that can be picked up now. Active fibers are already
+// picked up and statusless notes are not work, so neither is ready; nor is
+// a fiber snoozed past now.
+func ReadyFelts(felts []*Felt, now time.Time) []*Felt {
+	readiness := ExplainReadiness(felts, now)
+	var ready []*Felt
+	for _, f := range felts {
+		if r := readiness[f.ID]; r != nil && r.Ready {
+			ready = append(ready, f)
+		}
+	}
+	return ready
+}
+
+// Readiness says why an open fiber is or is not ready, so JSON consumers
+// need not re-derive the rule: the upstreams its inputs[].from entries name,
+// split into Closed and Open (open or active — the ones blocking it), and
+// the Missing refs that resolve to no fiber, which block nothing but are
+// likely mistakes (felt check reports them). DeferredUntil is set while
+// the fiber is snoozed.
+type Readiness struct {
+	Ready         bool       `json:"ready"`
+	Closed        []string   `json:"closed"`
+	Open          []string   `json:"open"`
+	Missing       []string   `json:"missing"`
+	DeferredUntil *time.Time `json:"deferred_until,omitempty"`
+}
+
+// Blocked reports whether r's fiber waits on an upstream that has not
+// closed.
+func (r *Readiness) Blocked() bool {
+	return len(r.Open) > 0
+}
+
+// ExplainReadiness returns the Readiness of each open fiber in felts, by
+// id. ReadyFelts is the fibers it marks Ready.
+func ExplainReadiness(felts []*Felt, now time.Time) map[string]*Readiness {
+	byID := make(map[string]*Felt, len(felts))
+	out := make(map[string]*Readiness)
+	for _, f := range felts {
+		byID[f.ID] = f
+		if f.IsOpen() {
+			out[f.ID] = &Readiness{Closed: []string{}, Open: []string{}, Missing: []string{}}
+		}
+	}
+	seen := make(map[[2]string]bool)
+	_ = iterRefs(felts, sortedFeltIDs(felts), func(ref resolvedRef) error {
+		r := out[ref.Source.ID]
+		if r == nil || ref.Kind != refKindDataFlow || ref.ResolvedID == ref.Source.ID {
+			return nil
+		}
+		key := [2]string{ref.Source.ID, ref.ResolvedID}
+		switch {
+		case ref.ResolveErr != nil:
+			key[1] = ref.Label
+			if !seen[key] {
+				r.Missing = append(r.Missing, ref.Label)
+			}
+		case seen[key]:
+		case byID[ref.ResolvedID].IsClosed():
+			r.Closed = append(r.Closed, ref.ResolvedID)
+		default:
+			r.Open = append(r.Open, ref.ResolvedID)
+		}
+		seen[key] = true
+		return nil
+	})
+	for id, r := range out {
+		sort.Strings(r.Closed)
+		sort.Strings(r.Open)
+		sort.Strings(r.Missing)
+		if f := byID[id]; f.IsDeferred(now) {
+			until, _ := f.DeferUntil()
+			r.DeferredUntil = &until
+		}
+		r.Ready = !r.Blocked() && r.DeferredUntil == nil
+	}
+	return out
+}
+
+// ConsumedToday sums the estimates of fibers closed on the local calendar day
+// of now — the part of today's capacity already spent.
+func ConsumedToday(felts []*Felt, now time.Time) time.Duration {
+	today := now.Local().Format("2006-01-02")
+	var total time.Duration
+	for _, f := range felts {
+		if !f.IsClosed() || f.ClosedAt == nil || f.ClosedAt.Local().Format("2006-01-02") != today {
+			continue
+		}
+		if d, ok := f.Estimate(); ok {
+			total += d
+		}
+	}
+	return total
+}
+
+// FitBudget filters candidates to the estimated fibers that individually fit
+// within budget, largest estimate first, and picks the combination that fills
+// the most of it. Fibers without an estimate cannot be planned and are
+// skipped. The combination is chosen by a 0/1 knapsack over whole minutes,
+// ties going to fewer fibers.
+func FitBudget(candidates []*Felt, budget time.Duration) (fits []*Felt, best []*Felt) {
+	type item struct {
+		f       *Felt
+		minutes int
+	}
+	capMinutes := int(budget / time.Minute)
+	var items []item
+	for _, f := range candidates {
+		d, ok := f.Estimate()
+		if !ok || d > budget {
+			continue
+		}
+		items = append(items, item{f: f, minutes: int((d + time.Minute - 1) / time.Minute)})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].minutes != items[j].minutes {
+			return items[i].minutes > items[j].minutes
+		}
+		return items[i].f.ID < items[j].f.ID
+	})
+	for _, it := range items {
+		fits = append(fits, it.f)
+	}
+	if len(items) == 0 || capMinutes <= 0 {
+		return fits, nil
+	}
+
+	// filled[w] is the fewest fibers reaching exactly w minutes (-1 when
+	// unreachable); pick[i][w] records whether item i was taken at w.
+	filled := make([]int, capMinutes+1)
+	for w := 1; w <= capMinutes; w++ {
+		filled[w] = -1
+	}
+	pick := make([][]bool, len(items))
+	for i, it := range items {
+		pick[i] = make([]bool, capMinutes+1)
+		for w := capMinutes; w >= it.minutes; w-- {
+			prev := filled[w-it.minutes]
+			if prev < 0 {
+				continue
+			}
+			if filled[w] < 0 || prev+1 < filled[w] {
+				filled[w] = prev + 1
+				pick[i][w] = true
+			}
+		}
+	}
+	w := capMinutes
+	for w > 0 && filled[w] < 0 {
+		w--
+	}
+	for i := len(items) - 1; i >= 0 && w > 0; i-- {
+		if pick[i][w] {
+			best = append(best, items[i].f)
+			w -= items[i].minutes
+		}
+	}
+	sort.SliceStable(best, func(i, j int) bool {
+		return best[i].ID < best[j].ID
+	})
+	return fits, best
+}
+
+// NewlyUnblocked returns the open data-flow consumers of closedID that closing
+// it frees: every other upstream they name is already closed. felts may be
+// read before or after closedID's own write; closedID counts as closed either
+// way. Sorted by creation, oldest first, so the longest-waiting work leads.
+func NewlyUnblocked(felts []*Felt, closedID string) []*Felt {
+	byID := make(map[string]*Felt, len(felts))
+	for _, f := range felts {
+		byID[f.ID] = f
+	}
+	var out []*Felt
+	for id, ups := range DataFlowUpstreams(felts) {
+		f := byID[id]
+		if f == nil || !f.IsOpen() || !slices.Contains(ups, closedID) {
+			continue
+		}
+		blocked := false
+		for _, up := range ups {
+			if other := byID[up]; up != closedID && other != nil && !other.IsClosed() {
+				blocked = true
+				break
+			}
+		}
+		if !blocked {
+			out = append(out, f)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// Unblocked is a ready fiber together with the upstream whose close freed
+// it, and when that happened.
+type Unblocked struct {
+	Felt *Felt
+	By   string
+	At   time.Time
+}
+
+// UnblockedSince returns the fibers ready at now whose last upstream closed
+// after since — work that opened up in that window, newest first. Fibers
+// with no data-flow upstreams were never blocked and are not listed.
+func UnblockedSince(felts []*Felt, since, now time.Time) []Unblocked {
+	byID := make(map[string]*Felt, len(felts))
+	for _, f := range felts {
+		byID[f.ID] = f
+	}
+	upstreams := DataFlowUpstreams(felts)
+	var out []Unblocked
+	for _, f := range ReadyFelts(felts, now) {
+		var last Unblocked
+		for _, id := range upstreams[f.ID] {
+			up := byID[id]
+			if up == nil || up.ClosedAt == nil {
+				continue
+			}
+			if last.By == "" || up.ClosedAt.After(last.At) {
+				last = Unblocked{Felt: f, By: up.ID, At: *up.ClosedAt}
+			}
+		}
+		if last.By != "" && last.At.After(since) {
+			out = append(out, last)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].At.Equal(out[j].At) {
+			return out[i].At.After(out[j].At)
+		}
+		return out[i].Felt.ID < out[j].Felt.ID
+	})
+	return out
+}
@@ -0,0 +1,214 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func estimated(t *testing.T, id, status, estimate string) *Felt {
+	t.Helper()
+	f := &Felt{ID: id, Status: status}
+	if estimate != "" {
+		mustExtraField(t, f, EstimateKey, estimate)
+	}
+	return f
+}
+
+func TestReadyFeltsGatesOnDataFlowUpstreams(t *testing.T) {
+	producer := &Felt{ID: "producer", Status: StatusActive}
+	closedProducer := &Felt{ID: "closed-producer", Status: StatusClosed}
+	blocked := &Felt{ID: "blocked", Status: StatusOpen}
+	mustExtraField(t, blocked, "inputs", []map[string]any{{"id": "in", "from": "producer.out"}})
+	unblocked := &Felt{ID: "unblocked", Status: StatusOpen}
+	mustExtraField(t, unblocked, "inputs", []map[string]any{{"id": "in", "from": "closed-producer"}})
+	dangling := &Felt{ID: "dangling", Status: StatusOpen}
+	mustExtraField(t, dangling, "inputs", []map[string]any{{"id": "in", "from": "missing"}})
+	note := &Felt{ID: "note"}
+
+	got := ids(ReadyFelts([]*Felt{producer, closedProducer, blocked, unblocked, dangling, note}, time.Now()))
+	if got != "unblocked,dangling" {
+		t.Fatalf("ReadyFelts = %s, want unblocked,dangling", got)
+	}
+}
+
+func TestExplainReadinessSplitsUpstreams(t *testing.T) {
+	now := time.Date(2026, 5, 13, 9, 0, 0, 0, time.UTC)
+	producer := &Felt{ID: "producer", Status: StatusActive}
+	closedProducer := &Felt{ID: "closed-producer", Status: StatusClosed}
+	consumer := &Felt{ID: "consumer", Status: StatusOpen}
+	mustExtraField(t, consumer, "inputs", []map[string]any{
+		{"id": "a", "from": "producer.out"},
+		{"id": "b", "from": "producer.other"},
+		{"id": "c", "from": "closed-producer"},
+		{"id": "d", "from": "missing.out"},
+	})
+	snoozed := &Felt{ID: "snoozed", Status: StatusOpen}
+	if err := snoozed.SetDeferUntil(now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ExplainReadiness([]*Felt{producer, closedProducer, consumer, snoozed}, now)
+	if len(got) != 2 || got["producer"] != nil {
+		t.Fatalf("ExplainReadiness should cover open fibers only, got %v", got)
+	}
+	r := got["consumer"]
+	if r.Ready || !r.Blocked() || strings.Join(r.Open, ",") != "producer" || strings.Join(r.Closed, ",") != "closed-producer" || strings.Join(r.Missing, ",") != "missing.out" {
+		t.Fatalf("consumer readiness = %+v", r)
+	}
+	if s := got["snoozed"]; s.Ready || s.Blocked() || s.DeferredUntil == nil || !s.DeferredUntil.Equal(now.Add(time.Hour)) {
+		t.Fatalf("snoozed readiness = %+v", s)
+	}
+}
+
+func TestReadyFeltsSkipsSnoozedUntilWake(t *testing.T) {
+	now := time.Date(2026, 5, 13, 9, 0, 0, 0, time.UTC)
+	snoozed := &Felt{ID: "snoozed", Status: StatusOpen}
+	if err := snoozed.SetDeferUntil(now.Add(3 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	woken := &Felt{ID: "woken", Status: StatusOpen}
+	if err := woken.SetDeferUntil(now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	felts := []*Felt{snoozed, woken}
+
+	if got := ids(ReadyFelts(felts, now)); got != "woken" {
+		t.Fatalf("ReadyFelts before wake = %s, want woken", got)
+	}
+	if got := ids(ReadyFelts(felts, now.Add(4*time.Hour))); got != "snoozed,woken" {
+		t.Fatalf("ReadyFelts after wake = %s, want snoozed,woken", got)
+	}
+	if !snoozed.WakesOn(now) || snoozed.WakesOn(now.AddDate(0, 0, 1)) {
+		t.Fatal("WakesOn should match only the wake day")
+	}
+	if err := snoozed.SetDeferUntil(time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := snoozed.DeferUntil(); ok || len(snoozed.ExtraFieldOrder) != 0 {
+		t.Fatalf("cleared snooze left %v", snoozed.ExtraFieldOrder)
+	}
+}
+
+func TestParseWakeTime(t *testing.T) {
+	now := time.Date(2026, 5, 13, 18, 30, 0, 0, time.FixedZone("PDT", -7*3600))
+	cases := map[string]string{
+		"4h":        "2026-05-13T22:30:00-07:00",
+		"3d":        "2026-05-16T18:30:00-07:00",
+		"friday":    "2026-05-15T00:00:00-07:00",
+		"next week": "2026-05-18T00:00:00-07:00",
+	}
+	for in, want := range cases {
+		got, err := ParseWakeTime(in, now)
+		if err != nil {
+			t.Fatalf("ParseWakeTime(%q): %v", in, err)
+		}
+		if got.Format(time.RFC3339) != want {
+			t.Fatalf("ParseWakeTime(%q) = %s, want %s", in, got.Format(time.RFC3339), want)
+		}
+	}
+	if _, err := ParseWakeTime("whenever", now); err == nil {
+		t.Fatal("ParseWakeTime(whenever) succeeded, want error")
+	}
+}
+
+func TestFitBudgetPicksFullestCombination(t *testing.T) {
+	candidates := []*Felt{
+		estimated(t, "a", StatusOpen, "2h"),
+		estimated(t, "b", StatusOpen, "90m"),
+		estimated(t, "c", StatusOpen, "90m"),
+		estimated(t, "d", StatusOpen, "5h"),
+		estimated(t, "e", StatusOpen, ""),
+	}
+	fits, best := FitBudget(candidates, 3*time.Hour)
+	if got := ids(fits); got != "a,b,c" {
+		t.Fatalf("fits = %s, want a,b,c (largest first, over-budget and unestimated dropped)", got)
+	}
+	if got := ids(best); got != "b,c" {
+		t.Fatalf("best = %s, want b,c (exactly fills 3h)", got)
+	}
+
+	if _, best := FitBudget(candidates, 0); best != nil {
+		t.Fatalf("zero budget best = %v, want none", ids(best))
+	}
+}
+
+func TestConsumedTodaySumsTodaysClosedEstimates(t *testing.T) {
+	now := time.Date(2026, 10, 14, 17, 0, 0, 0, time.Local)
+	today := now.Add(-2 * time.Hour)
+	yesterday := now.AddDate(0, 0, -1)
+	a := estimated(t, "a", StatusClosed, "2h")
+	a.ClosedAt = &today
+	b := estimated(t, "b", StatusClosed, "1h")
+	b.ClosedAt = &yesterday
+	c := estimated(t, "c", StatusOpen, "4h")
+
+	if got := ConsumedToday([]*Felt{a, b, c}, now); got != 2*time.Hour {
+		t.Fatalf("ConsumedToday = %v, want 2h", got)
+	}
+}
+
+func TestLoadConfigMissingIsZero(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if _, ok, err := cfg.DailyCapacity(); ok || err != nil {
+		t.Fatalf("DailyCapacity = ok %v err %v, want unset", ok, err)
+	}
+}
+
+func TestNewlyUnblockedNeedsEveryOtherUpstreamClosed(t *testing.T) {
+	closing := &Felt{ID: "closing", Status: StatusActive}
+	openUp := &Felt{ID: "open-up", Status: StatusOpen}
+	doneUp := &Felt{ID: "done-up", Status: StatusClosed}
+	freed := &Felt{ID: "freed", Status: StatusOpen}
+	mustExtraField(t, freed, "inputs", []map[string]any{{"id": "a", "from": "closing"}, {"id": "b", "from": "done-up"}})
+	held := &Felt{ID: "held", Status: StatusOpen}
+	mustExtraField(t, held, "inputs", []map[string]any{{"id": "a", "from": "closing"}, {"id": "b", "from": "open-up"}})
+	unrelated := &Felt{ID: "unrelated", Status: StatusOpen}
+	mustExtraField(t, unrelated, "inputs", []map[string]any{{"id": "a", "from": "done-up"}})
+
+	got := ids(NewlyUnblocked([]*Felt{closing, openUp, doneUp, freed, held, unrelated}, "closing"))
+	if got != "freed" {
+		t.Fatalf("NewlyUnblocked = %s, want freed", got)
+	}
+}
+
+func TestUnblockedSinceListsFibersFreedAfterTheWatermark(t *testing.T) {
+	mark := time.Date(2026, 5, 13, 9, 0, 0, 0, time.UTC)
+	before, after := mark.Add(-time.Hour), mark.Add(time.Hour)
+	oldUp := &Felt{ID: "old-up", Status: StatusClosed, ClosedAt: &before}
+	newUp := &Felt{ID: "new-up", Status: StatusClosed, ClosedAt: &after}
+	openUp := &Felt{ID: "open-up", Status: StatusOpen}
+	freed := &Felt{ID: "freed", Status: StatusOpen}
+	mustExtraField(t, freed, "inputs", []map[string]any{{"id": "a", "from": "old-up"}, {"id": "b", "from": "new-up"}})
+	stale := &Felt{ID: "stale", Status: StatusOpen}
+	mustExtraField(t, stale, "inputs", []map[string]any{{"id": "a", "from": "old-up"}})
+	held := &Felt{ID: "held", Status: StatusOpen}
+	mustExtraField(t, held, "inputs", []map[string]any{{"id": "a", "from": "new-up"}, {"id": "b", "from": "open-up"}})
+	free := &Felt{ID: "free", Status: StatusOpen}
+
+	got := UnblockedSince([]*Felt{oldUp, newUp, openUp, freed, stale, held, free}, mark, after)
+	if len(got) != 1 || got[0].Felt.ID != "freed" || got[0].By != "new-up" || !got[0].At.Equal(after) {
+		t.Fatalf("UnblockedSince = %+v, want freed by new-up", got)
+	}
+}
+
+func TestSessionMarkRoundTrips(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := s.SessionMark(); err != nil || ok {
+		t.Fatalf("SessionMark before any = ok %v, err %v", ok, err)
+	}
+	at := time.Date(2026, 5, 13, 9, 30, 0, 0, time.UTC)
+	if err := s.SetSessionMark(at); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, err := s.SessionMark(); err != nil || !ok || !got.Equal(at) {
+		t.Fatalf("SessionMark = %v, %v, %v; want %v", got, ok, err, at)
+	}
+}
@@ -0,0 +1,147 @@
+package felt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReferenceKey is the frontmatter block that makes a fiber a literature or
+// reference fiber:
+//
+//	reference:
+//	  authors: ["Heymans, C.", "Tröster, T."]
+//	  year: 2021
+//	  journal: A&A
+//	  doi: 10.1051/0004-6361/202039063
+//
+// Authors are quoted because "Surname, I." holds a comma: unquoted, a flow
+// list splits it into two authors.
+//
+// The fiber's name is the title unless the block overrides it. Decision
+// fibers cite a reference the ordinary way —
+// `inputs[].from: <reference-id>` — so the evidence chain is part of the
+// data-flow graph.
+const ReferenceKey = "reference"
+
+// Reference is a decoded `reference:` block.
+type Reference struct {
+	Key     string   `yaml:"key,omitempty" json:"key,omitempty"`
+	Title   string   `yaml:"title,omitempty" json:"title,omitempty"`
+	Authors []string `yaml:"-" json:"authors,omitempty"`
+	Year    string   `yaml:"year,omitempty" json:"year,omitempty"`
+	Journal string   `yaml:"journal,omitempty" json:"journal,omitempty"`
+	DOI     string   `yaml:"doi,omitempty" json:"doi,omitempty"`
+	URL     string   `yaml:"url,omitempty" json:"url,omitempty"`
+}
+
+// Reference returns f's `reference:` block. ok is false when the fiber has
+// none; a block that is not a mapping is an error.
+func (f *Felt) Reference() (*Reference, bool, error) {
+	node := extraFieldNode(f.ExtraFields, ReferenceKey)
+	if node == nil {
+		return nil, false, nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil, false, fmt.Errorf("%s: %s must be a mapping", f.ID, ReferenceKey)
+	}
+	var ref Reference
+	if err := node.Decode(&ref); err != nil {
+		return nil, false, fmt.Errorf("%s: %s: %w", f.ID, ReferenceKey, err)
+	}
+	// authors: a sequence, or one scalar already in "A and B" form.
+	if authors := mappingValueNode(node, "authors"); authors != nil {
+		switch authors.Kind {
+		case yaml.SequenceNode:
+			for _, a := range authors.Content {
+				if a.Kind == yaml.ScalarNode && strings.TrimSpace(a.Value) != "" {
+					ref.Authors = append(ref.Authors, strings.TrimSpace(a.Value))
+				}
+			}
+		case yaml.ScalarNode:
+			for _, a := range strings.Split(authors.Value, " and ") {
+				if a = strings.TrimSpace(a); a != "" {
+					ref.Authors = append(ref.Authors, a)
+				}
+			}
+		}
+	}
+	if ref.Title == "" {
+		ref.Title = f.DisplayName()
+	}
+	return &ref, true, nil
+}
+
+var citeKeyJunk = regexp.MustCompile(`[^a-z0-9]+`)
+
+// CiteKey returns the BibTeX key: the block's key when set, else the first
+// author's surname, the year, and the first title word longer than three
+// letters ("heymans2021cosmic"), falling back to the fiber's slug.
+func (r *Reference) CiteKey(f *Felt) string {
+	if r.Key != "" {
+		return r.Key
+	}
+	var key string
+	if len(r.Authors) > 0 {
+		surname := r.Authors[0]
+		if before, _, ok := strings.Cut(surname, ","); ok {
+			surname = before
+		} else if fields := strings.Fields(surname); len(fields) > 0 {
+			surname = fields[len(fields)-1]
+		}
+		key = citeKeyPart(surname)
+	}
+	key += citeKeyPart(r.Year)
+	for _, word := range strings.Fields(r.Title) {
+		if w := citeKeyPart(word); len(w) > 3 {
+			key += w
+			break
+		}
+	}
+	if key == "" {
+		key = citeKeyPart(f.ID)
+	}
+	return key
+}
+
+func citeKeyPart(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII {
+			b.WriteRune(r)
+		}
+	}
+	return citeKeyJunk.ReplaceAllString(b.String(), "")
+}
+
+// BibTeX renders r as one BibTeX entry: @article when a journal is given,
+// @misc otherwise.
+func (r *Reference) BibTeX(f *Felt) string {
+	entry := "misc"
+	if r.Journal != "" {
+		entry = "article"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "@%s{%s,\n", entry, r.CiteKey(f))
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "  %-8s= {%s},\n", name, bibtexEscape(value))
+		}
+	}
+	field("title", r.Title)
+	field("author", strings.Join(r.Authors, " and "))
+	field("year", r.Year)
+	field("journal", r.Journal)
+	field("doi", r.DOI)
+	field("url", r.URL)
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// bibtexEscape keeps a value from closing its braces early.
+func bibtexEscape(s string) string {
+	return strings.NewReplacer(`{`, `\{`, `}`, `\}`, "\n", " ").Replace(strings.TrimSpace(s))
+}
@@ -0,0 +1,62 @@
+package felt
+
+import "testing"
+
+func TestReferenceBibTeX(t *testing.T) {
+	f := &Felt{ID: "lit/kids-1000", Name: "KiDS-1000 cosmic shear {3x2pt}"}
+	mustExtraField(t, f, ReferenceKey, map[string]any{
+		"authors": []string{"Heymans, C.", "Tröster, T."},
+		"year":    2021,
+		"journal": "A&A",
+		"doi":     "10.1051/0004-6361/202039063",
+	})
+
+	ref, ok, err := f.Reference()
+	if err != nil || !ok {
+		t.Fatalf("Reference() = %v, %v", ok, err)
+	}
+	want := `@article{heymans2021kids1000,
+  title   = {KiDS-1000 cosmic shear \{3x2pt\}},
+  author  = {Heymans, C. and Tröster, T.},
+  year    = {2021},
+  journal = {A&A},
+  doi     = {10.1051/0004-6361/202039063},
+}
+`
+	if got := ref.BibTeX(f); got != want {
+		t.Fatalf("BibTeX:\n%s\nwant:\n%s", got, want)
+	}
+
+	plain := &Felt{ID: "notes", Name: "Notes"}
+	if _, ok, _ := plain.Reference(); ok {
+		t.Fatalf("fiber without reference: block reported as a reference")
+	}
+	misc := &Felt{ID: "lit/blog", Name: "A post"}
+	mustExtraField(t, misc, ReferenceKey, map[string]any{"url": "https://example.org", "authors": "Jane Doe and R. Roe"})
+	ref, _, _ = misc.Reference()
+	if got := ref.CiteKey(misc); got != "doepost" {
+		t.Fatalf("CiteKey = %q, want doepost", got)
+	}
+}
+
+// The authors: form shown in the ReferenceKey doc and felt cite --help
+// parses as one author per quoted entry.
+func TestReferenceDocumentedAuthorsParse(t *testing.T) {
+	f, err := Parse("lit/kids-1000", []byte(`---
+name: KiDS-1000 cosmic shear
+reference:
+  authors: ["Heymans, C.", "Tröster, T."]
+  year: 2021
+---
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	ref, ok, err := f.Reference()
+	if err != nil || !ok {
+		t.Fatalf("Reference() = %v, %v", ok, err)
+	}
+	if len(ref.Authors) != 2 || ref.Authors[0] != "Heymans, C." || ref.Authors[1] != "Tröster, T." {
+		t.Fatalf("Authors = %q", ref.Authors)
+	}
+}
@@ -0,0 +1,173 @@
+package felt
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReleaseFacetKey is the top-level frontmatter key that marks a fiber as a
+// release record. The block carries the version and the instant it was cut;
+// like sprints, membership lives on the member fibers as a tag.
+const ReleaseFacetKey = "release"
+
+// ReleaseContainerID is the parent path under which `felt release cut`
+// files release fibers, one per version.
+const ReleaseContainerID = "releases"
+
+// ReleaseTagPrefix prefixes the membership tag a released fiber carries:
+// `release:<version>`.
+const ReleaseTagPrefix = "release:"
+
+// Release is the decoded view of a release fiber.
+type Release struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Version string    `json:"version"`
+	Cut     time.Time `json:"cut"`
+}
+
+type releaseFacet struct {
+	Version string `yaml:"version"`
+	Cut     string `yaml:"cut"`
+}
+
+// Tag returns the membership tag fibers shipped in this release carry.
+func (r *Release) Tag() string {
+	return ReleaseTagPrefix + r.Version
+}
+
+// ReleaseFromFelt decodes f's release block. Returns ok=false for a fiber
+// without a well-formed one.
+func ReleaseFromFelt(f *Felt) (*Release, bool) {
+	node, ok := f.ExtraFields[ReleaseFacetKey]
+	if !ok || node == nil || node.Kind != yaml.MappingNode {
+		return nil, false
+	}
+	var facet releaseFacet
+	if err := node.Decode(&facet); err != nil || facet.Version == "" {
+		return nil, false
+	}
+	cut, err := time.Parse(time.RFC3339, facet.Cut)
+	if err != nil {
+		return nil, false
+	}
+	return &Release{ID: f.ID, Name: f.DisplayName(), Version: facet.Version, Cut: cut}, true
+}
+
+// Releases returns every release recorded in felts, oldest cut first.
+func Releases(felts []*Felt) []*Release {
+	var out []*Release
+	for _, f := range felts {
+		if r, ok := ReleaseFromFelt(f); ok {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Cut.Equal(out[j].Cut) {
+			return out[i].Cut.Before(out[j].Cut)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// ReleaseCandidates returns the fibers a release cut after previous would
+// ship: closed since previous was cut (or ever, when previous is nil), not
+// already in a release, and not themselves release records. They are
+// ordered by close time, then id.
+func ReleaseCandidates(felts []*Felt, previous *Release) []*Felt {
+	var out []*Felt
+	for _, f := range felts {
+		if !f.IsClosed() || f.ClosedAt == nil {
+			continue
+		}
+		if previous != nil && !f.ClosedAt.After(previous.Cut) {
+			continue
+		}
+		if _, ok := ReleaseFromFelt(f); ok || hasReleaseTag(f) {
+			continue
+		}
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].ClosedAt.Equal(*out[j].ClosedAt) {
+			return out[i].ClosedAt.Before(*out[j].ClosedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+func hasReleaseTag(f *Felt) bool {
+	for _, tag := range f.Tags {
+		if strings.HasPrefix(tag, ReleaseTagPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewRelease builds the closed fiber recording version, cut at now, whose
+// body lists members with their outcomes as wikilinks back to each.
+func NewRelease(version string, now time.Time, members []*Felt) (*Felt, *Release, error) {
+	version = strings.TrimSpace(version)
+	slug := Slugify(version)
+	if slug == "" {
+		return nil, nil, fmt.Errorf("release version must contain at least one alphanumeric character")
+	}
+	r := &Release{
+		ID:      path.Join(ReleaseContainerID, slug),
+		Name:    "Release " + version,
+		Version: version,
+		Cut:     now.UTC().Truncate(time.Second),
+	}
+	f, err := New(r.ID, r.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.AddTag("release")
+	f.Status = StatusClosed
+	f.ClosedAt = &r.Cut
+	f.Outcome = fmt.Sprintf("Shipped %d %s", len(members), pluralFibers(len(members)))
+	if err := f.SetExtraField(ReleaseFacetKey, releaseFacet{Version: version, Cut: r.Cut.Format(time.RFC3339)}); err != nil {
+		return nil, nil, err
+	}
+	var body strings.Builder
+	for _, m := range members {
+		fmt.Fprintf(&body, "- [[%s]] %s", m.ID, m.DisplayName())
+		if outcome := strings.TrimSpace(m.Outcome); outcome != "" {
+			fmt.Fprintf(&body, " — %s", outcome)
+		}
+		body.WriteString("\n")
+	}
+	f.Body = body.String()
+	return f, r, nil
+}
+
+// ReleaseNotes renders r as a Keep a Changelog section: one bullet per
+// member, its outcome when it has one, else its name, with the fiber id for
+// provenance.
+func ReleaseNotes(r *Release, members []*Felt) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## [%s] - %s\n\n", strings.TrimPrefix(r.Version, "v"), r.Cut.Local().Format("2006-01-02"))
+	for _, m := range members {
+		text := strings.TrimSpace(m.Outcome)
+		if text == "" {
+			text = m.DisplayName()
+		}
+		fmt.Fprintf(&sb, "- %s (`%s`)\n", strings.Join(strings.Fields(text), " "), m.ID)
+	}
+	return sb.String()
+}
+
+func pluralFibers(n int) string {
+	if n == 1 {
+		return "fiber"
+	}
+	return "fibers"
+}
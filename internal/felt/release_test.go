@@ -0,0 +1,41 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReleaseCandidatesSincePreviousCut(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-48 * time.Hour)
+	after := now.Add(-time.Hour)
+	prev, r, err := NewRelease("v1.1", now.Add(-24*time.Hour), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != "releases/v1-1" || r.Tag() != "release:v1.1" {
+		t.Fatalf("release = %+v", r)
+	}
+	felts := []*Felt{
+		prev,
+		{ID: "old", Status: StatusClosed, ClosedAt: &before},
+		{ID: "late", Status: StatusClosed, ClosedAt: &after, Tags: []string{"release:v1.1"}},
+		{ID: "new-b", Status: StatusClosed, ClosedAt: &after},
+		{ID: "new-a", Status: StatusClosed, ClosedAt: &after},
+		{ID: "open", Status: StatusOpen},
+	}
+	releases := Releases(felts)
+	if len(releases) != 1 || releases[0].Version != "v1.1" {
+		t.Fatalf("Releases() = %+v, want [v1.1]", releases)
+	}
+	var got []string
+	for _, f := range ReleaseCandidates(felts, releases[0]) {
+		got = append(got, f.ID)
+	}
+	if len(got) != 2 || got[0] != "new-a" || got[1] != "new-b" {
+		t.Fatalf("ReleaseCandidates() = %v, want [new-a new-b]", got)
+	}
+	if n := len(ReleaseCandidates(felts, nil)); n != 3 {
+		t.Fatalf("first release would ship %d fibers, want 3", n)
+	}
+}
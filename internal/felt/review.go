@@ -0,0 +1,222 @@
+package felt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Review proposal actions.
+const (
+	ReviewClose = "close"
+	ReviewMerge = "merge"
+	ReviewDefer = "defer"
+)
+
+// ReviewProposal is one judgment call `felt review propose` batches up for a
+// human or agent: close ID with Outcome, merge ID into Into, or defer ID
+// until Until. Reason says why it was proposed. Proposals are plain JSON so
+// they can be edited or pruned before `felt review apply`.
+type ReviewProposal struct {
+	Action  string     `json:"action"`
+	ID      string     `json:"id"`
+	Into    string     `json:"into,omitempty"`
+	Outcome string     `json:"outcome,omitempty"`
+	Until   *time.Time `json:"until,omitempty"`
+	Reason  string     `json:"reason"`
+}
+
+// ReviewProposals is the proposal file format.
+type ReviewProposals struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Proposals   []ReviewProposal `json:"proposals"`
+}
+
+// ProposeReview looks over the tracked (open and active) fibers for three
+// kinds of cleanup:
+//
+//   - close a fiber whose children have all closed, drafting its outcome
+//     from theirs;
+//   - merge a fiber into an older tracked sibling with the same name;
+//   - defer a fiber untouched for staleAfter, by deferFor.
+//
+// Each fiber gets at most one proposal, in that order of preference; fibers
+// already snoozed past now are left alone.
+func ProposeReview(felts []*Felt, now time.Time, staleAfter, deferFor time.Duration) []ReviewProposal {
+	ids := sortedFeltIDs(felts)
+	byID := make(map[string]*Felt, len(felts))
+	children := make(map[string][]*Felt)
+	for _, f := range felts {
+		byID[f.ID] = f
+		if i := strings.LastIndex(f.ID, "/"); i >= 0 {
+			children[f.ID[:i]] = append(children[f.ID[:i]], f)
+		}
+	}
+
+	var out []ReviewProposal
+	proposed := make(map[string]bool)
+	tracked := func(f *Felt) bool {
+		return (f.IsOpen() || f.IsActive()) && !f.IsDeferred(now) && !proposed[f.ID]
+	}
+
+	for _, id := range ids {
+		f := byID[id]
+		if !tracked(f) || len(children[id]) == 0 {
+			continue
+		}
+		var outcomes []string
+		done := true
+		for _, c := range children[id] {
+			if !c.IsClosed() {
+				done = false
+				break
+			}
+			if c.Outcome != "" {
+				outcomes = append(outcomes, strings.TrimSpace(c.Outcome))
+			}
+		}
+		if !done {
+			continue
+		}
+		sort.Strings(outcomes)
+		proposed[id] = true
+		out = append(out, ReviewProposal{
+			Action:  ReviewClose,
+			ID:      id,
+			Outcome: strings.Join(outcomes, "; "),
+			Reason:  fmt.Sprintf("all %d children closed", len(children[id])),
+		})
+	}
+
+	// Oldest fiber of a same-named sibling group keeps the work.
+	groups := make(map[string][]*Felt)
+	for _, id := range ids {
+		f := byID[id]
+		if !tracked(f) {
+			continue
+		}
+		parent := ""
+		if i := strings.LastIndex(id, "/"); i >= 0 {
+			parent = id[:i]
+		}
+		key := parent + "\x00" + Slugify(f.DisplayName())
+		groups[key] = append(groups[key], f)
+	}
+	var keys []string
+	for key, group := range groups {
+		if len(group) > 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool { return group[i].CreatedAt.Before(group[j].CreatedAt) })
+		for _, f := range group[1:] {
+			if len(children[f.ID]) > 0 {
+				continue
+			}
+			proposed[f.ID] = true
+			out = append(out, ReviewProposal{
+				Action: ReviewMerge,
+				ID:     f.ID,
+				Into:   group[0].ID,
+				Reason: fmt.Sprintf("same name as %s", group[0].ID),
+			})
+		}
+	}
+
+	for _, id := range ids {
+		f := byID[id]
+		if !tracked(f) || f.HasShuttleFacet() {
+			continue
+		}
+		idle := now.Sub(f.RecencyAnchor())
+		if f.RecencyAnchor().IsZero() || idle <= staleAfter {
+			continue
+		}
+		until := now.Add(deferFor).UTC()
+		proposed[id] = true
+		out = append(out, ReviewProposal{
+			Action: ReviewDefer,
+			ID:     id,
+			Until:  &until,
+			Reason: fmt.Sprintf("untouched for %d days", int(idle.Hours()/24)),
+		})
+	}
+	return out
+}
+
+// ValidateReviewProposal checks p is well formed against felts, and a close
+// against cfg's status.transitions, before any proposal is applied, so a
+// bad file changes nothing.
+func ValidateReviewProposal(p ReviewProposal, felts []*Felt, cfg *Config) error {
+	find := func(id string) *Felt {
+		for _, f := range felts {
+			if f.ID == id {
+				return f
+			}
+		}
+		return nil
+	}
+	if find(p.ID) == nil {
+		return fmt.Errorf("%s %s: no such fiber", p.Action, p.ID)
+	}
+	switch p.Action {
+	case ReviewClose:
+		if f := find(p.ID); !f.IsClosed() {
+			if err := cfg.CheckStatusTransition(f.Status, StatusClosed); err != nil {
+				return fmt.Errorf("close %s: %w", p.ID, err)
+			}
+		}
+	case ReviewMerge:
+		if p.Into == "" || p.Into == p.ID {
+			return fmt.Errorf("merge %s: needs a different into fiber", p.ID)
+		}
+		if find(p.Into) == nil {
+			return fmt.Errorf("merge %s: no such fiber %s", p.ID, p.Into)
+		}
+		for _, f := range felts {
+			if strings.HasPrefix(f.ID, p.ID+"/") {
+				return fmt.Errorf("merge %s: has children; nest them elsewhere first", p.ID)
+			}
+		}
+	case ReviewDefer:
+		if p.Until == nil {
+			return fmt.Errorf("defer %s: needs until", p.ID)
+		}
+	default:
+		return fmt.Errorf("%s: unknown review action %q (want close, merge, or defer)", p.ID, p.Action)
+	}
+	return nil
+}
+
+// ApplyReviewProposal carries out one validated proposal.
+func (s *Storage) ApplyReviewProposal(p ReviewProposal, now time.Time) error {
+	switch p.Action {
+	case ReviewMerge:
+		_, err := s.Merge(p.Into, p.ID, now)
+		return err
+	case ReviewClose, ReviewDefer:
+	default:
+		return fmt.Errorf("unknown review action %q", p.Action)
+	}
+	f, err := s.Read(p.ID)
+	if err != nil {
+		return err
+	}
+	if p.Action == ReviewClose {
+		if !f.IsClosed() {
+			f.Status = StatusClosed
+			f.ClosedAt = &now
+		}
+		if p.Outcome != "" {
+			f.Outcome = p.Outcome
+		}
+	} else if err := f.SetDeferUntil(*p.Until); err != nil {
+		return err
+	}
+	f.Touch(now)
+	return s.Write(f)
+}
@@ -0,0 +1,108 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProposeReview(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-24 * time.Hour)
+	old := now.Add(-60 * 24 * time.Hour)
+	snoozed := &Felt{ID: "snoozed", Status: StatusOpen, CreatedAt: old}
+	if err := snoozed.SetDeferUntil(now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	felts := []*Felt{
+		{ID: "paper", Name: "Paper", Status: StatusActive, CreatedAt: recent},
+		{ID: "paper/intro", Status: StatusClosed, Outcome: "Intro drafted", CreatedAt: recent},
+		{ID: "paper/methods", Status: StatusClosed, Outcome: "Methods drafted", CreatedAt: recent},
+		{ID: "fit", Name: "Fit the BAO scale", Status: StatusOpen, CreatedAt: recent.Add(-time.Hour)},
+		{ID: "fit-2", Name: "Fit the BAO scale", Status: StatusOpen, CreatedAt: recent},
+		{ID: "plots", Name: "Plots", Status: StatusOpen, CreatedAt: old},
+		{ID: "notes", Name: "Notes", CreatedAt: old},
+		snoozed,
+	}
+
+	var got []string
+	for _, p := range ProposeReview(felts, now, 30*24*time.Hour, 14*24*time.Hour) {
+		line := p.Action + " " + p.ID
+		switch p.Action {
+		case ReviewClose:
+			line += ": " + p.Outcome
+		case ReviewMerge:
+			line += " into " + p.Into
+		case ReviewDefer:
+			line += " until " + p.Until.Format("2006-01-02")
+		}
+		got = append(got, line)
+	}
+	want := []string{
+		"close paper: Intro drafted; Methods drafted",
+		"merge fit-2 into fit",
+		"defer plots until 2026-06-15",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("proposals:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestApplyReviewMergeUsesMerge(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	consumer := &Felt{ID: "paper", Name: "Paper", Status: StatusOpen}
+	mustExtraField(t, consumer, "inputs", []map[string]any{{"id": "fit", "from": "fit-2.result"}})
+	for _, f := range []*Felt{
+		{ID: "fit", Name: "Fit", Status: StatusOpen, Tags: []string{"methods"}, Body: "First attempt."},
+		{ID: "fit-2", Name: "Fit", Status: StatusOpen, Tags: []string{"urgent"}, Body: "Second attempt."},
+		consumer,
+	} {
+		if err := s.Write(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := s.ApplyReviewProposal(ReviewProposal{Action: ReviewMerge, ID: "fit-2", Into: "fit"}, time.Now()); err != nil {
+		t.Fatalf("ApplyReviewProposal: %v", err)
+	}
+	if _, err := s.Read("fit-2"); err == nil {
+		t.Fatal("merged fiber still exists")
+	}
+	fit, err := s.Read("fit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fit.Body != "First attempt.\n\n## Merged from fit-2 (Fit)\n\nSecond attempt." || !fit.HasTag("urgent") {
+		t.Fatalf("merged fiber = body %q tags %v", fit.Body, fit.Tags)
+	}
+	paper, err := s.Read("paper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inputs := paper.DataFlowInputs(); len(inputs) != 1 || inputs[0].From != "fit.result" {
+		t.Fatalf("consumer inputs = %v, want repointed at fit.result", inputs)
+	}
+}
+
+func TestReviewCloseChecksStatusTransitions(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{Status: StatusConfig{Transitions: map[string][]string{"open": {"active"}}}}
+	felts := []*Felt{
+		{ID: "a", Name: "A", Status: StatusOpen},
+		{ID: "b", Name: "B", Status: StatusActive},
+	}
+	err := ValidateReviewProposal(ReviewProposal{Action: ReviewClose, ID: "a"}, felts, cfg)
+	if err == nil || !strings.Contains(err.Error(), "open → closed is not allowed") {
+		t.Fatalf("close of an open fiber: err = %v", err)
+	}
+	if err := ValidateReviewProposal(ReviewProposal{Action: ReviewClose, ID: "b"}, felts, cfg); err != nil {
+		t.Fatalf("close of an active fiber: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package felt
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RunsKey is the frontmatter list `felt run record` appends to: one entry
+// per recorded command, tying a computational result to the fiber that
+// motivated it.
+const RunsKey = "runs"
+
+// RunRecord is one `runs:` entry. Field order is the on-disk order.
+type RunRecord struct {
+	Cmd       string    `yaml:"cmd" json:"cmd"`
+	Script    string    `yaml:"script,omitempty" json:"script,omitempty"`
+	Exit      int       `yaml:"exit" json:"exit"`
+	Duration  string    `yaml:"duration" json:"duration"`
+	GitSHA    string    `yaml:"git-sha,omitempty" json:"git_sha,omitempty"`
+	StartedAt time.Time `yaml:"started-at" json:"started_at"`
+}
+
+// Runs returns f's recorded runs, oldest first.
+func (f *Felt) Runs() ([]RunRecord, error) {
+	node := extraFieldNode(f.ExtraFields, RunsKey)
+	if node == nil {
+		return nil, nil
+	}
+	var runs []RunRecord
+	if err := node.Decode(&runs); err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", f.ID, RunsKey, err)
+	}
+	return runs, nil
+}
+
+// AppendRun adds rec to the end of f's `runs:` list, leaving existing
+// entries (and anything a person added to them) byte-for-byte as they were.
+func (f *Felt) AppendRun(rec RunRecord) error {
+	var entry yaml.Node
+	if err := entry.Encode(rec); err != nil {
+		return fmt.Errorf("encode run: %w", err)
+	}
+	node := extraFieldNode(f.ExtraFields, RunsKey)
+	if node == nil {
+		return f.SetExtraField(RunsKey, []RunRecord{rec})
+	}
+	if node.Kind != yaml.SequenceNode {
+		return fmt.Errorf("%s: %s is not a list", f.ID, RunsKey)
+	}
+	node.Content = append(node.Content, &entry)
+	return nil
+}
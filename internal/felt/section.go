@@ -0,0 +1,155 @@
+package felt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BodySection locates one markdown section of a body by line range:
+// Heading is the heading line index, and [Start, End) the content lines
+// beneath it, up to the next heading of the same or a higher level.
+type BodySection struct {
+	Heading int
+	Start   int
+	End     int
+	Level   int
+}
+
+// headingLevel returns the ATX heading level of line (1–6), or 0 when line
+// is not a heading.
+func headingLevel(line string) int {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return 0
+	}
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0
+	}
+	if level < len(trimmed) && trimmed[level] != ' ' && trimmed[level] != '\t' {
+		return 0
+	}
+	return level
+}
+
+// headingText returns a heading's text without its #-markers (leading, and
+// the optional closing sequence).
+func headingText(line string) string {
+	text := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+	return strings.TrimSpace(strings.TrimRight(text, "#"))
+}
+
+// FindBodySection finds the section named by heading in lines. heading is
+// either a full heading line ("## Findings"), which must match level and
+// text, or bare text ("Findings"), which matches a heading of any level.
+// Text compares case-insensitively; headings inside fenced code are ignored.
+// The first match wins.
+func FindBodySection(lines []string, heading string) (BodySection, bool) {
+	wantLevel := headingLevel(heading)
+	wantText := strings.ToLower(strings.TrimSpace(heading))
+	if wantLevel > 0 {
+		wantText = strings.ToLower(headingText(heading))
+	}
+
+	inFence := false
+	for i, line := range lines {
+		if isFenceLine(line) {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		level := headingLevel(line)
+		if level == 0 || (wantLevel > 0 && level != wantLevel) || strings.ToLower(headingText(line)) != wantText {
+			continue
+		}
+		sec := BodySection{Heading: i, Start: i + 1, End: len(lines), Level: level}
+		fence := false
+		for j := i + 1; j < len(lines); j++ {
+			if isFenceLine(lines[j]) {
+				fence = !fence
+				continue
+			}
+			if !fence {
+				if l := headingLevel(lines[j]); l > 0 && l <= level {
+					sec.End = j
+					break
+				}
+			}
+		}
+		return sec, true
+	}
+	return BodySection{}, false
+}
+
+func isFenceLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")
+}
+
+// GetBodySection returns the content beneath heading, trimmed of surrounding
+// blank lines.
+func GetBodySection(body, heading string) (string, error) {
+	lines := strings.Split(body, "\n")
+	sec, ok := FindBodySection(lines, heading)
+	if !ok {
+		return "", fmt.Errorf("no section %q", heading)
+	}
+	return strings.Trim(strings.Join(lines[sec.Start:sec.End], "\n"), "\n"), nil
+}
+
+// SetBodySection replaces the content beneath heading with text, keeping the
+// heading line and one blank line on either side. A missing section is
+// created at the end of the body; heading must then be a full heading line
+// so its level is known.
+func SetBodySection(body, heading, text string) (string, error) {
+	return editBodySection(body, heading, func(existing string) string { return text })
+}
+
+// AppendBodySection adds text as a new paragraph at the end of the section
+// named by heading, creating the section (as SetBodySection does) if needed.
+func AppendBodySection(body, heading, text string) (string, error) {
+	return editBodySection(body, heading, func(existing string) string {
+		if strings.TrimSpace(existing) == "" {
+			return text
+		}
+		return existing + "\n\n" + text
+	})
+}
+
+func editBodySection(body, heading string, replace func(existing string) string) (string, error) {
+	var lines []string
+	if body != "" {
+		lines = strings.Split(body, "\n")
+	}
+	sec, ok := FindBodySection(lines, heading)
+	if !ok {
+		if headingLevel(heading) == 0 {
+			return "", fmt.Errorf("no section %q (pass the full heading, e.g. \"## %s\", to create it)", heading, strings.TrimSpace(heading))
+		}
+		content := strings.Trim(replace(""), "\n")
+		out := strings.TrimRight(body, "\n")
+		if out != "" {
+			out += "\n\n"
+		}
+		return out + strings.TrimSpace(heading) + "\n\n" + content, nil
+	}
+
+	existing := strings.Trim(strings.Join(lines[sec.Start:sec.End], "\n"), "\n")
+	content := strings.Trim(replace(existing), "\n")
+	var out []string
+	out = append(out, lines[:sec.Start]...)
+	out = append(out, "")
+	if content != "" {
+		out = append(out, strings.Split(content, "\n")...)
+	}
+	if sec.End < len(lines) {
+		out = append(out, "")
+		out = append(out, lines[sec.End:]...)
+	}
+	return strings.Join(out, "\n"), nil
+}
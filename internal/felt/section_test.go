@@ -0,0 +1,52 @@
+package felt
+
+import "testing"
+
+const sectionedBody = "Lede.\n\n## Motivation\n\nWhy.\n\n## Findings\n\nFirst.\n\n### Detail\n\nNested.\n\n```\n## not a heading\n```\n\n## Next\n\nAfter."
+
+func TestGetBodySectionStopsAtSameLevel(t *testing.T) {
+	got, err := GetBodySection(sectionedBody, "## Findings")
+	if err != nil {
+		t.Fatalf("GetBodySection: %v", err)
+	}
+	want := "First.\n\n### Detail\n\nNested.\n\n```\n## not a heading\n```"
+	if got != want {
+		t.Fatalf("section = %q, want %q", got, want)
+	}
+	if got, _ := GetBodySection(sectionedBody, "motivation"); got != "Why." {
+		t.Fatalf("bare-text lookup = %q, want Why.", got)
+	}
+	if _, err := GetBodySection(sectionedBody, "### Findings"); err == nil {
+		t.Fatalf("level mismatch should not match")
+	}
+}
+
+func TestSetAndAppendBodySection(t *testing.T) {
+	got, err := SetBodySection(sectionedBody, "Motivation", "Because.")
+	if err != nil {
+		t.Fatalf("SetBodySection: %v", err)
+	}
+	want := "Lede.\n\n## Motivation\n\nBecause.\n\n## Findings"
+	if got[:len(want)] != want {
+		t.Fatalf("set body = %q", got)
+	}
+
+	got, err = AppendBodySection("Lede.\n\n## Next\n\nAfter.", "## Next", "More.")
+	if err != nil {
+		t.Fatalf("AppendBodySection: %v", err)
+	}
+	if got != "Lede.\n\n## Next\n\nAfter.\n\nMore." {
+		t.Fatalf("append body = %q", got)
+	}
+
+	got, err = AppendBodySection("Lede.", "## Findings", "New.")
+	if err != nil {
+		t.Fatalf("AppendBodySection (create): %v", err)
+	}
+	if got != "Lede.\n\n## Findings\n\nNew." {
+		t.Fatalf("created section body = %q", got)
+	}
+	if _, err := SetBodySection("Lede.", "Findings", "x"); err == nil {
+		t.Fatalf("creating from bare text should ask for a full heading")
+	}
+}
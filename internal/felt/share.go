@@ -0,0 +1,98 @@
+package felt
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ShareKeyName is the secret share links are signed with,
+// `.felt/share.key`: hex-encoded random bytes, created by the first `felt
+// share`. Anyone holding it can mint links, so it never leaves the machine
+// — the store's .gitignore covers it — and replacing it revokes every link
+// signed with the old one.
+const ShareKeyName = "share.key"
+
+// ErrShareInvalid and ErrShareExpired are the reasons VerifyShare rejects
+// a link.
+var (
+	ErrShareInvalid = errors.New("invalid share link")
+	ErrShareExpired = errors.New("share link expired")
+)
+
+// Share is what a share link grants: read-only access to one fiber until
+// Expires, with its data-flow upstreams' outcomes when Upstream is set.
+type Share struct {
+	ID       string
+	Expires  time.Time
+	Upstream bool
+}
+
+// ShareKeyPath returns the path of the store's share-link key.
+func (s *Storage) ShareKeyPath() string {
+	return filepath.Join(s.root, ShareKeyName)
+}
+
+// ShareKey returns the store's share-link key, creating it first when
+// create is set and it does not exist yet. rotate replaces it, revoking
+// every link signed so far.
+func (s *Storage) ShareKey(create, rotate bool) ([]byte, error) {
+	if !rotate {
+		data, err := os.ReadFile(s.ShareKeyPath())
+		if err == nil {
+			key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+			if err != nil || len(key) == 0 {
+				return nil, fmt.Errorf("%s is not a hex key; delete it to start over", ShareKeyName)
+			}
+			return key, nil
+		}
+		if !os.IsNotExist(err) || !create {
+			return nil, fmt.Errorf("reading %s: %w", ShareKeyName, err)
+		}
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	ensureGitignoreCovers(s.root, ShareKeyName)
+	if err := os.WriteFile(s.ShareKeyPath(), []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+		return nil, fmt.Errorf("writing %s: %w", ShareKeyName, err)
+	}
+	return key, nil
+}
+
+// Sign returns the signature a link for sh carries.
+func (sh Share) Sign(key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	upstream := "0"
+	if sh.Upstream {
+		upstream = "1"
+	}
+	fmt.Fprintf(mac, "%s\n%d\n%s", sh.ID, sh.Expires.Unix(), upstream)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyShare checks a link's signature and expiry, exp being its Unix
+// expiry time.
+func VerifyShare(key []byte, id, exp string, upstream bool, sig string, now time.Time) (Share, error) {
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || len(key) == 0 {
+		return Share{}, ErrShareInvalid
+	}
+	sh := Share{ID: id, Expires: time.Unix(unix, 0).UTC(), Upstream: upstream}
+	if !hmac.Equal([]byte(sh.Sign(key)), []byte(sig)) {
+		return Share{}, ErrShareInvalid
+	}
+	if !now.Before(sh.Expires) {
+		return Share{}, ErrShareExpired
+	}
+	return sh, nil
+}
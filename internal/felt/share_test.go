@@ -0,0 +1,57 @@
+package felt
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestShareKeySignsAndVerifies(t *testing.T) {
+	storage := NewStorage(t.TempDir())
+	if err := storage.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if _, err := storage.ShareKey(false, false); err == nil {
+		t.Fatal("ShareKey without create should fail before a key exists")
+	}
+	key, err := storage.ShareKey(true, false)
+	if err != nil {
+		t.Fatalf("ShareKey: %v", err)
+	}
+	if again, err := storage.ShareKey(false, false); err != nil || string(again) != string(key) {
+		t.Fatalf("ShareKey reread = %x, %v; want %x", again, err, key)
+	}
+
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	sh := Share{ID: "decision", Expires: now.Add(time.Hour), Upstream: true}
+	sig := sh.Sign(key)
+	exp := strconv.FormatInt(sh.Expires.Unix(), 10)
+	if got, err := VerifyShare(key, "decision", exp, true, sig, now); err != nil || got.ID != "decision" || !got.Upstream {
+		t.Fatalf("VerifyShare = %+v, %v", got, err)
+	}
+	for name, check := range map[string]func() error{
+		"other fiber":  func() error { _, err := VerifyShare(key, "other", exp, true, sig, now); return err },
+		"upstream off": func() error { _, err := VerifyShare(key, "decision", exp, false, sig, now); return err },
+		"later expiry": func() error {
+			_, err := VerifyShare(key, "decision", strconv.FormatInt(sh.Expires.Unix()+60, 10), true, sig, now)
+			return err
+		},
+		"malformed expiry": func() error { _, err := VerifyShare(key, "decision", "soon", true, sig, now); return err },
+	} {
+		if err := check(); !errors.Is(err, ErrShareInvalid) {
+			t.Errorf("%s: err = %v, want ErrShareInvalid", name, err)
+		}
+	}
+	if _, err := VerifyShare(key, "decision", exp, true, sig, now.Add(2*time.Hour)); !errors.Is(err, ErrShareExpired) {
+		t.Fatalf("expired: err = %v", err)
+	}
+
+	rotated, err := storage.ShareKey(true, true)
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if _, err := VerifyShare(rotated, "decision", exp, true, sig, now); !errors.Is(err, ErrShareInvalid) {
+		t.Fatalf("link survived rotation: err = %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package felt
+
+import (
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DeferUntilKey is the frontmatter key holding a fiber's wake time, written
+// by `felt snooze`. Until then the fiber is out of the ready queue and the
+// session hook's working set. The instant is stored as RFC3339 UTC, and a
+// hand-written date (2026-05-20) wakes at local midnight.
+const DeferUntilKey = "defer-until"
+
+// DeferUntil returns f's wake time. ok is false when the field is absent or
+// unparseable.
+func (f *Felt) DeferUntil() (time.Time, bool) {
+	node := extraFieldNode(f.ExtraFields, DeferUntilKey)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return time.Time{}, false
+	}
+	value := strings.TrimSpace(node.Value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// IsDeferred reports whether f is snoozed past now.
+func (f *Felt) IsDeferred(now time.Time) bool {
+	until, ok := f.DeferUntil()
+	return ok && until.After(now)
+}
+
+// SetDeferUntil snoozes f until t; the zero time clears the snooze.
+func (f *Felt) SetDeferUntil(t time.Time) error {
+	if t.IsZero() {
+		return f.SetExtraField(DeferUntilKey, nil)
+	}
+	return f.SetExtraField(DeferUntilKey, t.UTC().Format(time.RFC3339))
+}
+
+// ParseWakeTime reads a snooze length: a span ("4h", "3d", "2w") counts from
+// now; anything ParseDateExpr accepts ("friday", "next week") wakes at
+// midnight starting that day in now's zone.
+func ParseWakeTime(s string, now time.Time) (time.Time, error) {
+	if d, err := ParseSpan(s); err == nil {
+		return now.Add(d), nil
+	}
+	date, err := ParseDateExpr(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, now.Location()), nil
+}
+
+// WakesOn reports whether f's snooze ends on now's calendar day (in now's
+// zone) — whether it has already woken today or is still to wake.
+func (f *Felt) WakesOn(now time.Time) bool {
+	until, ok := f.DeferUntil()
+	if !ok {
+		return false
+	}
+	return until.In(now.Location()).Format("2006-01-02") == now.Format("2006-01-02")
+}
@@ -0,0 +1,82 @@
+package felt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// spanUnits maps the suffixes ParseSpan accepts to their length. Days and
+// weeks are calendar-naive (24h / 168h): spans feed planning surfaces (sprint
+// length, capacity, snoozes), where "2w" means fourteen days, not a
+// DST-adjusted wall-clock interval.
+var spanUnits = map[string]time.Duration{
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+}
+
+// ParseSpan parses a human planning span such as "90m", "4h", "3d", "2w", or
+// a compound "1d4h". Fractional amounts ("1.5h") are accepted. Go's
+// time.ParseDuration is deliberately not used: it has no day or week unit,
+// which are the units planning input is actually written in.
+func ParseSpan(s string) (time.Duration, error) {
+	raw := s
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty span")
+	}
+	var total time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] == '.' || (s[i] >= '0' && s[i] <= '9')) {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return 0, fmt.Errorf("invalid span %q (use e.g. 90m, 4h, 3d, 2w)", raw)
+		}
+		amount, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid span %q: %w", raw, err)
+		}
+		unit, ok := spanUnits[s[i:i+1]]
+		if !ok {
+			return 0, fmt.Errorf("invalid span unit %q in %q (valid: m, h, d, w)", s[i:i+1], raw)
+		}
+		total += time.Duration(amount * float64(unit))
+		s = s[i+1:]
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("span %q must be positive", raw)
+	}
+	return total, nil
+}
+
+// FormatSpan renders a duration in the largest whole units ParseSpan reads
+// back: "2w", "3d", "1d4h", "45m". Sub-minute remainders are dropped.
+func FormatSpan(d time.Duration) string {
+	if d <= 0 {
+		return "0m"
+	}
+	var sb strings.Builder
+	for _, u := range []struct {
+		suffix string
+		size   time.Duration
+	}{
+		{"w", spanUnits["w"]},
+		{"d", spanUnits["d"]},
+		{"h", spanUnits["h"]},
+		{"m", spanUnits["m"]},
+	} {
+		if n := d / u.size; n > 0 {
+			fmt.Fprintf(&sb, "%d%s", n, u.suffix)
+			d -= n * u.size
+		}
+	}
+	if sb.Len() == 0 {
+		return "0m"
+	}
+	return sb.String()
+}
@@ -0,0 +1,233 @@
+package felt
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SprintFacetKey is the top-level frontmatter key that marks a fiber as a
+// sprint record. The block carries the sprint window as two local calendar
+// dates (start and the inclusive last day); membership is not stored on the
+// sprint at all — a fiber is committed to a sprint by carrying its tag.
+const SprintFacetKey = "sprint"
+
+// SprintContainerID is the parent path under which `felt sprint start` files
+// sprint fibers, one per start date.
+const SprintContainerID = "sprints"
+
+// SprintTagPrefix prefixes the membership tag a committed fiber carries:
+// `sprint:<start-date>`.
+const SprintTagPrefix = "sprint:"
+
+// sprintDateLayout is the calendar-date form the sprint block stores.
+const sprintDateLayout = "2006-01-02"
+
+// Sprint is the decoded view of a sprint fiber.
+type Sprint struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type sprintFacet struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// Tag returns the membership tag fibers committed to this sprint carry.
+func (s *Sprint) Tag() string {
+	return SprintTagPrefix + path.Base(s.ID)
+}
+
+// Contains reports whether the local calendar day of t falls inside the
+// sprint window (both ends inclusive).
+func (s *Sprint) Contains(t time.Time) bool {
+	day := t.Local().Format(sprintDateLayout)
+	return day >= s.Start && day <= s.End
+}
+
+// Ended reports whether the sprint's last day is before the local day of now.
+func (s *Sprint) Ended(now time.Time) bool {
+	return now.Local().Format(sprintDateLayout) > s.End
+}
+
+// endBoundary is the instant the sprint stops accepting completions: local
+// midnight after its inclusive last day.
+func (s *Sprint) endBoundary() time.Time {
+	end, err := time.ParseInLocation(sprintDateLayout, s.End, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return end.AddDate(0, 0, 1)
+}
+
+// Days returns the sprint length in calendar days and, for a sprint in
+// progress, which day now falls on (0 when now is outside the window).
+func (s *Sprint) Days(now time.Time) (length, current int) {
+	start, err1 := time.ParseInLocation(sprintDateLayout, s.Start, time.Local)
+	end, err2 := time.ParseInLocation(sprintDateLayout, s.End, time.Local)
+	if err1 != nil || err2 != nil {
+		return 0, 0
+	}
+	length = int(end.Sub(start).Hours()/24+0.5) + 1
+	if s.Contains(now) {
+		today, _ := time.ParseInLocation(sprintDateLayout, now.Local().Format(sprintDateLayout), time.Local)
+		current = int(today.Sub(start).Hours()/24+0.5) + 1
+	}
+	return length, current
+}
+
+// SprintFromFelt decodes f's sprint block. Returns ok=false for a fiber
+// without a well-formed one.
+func SprintFromFelt(f *Felt) (*Sprint, bool) {
+	node, ok := f.ExtraFields[SprintFacetKey]
+	if !ok || node == nil || node.Kind != yaml.MappingNode {
+		return nil, false
+	}
+	var facet sprintFacet
+	if err := node.Decode(&facet); err != nil {
+		return nil, false
+	}
+	if _, err := time.Parse(sprintDateLayout, facet.Start); err != nil {
+		return nil, false
+	}
+	if _, err := time.Parse(sprintDateLayout, facet.End); err != nil {
+		return nil, false
+	}
+	return &Sprint{ID: f.ID, Name: f.DisplayName(), Start: facet.Start, End: facet.End}, true
+}
+
+// NewSprint builds the fiber recording a sprint that starts on the local day
+// of start and runs for span (rounded up to whole days, minimum one).
+func NewSprint(start time.Time, span time.Duration) (*Felt, *Sprint, error) {
+	days := int((span + 24*time.Hour - 1) / (24 * time.Hour))
+	if days < 1 {
+		return nil, nil, fmt.Errorf("sprint span must be at least one day")
+	}
+	local := start.Local()
+	first := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	last := first.AddDate(0, 0, days-1)
+	sp := &Sprint{
+		ID:    path.Join(SprintContainerID, first.Format(sprintDateLayout)),
+		Start: first.Format(sprintDateLayout),
+		End:   last.Format(sprintDateLayout),
+	}
+	sp.Name = fmt.Sprintf("Sprint %s → %s", sp.Start, sp.End)
+
+	f, err := New(sp.ID, sp.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.AddTag("sprint")
+	if err := f.SetExtraField(SprintFacetKey, sprintFacet{Start: sp.Start, End: sp.End}); err != nil {
+		return nil, nil, err
+	}
+	return f, sp, nil
+}
+
+// Sprints returns every sprint recorded in felts, oldest start first.
+func Sprints(felts []*Felt) []*Sprint {
+	var out []*Sprint
+	for _, f := range felts {
+		if sp, ok := SprintFromFelt(f); ok {
+			out = append(out, sp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Start != out[j].Start {
+			return out[i].Start < out[j].Start
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out
+}
+
+// CurrentSprint returns the sprint whose window contains now, preferring the
+// latest start when windows overlap. Returns nil when no sprint is running.
+func CurrentSprint(sprints []*Sprint, now time.Time) *Sprint {
+	for i := len(sprints) - 1; i >= 0; i-- {
+		if sprints[i].Contains(now) {
+			return sprints[i]
+		}
+	}
+	return nil
+}
+
+// LatestSprint returns the sprint with the latest start on or before now —
+// the running sprint if any, else the one that most recently ended.
+func LatestSprint(sprints []*Sprint, now time.Time) *Sprint {
+	today := now.Local().Format(sprintDateLayout)
+	for i := len(sprints) - 1; i >= 0; i-- {
+		if sprints[i].Start <= today {
+			return sprints[i]
+		}
+	}
+	return nil
+}
+
+// SprintReport is committed-vs-completed for one sprint. A member is completed
+// when it closed before the sprint's end boundary; every other member is
+// remaining work while the sprint runs and spillover once it has ended.
+type SprintReport struct {
+	Sprint    *Sprint  `json:"sprint"`
+	Ended     bool     `json:"ended"`
+	Committed []*Felt  `json:"committed"`
+	Completed []*Felt  `json:"completed"`
+	Remaining []*Felt  `json:"remaining"`
+	CarriedTo string   `json:"carried_to,omitempty"`
+	Carried   []string `json:"carried,omitempty"`
+}
+
+// BuildSprintReport partitions the sprint's members. next, when non-nil, is
+// the following sprint: remaining members already tagged into it are listed
+// as carried over.
+func BuildSprintReport(sp *Sprint, felts []*Felt, next *Sprint, now time.Time) *SprintReport {
+	report := &SprintReport{Sprint: sp, Ended: sp.Ended(now)}
+	boundary := sp.endBoundary()
+	for _, f := range felts {
+		if f.ID == sp.ID || !f.HasTag(sp.Tag()) {
+			continue
+		}
+		report.Committed = append(report.Committed, f)
+		if f.IsClosed() && f.ClosedAt != nil && f.ClosedAt.Before(boundary) {
+			report.Completed = append(report.Completed, f)
+			continue
+		}
+		report.Remaining = append(report.Remaining, f)
+		if next != nil && f.HasTag(next.Tag()) {
+			report.Carried = append(report.Carried, f.ID)
+		}
+	}
+	if next != nil && len(report.Carried) > 0 {
+		report.CarriedTo = next.ID
+	}
+	for _, group := range [][]*Felt{report.Committed, report.Completed, report.Remaining} {
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+	}
+	return report
+}
+
+// SprintSpillover returns the members of sp that have not closed, which
+// `felt sprint start` carries into the sprint that follows.
+func SprintSpillover(sp *Sprint, felts []*Felt) []*Felt {
+	var out []*Felt
+	for _, f := range felts {
+		if f.ID == sp.ID || !f.HasTag(sp.Tag()) || f.IsClosed() {
+			continue
+		}
+		out = append(out, f)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// IsSprintTag reports whether tag is a sprint membership tag.
+func IsSprintTag(tag string) bool {
+	return strings.HasPrefix(tag, SprintTagPrefix)
+}
@@ -0,0 +1,115 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSpan(t *testing.T) {
+	cases := map[string]time.Duration{
+		"90m":  90 * time.Minute,
+		"4h":   4 * time.Hour,
+		"1.5h": 90 * time.Minute,
+		"3d":   72 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"1d4h": 28 * time.Hour,
+		" 2W ": 14 * 24 * time.Hour,
+	}
+	for in, want := range cases {
+		got, err := ParseSpan(in)
+		if err != nil {
+			t.Fatalf("ParseSpan(%q): %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseSpan(%q) = %v, want %v", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "2", "w", "3y", "0h", "1h30"} {
+		if _, err := ParseSpan(bad); err == nil {
+			t.Fatalf("ParseSpan(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestFormatSpanRoundTrips(t *testing.T) {
+	for _, in := range []string{"2w", "3d", "1d4h", "45m", "1w3d"} {
+		d, err := ParseSpan(in)
+		if err != nil {
+			t.Fatalf("ParseSpan(%q): %v", in, err)
+		}
+		if got := FormatSpan(d); got != in {
+			t.Fatalf("FormatSpan(%v) = %q, want %q", d, got, in)
+		}
+	}
+}
+
+func TestNewSprintWindow(t *testing.T) {
+	start := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local)
+	f, sp, err := NewSprint(start, 14*24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewSprint: %v", err)
+	}
+	if sp.ID != "sprints/2026-10-14" || sp.Start != "2026-10-14" || sp.End != "2026-10-27" {
+		t.Fatalf("sprint = %+v, want sprints/2026-10-14 spanning 2026-10-14..2026-10-27", sp)
+	}
+	if sp.Tag() != "sprint:2026-10-14" {
+		t.Fatalf("Tag() = %q", sp.Tag())
+	}
+
+	// The fiber is the record: decoding it back yields the same window.
+	decoded, ok := SprintFromFelt(f)
+	if !ok || *decoded != *sp {
+		t.Fatalf("SprintFromFelt = %+v, %v; want %+v", decoded, ok, sp)
+	}
+
+	if _, _, err := NewSprint(start, 4*time.Hour); err != nil {
+		t.Fatalf("sub-day span should round up to one day: %v", err)
+	}
+}
+
+func TestBuildSprintReportPartitionsMembers(t *testing.T) {
+	sp := &Sprint{ID: "sprints/2026-10-01", Start: "2026-10-01", End: "2026-10-14"}
+	next := &Sprint{ID: "sprints/2026-10-15", Start: "2026-10-15", End: "2026-10-28"}
+	inWindow := time.Date(2026, 10, 10, 12, 0, 0, 0, time.Local)
+	afterWindow := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+
+	felts := []*Felt{
+		{ID: "done", Status: StatusClosed, ClosedAt: &inWindow, Tags: []string{sp.Tag()}},
+		{ID: "late", Status: StatusClosed, ClosedAt: &afterWindow, Tags: []string{sp.Tag()}},
+		{ID: "carried", Status: StatusActive, Tags: []string{sp.Tag(), next.Tag()}},
+		{ID: "left", Status: StatusOpen, Tags: []string{sp.Tag()}},
+		{ID: "other", Status: StatusOpen},
+	}
+
+	report := BuildSprintReport(sp, felts, next, afterWindow)
+	if !report.Ended {
+		t.Fatalf("report.Ended = false after the window")
+	}
+	if got := ids(report.Committed); got != "carried,done,late,left" {
+		t.Fatalf("committed = %s", got)
+	}
+	if got := ids(report.Completed); got != "done" {
+		t.Fatalf("completed = %s, want only the fiber closed inside the window", got)
+	}
+	if got := ids(report.Remaining); got != "carried,late,left" {
+		t.Fatalf("remaining = %s", got)
+	}
+	if report.CarriedTo != next.ID || len(report.Carried) != 1 || report.Carried[0] != "carried" {
+		t.Fatalf("carried = %v to %q", report.Carried, report.CarriedTo)
+	}
+
+	if got := ids(SprintSpillover(sp, felts)); got != "carried,left" {
+		t.Fatalf("spillover = %s, want the unclosed members", got)
+	}
+}
+
+func ids(felts []*Felt) string {
+	out := ""
+	for i, f := range felts {
+		if i > 0 {
+			out += ","
+		}
+		out += f.ID
+	}
+	return out
+}
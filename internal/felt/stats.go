@@ -0,0 +1,191 @@
+package felt
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StatusCounts tallies fibers by status; Untracked counts statusless notes.
+type StatusCounts struct {
+	Total     int `json:"total"`
+	Open      int `json:"open"`
+	Active    int `json:"active"`
+	Closed    int `json:"closed"`
+	Untracked int `json:"untracked"`
+}
+
+// CountStatuses tallies felts by status.
+func CountStatuses(felts []*Felt) StatusCounts {
+	var c StatusCounts
+	for _, f := range felts {
+		c.Total++
+		switch {
+		case f.IsOpen():
+			c.Open++
+		case f.IsActive():
+			c.Active++
+		case f.IsClosed():
+			c.Closed++
+		default:
+			c.Untracked++
+		}
+	}
+	return c
+}
+
+// HealthStaleAfter is how long a tracked fiber may go untouched (by
+// updated-at, else created-at) before the health score counts it stale.
+const HealthStaleAfter = 30 * 24 * time.Hour
+
+// HealthComponent is one scored dimension of project health. Score runs
+// 0–100; Detail is the measured value behind it and Explanation says what
+// the component rewards.
+type HealthComponent struct {
+	Name        string `json:"name"`
+	Score       int    `json:"score"`
+	Detail      string `json:"detail"`
+	Explanation string `json:"explanation"`
+}
+
+// Health is the composite project health: the mean of its components.
+type Health struct {
+	Score      int               `json:"score"`
+	Components []HealthComponent `json:"components"`
+}
+
+// ProjectHealth scores the store on four components — ready vs blocked open
+// work, stale tracked fibers, active WIP against wip.limit, and data-flow
+// cycles — each 0–100, averaged into one number for weekly review. Every
+// component of an empty store scores 100: nothing is wrong with it.
+func ProjectHealth(felts []*Felt, cfg *Config, now time.Time) *Health {
+	counts := CountStatuses(felts)
+	h := &Health{}
+
+	ready := len(ReadyFelts(felts, now))
+	blocked := counts.Open - ready
+	h.Components = append(h.Components, HealthComponent{
+		Name:        "ready",
+		Score:       ratioScore(ready, counts.Open),
+		Detail:      fmt.Sprintf("%d ready, %d blocked", ready, blocked),
+		Explanation: "share of open fibers whose data-flow inputs have all closed",
+	})
+
+	tracked, stale := 0, 0
+	for _, f := range felts {
+		if !f.IsOpen() && !f.IsActive() {
+			continue
+		}
+		tracked++
+		if now.Sub(f.RecencyAnchor()) > HealthStaleAfter {
+			stale++
+		}
+	}
+	days := int(HealthStaleAfter / (24 * time.Hour))
+	h.Components = append(h.Components, HealthComponent{
+		Name:        "stale",
+		Score:       ratioScore(tracked-stale, tracked),
+		Detail:      fmt.Sprintf("%d of %d tracked untouched in %d days", stale, tracked, days),
+		Explanation: "share of open and active fibers touched recently",
+	})
+
+	limit := cfg.WIPLimit()
+	wipScore := 100
+	if counts.Active > limit {
+		wipScore = int(math.Round(100 * float64(limit) / float64(counts.Active)))
+	}
+	h.Components = append(h.Components, HealthComponent{
+		Name:        "wip",
+		Score:       wipScore,
+		Detail:      fmt.Sprintf("%d active, limit %d", counts.Active, limit),
+		Explanation: "active fibers within wip.limit; each one over dilutes focus",
+	})
+
+	cycles := DataFlowCycles(felts)
+	cycleDetail := "none"
+	if len(cycles) > 0 {
+		cycleDetail = fmt.Sprintf("%d, e.g. %s", len(cycles), strings.Join(cycles[0], ", "))
+	}
+	h.Components = append(h.Components, HealthComponent{
+		Name:        "cycles",
+		Score:       int(math.Round(100 / float64(1+len(cycles)))),
+		Detail:      cycleDetail,
+		Explanation: "inputs[].from loops; a fiber inside one waits on itself and is never ready",
+	})
+
+	total := 0
+	for _, c := range h.Components {
+		total += c.Score
+	}
+	h.Score = int(math.Round(float64(total) / float64(len(h.Components))))
+	return h
+}
+
+func ratioScore(good, of int) int {
+	if of == 0 {
+		return 100
+	}
+	return int(math.Round(100 * float64(good) / float64(of)))
+}
+
+// DataFlowCycles returns each data-flow loop among felts — a strongly
+// connected component of the inputs[].from graph with more than one fiber —
+// as sorted ids. Loops are ordered by their first id. DataFlowUpstreams
+// already drops a fiber's references to itself.
+func DataFlowCycles(felts []*Felt) [][]string {
+	upstreams := DataFlowUpstreams(felts)
+	ids := make([]string, 0, len(upstreams))
+	for id := range upstreams {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Tarjan's algorithm over the upstream edges.
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var cycles [][]string
+	next := 0
+	var visit func(id string)
+	visit = func(id string) {
+		index[id], low[id] = next, next
+		next++
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, up := range upstreams[id] {
+			if _, seen := index[up]; !seen {
+				visit(up)
+				low[id] = min(low[id], low[up])
+			} else if onStack[up] {
+				low[id] = min(low[id], index[up])
+			}
+		}
+		if low[id] != index[id] {
+			return
+		}
+		var component []string
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+	for _, id := range ids {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
@@ -0,0 +1,46 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDataFlowCyclesFindsLoops(t *testing.T) {
+	a, b, c, d := &Felt{ID: "a", Name: "A"}, &Felt{ID: "b", Name: "B"}, &Felt{ID: "c", Name: "C"}, &Felt{ID: "d", Name: "D"}
+	mustExtraField(t, a, "inputs", []map[string]any{{"id": "x", "from": "b"}})
+	mustExtraField(t, b, "inputs", []map[string]any{{"id": "x", "from": "a"}})
+	mustExtraField(t, c, "inputs", []map[string]any{{"id": "x", "from": "a"}})
+	mustExtraField(t, d, "inputs", []map[string]any{{"id": "x", "from": "d"}})
+
+	cycles := DataFlowCycles([]*Felt{a, b, c, d})
+	if len(cycles) != 1 || strings.Join(cycles[0], ",") != "a,b" {
+		t.Fatalf("cycles = %v, want [[a b]]", cycles)
+	}
+}
+
+func TestProjectHealthComponents(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	fresh, old := now.AddDate(0, 0, -1), now.AddDate(0, -2, 0)
+	up := &Felt{ID: "up", Name: "Up", Status: StatusActive, CreatedAt: fresh}
+	down := &Felt{ID: "down", Name: "Down", Status: StatusOpen, CreatedAt: old}
+	mustExtraField(t, down, "inputs", []map[string]any{{"id": "x", "from": "up"}})
+	free := &Felt{ID: "free", Name: "Free", Status: StatusOpen, CreatedAt: fresh}
+
+	h := ProjectHealth([]*Felt{up, down, free}, &Config{WIP: WIPConfig{Limit: 1}}, now)
+	scores := map[string]int{}
+	for _, c := range h.Components {
+		scores[c.Name] = c.Score
+	}
+	// ready: 1 of 2 open; stale: 1 of 3 tracked; wip: at limit; no cycles.
+	if scores["ready"] != 50 || scores["stale"] != 67 || scores["wip"] != 100 || scores["cycles"] != 100 {
+		t.Fatalf("component scores = %v", scores)
+	}
+	if h.Score != 79 {
+		t.Fatalf("composite = %d, want 79", h.Score)
+	}
+
+	if empty := ProjectHealth(nil, &Config{}, now); empty.Score != 100 {
+		t.Fatalf("empty store score = %d, want 100", empty.Score)
+	}
+}
@@ -1,6 +1,7 @@
 package felt
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -12,6 +13,7 @@ import (
 	"sort"
 	"strings"
 	"syscall"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -37,13 +39,20 @@ site:
   template: article-theme
 `
 
-const defaultGitignore = `# Generated by felt — local fiber-write locks
+const defaultGitignore = `# Generated by felt — local fiber-write locks, read log, metadata index, transaction staging, session mark, and hook logs
 *.md.lock
+access.log
+index.json
+.tx-*/
+session-mark
+hook-debug.log
+hook-log/
 `
 
 // Storage handles reading and writing felt files.
 type Storage struct {
-	root string // Path to .felt directory
+	root    string    // Path to .felt directory
+	patches io.Writer // when set, fiber file changes are printed here instead of made; see EmitPatchEnv
 }
 
 type fiberFile struct {
@@ -63,6 +72,11 @@ type MigrationResult struct {
 	TitleToNameIDs        []string
 	RemovedDependsOnIDs   []string
 	StrippedMystAnchorIDs []string
+	// CaseEntries are fibers renamed into canonical (lower) case;
+	// CaseCollisionIDs the ones left alone because the lowercase id is
+	// another fiber's.
+	CaseEntries      []MigrationEntry
+	CaseCollisionIDs []string
 }
 
 type IdentityBackfillResult struct {
@@ -72,9 +86,13 @@ type IdentityBackfillResult struct {
 // NewStorage creates a storage instance for the given directory.
 // The directory should be the project root (containing .felt/).
 func NewStorage(projectRoot string) *Storage {
-	return &Storage{
+	s := &Storage{
 		root: filepath.Join(projectRoot, DirName),
 	}
+	if os.Getenv(EmitPatchEnv) != "" {
+		s.patches = os.Stderr
+	}
+	return s
 }
 
 // ProjectRoot returns the project directory that owns this .felt store.
@@ -175,22 +193,58 @@ func (s *Storage) CheckAvailableID(id string) error {
 	return nil
 }
 
+// AvailableID returns base when no fiber lives there, else the first free
+// disambiguation of it (base-2, base-3, …).
+func (s *Storage) AvailableID(base string) (string, error) {
+	return s.nextAvailableMigrationID(base, nil)
+}
+
+// CheckAvailableUID returns an error if another fiber already carries the
+// intrinsic id uid (compared case-insensitively, as lookups are).
+func (s *Storage) CheckAvailableUID(uid string) error {
+	felts, err := s.ListMetadata()
+	if err != nil {
+		return err
+	}
+	for _, f := range felts {
+		if f.MatchesUID(uid) {
+			return fmt.Errorf("id %s is already used by %s", uid, f.ID)
+		}
+	}
+	return nil
+}
+
 // Write saves a felt to disk.
 func (s *Storage) Write(f *Felt) error {
 	if f == nil {
 		return fmt.Errorf("cannot write nil felt")
 	}
-	data, err := f.Marshal()
+	path := s.Path(f.ID)
+	writes, err := fiberFileWrites(f, path)
 	if err != nil {
 		return err
 	}
-
-	path := s.Path(f.ID)
+	if err := s.refuseCaseCollision(path, f.ID); err != nil {
+		return err
+	}
+	if s.patches != nil {
+		for _, w := range writes {
+			if err := s.emitPatch(w.path, w.data, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory %s: %w", filepath.Dir(path), err)
 	}
-	if err := os.WriteFile(path, data, 0644); err != nil {
-		return fmt.Errorf("writing file %s: %w", path, err)
+	for _, w := range writes {
+		if err := os.WriteFile(w.path, w.data, 0644); err != nil {
+			if w.path != path {
+				return fmt.Errorf("writing body file %s: %w", w.path, err)
+			}
+			return fmt.Errorf("writing file %s: %w", path, err)
+		}
 	}
 	return nil
 }
@@ -261,6 +315,11 @@ func (s *Storage) readPathWithMode(path, id string, mode ParseMode) (*Felt, erro
 	if err != nil {
 		return nil, err
 	}
+	if mode == ParseFull {
+		if err := loadBodyFile(f, path); err != nil {
+			return nil, err
+		}
+	}
 	// Carry the on-disk path so consumers read it instead of
 	// reconstructing it from the id.
 	f.Path = carriedPath
@@ -270,6 +329,18 @@ func (s *Storage) readPathWithMode(path, id string, mode ParseMode) (*Felt, erro
 // Delete removes a felt from disk.
 func (s *Storage) Delete(id string) error {
 	path := s.Path(id)
+	if s.patches != nil {
+		return s.emitDeletePatch(id, path)
+	}
+	// An externalized body goes with its fiber; a malformed pointer is left
+	// alone rather than blocking the delete.
+	if f, err := readMetadataFile(path, id); err == nil {
+		if bodyPath, ok, err := bodyFilePath(f, path); err == nil && ok {
+			if err := os.Remove(bodyPath); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("deleting body file %s: %w", bodyPath, err)
+			}
+		}
+	}
 	if err := os.Remove(path); err != nil {
 		return fmt.Errorf("deleting file %s: %w", path, err)
 	}
@@ -296,6 +367,9 @@ func (s *Storage) pruneEmptyDirs(startDir string) error {
 // MoveSubtree moves a fiber and any nested descendants to a new path, rewriting
 // data-flow references across the repository.
 func (s *Storage) MoveSubtree(oldID, newID string) error {
+	if err := s.refuseUnpatchable("moving fibers"); err != nil {
+		return err
+	}
 	oldID = filepath.ToSlash(filepath.Clean(strings.TrimSpace(oldID)))
 	newID = filepath.ToSlash(filepath.Clean(strings.TrimSpace(newID)))
 	if oldID == "." || oldID == "" || newID == "." || newID == "" {
@@ -307,10 +381,17 @@ func (s *Storage) MoveSubtree(oldID, newID string) error {
 	if strings.HasPrefix(newID, oldID+"/") {
 		return fmt.Errorf("cannot move %s into its own subtree %s", oldID, newID)
 	}
-	if _, err := os.Stat(s.Path(newID)); err == nil {
-		return fmt.Errorf("destination %s already exists", newID)
-	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("checking destination %s: %w", newID, err)
+	oldRoot := filepath.Join(s.root, filepath.FromSlash(oldID))
+	newRoot := filepath.Join(s.root, filepath.FromSlash(newID))
+	// A case-only rename on a case-insensitive filesystem finds its own
+	// source at the destination; that is not a clash.
+	caseOnly := strings.EqualFold(oldID, newID)
+	if !caseOnly || distinctEntryExists(newRoot, oldRoot) {
+		if _, err := os.Stat(s.Path(newID)); err == nil {
+			return fmt.Errorf("destination %s already exists", newID)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("checking destination %s: %w", newID, err)
+		}
 	}
 
 	felts, err := s.List()
@@ -336,19 +417,43 @@ func (s *Storage) MoveSubtree(oldID, newID string) error {
 		return fmt.Errorf("no felt found at %s", oldID)
 	}
 
-	oldRoot := filepath.Join(s.root, filepath.FromSlash(oldID))
-	newRoot := filepath.Join(s.root, filepath.FromSlash(newID))
-	if _, err := os.Stat(newRoot); err == nil {
-		return fmt.Errorf("destination %s already exists", newID)
-	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("checking destination directory %s: %w", newID, err)
+	if !caseOnly || distinctEntryExists(newRoot, oldRoot) {
+		if _, err := os.Stat(newRoot); err == nil {
+			return fmt.Errorf("destination %s already exists", newID)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("checking destination directory %s: %w", newID, err)
+		}
 	}
 	if err := os.MkdirAll(filepath.Dir(newRoot), 0755); err != nil {
 		return fmt.Errorf("creating destination parent %s: %w", filepath.Dir(newRoot), err)
 	}
-	if err := os.Rename(oldRoot, newRoot); err != nil {
+	// A case-only rename goes through a temporary name, since some
+	// case-insensitive filesystems treat renaming a directory onto itself as
+	// a no-op.
+	from := oldRoot
+	if caseOnly {
+		from = oldRoot + ".case-rename"
+		if err := os.Rename(oldRoot, from); err != nil {
+			return fmt.Errorf("moving subtree %s -> %s: %w", oldRoot, from, err)
+		}
+	}
+	if err := os.Rename(from, newRoot); err != nil {
 		return fmt.Errorf("moving subtree %s -> %s: %w", oldRoot, newRoot, err)
 	}
+	// The fiber file is named for its directory, so a case-only rename
+	// renames it too, by the same detour.
+	if oldBase, newBase := path.Base(oldID), path.Base(newID); caseOnly && oldBase != newBase {
+		oldFile := filepath.Join(newRoot, oldBase+FileExt)
+		if _, err := os.Stat(oldFile); err == nil {
+			tmp := oldFile + ".case-rename"
+			if err := os.Rename(oldFile, tmp); err != nil {
+				return fmt.Errorf("renaming %s: %w", oldFile, err)
+			}
+			if err := os.Rename(tmp, filepath.Join(newRoot, newBase+FileExt)); err != nil {
+				return fmt.Errorf("renaming %s: %w", oldFile, err)
+			}
+		}
+	}
 
 	for _, f := range updated {
 		if err := s.Write(f); err != nil {
@@ -360,7 +465,8 @@ func (s *Storage) MoveSubtree(oldID, newID string) error {
 
 // Migrate performs the storage-model normalization pass:
 // flat-file fibers become directory fibers, legacy frontmatter `title` fields
-// become `name`, and leading MyST anchor lines are stripped from bodies.
+// become `name`, leading MyST anchor lines are stripped from bodies, and ids
+// are lowercased into canonical case.
 func (s *Storage) Migrate(dryRun bool) (*MigrationResult, error) {
 	result, err := s.MigrateFlatFiles(dryRun)
 	if err != nil {
@@ -374,12 +480,19 @@ func (s *Storage) Migrate(dryRun bool) (*MigrationResult, error) {
 	result.TitleToNameIDs = titleIDs
 	result.RemovedDependsOnIDs = dependsOnIDs
 	result.StrippedMystAnchorIDs = anchorIDs
+
+	if result.CaseEntries, result.CaseCollisionIDs, err = s.NormalizeIDCase(dryRun); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
 // MigrateFlatFiles converts legacy top-level flat markdown fibers to
 // directory-based fibers with slug IDs, rewriting data-flow references.
 func (s *Storage) MigrateFlatFiles(dryRun bool) (*MigrationResult, error) {
+	if err := s.refuseUnpatchable("migrating flat files"); !dryRun && err != nil {
+		return nil, err
+	}
 	if err := s.Init(); err != nil {
 		return nil, err
 	}
@@ -432,9 +545,17 @@ func (s *Storage) MigrateFlatFiles(dryRun bool) (*MigrationResult, error) {
 		return result, nil
 	}
 
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
 	used := map[string]struct{}{}
 	for _, f := range legacy {
-		baseID, err := GenerateID(f.felt.DisplayName())
+		title := f.felt.DisplayName()
+		if cfg.Slug.Transliterate {
+			title = Transliterate(title)
+		}
+		baseID, err := GenerateID(title)
 		if err != nil {
 			return nil, fmt.Errorf("generate slug for %s: %w", f.oldID, err)
 		}
@@ -520,6 +641,9 @@ func (s *Storage) MigrateFlatFiles(dryRun bool) (*MigrationResult, error) {
 // NormalizeFiberFiles rewrites legacy per-file format details in-place:
 // frontmatter `title` -> `name`, and leading MyST anchor lines in bodies.
 func (s *Storage) NormalizeFiberFiles(dryRun bool) ([]string, []string, []string, error) {
+	if err := s.refuseUnpatchable("normalizing fiber files"); !dryRun && err != nil {
+		return nil, nil, nil, err
+	}
 	files, err := s.listFiberFiles()
 	if err != nil {
 		return nil, nil, nil, err
@@ -568,6 +692,9 @@ func (s *Storage) NormalizeFiberFiles(dryRun bool) ([]string, []string, []string
 // This is intentionally separate from Migrate: replicas must not run it
 // casually, or the same git-synced fiber can split into multiple identities.
 func (s *Storage) BackfillIntrinsicIDs(dryRun bool) (*IdentityBackfillResult, error) {
+	if err := s.refuseUnpatchable("backfilling intrinsic ids"); !dryRun && err != nil {
+		return nil, err
+	}
 	files, err := s.listFiberFiles()
 	if err != nil {
 		return nil, err
@@ -579,7 +706,7 @@ func (s *Storage) BackfillIntrinsicIDs(dryRun bool) (*IdentityBackfillResult, er
 		if err != nil {
 			return nil, fmt.Errorf("reading fiber %s: %w", file.path, err)
 		}
-		rewritten, changed, err := backfillIntrinsicID(data)
+		rewritten, changed, err := backfillIntrinsicID(file.id, data)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "warning: failed to backfill %s: %v\n", file.path, err)
 			continue
@@ -630,13 +757,13 @@ func normalizeFiberFile(id string, content []byte) ([]byte, bool, bool, bool, bo
 	return out.Bytes(), renamedTitle, removedDependsOn, strippedAnchor, true, nil
 }
 
-func backfillIntrinsicID(content []byte) ([]byte, bool, error) {
+func backfillIntrinsicID(id string, content []byte) ([]byte, bool, error) {
 	frontmatter, body, err := splitFrontmatter(content, true)
 	if err != nil {
 		return nil, false, err
 	}
 
-	rewrittenFrontmatter, changed, err := backfillIntrinsicIDFrontmatter(frontmatter)
+	rewrittenFrontmatter, changed, err := backfillIntrinsicIDFrontmatter(id, frontmatter)
 	if err != nil {
 		return nil, false, err
 	}
@@ -654,7 +781,7 @@ func backfillIntrinsicID(content []byte) ([]byte, bool, error) {
 	return out.Bytes(), true, nil
 }
 
-func backfillIntrinsicIDFrontmatter(frontmatter []byte) ([]byte, bool, error) {
+func backfillIntrinsicIDFrontmatter(id string, frontmatter []byte) ([]byte, bool, error) {
 	var node yaml.Node
 	if err := yaml.Unmarshal(frontmatter, &node); err != nil {
 		return nil, false, fmt.Errorf("parsing YAML frontmatter: %w", err)
@@ -675,7 +802,7 @@ func backfillIntrinsicIDFrontmatter(frontmatter []byte) ([]byte, bool, error) {
 	}
 
 	idKey := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "id"}
-	idValue := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: NewULID()}
+	idValue := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: NewUID(id)}
 	mapping.Content = append([]*yaml.Node{idKey, idValue}, mapping.Content...)
 
 	rewritten, err := yaml.Marshal(mapping)
@@ -690,7 +817,9 @@ func (s *Storage) List() ([]*Felt, error) {
 	return s.listWithMode(ParseFull, false)
 }
 
-// ListMetadata returns all felts with frontmatter only.
+// ListMetadata returns all felts with frontmatter only. Each file is read
+// only up to its closing ---, or not at all when the index already holds
+// it, so a store with huge bodies lists at the cost of its headers.
 func (s *Storage) ListMetadata() ([]*Felt, error) {
 	return s.listWithMode(ParseMetadataOnly, false)
 }
@@ -726,24 +855,17 @@ func (s *Storage) listWithModeHavingFrontmatterFields(mode ParseMode, includeMod
 		return nil, err
 	}
 
+	if len(fields) == 0 || mode != ParseMetadataOnly {
+		fields = nil
+	}
+	idx := s.loadIndex()
+	fresh := &fiberIndex{Version: indexVersion, Entries: make(map[string]indexEntry, len(files))}
+	now := time.Now()
+
 	var felts []*Felt
 	evicted := 0 // iCloud-dataless files that couldn't be materialized during the walk
 	for _, file := range files {
-		if len(fields) > 0 && mode == ParseMetadataOnly {
-			matched, err := fileFrontmatterHasTopLevelFields(file.path, fields)
-			if err != nil {
-				if isEvictedFileError(err) {
-					evicted++
-				} else {
-					fmt.Fprintf(os.Stderr, "warning: failed to parse %s: %v\n", file.path, err)
-				}
-				continue
-			}
-			if !matched {
-				continue
-			}
-		}
-		f, err := s.readPathWithMode(file.path, file.id, mode)
+		f, info, err := s.readIndexed(idx, fresh, file, mode, fields, now)
 		if err != nil {
 			if isEvictedFileError(err) {
 				evicted++
@@ -752,10 +874,11 @@ func (s *Storage) listWithModeHavingFrontmatterFields(mode ParseMode, includeMod
 			}
 			continue
 		}
+		if f == nil {
+			continue
+		}
 		if includeModTime {
-			if info, err := os.Stat(file.path); err == nil {
-				f.ModifiedAt = info.ModTime()
-			}
+			f.ModifiedAt = info.ModTime()
 		}
 		f.EntryPoint = file.entryPoint
 		f.ReportPath = file.reportPath
@@ -765,10 +888,42 @@ func (s *Storage) listWithModeHavingFrontmatterFields(mode ParseMode, includeMod
 	if evicted > 0 {
 		fmt.Fprintf(os.Stderr, "warning: %d file(s) not materialized (iCloud) under %s; run `brctl download` or open them in Files to hydrate\n", evicted, s.root)
 	}
+	if fresh.dirty || len(fresh.Entries) != len(idx.Entries) {
+		s.saveIndex(fresh)
+	}
 
 	return felts, nil
 }
 
+// Stream calls fn with every fiber in the store, body and modification time
+// included, one file at a time, so a caller writing fibers out holds one in
+// memory rather than the whole store. done counts the fibers read so far,
+// this one included, of total fiber files. Files that fail to parse are
+// warned about and skipped, as List does; an error from fn stops the walk
+// and is returned.
+func (s *Storage) Stream(fn func(f *Felt, done, total int) error) error {
+	files, err := s.listFiberFiles()
+	if err != nil {
+		return err
+	}
+	for i, file := range files {
+		f, err := s.readPathWithMode(file.path, file.id, ParseFull)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to parse %s: %v\n", file.path, err)
+			continue
+		}
+		if info, err := os.Stat(file.path); err == nil {
+			f.ModifiedAt = info.ModTime()
+		}
+		f.EntryPoint = file.entryPoint
+		f.ReportPath = file.reportPath
+		if err := fn(f, i+1, len(files)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // isEvictedFileError reports whether err is the failure signature of an
 // iCloud-dataless file — one whose metadata is present but whose contents
 // haven't been downloaded locally. Reading such a file returns EDEADLK
@@ -842,12 +997,22 @@ func (s *Storage) findWithModeAndScope(scopeID, query string, mode ParseMode) (*
 // findByUIDWithMode resolves a fiber by an exact (case-insensitive) UID match.
 // The UID is frontmatter-only, so this walks the file list parsing metadata to
 // read each UID; the caller gates it on LooksLikeUID so the scan only runs for
-// UID-shaped queries that slug resolution already failed to resolve.
+// UID-shaped queries that slug resolution already failed to resolve. Files
+// the metadata index still holds fresh are matched without a parse.
 func (s *Storage) findByUIDWithMode(files []fiberFile, query string, mode ParseMode) (*Felt, bool, error) {
+	idx := s.loadIndex()
 	for _, file := range files {
-		meta, err := s.readPathWithMode(file.path, file.id, ParseMetadataOnly)
-		if err != nil {
-			continue
+		var meta *Felt
+		if info, err := os.Stat(file.path); err == nil {
+			if entry, ok := idx.lookup(file, info); ok {
+				meta = entry.felt()
+			}
+		}
+		if meta == nil {
+			var err error
+			if meta, err = s.readPathWithMode(file.path, file.id, ParseMetadataOnly); err != nil {
+				continue
+			}
 		}
 		if !meta.MatchesUID(query) {
 			continue
@@ -977,6 +1142,11 @@ func (s *Storage) listFiberFiles() ([]fiberFile, error) {
 			}
 		}
 		for _, d := range entries {
+			// A sync tool's conflicted copy is neither a fiber nor a broken
+			// one; FindSyncConflicts reports it instead.
+			if IsSyncConflictName(d.Name()) {
+				continue
+			}
 			fullPath := filepath.Join(dir, d.Name())
 			// Symlinked subdirectory: capture its logical position relative to
 			// walkBaseResolved before resolving, so the recursive walk lifts
@@ -1426,17 +1596,55 @@ func displayScope(scopeID string) string {
 	return scopeID
 }
 
-// FindProjectRoot walks up from the current directory to find a .felt directory.
+// FeltDirEnv names the environment variable that overrides root discovery.
+// It may point at a project directory or at its .felt directory.
+const FeltDirEnv = "FELT_DIR"
+
+// feltDirRedirectKey is the key of a .felt redirect file. A regular file
+// named .felt holding `feltdir: <path>` (like git's `gitdir:`) stands in for
+// the directory, so a git worktree or cluster checkout can share the store
+// of another checkout. A relative path is resolved against the file's
+// directory.
+const feltDirRedirectKey = "feltdir:"
+
+// FindProjectRoot returns the project root for the current directory: FELT_DIR
+// when set, otherwise the nearest ancestor holding a .felt directory or
+// redirect file. When the walk fails along the working directory as given,
+// it is retried along the symlink-resolved path, so a project reached
+// through a symlinked checkout is still found.
 func FindProjectRoot() (string, error) {
+	if env := os.Getenv(FeltDirEnv); env != "" {
+		root, err := projectRootFor(env)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", FeltDirEnv, err)
+		}
+		return root, nil
+	}
+
 	dir, err := os.Getwd()
 	if err != nil {
 		return "", fmt.Errorf("getting working directory: %w", err)
 	}
+	root, err := findProjectRootFrom(dir)
+	if err == nil {
+		return root, nil
+	}
+	if resolved, rerr := filepath.EvalSymlinks(dir); rerr == nil && resolved != dir {
+		if root, rerr := findProjectRootFrom(resolved); rerr == nil {
+			return root, nil
+		}
+	}
+	return "", err
+}
 
+func findProjectRootFrom(dir string) (string, error) {
 	for {
-		feltDir := filepath.Join(dir, DirName)
-		if info, err := os.Stat(feltDir); err == nil && info.IsDir() {
-			return dir, nil
+		root, ok, err := ProjectRootAt(dir)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return root, nil
 		}
 
 		parent := filepath.Dir(dir)
@@ -1448,6 +1656,87 @@ func FindProjectRoot() (string, error) {
 	}
 }
 
+// ProjectRootAt reports the project root that dir's .felt entry designates:
+// dir itself for a .felt directory, or the redirect target's project for a
+// .felt redirect file. ok is false when dir has no .felt entry at all.
+func ProjectRootAt(dir string) (root string, ok bool, err error) {
+	feltPath := filepath.Join(dir, DirName)
+	info, err := os.Stat(feltPath)
+	if err != nil {
+		return "", false, nil
+	}
+	if info.IsDir() {
+		return dir, true, nil
+	}
+	target, err := readFeltDirRedirect(feltPath)
+	if err != nil {
+		return "", false, err
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(dir, target)
+	}
+	root, err = projectRootFor(target)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", feltPath, err)
+	}
+	return root, true, nil
+}
+
+// readFeltDirRedirect returns the path named by a .felt redirect file.
+func readFeltDirRedirect(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, feltDirRedirectKey) {
+			if target := strings.TrimSpace(strings.TrimPrefix(line, feltDirRedirectKey)); target != "" {
+				return target, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%s is a file but has no %q line", path, feltDirRedirectKey)
+}
+
+// projectRootFor maps path — a .felt directory or a project directory
+// holding one — to its project root, with symlinks resolved.
+func projectRootFor(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	if filepath.Base(abs) == DirName {
+		if info, err := os.Stat(abs); err == nil && info.IsDir() {
+			return filepath.Dir(abs), nil
+		}
+	}
+	if info, err := os.Stat(filepath.Join(abs, DirName)); err == nil && info.IsDir() {
+		return abs, nil
+	}
+	return "", fmt.Errorf("no .felt directory at %s", path)
+}
+
+// FindAncestorRoots returns the project roots enclosing root — directories
+// above it that hold their own .felt/ — nearest first.
+func FindAncestorRoots(root string) []string {
+	var roots []string
+	dir := filepath.Dir(root)
+	for {
+		if info, err := os.Stat(filepath.Join(dir, DirName)); err == nil && info.IsDir() {
+			roots = append(roots, dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return roots
+		}
+		dir = parent
+	}
+}
+
 func readMetadataFile(path, id string) (*Felt, error) {
 	frontmatter, err := readFrontmatterFile(path)
 	if err != nil {
@@ -1507,24 +1796,40 @@ func scanFrontmatterTopLevelFields(r io.Reader, fields []string) (bool, error) {
 	if len(fields) == 0 {
 		return true, nil
 	}
-	data, err := io.ReadAll(r)
-	if err != nil {
-		return false, fmt.Errorf("reading file: %w", err)
-	}
-	frontmatter, _, err := splitFrontmatter(data, false)
+	frontmatter, err := readFrontmatter(r)
 	if err != nil {
 		return false, err
 	}
 	return frontmatterHasTopLevelFields(frontmatter, fields), nil
 }
 
+// readFrontmatter reads r line by line up to the closing ---, so a fiber's
+// body — however large — is never read into memory. Lines are split and
+// delimiters matched exactly as frontmatterBounds does for a whole file.
 func readFrontmatter(r io.Reader) ([]byte, error) {
-	data, err := io.ReadAll(r)
-	if err != nil {
-		return nil, fmt.Errorf("reading file: %w", err)
+	br := bufio.NewReader(r)
+	var frontmatter []byte
+	for n := 0; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("reading file: %w", err)
+		}
+		if n == 0 {
+			if len(line) == 0 {
+				return nil, fmt.Errorf("empty file")
+			}
+			if !isDocumentDelimiterLine(bytes.TrimSuffix(line, []byte("\n"))) {
+				return nil, fmt.Errorf("file must start with ---")
+			}
+		} else if isDocumentDelimiterLine(bytes.TrimSuffix(line, []byte("\n"))) {
+			return frontmatter, nil
+		} else {
+			frontmatter = append(frontmatter, line...)
+		}
+		if err == io.EOF {
+			return nil, fmt.Errorf("unclosed frontmatter (missing closing ---)")
+		}
 	}
-	frontmatter, _, err := splitFrontmatter(data, false)
-	return frontmatter, err
 }
 
 func (s *Storage) nextAvailableMigrationID(baseID string, reserved map[string]struct{}) (string, error) {
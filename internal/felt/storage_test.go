@@ -1,6 +1,8 @@
 package felt
 
 import (
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -547,6 +549,42 @@ Body should never be read.
 	}
 }
 
+// failingReader errors on any read, standing in for a body that must not be
+// read.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("read past the frontmatter") }
+
+func TestReadFrontmatterStopsAtClosingDelimiter(t *testing.T) {
+	header := "---\nname: Huge\nstatus: open\n---\n"
+	frontmatter, err := readFrontmatter(io.MultiReader(strings.NewReader(header), failingReader{}))
+	if err != nil {
+		t.Fatalf("readFrontmatter() error: %v", err)
+	}
+	if got := string(frontmatter); got != "name: Huge\nstatus: open\n" {
+		t.Fatalf("frontmatter = %q", got)
+	}
+}
+
+func TestListMetadataSkipsBodies(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	f := &Felt{ID: "huge", Name: "Huge", Status: StatusOpen, CreatedAt: time.Now(), Body: strings.Repeat("notes\n", 1<<16)}
+	if err := s.Write(f); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	felts, err := s.ListMetadata()
+	if err != nil || len(felts) != 1 {
+		t.Fatalf("ListMetadata: %v, %d fibers", err, len(felts))
+	}
+	if got := felts[0]; got.Name != "Huge" || got.Body != "" {
+		t.Fatalf("ListMetadata = %+v, want the header without its body", got)
+	}
+}
+
 func TestFrontmatterHasTopLevelFields(t *testing.T) {
 	frontmatter := []byte(`name: Test
 status: active
@@ -1125,6 +1163,83 @@ func TestFindProjectRootNotFound(t *testing.T) {
 	}
 }
 
+func TestFindProjectRootRedirects(t *testing.T) {
+	shared := t.TempDir()
+	if err := os.Mkdir(filepath.Join(shared, DirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := filepath.EvalSymlinks(shared)
+	findFrom := func(dir string) (string, error) {
+		t.Helper()
+		oldWd, _ := os.Getwd()
+		defer os.Chdir(oldWd)
+		if err := os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+		return FindProjectRoot()
+	}
+
+	// A .felt file with feltdir: redirects, relative to its own directory.
+	worktree := t.TempDir()
+	rel, err := filepath.Rel(worktree, filepath.Join(shared, DirName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(worktree, DirName), []byte("feltdir: "+rel+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(worktree, "src")
+	os.Mkdir(nested, 0755)
+	if got, err := findFrom(nested); err != nil || got != want {
+		t.Errorf("redirect file: FindProjectRoot() = %q, %v; want %q", got, err, want)
+	}
+
+	// A redirect file without a feltdir: line is an error, not a silent skip.
+	broken := t.TempDir()
+	os.WriteFile(filepath.Join(broken, DirName), []byte("nonsense\n"), 0644)
+	if _, err := findFrom(broken); err == nil {
+		t.Error("malformed redirect file should error")
+	}
+
+	// FELT_DIR wins over discovery and may name the .felt directory itself.
+	t.Setenv(FeltDirEnv, filepath.Join(shared, DirName))
+	if got, err := findFrom(broken); err != nil || got != want {
+		t.Errorf("FELT_DIR: FindProjectRoot() = %q, %v; want %q", got, err, want)
+	}
+	t.Setenv(FeltDirEnv, broken)
+	if _, err := findFrom(shared); err == nil {
+		t.Error("FELT_DIR without a store should error")
+	}
+}
+
+func TestFindProjectRootThroughSymlinkedCheckout(t *testing.T) {
+	project := t.TempDir()
+	os.Mkdir(filepath.Join(project, DirName), 0755)
+	run := filepath.Join(project, "runs", "a")
+	os.MkdirAll(run, 0755)
+
+	// scratch/link -> project/runs/a: walking up the logical path finds no
+	// store, the resolved path does.
+	scratch := t.TempDir()
+	link := filepath.Join(scratch, "link")
+	if err := os.Symlink(run, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(link)
+	t.Setenv("PWD", link)
+
+	found, err := FindProjectRoot()
+	if err != nil {
+		t.Fatalf("FindProjectRoot() error: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(project)
+	if got, _ := filepath.EvalSymlinks(found); got != want {
+		t.Errorf("FindProjectRoot() = %q, want %q", found, project)
+	}
+}
+
 func TestStorageMoveSubtreeRewritesInputRefs(t *testing.T) {
 	dir := t.TempDir()
 	s := NewStorage(dir)
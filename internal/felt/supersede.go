@@ -0,0 +1,115 @@
+package felt
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supersession pointers, written in pairs by `felt supersede`: the old
+// decision's superseded-by names its replacement, and the replacement's
+// supersedes names the decision it revises.
+const (
+	SupersededByKey = "superseded-by"
+	SupersedesKey   = "supersedes"
+)
+
+// SupersededBy returns the id of the decision that replaced f, or "".
+func (f *Felt) SupersededBy() string {
+	return extraFieldString(f, SupersededByKey)
+}
+
+// Supersedes returns the id of the decision f replaced, or "".
+func (f *Felt) Supersedes() string {
+	return extraFieldString(f, SupersedesKey)
+}
+
+func extraFieldString(f *Felt, key string) string {
+	node := extraFieldNode(f.ExtraFields, key)
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimSpace(node.Value)
+}
+
+// Supersede writes a new decision at newID titled title that revises oldID,
+// and points each at the other. The new fiber takes the old one's tags
+// (always including decision) and opens on a body that links back to the
+// old decision, quotes its outcome, and carries its body forward as context
+// to edit. It starts open, as a decision still to be settled, unless an
+// outcome is given, in which case it is closed with it. The old fiber's
+// status is left alone.
+func (s *Storage) Supersede(oldID, newID, title, outcome string, now time.Time) (*Felt, error) {
+	old, err := s.Read(oldID)
+	if err != nil {
+		return nil, err
+	}
+	if next := old.SupersededBy(); next != "" {
+		return nil, fmt.Errorf("%s is already superseded by %s", oldID, next)
+	}
+
+	f, err := New(newID, title)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range old.Tags {
+		f.AddTag(tag)
+	}
+	f.AddTag("decision")
+	if outcome = strings.TrimSpace(outcome); outcome != "" {
+		f.Status = StatusClosed
+		f.Outcome = outcome
+		f.ClosedAt = &now
+	} else {
+		f.Status = StatusOpen
+	}
+	cfg, err := s.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.CheckStatusTransition("", f.Status); err != nil {
+		return nil, err
+	}
+	f.NoteStatusChange("", now)
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Supersedes [[%s]] (%s).", oldID, old.DisplayName())
+	if old.Outcome != "" {
+		fmt.Fprintf(&body, "\n\nPreviously decided: %s", strings.TrimSpace(old.Outcome))
+	}
+	if context := strings.TrimSpace(old.Body); context != "" {
+		fmt.Fprintf(&body, "\n\n## Context from %s\n\n%s", path.Base(oldID), context)
+	}
+	f.Body = body.String()
+	if err := f.SetExtraField(SupersedesKey, oldID); err != nil {
+		return nil, err
+	}
+	if err := s.EnsureAvailableUID(f); err != nil {
+		return nil, err
+	}
+	f.CreatedAt = now
+	f.Touch(now)
+	if err := old.SetExtraField(SupersededByKey, f.ID); err != nil {
+		return nil, err
+	}
+	old.Touch(now)
+
+	tx, err := s.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if err := tx.Write(f); err != nil {
+		return nil, err
+	}
+	if err := tx.Write(old); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
@@ -0,0 +1,54 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSupersedeLinksBothDecisions(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatal(err)
+	}
+	closedAt := time.Now().Add(-time.Hour)
+	old := &Felt{ID: "analysis/mask", Name: "Mask choice", Status: StatusClosed, ClosedAt: &closedAt, Tags: []string{"mask"}, Outcome: "Use the DR1 mask", Body: "Halos were out of scope."}
+	if err := s.Write(old); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := s.Supersede("analysis/mask", "analysis/dr2-mask", "Use the DR2 mask", "", time.Now())
+	if err != nil {
+		t.Fatalf("Supersede: %v", err)
+	}
+	if f.Supersedes() != "analysis/mask" || !f.IsOpen() || !f.HasTag("decision") || !f.HasTag("mask") {
+		t.Fatalf("new decision = status %q tags %v supersedes %q", f.Status, f.Tags, f.Supersedes())
+	}
+	for _, want := range []string{"Supersedes [[analysis/mask]] (Mask choice).", "Previously decided: Use the DR1 mask", "## Context from mask\n\nHalos were out of scope."} {
+		if !strings.Contains(f.Body, want) {
+			t.Fatalf("new body missing %q:\n%s", want, f.Body)
+		}
+	}
+	got, err := s.Read("analysis/mask")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SupersededBy() != "analysis/dr2-mask" || !got.IsClosed() {
+		t.Fatalf("old decision = status %q superseded-by %q", got.Status, got.SupersededBy())
+	}
+	if _, err := s.Supersede("analysis/mask", "analysis/other", "Other", "", time.Now()); err == nil {
+		t.Fatal("second Supersede succeeded, want already superseded error")
+	}
+
+	if err := s.Delete("analysis/dr2-mask"); err != nil {
+		t.Fatal(err)
+	}
+	felts, err := s.ListMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := Check(felts)
+	if len(issues) != 1 || issues[0].Path != "frontmatter.superseded-by" {
+		t.Fatalf("Check() = %+v, want one dangling superseded-by warning", issues)
+	}
+}
@@ -0,0 +1,368 @@
+package felt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SyncStateName is the per-store record of past peer syncs,
+// `.felt/sync-state.json`. For each peer it keeps the field values every
+// fiber had when the two stores last agreed — the merge base — and the
+// tombstones of fibers deleted through sync. It is local bookkeeping, never
+// copied to a peer.
+const SyncStateName = "sync-state.json"
+
+// syncBodyField is the pseudo-field carrying a fiber's body in field maps.
+// Parentheses cannot start a frontmatter key felt writes, so it never
+// collides with a real one.
+const syncBodyField = "(body)"
+
+// SyncState is the decoded sync-state file, keyed by peer.
+type SyncState struct {
+	Peers map[string]*PeerSyncState `json:"peers"`
+}
+
+// PeerSyncState is what one store remembers about one peer. Fibers maps id
+// to field to rendered YAML value at the last sync; Tombstones maps the id
+// of each fiber deleted through sync to when the deletion was applied, so a
+// stale copy arriving later is deleted again rather than resurrected.
+type PeerSyncState struct {
+	SyncedAt   time.Time                    `json:"synced_at"`
+	Fibers     map[string]map[string]string `json:"fibers"`
+	Tombstones map[string]time.Time         `json:"tombstones,omitempty"`
+}
+
+// SyncResult reports what a sync did to each side, by fiber id.
+type SyncResult struct {
+	Pulled  []string `json:"pulled"`  // fibers written locally
+	Pushed  []string `json:"pushed"`  // fibers written on the peer
+	Deleted []string `json:"deleted"` // fibers deleted on either side
+	// Conflicts are "<id>: <field>" pairs both sides had changed since the
+	// last sync; the side with the later updated-at won each one.
+	Conflicts []string `json:"conflicts"`
+}
+
+// SyncStatePath returns the path of the store's sync-state file.
+func (s *Storage) SyncStatePath() string {
+	return filepath.Join(s.root, SyncStateName)
+}
+
+// LoadSyncState reads the sync-state file. A missing file is an empty state.
+func (s *Storage) LoadSyncState() (*SyncState, error) {
+	state := &SyncState{Peers: make(map[string]*PeerSyncState)}
+	data, err := os.ReadFile(s.SyncStatePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", SyncStateName, err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", SyncStateName, err)
+	}
+	if state.Peers == nil {
+		state.Peers = make(map[string]*PeerSyncState)
+	}
+	return state, nil
+}
+
+// SaveSyncState writes the sync-state file.
+func (s *Storage) SaveSyncState(state *SyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	ensureGitignoreCovers(s.root, SyncStateName)
+	if err := os.WriteFile(s.SyncStatePath(), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", SyncStateName, err)
+	}
+	return nil
+}
+
+// SyncPeer merges local and peer in both directions, using state as the
+// merge base and updating it in place. Per fiber and per field:
+//
+//   - a field changed on one side since the last sync takes that side's value;
+//   - a field changed on both sides takes the value from the fiber with the
+//     later updated-at (last writer wins), ties going to local;
+//   - with no merge base yet (first sync), differing fields take the newer
+//     fiber's value and fields present on only one side are kept.
+//
+// A fiber deleted on one side is deleted on the other unless the other side
+// edited it since the last sync — an edit outlives a concurrent delete.
+// With dryRun set nothing is written, but the result reports what would be.
+func SyncPeer(local, peer *Storage, state *PeerSyncState, now time.Time, dryRun bool) (*SyncResult, error) {
+	localFibers, err := syncSnapshot(local)
+	if err != nil {
+		return nil, err
+	}
+	peerFibers, err := syncSnapshot(peer)
+	if err != nil {
+		return nil, err
+	}
+	if state.Fibers == nil {
+		state.Fibers = make(map[string]map[string]string)
+	}
+	if state.Tombstones == nil {
+		state.Tombstones = make(map[string]time.Time)
+	}
+
+	ids := make(map[string]struct{})
+	for id := range localFibers {
+		ids[id] = struct{}{}
+	}
+	for id := range peerFibers {
+		ids[id] = struct{}{}
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	result := &SyncResult{}
+	base := make(map[string]map[string]string)
+	for _, id := range sorted {
+		l, lok := localFibers[id]
+		p, pok := peerFibers[id]
+		prev, seen := state.Fibers[id]
+
+		var merged map[string]string
+		switch {
+		case lok && pok:
+			var conflicts []string
+			merged, conflicts = mergeSyncFields(l, p, prev, seen)
+			for _, field := range conflicts {
+				result.Conflicts = append(result.Conflicts, id+": "+field)
+			}
+		case lok || pok:
+			present, side := l, local
+			if pok {
+				present, side = p, peer
+			}
+			if syncShouldDelete(id, present, prev, seen, state.Tombstones) {
+				if !dryRun {
+					if err := side.Delete(id); err != nil {
+						return nil, err
+					}
+				}
+				state.Tombstones[id] = now
+				result.Deleted = append(result.Deleted, id)
+				continue
+			}
+			merged = present.fields
+		}
+
+		if !lok || !syncFieldsEqual(l.fields, merged) {
+			if err := syncWrite(local, id, merged, slices.Concat(l.order, p.order), dryRun); err != nil {
+				return nil, err
+			}
+			result.Pulled = append(result.Pulled, id)
+		}
+		if !pok || !syncFieldsEqual(p.fields, merged) {
+			if err := syncWrite(peer, id, merged, slices.Concat(p.order, l.order), dryRun); err != nil {
+				return nil, err
+			}
+			result.Pushed = append(result.Pushed, id)
+		}
+		delete(state.Tombstones, id)
+		base[id] = merged
+	}
+
+	for id := range state.Fibers {
+		if _, ok := ids[id]; !ok {
+			// Gone from both sides: nothing left to reconcile.
+			state.Tombstones[id] = now
+		}
+	}
+	state.Fibers = base
+	state.SyncedAt = now
+	return result, nil
+}
+
+// syncFiber is one side's view of a fiber during sync. order is the
+// fiber's ExtraFieldOrder, so a rewrite keeps its extra keys where they
+// were.
+type syncFiber struct {
+	fields  map[string]string
+	updated time.Time
+	order   []string
+}
+
+func syncSnapshot(s *Storage) (map[string]syncFiber, error) {
+	felts, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]syncFiber, len(felts))
+	for _, f := range felts {
+		fields, err := syncFields(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.ID, err)
+		}
+		out[f.ID] = syncFiber{fields: fields, updated: f.RecencyAnchor(), order: f.ExtraFieldOrder}
+	}
+	return out, nil
+}
+
+// syncFields renders f as a map of top-level frontmatter key to YAML value,
+// plus its body under syncBodyField.
+func syncFields(f *Felt) (map[string]string, error) {
+	data, err := f.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	frontmatter, body, err := splitFrontmatter(data, true)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(frontmatter, &doc); err != nil {
+		return nil, err
+	}
+	fields := map[string]string{syncBodyField: body}
+	if len(doc.Content) == 0 {
+		return fields, nil
+	}
+	mapping := doc.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		value, err := yaml.Marshal(mapping.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+		fields[mapping.Content[i].Value] = string(value)
+	}
+	return fields, nil
+}
+
+// mergeSyncFields merges two versions of a fiber field by field, returning
+// the merged fields and the fields both sides changed since prev.
+func mergeSyncFields(l, p syncFiber, prev map[string]string, seen bool) (map[string]string, []string) {
+	keys := make(map[string]struct{})
+	for k := range l.fields {
+		keys[k] = struct{}{}
+	}
+	for k := range p.fields {
+		keys[k] = struct{}{}
+	}
+	peerNewer := p.updated.After(l.updated)
+
+	merged := make(map[string]string)
+	var conflicts []string
+	for k := range keys {
+		lv, lok := l.fields[k]
+		pv, pok := p.fields[k]
+		if lok == pok && lv == pv {
+			if lok {
+				merged[k] = lv
+			}
+			continue
+		}
+		var takePeer bool
+		if !seen {
+			// No base: keep one-sided fields, newer fiber wins differences.
+			takePeer = !lok || (pok && peerNewer)
+		} else {
+			bv, bok := prev[k]
+			lChanged := lok != bok || lv != bv
+			pChanged := pok != bok || pv != bv
+			switch {
+			case lChanged && pChanged:
+				takePeer = peerNewer
+				if k != "updated-at" {
+					conflicts = append(conflicts, k)
+				}
+			case pChanged:
+				takePeer = true
+			}
+		}
+		if takePeer {
+			if pok {
+				merged[k] = pv
+			}
+		} else if lok {
+			merged[k] = lv
+		}
+	}
+	sort.Strings(conflicts)
+	return merged, conflicts
+}
+
+// syncShouldDelete decides whether a fiber present on one side only should
+// be deleted there: the other side deleted it since the last sync and this
+// side left it untouched, or a tombstone outlives this copy's last edit.
+func syncShouldDelete(id string, present syncFiber, prev map[string]string, seen bool, tombstones map[string]time.Time) bool {
+	if seen {
+		return syncFieldsEqual(present.fields, prev)
+	}
+	if at, ok := tombstones[id]; ok {
+		return !present.updated.After(at)
+	}
+	return false
+}
+
+func syncFieldsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// syncWrite reassembles fields into a fiber and writes it to s. Keys are
+// written in order, skipping repeats and keys no longer present, then any
+// others sorted; native keys land where Marshal puts them regardless.
+func syncWrite(s *Storage, id string, fields map[string]string, order []string, dryRun bool) error {
+	if dryRun {
+		return nil
+	}
+	keys := make([]string, 0, len(fields))
+	placed := map[string]bool{syncBodyField: true}
+	for _, k := range order {
+		if _, ok := fields[k]; ok && !placed[k] {
+			keys = append(keys, k)
+			placed[k] = true
+		}
+	}
+	var rest []string
+	for k := range fields {
+		if !placed[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	keys = append(keys, rest...)
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	for _, k := range keys {
+		var value yaml.Node
+		if err := yaml.Unmarshal([]byte(fields[k]), &value); err != nil {
+			return fmt.Errorf("%s: %s: %w", id, k, err)
+		}
+		entry, err := yaml.Marshal(map[string]*yaml.Node{k: value.Content[0]})
+		if err != nil {
+			return err
+		}
+		sb.Write(entry)
+	}
+	sb.WriteString("---\n")
+	sb.WriteString(fields[syncBodyField])
+	f, err := Parse(id, []byte(sb.String()))
+	if err != nil {
+		return fmt.Errorf("%s: rebuilding merged fiber: %w", id, err)
+	}
+	return s.Write(f)
+}
@@ -0,0 +1,180 @@
+package felt
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newSyncStore(t *testing.T, felts ...*Felt) *Storage {
+	t.Helper()
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init() error: %v", err)
+	}
+	for _, f := range felts {
+		if err := s.Write(f); err != nil {
+			t.Fatalf("Write(%s): %v", f.ID, err)
+		}
+	}
+	return s
+}
+
+func mustSyncRead(t *testing.T, s *Storage, id string) *Felt {
+	t.Helper()
+	f, err := s.Read(id)
+	if err != nil {
+		t.Fatalf("Read(%s): %v", id, err)
+	}
+	return f
+}
+
+func TestSyncPeerMergesFieldsAndPropagatesDeletes(t *testing.T) {
+	t0 := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	local := newSyncStore(t,
+		&Felt{ID: "shared", Name: "Shared", Status: StatusOpen, CreatedAt: t0, Body: "notes\n"},
+		&Felt{ID: "mine", Name: "Mine", CreatedAt: t0},
+	)
+	peer := newSyncStore(t,
+		&Felt{ID: "theirs", Name: "Theirs", CreatedAt: t0},
+	)
+	state := &PeerSyncState{}
+
+	// First sync: each side's fibers reach the other.
+	result, err := SyncPeer(local, peer, state, t0, false)
+	if err != nil {
+		t.Fatalf("first SyncPeer: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pulled, []string{"theirs"}) || !reflect.DeepEqual(result.Pushed, []string{"mine", "shared"}) {
+		t.Fatalf("first sync = %+v", result)
+	}
+	if got, want := mustSyncRead(t, peer, "shared").Body, mustSyncRead(t, local, "shared").Body; got != want {
+		t.Fatalf("peer body = %q, want %q", got, want)
+	}
+
+	// Disjoint field edits merge; the later writer wins a field both changed.
+	t1, t2 := t0.Add(time.Hour), t0.Add(2*time.Hour)
+	l := mustSyncRead(t, local, "shared")
+	l.Status, l.Name = StatusActive, "Local name"
+	l.Touch(t1)
+	if err := local.Write(l); err != nil {
+		t.Fatal(err)
+	}
+	p := mustSyncRead(t, peer, "shared")
+	p.Tags, p.Name = []string{"paper"}, "Peer name"
+	p.Touch(t2)
+	if err := peer.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	// The peer deletes "mine" untouched; local edits "theirs", which the peer
+	// deletes concurrently.
+	if err := peer.Delete("mine"); err != nil {
+		t.Fatal(err)
+	}
+	th := mustSyncRead(t, local, "theirs")
+	th.Outcome = "kept"
+	th.Touch(t1)
+	if err := local.Write(th); err != nil {
+		t.Fatal(err)
+	}
+	if err := peer.Delete("theirs"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = SyncPeer(local, peer, state, t2, false)
+	if err != nil {
+		t.Fatalf("second SyncPeer: %v", err)
+	}
+	if !reflect.DeepEqual(result.Conflicts, []string{"shared: name"}) {
+		t.Fatalf("conflicts = %v", result.Conflicts)
+	}
+	for _, s := range []*Storage{local, peer} {
+		got := mustSyncRead(t, s, "shared")
+		if got.Status != StatusActive || got.Name != "Peer name" || !reflect.DeepEqual(got.Tags, []string{"paper"}) {
+			t.Fatalf("merged shared = status %q name %q tags %v", got.Status, got.Name, got.Tags)
+		}
+		if got := mustSyncRead(t, s, "theirs"); got.Outcome != "kept" {
+			t.Fatalf("edited fiber should outlive the concurrent delete, outcome = %q", got.Outcome)
+		}
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{"mine"}) {
+		t.Fatalf("deleted = %v", result.Deleted)
+	}
+	if _, err := local.Read("mine"); err == nil {
+		t.Fatal("delete on the peer should propagate to local")
+	}
+	if _, ok := state.Tombstones["mine"]; !ok {
+		t.Fatalf("tombstones = %v", state.Tombstones)
+	}
+
+	// A stale copy of a tombstoned fiber is deleted again, not resurrected.
+	if err := peer.Write(&Felt{ID: "mine", Name: "Mine", CreatedAt: t0}); err != nil {
+		t.Fatal(err)
+	}
+	result, err = SyncPeer(local, peer, state, t2.Add(time.Hour), false)
+	if err != nil {
+		t.Fatalf("third SyncPeer: %v", err)
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{"mine"}) || len(result.Pulled)+len(result.Pushed) != 0 {
+		t.Fatalf("third sync = %+v", result)
+	}
+}
+
+func TestSyncPeerDryRunWritesNothing(t *testing.T) {
+	t0 := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	local := newSyncStore(t, &Felt{ID: "mine", Name: "Mine", CreatedAt: t0})
+	peer := newSyncStore(t)
+	result, err := SyncPeer(local, peer, &PeerSyncState{}, t0, true)
+	if err != nil {
+		t.Fatalf("SyncPeer: %v", err)
+	}
+	if !reflect.DeepEqual(result.Pushed, []string{"mine"}) {
+		t.Fatalf("pushed = %v", result.Pushed)
+	}
+	if felts, _ := peer.List(); len(felts) != 0 {
+		t.Fatalf("dry run wrote %d fibers to the peer", len(felts))
+	}
+}
+
+func TestSyncPeerKeepsExtraFieldOrder(t *testing.T) {
+	t0 := time.Date(2026, 4, 10, 9, 0, 0, 0, time.UTC)
+	f := &Felt{ID: "fit", Name: "Fit", Status: StatusOpen, CreatedAt: t0}
+	for _, key := range []string{"zeta", "alpha"} {
+		if err := f.SetExtraField(key, "x"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	local, peer := newSyncStore(t, f), newSyncStore(t)
+	state := &PeerSyncState{}
+	if _, err := SyncPeer(local, peer, state, t0, false); err != nil {
+		t.Fatalf("first SyncPeer: %v", err)
+	}
+
+	p := mustSyncRead(t, peer, "fit")
+	if err := p.SetExtraField("beta", "y"); err != nil {
+		t.Fatal(err)
+	}
+	p.Touch(t0.Add(time.Hour))
+	if err := peer.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SyncPeer(local, peer, state, t0.Add(time.Hour), false); err != nil {
+		t.Fatalf("second SyncPeer: %v", err)
+	}
+	if got := mustSyncRead(t, local, "fit").ExtraFieldOrder; !reflect.DeepEqual(got, []string{"zeta", "alpha", "beta"}) {
+		t.Fatalf("pulled extra field order = %v, want the local order with the peer's new key last", got)
+	}
+}
+
+func TestSaveSyncStateIsGitignored(t *testing.T) {
+	s := newSyncStore(t)
+	if err := s.SaveSyncState(&SyncState{Peers: map[string]*PeerSyncState{}}); err != nil {
+		t.Fatalf("SaveSyncState: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(s.root, GitignoreName)); !strings.Contains(string(data), "\n"+SyncStateName+"\n") {
+		t.Fatalf(".gitignore does not cover %s:\n%s", SyncStateName, data)
+	}
+}
@@ -0,0 +1,85 @@
+package felt
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TargetsKey is the conventional frontmatter list naming the workflow rules
+// a fiber owns — snakemake rule names, make targets, or glob patterns over
+// them:
+//
+//	targets: [fit_cosebis, plots/cosebis_*.pdf]
+//
+// A single scalar is one target.
+const TargetsKey = "targets"
+
+// Targets returns f's `targets:` entries, trimmed, in written order.
+func (f *Felt) Targets() []string {
+	node := extraFieldNode(f.ExtraFields, TargetsKey)
+	if node == nil {
+		return nil
+	}
+	var out []string
+	add := func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode {
+			if v := strings.TrimSpace(n.Value); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	if node.Kind == yaml.SequenceNode {
+		for _, n := range node.Content {
+			add(n)
+		}
+	} else {
+		add(node)
+	}
+	return out
+}
+
+// OwnsTarget reports whether one of f's targets names rule, exactly or as a
+// glob pattern (path.Match syntax).
+func (f *Felt) OwnsTarget(rule string) bool {
+	for _, t := range f.Targets() {
+		if t == rule {
+			return true
+		}
+		if ok, err := path.Match(t, rule); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FibersForTarget returns the fibers owning rule: unfinished ones (active,
+// then open) before closed and statusless ones, then by id — so the fiber
+// to look at for a failing rule comes first.
+func FibersForTarget(felts []*Felt, rule string) []*Felt {
+	var owners []*Felt
+	for _, f := range felts {
+		if f.OwnsTarget(rule) {
+			owners = append(owners, f)
+		}
+	}
+	rank := func(f *Felt) int {
+		switch {
+		case f.IsActive():
+			return 0
+		case f.IsOpen():
+			return 1
+		default:
+			return 2
+		}
+	}
+	sort.SliceStable(owners, func(i, j int) bool {
+		if ri, rj := rank(owners[i]), rank(owners[j]); ri != rj {
+			return ri < rj
+		}
+		return owners[i].ID < owners[j].ID
+	})
+	return owners
+}
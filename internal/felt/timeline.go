@@ -0,0 +1,89 @@
+package felt
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Timeline event kinds, in the order a fiber's events fall on a tie.
+const (
+	TimelineCreated   = "created"
+	TimelineActivated = "activated"
+	TimelineUpdated   = "updated"
+	TimelineClosed    = "closed"
+)
+
+// timelineKindOrder ranks kinds so same-instant events read in lifecycle
+// order (a fiber created already active shows created, then activated).
+var timelineKindOrder = map[string]int{
+	TimelineCreated:   0,
+	TimelineActivated: 1,
+	TimelineUpdated:   2,
+	TimelineClosed:    3,
+}
+
+// timelineUpdateSlack is how close an updated-at may sit to one of the
+// fiber's other events and still count as that event: every felt write
+// touches updated-at, so a close stamps both closed-at and updated-at.
+const timelineUpdateSlack = time.Minute
+
+// TimelineEvent is one dated entry in the activity feed. Detail carries the
+// first line of the outcome on close events.
+type TimelineEvent struct {
+	At     time.Time `json:"at"`
+	Kind   string    `json:"kind"`
+	ID     string    `json:"id"`
+	Name   string    `json:"name"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// Timeline interleaves every fiber's dated frontmatter — created-at,
+// activated-at, closed-at, and updated-at — into one feed of events at or
+// after since, oldest first. Only the latest activation survives in
+// frontmatter, so earlier open→active→open cycles are not replayed, and an
+// updated-at that coincides with another event is folded into it.
+func Timeline(felts []*Felt, since time.Time) []TimelineEvent {
+	var events []TimelineEvent
+	for _, f := range felts {
+		add := func(at *time.Time, kind, detail string) {
+			if at == nil || at.IsZero() || at.Before(since) {
+				return
+			}
+			events = append(events, TimelineEvent{At: *at, Kind: kind, ID: f.ID, Name: f.DisplayName(), Detail: detail})
+		}
+		created := f.CreatedAt
+		add(&created, TimelineCreated, "")
+		add(f.ActivatedAt, TimelineActivated, "")
+		if f.IsClosed() {
+			outcome, _, _ := strings.Cut(strings.TrimSpace(f.Outcome), "\n")
+			add(f.ClosedAt, TimelineClosed, outcome)
+		}
+		if f.UpdatedAt != nil && !nearAny(*f.UpdatedAt, &created, f.ActivatedAt, f.ClosedAt) {
+			add(f.UpdatedAt, TimelineUpdated, "")
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if !a.At.Equal(b.At) {
+			return a.At.Before(b.At)
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return timelineKindOrder[a.Kind] < timelineKindOrder[b.Kind]
+	})
+	return events
+}
+
+func nearAny(t time.Time, anchors ...*time.Time) bool {
+	for _, a := range anchors {
+		if a == nil {
+			continue
+		}
+		if d := t.Sub(*a); d < timelineUpdateSlack && d > -timelineUpdateSlack {
+			return true
+		}
+	}
+	return false
+}
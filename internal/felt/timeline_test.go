@@ -0,0 +1,40 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimelineInterleavesAndFoldsTouches(t *testing.T) {
+	at := func(day, hour int) *time.Time {
+		tt := time.Date(2026, 5, day, hour, 0, 0, 0, time.UTC)
+		return &tt
+	}
+	felts := []*Felt{
+		{ID: "old", Name: "Old", Status: StatusOpen, CreatedAt: *at(1, 9), UpdatedAt: at(6, 10)},
+		{
+			ID: "fit", Name: "Fit", Status: StatusClosed, Outcome: "Converged.\nDetails below.",
+			CreatedAt: *at(5, 9), ActivatedAt: at(5, 11), ClosedAt: at(7, 16),
+			// Touched by the close itself: folded into the closed event.
+			UpdatedAt: at(7, 16),
+		},
+	}
+
+	events := Timeline(felts, *at(4, 0))
+	var got []string
+	for _, ev := range events {
+		got = append(got, ev.ID+" "+ev.Kind)
+	}
+	want := []string{"fit created", "fit activated", "old updated", "fit closed"}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+	}
+	if events[3].Detail != "Converged." {
+		t.Fatalf("close detail = %q, want outcome headline", events[3].Detail)
+	}
+}
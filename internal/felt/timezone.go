@@ -0,0 +1,53 @@
+package felt
+
+import "time"
+
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// HasNonUTCTimestamps reports whether any of f's instants (created-at,
+// updated-at, activated-at, closed-at) was written with a zone offset other
+// than UTC — a fiber last written by a felt that stamped local time.
+func (f *Felt) HasNonUTCTimestamps() bool {
+	if !f.CreatedAt.IsZero() && f.CreatedAt.Location() != time.UTC {
+		return true
+	}
+	for _, t := range []*time.Time{f.UpdatedAt, f.ActivatedAt, f.ClosedAt} {
+		if t != nil && t.Location() != time.UTC {
+			return true
+		}
+	}
+	return false
+}
+
+// FindNonUTCTimestamps returns the ids of fibers whose instants carry a
+// non-UTC offset, sorted.
+func (s *Storage) FindNonUTCTimestamps() ([]string, error) {
+	felts, err := s.ListMetadata()
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, f := range felts {
+		if f.HasNonUTCTimestamps() {
+			ids = append(ids, f.ID)
+		}
+	}
+	return ids, nil
+}
+
+// NormalizeTimestamps rewrites the fiber at id with its instants in UTC. The
+// instants themselves are unchanged, so this is not a content edit and does
+// not touch updated-at.
+func (s *Storage) NormalizeTimestamps(id string) error {
+	f, err := s.Read(id)
+	if err != nil {
+		return err
+	}
+	return s.Write(f)
+}
@@ -0,0 +1,131 @@
+package felt
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Topic entry kinds.
+const (
+	TopicTag  = "tag"
+	TopicTerm = "term"
+)
+
+// DefaultTopicMinFibers is how many fibers' names a word must appear in
+// before `felt index` lists it as a topic.
+const DefaultTopicMinFibers = 2
+
+// TopicEntry is one heading of the topic index: a tag, or a word common to
+// several fibers' names, with the fibers it covers in creation order.
+type TopicEntry struct {
+	Topic  string   `json:"topic"`
+	Kind   string   `json:"kind"`
+	IDs    []string `json:"fibers"`
+	Fibers []*Felt  `json:"-"`
+}
+
+// topicStopwords are the title words too common to be topics.
+var topicStopwords = map[string]bool{
+	"about": true, "after": true, "all": true, "and": true, "are": true, "before": true,
+	"but": true, "can": true, "for": true, "from": true, "how": true, "into": true,
+	"its": true, "new": true, "not": true, "now": true, "off": true, "one": true,
+	"our": true, "out": true, "over": true, "should": true, "that": true, "the": true,
+	"them": true, "then": true, "this": true, "use": true, "using": true, "was": true,
+	"what": true, "when": true, "which": true, "why": true, "with": true, "without": true,
+}
+
+// TopicIndex builds an alphabetical topic index of felts: one entry per
+// tag, and one per name word (three letters or more, not a stopword or
+// already a tag) shared by at least minFibers fibers. Daily log fibers are
+// left out, their names being dates.
+func TopicIndex(felts []*Felt, minFibers int) []TopicEntry {
+	tagged := make(map[string][]*Felt)
+	named := make(map[string][]*Felt)
+	ordered := make([]*Felt, 0, len(felts))
+	for _, f := range felts {
+		if path.Dir(f.ID) != DailyLogContainerID {
+			ordered = append(ordered, f)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].CreatedAt.Before(ordered[j].CreatedAt) })
+	for _, f := range ordered {
+		for _, tag := range f.Tags {
+			tagged[tag] = append(tagged[tag], f)
+		}
+		seen := make(map[string]bool)
+		for _, word := range knowledgeTerms(f.DisplayName()) {
+			if seen[word] || topicStopwords[word] || utf8.RuneCountInString(word) < 3 || isDigits(word) {
+				continue
+			}
+			seen[word] = true
+			named[word] = append(named[word], f)
+		}
+	}
+
+	var out []TopicEntry
+	for tag, fibers := range tagged {
+		out = append(out, TopicEntry{Topic: tag, Kind: TopicTag, Fibers: fibers})
+	}
+	for word, fibers := range named {
+		if _, isTag := tagged[word]; isTag || len(fibers) < minFibers {
+			continue
+		}
+		out = append(out, TopicEntry{Topic: word, Kind: TopicTerm, Fibers: fibers})
+	}
+	for i := range out {
+		for _, f := range out[i].Fibers {
+			out[i].IDs = append(out[i].IDs, f.ID)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := strings.ToLower(out[i].Topic), strings.ToLower(out[j].Topic)
+		if a != b {
+			return a < b
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
+
+// TopicIndexMarkdown renders entries as a markdown index under one heading
+// per initial letter, each entry linking its fibers as [[id]] wiki links.
+// Tags are set as code, so they read apart from title words.
+func TopicIndexMarkdown(entries []TopicEntry) string {
+	var sb strings.Builder
+	sb.WriteString("# Index\n")
+	letter := ""
+	for _, e := range entries {
+		if l := topicLetter(e.Topic); l != letter {
+			letter = l
+			fmt.Fprintf(&sb, "\n## %s\n\n", letter)
+		}
+		topic := e.Topic
+		if e.Kind == TopicTag {
+			topic = "`" + topic + "`"
+		}
+		links := make([]string, len(e.Fibers))
+		for i, f := range e.Fibers {
+			links[i] = fmt.Sprintf("[[%s]] %s", f.ID, f.DisplayName())
+		}
+		fmt.Fprintf(&sb, "- %s — %s\n", topic, strings.Join(links, "; "))
+	}
+	return sb.String()
+}
+
+// topicLetter is the index heading a topic files under: its first letter,
+// upper-cased, or # for one that starts with anything else.
+func topicLetter(topic string) string {
+	r, _ := utf8.DecodeRuneInString(topic)
+	if !unicode.IsLetter(r) {
+		return "#"
+	}
+	return string(unicode.ToUpper(r))
+}
+
+func isDigits(s string) bool {
+	return strings.TrimFunc(s, unicode.IsDigit) == ""
+}
@@ -0,0 +1,39 @@
+package felt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTopicIndexListsTagsAndSharedTitleWords(t *testing.T) {
+	at := func(d int) time.Time { return time.Date(2026, 5, d, 0, 0, 0, 0, time.UTC) }
+	felts := []*Felt{
+		{ID: "psf-fit", Name: "Fit the PSF model", Tags: []string{"thread:psf"}, CreatedAt: at(2)},
+		{ID: "psf-check", Name: "Check PSF residuals", Tags: []string{"thread:psf"}, CreatedAt: at(1)},
+		{ID: "bao-fit", Name: "Fit the BAO peak", CreatedAt: at(3)},
+		{ID: "2026", Name: "2026 plan", Tags: []string{"plan"}, CreatedAt: at(4)},
+		{ID: "log/2026-05-04", Name: "Log Monday 4 May 2026", Tags: []string{DailyLogTag}, CreatedAt: at(4)},
+	}
+	entries := TopicIndex(felts, 2)
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Kind+":"+e.Topic)
+	}
+	want := "term:fit,tag:plan,term:psf,tag:thread:psf"
+	if joined := strings.Join(got, ","); joined != want {
+		t.Fatalf("topics = %s, want %s", joined, want)
+	}
+	if psf := entries[2]; strings.Join(psf.IDs, ",") != "psf-check,psf-fit" {
+		t.Fatalf("psf fibers = %v, want creation order", psf.IDs)
+	}
+
+	md := TopicIndexMarkdown(entries)
+	wantMD := "# Index\n\n## F\n\n- fit — [[psf-fit]] Fit the PSF model; [[bao-fit]] Fit the BAO peak\n\n" +
+		"## P\n\n- `plan` — [[2026]] 2026 plan\n" +
+		"- psf — [[psf-check]] Check PSF residuals; [[psf-fit]] Fit the PSF model\n\n" +
+		"## T\n\n- `thread:psf` — [[psf-check]] Check PSF residuals; [[psf-fit]] Fit the PSF model\n"
+	if md != wantMD {
+		t.Fatalf("markdown =\n%s\nwant\n%s", md, wantMD)
+	}
+}
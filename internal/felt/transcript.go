@@ -0,0 +1,191 @@
+package felt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Transcript candidate kinds.
+const (
+	CandidateAction   = "action"
+	CandidateDecision = "decision"
+	CandidateQuestion = "question"
+)
+
+// TranscriptCandidate is one line of a meeting transcript or dictated note
+// that reads as an action or a decision — a fiber worth proposing. Line is
+// 1-based; Text is the line as written, Name the proposed fiber name.
+type TranscriptCandidate struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+var (
+	// transcriptLead strips list markers and timestamps ("[00:12:31]",
+	// "12:31") from the start of a line; transcriptSpeaker strips a short
+	// speaker label ("Ana:", "SPEAKER 2:").
+	transcriptLead    = regexp.MustCompile(`^(?:[-*•]\s+|\d+[.)]\s+)?(?:\[?\(?\d{1,2}:\d{2}(?::\d{2})?\)?\]?\s*)?`)
+	transcriptSpeaker = regexp.MustCompile(`^[A-Za-z][\w .'-]{0,30}:\s+`)
+	// Explicit markers name the kind and keep only the text after them.
+	transcriptActionMarker   = regexp.MustCompile(`(?i)^(?:action(?:\s+item)?|todo|to-do|ai|follow[- ]up|next\s+steps?)\s*[:\-—]\s*(.+)$`)
+	transcriptDecisionMarker = regexp.MustCompile(`(?i)^(?:decision|decided|agreed|resolution)\s*[:\-—]\s*(.+)$`)
+	transcriptCheckbox       = regexp.MustCompile(`^\[ \]\s+(.+)$`)
+	// Phrasings keep the whole sentence: "Ana will rerun the mocks".
+	transcriptActionPhrase   = regexp.MustCompile(`(?i)^(?:i'll|i will|we'll|we will|we need to|we should|let me) \S`)
+	transcriptNamedAction    = regexp.MustCompile(`^([A-Z][a-z]+) will \S`)
+	transcriptDecisionPhrase = regexp.MustCompile(`(?i)^(?:we (?:decided|agreed|chose|are going with|will go with)|let's go with|decision is|the decision is)\b`)
+)
+
+// ExtractTranscriptCandidates scans text line by line for actions and
+// decisions. Explicit markers ("Action:", "TODO:", "Decision:", "- [ ]")
+// always count; otherwise a line counts when it opens with a commitment
+// ("I'll", "we need to", "Ana will") or a decision ("we decided", "let's go
+// with"). Decisions win when a line reads as both.
+func ExtractTranscriptCandidates(text string) []TranscriptCandidate {
+	var out []TranscriptCandidate
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if c, ok := classifyTranscriptLine(line); ok {
+			c.Line, c.Text = i+1, line
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// nonSpeakers are capitalized words that open a "<Word> will" sentence
+// without naming who commits to anything.
+var nonSpeakers = map[string]bool{"It": true, "This": true, "That": true, "There": true, "What": true, "Which": true, "Who": true}
+
+func classifyTranscriptLine(line string) (TranscriptCandidate, bool) {
+	lead := strings.TrimSpace(transcriptLead.ReplaceAllString(line, ""))
+	// Markers are tried before a speaker label is stripped, since "Action:"
+	// is itself shaped like one.
+	for _, body := range []string{lead, strings.TrimSpace(transcriptSpeaker.ReplaceAllString(lead, ""))} {
+		if m := transcriptCheckbox.FindStringSubmatch(body); m != nil {
+			return newTranscriptCandidate(CandidateAction, m[1]), true
+		}
+		if m := transcriptDecisionMarker.FindStringSubmatch(body); m != nil {
+			return newTranscriptCandidate(CandidateDecision, m[1]), true
+		}
+		if m := transcriptActionMarker.FindStringSubmatch(body); m != nil {
+			return newTranscriptCandidate(CandidateAction, m[1]), true
+		}
+	}
+	body := strings.TrimSpace(transcriptSpeaker.ReplaceAllString(lead, ""))
+	switch {
+	case transcriptDecisionPhrase.MatchString(body):
+		return newTranscriptCandidate(CandidateDecision, body), true
+	case transcriptActionPhrase.MatchString(body):
+		return newTranscriptCandidate(CandidateAction, body), true
+	}
+	if m := transcriptNamedAction.FindStringSubmatch(body); m != nil && !nonSpeakers[m[1]] {
+		return newTranscriptCandidate(CandidateAction, body), true
+	}
+	return TranscriptCandidate{}, false
+}
+
+func newTranscriptCandidate(kind, name string) TranscriptCandidate {
+	name = strings.TrimRight(strings.TrimSpace(name), ".;,")
+	if r := []rune(name); len(r) > 0 {
+		name = strings.ToUpper(string(r[0])) + string(r[1:])
+	}
+	return TranscriptCandidate{Kind: kind, Name: name}
+}
+
+// transcriptQuestionMarker names an open question outright; a question is
+// otherwise a line that asks one of the team ("should we …?").
+var (
+	transcriptQuestionMarker = regexp.MustCompile(`(?i)^(?:open question|question|unresolved|tbd|to decide)\s*[:\-—]\s*(.+)$`)
+	transcriptQuestionPhrase = regexp.MustCompile(`(?i)^(?:should we|do we (?:want|need)|are we sure|which (?:one|option|approach) should|whether (?:we|to)) .+\?$`)
+)
+
+// SessionRules are the extra patterns a session transcript is mined with,
+// beyond the built-in ones: each matching line becomes a candidate of its
+// kind, named by its first capture group or, without one, the whole line.
+type SessionRules struct {
+	Decisions []*regexp.Regexp
+	Questions []*regexp.Regexp
+}
+
+// CompileSessionRules compiles the patterns under hook.transcript in
+// .felt/config.yaml.
+func CompileSessionRules(cfg TranscriptHookConfig) (SessionRules, error) {
+	var rules SessionRules
+	for _, group := range []struct {
+		key      string
+		patterns []string
+		into     *[]*regexp.Regexp
+	}{
+		{"decisions", cfg.Decisions, &rules.Decisions},
+		{"questions", cfg.Questions, &rules.Questions},
+	} {
+		for _, pattern := range group.patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return SessionRules{}, fmt.Errorf("%s hook.transcript.%s: %w", ConfigName, group.key, err)
+			}
+			*group.into = append(*group.into, re)
+		}
+	}
+	return rules, nil
+}
+
+// ExtractSessionCandidates scans the text of an agent session for the
+// decisions and unresolved questions in it — the context that lands nowhere
+// when work is tracked only as to-dos. Decisions are found as by
+// ExtractTranscriptCandidates; questions by a marker ("Open question:",
+// "TBD:") or a line asking one ("should we …?"). rules add patterns of
+// either kind, tried first. Actions are left out: an agent's to-dos already
+// have a home.
+func ExtractSessionCandidates(text string, rules SessionRules) []TranscriptCandidate {
+	var out []TranscriptCandidate
+	for i, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if c, ok := classifySessionLine(line, rules); ok {
+			c.Line, c.Text = i+1, line
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func classifySessionLine(line string, rules SessionRules) (TranscriptCandidate, bool) {
+	body := strings.TrimSpace(transcriptLead.ReplaceAllString(line, ""))
+	for _, group := range []struct {
+		kind     string
+		patterns []*regexp.Regexp
+	}{
+		{CandidateDecision, rules.Decisions},
+		{CandidateQuestion, rules.Questions},
+	} {
+		for _, re := range group.patterns {
+			if m := re.FindStringSubmatch(body); m != nil {
+				name := m[0]
+				if len(m) > 1 && m[1] != "" {
+					name = m[1]
+				}
+				return newTranscriptCandidate(group.kind, name), true
+			}
+		}
+	}
+	if m := transcriptQuestionMarker.FindStringSubmatch(body); m != nil {
+		return newTranscriptCandidate(CandidateQuestion, m[1]), true
+	}
+	if transcriptQuestionPhrase.MatchString(body) {
+		return TranscriptCandidate{Kind: CandidateQuestion, Name: body}, true
+	}
+	if c, ok := classifyTranscriptLine(line); ok && c.Kind == CandidateDecision {
+		return c, true
+	}
+	return TranscriptCandidate{}, false
+}
@@ -0,0 +1,68 @@
+package felt
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTranscriptCandidates(t *testing.T) {
+	text := `Telecon 2026-05-12
+
+[00:01:12] Ana: The mocks look about 3% high at small scales.
+[00:02:40] Ben: I'll rerun them at double resolution.
+Ana: Decision: keep the Gaussian damping prior.
+- [ ] update the covariance notebook
+Action item: send the DR1 checksums to Carla.
+Carla will check the ELG randoms
+It will take a while to converge.
+We decided to drop the flat prior entirely.
+TODO - write up the jackknife test`
+
+	got := ExtractTranscriptCandidates(text)
+	want := []TranscriptCandidate{
+		{Kind: CandidateAction, Name: "I'll rerun them at double resolution", Line: 4},
+		{Kind: CandidateDecision, Name: "Keep the Gaussian damping prior", Line: 5},
+		{Kind: CandidateAction, Name: "Update the covariance notebook", Line: 6},
+		{Kind: CandidateAction, Name: "Send the DR1 checksums to Carla", Line: 7},
+		{Kind: CandidateAction, Name: "Carla will check the ELG randoms", Line: 8},
+		{Kind: CandidateDecision, Name: "We decided to drop the flat prior entirely", Line: 10},
+		{Kind: CandidateAction, Name: "Write up the jackknife test", Line: 11},
+	}
+	for i := range got {
+		got[i].Text = ""
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("candidates:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestExtractSessionCandidates(t *testing.T) {
+	text := `I'll rerun the mocks first.
+We decided to keep the Gaussian damping prior.
+Should we mask the low-z bins as well?
+Open question: does the covariance need the super-sample term?
+Why does this test fail?
+Going with: fixed-width bins for the release`
+
+	rules, err := CompileSessionRules(TranscriptHookConfig{Decisions: []string{`(?i)^going with:\s*(.+)$`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := ExtractSessionCandidates(text, rules)
+	want := []TranscriptCandidate{
+		{Kind: CandidateDecision, Name: "We decided to keep the Gaussian damping prior", Line: 2},
+		{Kind: CandidateQuestion, Name: "Should we mask the low-z bins as well?", Line: 3},
+		{Kind: CandidateQuestion, Name: "Does the covariance need the super-sample term?", Line: 4},
+		{Kind: CandidateDecision, Name: "Fixed-width bins for the release", Line: 6},
+	}
+	for i := range got {
+		got[i].Text = ""
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("candidates:\n got %+v\nwant %+v", got, want)
+	}
+
+	if _, err := CompileSessionRules(TranscriptHookConfig{Questions: []string{"("}}); err == nil {
+		t.Fatalf("CompileSessionRules accepted an invalid pattern")
+	}
+}
@@ -0,0 +1,67 @@
+package felt
+
+import "strings"
+
+// translitGroups maps runes to ASCII, one replacement per group of
+// lowercase sources. It covers the Latin accents, Greek, and Cyrillic that
+// titles commonly carry; scripts with no letter-by-letter romanization
+// (Japanese, Chinese, ...) are left as they are.
+var translitGroups = []struct{ from, to string }{
+	{"àáâãäåāăąǎ", "a"}, {"çćĉċč", "c"}, {"ďđ", "d"}, {"èéêëēĕėęěə", "e"},
+	{"ĝğġģ", "g"}, {"ĥħ", "h"}, {"ìíîïĩīĭįıǐ", "i"}, {"ĵ", "j"}, {"ķ", "k"},
+	{"ĺļľŀł", "l"}, {"ñńņňŉ", "n"}, {"òóôõöøōŏőǒ", "o"}, {"ŕŗř", "r"},
+	{"śŝşšș", "s"}, {"ţťŧț", "t"}, {"ùúûüũūŭůűųǔ", "u"}, {"ŵ", "w"},
+	{"ýÿŷ", "y"}, {"źżž", "z"},
+	{"ß", "ss"}, {"æ", "ae"}, {"œ", "oe"}, {"þ", "th"}, {"ð", "d"},
+	// Greek
+	{"αά", "a"}, {"β", "v"}, {"γ", "g"}, {"δ", "d"}, {"εέ", "e"}, {"ζ", "z"},
+	{"ηή", "i"}, {"θ", "th"}, {"ιίϊΐ", "i"}, {"κ", "k"}, {"λ", "l"}, {"μ", "m"},
+	{"ν", "n"}, {"ξ", "x"}, {"οό", "o"}, {"π", "p"}, {"ρ", "r"}, {"σς", "s"},
+	{"τ", "t"}, {"υύϋΰ", "y"}, {"φ", "f"}, {"χ", "ch"}, {"ψ", "ps"}, {"ωώ", "o"},
+	// Cyrillic
+	{"а", "a"}, {"б", "b"}, {"в", "v"}, {"гґ", "g"}, {"д", "d"}, {"её", "e"},
+	{"є", "ye"}, {"ж", "zh"}, {"з", "z"}, {"иі", "i"}, {"ї", "yi"}, {"й", "y"},
+	{"к", "k"}, {"л", "l"}, {"м", "m"}, {"н", "n"}, {"о", "o"}, {"п", "p"},
+	{"р", "r"}, {"с", "s"}, {"т", "t"}, {"у", "u"}, {"ф", "f"}, {"х", "kh"},
+	{"ц", "ts"}, {"ч", "ch"}, {"ш", "sh"}, {"щ", "shch"}, {"ъь", ""}, {"ы", "y"},
+	{"э", "e"}, {"ю", "yu"}, {"я", "ya"},
+}
+
+var translitTable = buildTranslitTable()
+
+func buildTranslitTable() map[rune]string {
+	table := make(map[rune]string)
+	for _, g := range translitGroups {
+		for _, r := range g.from {
+			table[r] = g.to
+		}
+	}
+	return table
+}
+
+// Transliterate lowercases s and romanizes the letters it has a mapping
+// for ("Über Größe" → "uber grosse", "Ковариация" → "kovariatsiya").
+// Runes without one pass through unchanged, so a title in an unmapped script
+// still slugifies to its own letters rather than to nothing. Enabled for new
+// slugs by `slug.transliterate: true` in .felt/config.yaml.
+func Transliterate(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if to, ok := translitTable[r]; ok {
+			sb.WriteString(to)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// TransliteratePath transliterates only the final segment of a slug path:
+// prefix segments name existing directories and must match them as spelled.
+func TransliteratePath(s string) string {
+	idx := strings.LastIndex(s, "/")
+	if idx < 0 {
+		return Transliterate(s)
+	}
+	return s[:idx+1] + Transliterate(s[idx+1:])
+}
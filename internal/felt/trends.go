@@ -0,0 +1,89 @@
+package felt
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultTrendWeeks is how many weeks `felt stats --trends` looks back.
+const DefaultTrendWeeks = 8
+
+// TagTrend is one tag's weekly history. Open[i] is how many of its tracked
+// fibers were open or active at the end of week i; Closed[i] is how many
+// closed during it. ClosedPerWeek is Closed's mean.
+type TagTrend struct {
+	Tag           string  `json:"tag"`
+	Open          []int   `json:"open"`
+	Closed        []int   `json:"closed"`
+	ClosedPerWeek float64 `json:"closed_per_week"`
+}
+
+// Trends is the per-tag history behind `felt stats --trends`: one TagTrend
+// per tag over the weeks ending at WeekEnds, oldest first.
+type Trends struct {
+	WeekEnds []time.Time `json:"week_ends"`
+	Tags     []TagTrend  `json:"tags"`
+}
+
+// TagTrends replays the last weeks weeks up to now from each tracked
+// fiber's created-at and closed-at, per tag that match accepts (every tag
+// when match is nil). A fiber counts toward each of its tags. Status
+// changes short of closing, and a reopen, leave no dates behind, so a
+// reopened fiber reads as open since it was created. Tags with no fiber
+// open or closed in the window are left out; the rest are ordered by
+// current open count, most first, then name.
+func TagTrends(felts []*Felt, match func(tag string) bool, weeks int, now time.Time) *Trends {
+	if weeks < 1 {
+		weeks = 1
+	}
+	t := &Trends{WeekEnds: make([]time.Time, weeks)}
+	for i := range t.WeekEnds {
+		t.WeekEnds[i] = now.AddDate(0, 0, -7*(weeks-1-i))
+	}
+	byTag := make(map[string]*TagTrend)
+	for _, f := range felts {
+		if !f.HasStatus() {
+			continue
+		}
+		for _, tag := range f.Tags {
+			if match != nil && !match(tag) {
+				continue
+			}
+			tr := byTag[tag]
+			if tr == nil {
+				tr = &TagTrend{Tag: tag, Open: make([]int, weeks), Closed: make([]int, weeks)}
+				byTag[tag] = tr
+			}
+			for i, end := range t.WeekEnds {
+				start := end.AddDate(0, 0, -7)
+				closedBy := f.IsClosed() && f.ClosedAt != nil && !f.ClosedAt.After(end)
+				if !f.CreatedAt.After(end) && !closedBy {
+					tr.Open[i]++
+				}
+				if f.IsClosed() && f.ClosedAt != nil && f.ClosedAt.After(start) && !f.ClosedAt.After(end) {
+					tr.Closed[i]++
+				}
+			}
+		}
+	}
+	for _, tr := range byTag {
+		active, closed := false, 0
+		for i := range tr.Open {
+			active = active || tr.Open[i] > 0 || tr.Closed[i] > 0
+			closed += tr.Closed[i]
+		}
+		if !active {
+			continue
+		}
+		tr.ClosedPerWeek = float64(closed) / float64(weeks)
+		t.Tags = append(t.Tags, *tr)
+	}
+	sort.Slice(t.Tags, func(i, j int) bool {
+		a, b := t.Tags[i].Open[weeks-1], t.Tags[j].Open[weeks-1]
+		if a != b {
+			return a > b
+		}
+		return t.Tags[i].Tag < t.Tags[j].Tag
+	})
+	return t
+}
@@ -0,0 +1,35 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTagTrendsReplaysOpenCountsAndClosures(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	at := func(days int) *time.Time { t := now.AddDate(0, 0, -days); return &t }
+	felts := []*Felt{
+		{ID: "a", Status: StatusClosed, Tags: []string{"x"}, CreatedAt: *at(20), ClosedAt: at(10)},
+		{ID: "b", Status: StatusOpen, Tags: []string{"x", "y"}, CreatedAt: *at(9)},
+		{ID: "c", Status: StatusClosed, Tags: []string{"y"}, CreatedAt: *at(2), ClosedAt: at(1)},
+		{ID: "note", Tags: []string{"x"}, CreatedAt: *at(20)},
+		{ID: "old", Status: StatusClosed, Tags: []string{"z"}, CreatedAt: *at(90), ClosedAt: at(80)},
+	}
+	tr := TagTrends(felts, nil, 3, now)
+	if len(tr.WeekEnds) != 3 || !tr.WeekEnds[0].Equal(now.AddDate(0, 0, -14)) {
+		t.Fatalf("week ends = %v", tr.WeekEnds)
+	}
+	if len(tr.Tags) != 2 {
+		t.Fatalf("tags = %+v, want x and y only (z idle, notes untracked)", tr.Tags)
+	}
+	x, y := tr.Tags[0], tr.Tags[1]
+	if x.Tag != "x" || x.Open[0] != 1 || x.Open[1] != 1 || x.Open[2] != 1 || x.Closed[1] != 1 {
+		t.Fatalf("x = %+v", x)
+	}
+	if y.Tag != "y" || y.Open[1] != 1 || y.Open[2] != 1 || y.Closed[2] != 1 || y.ClosedPerWeek != 1.0/3 {
+		t.Fatalf("y = %+v", y)
+	}
+	if got := TagTrends(felts, func(tag string) bool { return tag == "y" }, 3, now); len(got.Tags) != 1 || got.Tags[0].Tag != "y" {
+		t.Fatalf("matched = %+v", got.Tags)
+	}
+}
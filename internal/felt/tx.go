@@ -0,0 +1,419 @@
+package felt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// txDirPrefix names transaction staging directories, `.felt/.tx-*/`. A
+// staging directory holds a transaction's new file contents until commit,
+// then the backups and journal that let an interrupted commit be undone.
+// None of its files end in .md, so the fiber walk never lists them.
+const txDirPrefix = ".tx-"
+
+// txJournalName is the commit journal inside a staging directory. It is
+// written only once every backup is in place, so its presence means the
+// commit may have started replacing files.
+const txJournalName = "journal.json"
+
+// txCommittedName is the commit record inside a staging directory, written
+// once every step has landed and before the directory is removed. A crash
+// in between leaves a finished commit, which recovery keeps rather than
+// undoes.
+const txCommittedName = "committed"
+
+// txLockName is the file a transaction holds an exclusive flock on for as
+// long as it lives. The kernel drops the lock when its process exits, so a
+// staging directory recovery can lock is one nobody is committing.
+const txLockName = "lock"
+
+// txStaleAfter is how long a staging directory without a lock file — one
+// left by an older felt, or caught between creation and locking — must sit
+// untouched before Begin treats it as abandoned.
+const txStaleAfter = 10 * time.Minute
+
+// Tx is a batch of fiber writes and deletes that land together or not at
+// all. Writes are staged in a directory inside the store, so nothing
+// outside it changes until Commit; Commit backs up every file it will
+// replace before replacing any, and restores them all if a step fails.
+// Reads during a transaction see the store as it was before Begin.
+type Tx struct {
+	s      *Storage
+	dir    string
+	steps  []txStep
+	unlock func() error
+}
+
+// txStep is one file change: Staged names the file in the staging
+// directory holding its new contents, or is empty for a delete, and SHA256
+// is those contents' hash. Backup, set during commit, holds the prior
+// contents when the file existed.
+type txStep struct {
+	Path   string `json:"path"`
+	Staged string `json:"staged,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+	Backup string `json:"backup,omitempty"`
+}
+
+// Begin starts a transaction, first recovering any staging directory a
+// crashed commit left behind.
+func (s *Storage) Begin() (*Tx, error) {
+	s.recoverTransactions()
+	ensureGitignoreCovers(s.root, txDirPrefix+"*/")
+	dir, err := os.MkdirTemp(s.root, txDirPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+	f, err := os.OpenFile(filepath.Join(dir, txLockName), os.O_RDWR|os.O_CREATE, 0644)
+	if err == nil {
+		if err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+			f.Close()
+		}
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+	return &Tx{s: s, dir: dir, unlock: f.Close}, nil
+}
+
+// Write stages f, as Storage.Write would write it.
+func (tx *Tx) Write(f *Felt) error {
+	if f == nil {
+		return fmt.Errorf("cannot write nil felt")
+	}
+	writes, err := fiberFileWrites(f, tx.s.Path(f.ID))
+	if err != nil {
+		return err
+	}
+	for _, w := range writes {
+		if err := tx.stage(w.path, w.data, f.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFile stages data as the new contents of path, a file in the store.
+func (tx *Tx) WriteFile(path string, data []byte) error {
+	rel, _ := filepath.Rel(tx.s.root, path)
+	return tx.stage(path, data, filepath.ToSlash(rel))
+}
+
+// stage stages data for path, refusing a path outside the store or one that
+// differs only in case from an existing entry, as Storage.Write does; name
+// is what the refusal calls it.
+func (tx *Tx) stage(path string, data []byte, name string) error {
+	if !tx.s.pathInStore(path) {
+		return fmt.Errorf("%s is outside the felt store", path)
+	}
+	if err := tx.s.refuseCaseCollision(path, name); err != nil {
+		return err
+	}
+	staged := fmt.Sprintf("%04d.new", len(tx.steps))
+	if err := os.WriteFile(filepath.Join(tx.dir, staged), data, 0644); err != nil {
+		return fmt.Errorf("staging %s: %w", path, err)
+	}
+	tx.steps = append(tx.steps, txStep{Path: path, Staged: staged, SHA256: sha256Hex(data)})
+	return nil
+}
+
+// Remove stages deleting path, a file in the store.
+func (tx *Tx) Remove(path string) error {
+	if !tx.s.pathInStore(path) {
+		return fmt.Errorf("%s is outside the felt store", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("deleting file %s: %w", path, err)
+	}
+	tx.steps = append(tx.steps, txStep{Path: path})
+	return nil
+}
+
+// Delete stages removing the fiber id and its body sidecar, as
+// Storage.Delete would.
+func (tx *Tx) Delete(id string) error {
+	path := tx.s.Path(id)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("deleting file %s: %w", path, err)
+	}
+	if f, err := readMetadataFile(path, id); err == nil {
+		if bodyPath, ok, err := bodyFilePath(f, path); err == nil && ok {
+			tx.steps = append(tx.steps, txStep{Path: bodyPath})
+		}
+	}
+	tx.steps = append(tx.steps, txStep{Path: path})
+	return nil
+}
+
+// Rollback discards the transaction. It is a no-op after Commit, so it can
+// be deferred.
+func (tx *Tx) Rollback() {
+	if tx.dir != "" {
+		os.RemoveAll(tx.dir)
+		tx.unlock()
+		tx.dir = ""
+	}
+}
+
+// Commit applies the staged changes. If any step fails, every file already
+// changed is restored and the error is returned.
+func (tx *Tx) Commit() error {
+	if tx.dir == "" {
+		return fmt.Errorf("transaction already finished")
+	}
+	defer tx.Rollback()
+	if tx.s.patches != nil {
+		return tx.emitPatches()
+	}
+
+	backedUp := make(map[string]bool)
+	for i := range tx.steps {
+		step := &tx.steps[i]
+		if backedUp[step.Path] {
+			continue
+		}
+		backedUp[step.Path] = true
+		data, err := os.ReadFile(step.Path)
+		if os.IsNotExist(err) || isNotDirError(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("backing up %s: %w", step.Path, err)
+		}
+		step.Backup = fmt.Sprintf("%04d.orig", i)
+		if err := os.WriteFile(filepath.Join(tx.dir, step.Backup), data, 0644); err != nil {
+			return fmt.Errorf("backing up %s: %w", step.Path, err)
+		}
+	}
+	journal, err := json.Marshal(tx.steps)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tx.dir, txJournalName), journal, 0644); err != nil {
+		return fmt.Errorf("writing transaction journal: %w", err)
+	}
+
+	for i, step := range tx.steps {
+		if err := tx.apply(step); err != nil {
+			if rbErr := undoSteps(tx.s, tx.dir, tx.steps[:i+1]); rbErr != nil {
+				return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+			}
+			return fmt.Errorf("%w (no changes kept)", err)
+		}
+	}
+	// Every step has landed; a crash before Rollback removes the directory
+	// must not undo them. If the record cannot be written the commit still
+	// stands, and only a crash in the next instant would lose it.
+	_ = os.WriteFile(filepath.Join(tx.dir, txCommittedName), nil, 0644)
+	return nil
+}
+
+// emitPatches prints what Commit would change instead of changing it: one
+// diff per path, against its final staged state.
+func (tx *Tx) emitPatches() error {
+	last := make(map[string]txStep)
+	var order []string
+	for _, step := range tx.steps {
+		if _, ok := last[step.Path]; !ok {
+			order = append(order, step.Path)
+		}
+		last[step.Path] = step
+	}
+	for _, path := range order {
+		step := last[path]
+		var data []byte
+		if step.Staged != "" {
+			var err error
+			if data, err = os.ReadFile(filepath.Join(tx.dir, step.Staged)); err != nil {
+				return err
+			}
+		}
+		if err := tx.s.emitPatch(path, data, step.Staged != ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tx *Tx) apply(step txStep) error {
+	if step.Staged == "" {
+		if err := os.Remove(step.Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("deleting file %s: %w", step.Path, err)
+		}
+		return tx.s.pruneEmptyDirs(filepath.Dir(step.Path))
+	}
+	if err := os.MkdirAll(filepath.Dir(step.Path), 0755); err != nil {
+		return fmt.Errorf("creating directory %s: %w", filepath.Dir(step.Path), err)
+	}
+	staged := filepath.Join(tx.dir, step.Staged)
+	if err := os.Rename(staged, step.Path); err == nil {
+		return nil
+	}
+	// A symlinked sub-store can put the target on another filesystem, where
+	// rename cannot reach; copy there instead.
+	data, err := os.ReadFile(staged)
+	if err == nil {
+		err = os.WriteFile(step.Path, data, 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("writing file %s: %w", step.Path, err)
+	}
+	return nil
+}
+
+// undoSteps puts back every path steps touched, newest first: from its
+// backup when it existed before, else by removing it and any directories
+// left empty. Undoing a step that never ran is harmless, which is what lets
+// recovery undo a whole journal without knowing how far the commit got.
+func undoSteps(s *Storage, dir string, steps []txStep) error {
+	backups := make(map[string]string)
+	for _, step := range steps {
+		if step.Backup != "" {
+			backups[step.Path] = step.Backup
+		}
+	}
+	var firstErr error
+	done := make(map[string]bool)
+	for i := len(steps) - 1; i >= 0; i-- {
+		path := steps[i].Path
+		if done[path] {
+			continue
+		}
+		done[path] = true
+		var err error
+		if backup, ok := backups[path]; ok {
+			var data []byte
+			if data, err = os.ReadFile(filepath.Join(dir, backup)); err == nil {
+				if err = os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+					err = os.WriteFile(path, data, 0644)
+				}
+			}
+		} else if err = os.Remove(path); err == nil {
+			err = s.pruneEmptyDirs(filepath.Dir(path))
+		} else if os.IsNotExist(err) || isNotDirError(err) {
+			err = nil
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// recoverTransactions clears staging directories abandoned by a crashed
+// felt. One holding a commit record is finished and simply removed; one
+// with only a journal had begun its commit and is undone first, unless a
+// file it touched has changed since, which the directory is then left for
+// a person to sort out. Directories another felt still holds the lock on
+// are left to it.
+func (s *Storage) recoverTransactions() {
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), txDirPrefix) {
+			continue
+		}
+		dir := filepath.Join(s.root, e.Name())
+		unlock, ok := lockAbandonedTx(dir, e)
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, txCommittedName)); err != nil {
+			if data, err := os.ReadFile(filepath.Join(dir, txJournalName)); err == nil {
+				var steps []txStep
+				if json.Unmarshal(data, &steps) != nil || !txUntouchedSince(dir, steps) || undoSteps(s, dir, steps) != nil {
+					unlock()
+					continue // keep the backups for a person to sort out
+				}
+			}
+		}
+		os.RemoveAll(dir)
+		unlock()
+	}
+}
+
+// lockAbandonedTx takes the lock on staging directory dir, reporting false
+// when its transaction is still alive. A directory with no lock file falls
+// back to txStaleAfter.
+func lockAbandonedTx(dir string, e os.DirEntry) (unlock func() error, ok bool) {
+	f, err := os.OpenFile(filepath.Join(dir, txLockName), os.O_RDWR, 0)
+	if os.IsNotExist(err) {
+		info, err := e.Info()
+		if err != nil || time.Since(info.ModTime()) < txStaleAfter {
+			return nil, false
+		}
+		return func() error { return nil }, true
+	}
+	if err != nil {
+		return nil, false
+	}
+	if syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB) != nil {
+		f.Close()
+		return nil, false
+	}
+	return f.Close, true
+}
+
+// txUntouchedSince reports whether every path steps name is still in a
+// state the interrupted commit could have left it in: its backup, the
+// contents a step wrote, or absent where the commit deleted it or it did
+// not exist before. Anything else is an edit made after the crash, which
+// undoing would destroy.
+func txUntouchedSince(dir string, steps []txStep) bool {
+	known := make(map[string]map[string]bool) // path → acceptable hashes; "" is absent
+	hadBackup := make(map[string]bool)
+	for _, step := range steps {
+		if known[step.Path] == nil {
+			known[step.Path] = make(map[string]bool)
+		}
+		known[step.Path][step.SHA256] = true // a delete's empty hash is absent
+		if step.Backup != "" {
+			data, err := os.ReadFile(filepath.Join(dir, step.Backup))
+			if err != nil {
+				return false
+			}
+			known[step.Path][sha256Hex(data)] = true
+			hadBackup[step.Path] = true
+		}
+	}
+	for path, hashes := range known {
+		if !hadBackup[path] {
+			hashes[""] = true
+		}
+		data, err := os.ReadFile(path)
+		current := ""
+		switch {
+		case err == nil:
+			current = sha256Hex(data)
+		case !os.IsNotExist(err) && !isNotDirError(err):
+			return false
+		}
+		if !hashes[current] {
+			return false
+		}
+	}
+	return true
+}
+
+// sha256Hex is data's hex-encoded SHA-256.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// isNotDirError reports whether err is ENOTDIR: a path component is a
+// file, so the path itself cannot exist.
+func isNotDirError(err error) bool {
+	return errors.Is(err, syscall.ENOTDIR)
+}
@@ -0,0 +1,249 @@
+package felt
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func txStagingDirs(t *testing.T, s *Storage) []string {
+	t.Helper()
+	dirs, err := filepath.Glob(filepath.Join(s.root, txDirPrefix+"*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dirs
+}
+
+func TestTxCommitsWritesAndDeletes(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	for _, id := range []string{"keep", "gone"} {
+		if err := s.Write(&Felt{ID: id, Name: id, CreatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tx, err := s.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := tx.Write(&Felt{ID: "keep", Name: "Kept", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Write(&Felt{ID: "keep/new", Name: "New", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Delete("gone"); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := s.Read("keep"); f.Name != "keep" {
+		t.Fatalf("staged write visible before commit: %q", f.Name)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	if f, _ := s.Read("keep"); f.Name != "Kept" {
+		t.Fatalf("keep = %q after commit", f.Name)
+	}
+	if _, err := s.Read("keep/new"); err != nil {
+		t.Fatalf("new fiber missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(s.root, "gone")); !os.IsNotExist(err) {
+		t.Fatalf("deleted fiber's directory remains: %v", err)
+	}
+	if dirs := txStagingDirs(t, s); len(dirs) != 0 {
+		t.Fatalf("staging left behind: %v", dirs)
+	}
+	felts, _ := s.List()
+	if len(felts) != 2 {
+		t.Fatalf("List() = %d fibers, want 2", len(felts))
+	}
+}
+
+func TestTxRollsBackAFailedCommit(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "a", Name: "A", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.ReadFile(s.Path("a"))
+	// A plain file where a directory must go fails the second step.
+	if err := os.WriteFile(filepath.Join(s.root, "blocker"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := s.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if err := tx.Write(&Felt{ID: "a", Name: "Changed", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Write(&Felt{ID: "blocker/child", Name: "Child", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err == nil || !strings.Contains(err.Error(), "no changes kept") {
+		t.Fatalf("Commit = %v, want a rolled-back error", err)
+	}
+	if after, _ := os.ReadFile(s.Path("a")); string(after) != string(before) {
+		t.Fatalf("a not restored:\n%s", after)
+	}
+	if dirs := txStagingDirs(t, s); len(dirs) != 0 {
+		t.Fatalf("staging left behind: %v", dirs)
+	}
+
+	// Rollback before commit discards everything staged.
+	tx, _ = s.Begin()
+	if err := tx.Write(&Felt{ID: "a", Name: "Discarded", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	tx.Rollback()
+	if after, _ := os.ReadFile(s.Path("a")); string(after) != string(before) {
+		t.Fatalf("rolled-back write landed:\n%s", after)
+	}
+	if err := tx.Commit(); err == nil {
+		t.Fatal("Commit after Rollback should fail")
+	}
+}
+
+func TestBeginRecoversAnInterruptedCommit(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStorage(dir)
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "a", Name: "A", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.ReadFile(s.Path("a"))
+
+	// Simulate a crash mid-commit: backups and journal written, a already
+	// replaced, b (new) already created.
+	tx, err := s.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Write(&Felt{ID: "a", Name: "Half", CreatedAt: time.Now()})
+	tx.Write(&Felt{ID: "b", Name: "B", CreatedAt: time.Now()})
+	tx.steps[0].Backup = "0000.orig"
+	os.WriteFile(filepath.Join(tx.dir, "0000.orig"), before, 0644)
+	journal, _ := json.Marshal(tx.steps)
+	os.WriteFile(filepath.Join(tx.dir, txJournalName), journal, 0644)
+	tx.apply(tx.steps[0])
+	tx.apply(tx.steps[1])
+	tx.unlock() // the crashed process's lock dies with it
+
+	next, err := s.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	defer next.Rollback()
+	if after, _ := os.ReadFile(s.Path("a")); string(after) != string(before) {
+		t.Fatalf("a not recovered:\n%s", after)
+	}
+	if _, err := os.Stat(filepath.Join(s.root, "b")); !os.IsNotExist(err) {
+		t.Fatalf("half-created fiber b survived recovery: %v", err)
+	}
+	if dirs := txStagingDirs(t, s); len(dirs) != 1 || dirs[0] != next.dir {
+		t.Fatalf("staging dirs = %v, want only the new transaction's", dirs)
+	}
+}
+
+// crashAfterApply commits id's rename to name as far as the step loop and
+// then "crashes": the staging directory stays, and its lock is released.
+func crashAfterApply(t *testing.T, s *Storage, id, name string, committed bool) {
+	t.Helper()
+	tx, err := s.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.ReadFile(s.Path(id))
+	if err := tx.Write(&Felt{ID: id, Name: name, CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	tx.steps[0].Backup = "0000.orig"
+	os.WriteFile(filepath.Join(tx.dir, "0000.orig"), before, 0644)
+	journal, _ := json.Marshal(tx.steps)
+	os.WriteFile(filepath.Join(tx.dir, txJournalName), journal, 0644)
+	if err := tx.apply(tx.steps[0]); err != nil {
+		t.Fatal(err)
+	}
+	if committed {
+		os.WriteFile(filepath.Join(tx.dir, txCommittedName), nil, 0644)
+	}
+	tx.unlock()
+}
+
+func TestBeginKeepsACommitThatFinished(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "a", Name: "A", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	crashAfterApply(t, s, "a", "Done", true)
+
+	next, err := s.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	defer next.Rollback()
+	if f, _ := s.Read("a"); f.Name != "Done" {
+		t.Fatalf("finished commit undone: a = %q", f.Name)
+	}
+	if dirs := txStagingDirs(t, s); len(dirs) != 1 || dirs[0] != next.dir {
+		t.Fatalf("staging dirs = %v, want only the new transaction's", dirs)
+	}
+}
+
+func TestBeginLeavesLiveAndEditedTransactions(t *testing.T) {
+	s := NewStorage(t.TempDir())
+	if err := s.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	if err := s.Write(&Felt{ID: "a", Name: "A", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file edited after the crash is not rolled back over.
+	crashAfterApply(t, s, "a", "Half", false)
+	if err := s.Write(&Felt{ID: "a", Name: "Edited", CreatedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	next, err := s.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	if f, _ := s.Read("a"); f.Name != "Edited" {
+		t.Fatalf("recovery clobbered a later edit: a = %q", f.Name)
+	}
+	if dirs := txStagingDirs(t, s); len(dirs) != 2 {
+		t.Fatalf("staging dirs = %v, want the crashed one kept", dirs)
+	}
+
+	// A transaction still holding its lock is never recovered, however old.
+	stale := time.Now().Add(-time.Hour)
+	os.Chtimes(next.dir, stale, stale)
+	os.WriteFile(filepath.Join(next.dir, txJournalName), []byte("[]"), 0644)
+	other, err := s.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %v", err)
+	}
+	defer other.Rollback()
+	if _, err := os.Stat(next.dir); err != nil {
+		t.Fatalf("live transaction recovered: %v", err)
+	}
+	next.Rollback()
+}
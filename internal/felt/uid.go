@@ -0,0 +1,108 @@
+package felt
+
+import (
+	"sort"
+	"strings"
+)
+
+// DuplicateUID is an intrinsic id carried by more than one fiber — almost
+// always a fiber directory copied by hand, which duplicates its frontmatter
+// id with it. IDs are ordered oldest first, so IDs[0] is the likely original.
+type DuplicateUID struct {
+	UID string   `json:"uid"`
+	IDs []string `json:"ids"`
+}
+
+// FindDuplicateUIDs returns every intrinsic id shared by two or more fibers,
+// sorted by id. Matching is case-insensitive, as lookups are.
+func (s *Storage) FindDuplicateUIDs() ([]DuplicateUID, error) {
+	felts, err := s.ListMetadata()
+	if err != nil {
+		return nil, err
+	}
+	byUID := make(map[string][]*Felt)
+	for _, f := range felts {
+		if f.UID == "" {
+			continue
+		}
+		key := strings.ToUpper(f.UID)
+		byUID[key] = append(byUID[key], f)
+	}
+	var dups []DuplicateUID
+	for _, group := range byUID {
+		if len(group) < 2 {
+			continue
+		}
+		sort.Slice(group, func(i, j int) bool {
+			if !group[i].CreatedAt.Equal(group[j].CreatedAt) {
+				return group[i].CreatedAt.Before(group[j].CreatedAt)
+			}
+			return group[i].ID < group[j].ID
+		})
+		dup := DuplicateUID{UID: group[0].UID}
+		for _, f := range group {
+			dup.IDs = append(dup.IDs, f.ID)
+		}
+		dups = append(dups, dup)
+	}
+	sort.Slice(dups, func(i, j int) bool { return dups[i].UID < dups[j].UID })
+	return dups, nil
+}
+
+// RemintUID gives the fiber at id a freshly minted intrinsic id and returns
+// it. References that named the old id keep resolving to whichever fiber
+// still carries it.
+func (s *Storage) RemintUID(id string) (string, error) {
+	taken, err := s.takenUIDs()
+	if err != nil {
+		return "", err
+	}
+	f, err := s.Read(id)
+	if err != nil {
+		return "", err
+	}
+	f.UID = mintAvailableUID(f.ID, taken)
+	if err := s.Write(f); err != nil {
+		return "", err
+	}
+	return f.UID, nil
+}
+
+// EnsureAvailableUID re-mints f's intrinsic id if another fiber in the store
+// already carries it, so a new fiber never starts life sharing an identity.
+func (s *Storage) EnsureAvailableUID(f *Felt) error {
+	taken, err := s.takenUIDs()
+	if err != nil {
+		return err
+	}
+	if f.UID == "" || taken[strings.ToUpper(f.UID)] {
+		f.UID = mintAvailableUID(f.ID, taken)
+	}
+	return nil
+}
+
+func (s *Storage) takenUIDs() (map[string]bool, error) {
+	felts, err := s.ListMetadata()
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(felts))
+	for _, f := range felts {
+		if f.UID != "" {
+			taken[strings.ToUpper(f.UID)] = true
+		}
+	}
+	return taken, nil
+}
+
+// mintAvailableUID mints an intrinsic id for slug that is not in taken. A
+// random ULID collision is vanishingly unlikely; the retry exists for seeded
+// ids, which a re-created slug or a hand-copied fiber can reproduce, and
+// falls back to random ULIDs.
+func mintAvailableUID(slug string, taken map[string]bool) string {
+	uid := NewUID(slug)
+	for taken[strings.ToUpper(uid)] {
+		uid = NewULID()
+	}
+	return uid
+}
@@ -0,0 +1,47 @@
+package felt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SessionMarkName is the store's session watermark, `.felt/session-mark`:
+// the time the session context was last built, so the next one can say what
+// changed in between. It is local bookkeeping — one agent's last look, not
+// the project's — so the store's .gitignore covers it.
+const SessionMarkName = "session-mark"
+
+// SessionMarkPath returns the path of the store's session watermark.
+func (s *Storage) SessionMarkPath() string {
+	return filepath.Join(s.root, SessionMarkName)
+}
+
+// SessionMark returns the recorded watermark. ok is false when none has been
+// recorded yet, or the file does not hold one.
+func (s *Storage) SessionMark() (time.Time, bool, error) {
+	data, err := os.ReadFile(s.SessionMarkPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("reading %s: %w", SessionMarkName, err)
+	}
+	t, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(data)))
+	if err != nil {
+		return time.Time{}, false, nil
+	}
+	return t, true, nil
+}
+
+// SetSessionMark records t as the watermark.
+func (s *Storage) SetSessionMark(t time.Time) error {
+	ensureGitignoreCovers(s.root, SessionMarkName)
+	if err := os.WriteFile(s.SessionMarkPath(), []byte(t.UTC().Format(time.RFC3339Nano)+"\n"), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", SessionMarkName, err)
+	}
+	return nil
+}
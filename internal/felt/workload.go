@@ -0,0 +1,134 @@
+package felt
+
+import (
+	"sort"
+	"time"
+)
+
+// WorkloadDueLimit is how many of an owner's nearest due dates a Workload
+// lists.
+const WorkloadDueLimit = 3
+
+// DueFiber is an unfinished fiber with a due date, as a Workload lists it.
+type DueFiber struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Due  string `json:"due"`
+}
+
+// Overcommit records the earliest due date by which an owner's estimated
+// work exceeds their capacity: Estimated is due by By, with Capacity
+// available from today through it.
+type Overcommit struct {
+	By        string        `json:"by"`
+	Estimated time.Duration `json:"estimated"`
+	Capacity  time.Duration `json:"capacity"`
+}
+
+// Workload is one owner's share of the unfinished work. Owner is "" for
+// fibers nobody owns. Estimated sums the span estimates of the open and
+// active fibers; Unestimated counts those without one. Due lists the
+// nearest due dates, overdue first.
+type Workload struct {
+	Owner       string        `json:"owner"`
+	Active      int           `json:"active"`
+	Open        int           `json:"open"`
+	Estimated   time.Duration `json:"estimated"`
+	Unestimated int           `json:"unestimated"`
+	Due         []DueFiber    `json:"due"`
+	Overdue     int           `json:"overdue"`
+	OverActive  bool          `json:"over_active"`
+	Overcommit  *Overcommit   `json:"overcommit,omitempty"`
+}
+
+// Overloaded reports whether w trips any overload flag: overdue work, more
+// active fibers than the per-owner limit, or more estimated work due than
+// capacity covers.
+func (w Workload) Overloaded() bool {
+	return w.Overdue > 0 || w.OverActive || w.Overcommit != nil
+}
+
+// Workloads groups the open and active fibers by owner. maxActive (zero for
+// none) flags owners with more fibers active; daily (zero for none) is the
+// estimated work one owner gets through per day, against which the work
+// due by each date is checked. Today is now's calendar day in loc; due
+// dates are dates as written. Owners sort by name, with the unowned last.
+func Workloads(felts []*Felt, maxActive int, daily time.Duration, now time.Time, loc *time.Location) []Workload {
+	byOwner := make(map[string][]*Felt)
+	for _, f := range felts {
+		if f.Status == StatusOpen || f.Status == StatusActive {
+			byOwner[f.Owner()] = append(byOwner[f.Owner()], f)
+		}
+	}
+	today := civilDay(now, loc)
+	out := make([]Workload, 0, len(byOwner))
+	for owner, fibers := range byOwner {
+		w := Workload{Owner: owner, Due: []DueFiber{}}
+		var due []*Felt
+		for _, f := range fibers {
+			if f.Status == StatusActive {
+				w.Active++
+			} else {
+				w.Open++
+			}
+			if d, ok := f.Estimate(); ok {
+				w.Estimated += d
+			} else {
+				w.Unestimated++
+			}
+			if f.Due != nil {
+				due = append(due, f)
+				if dueDay(*f.Due).Before(today) {
+					w.Overdue++
+				}
+			}
+		}
+		w.OverActive = maxActive > 0 && w.Active > maxActive
+		sort.SliceStable(due, func(i, j int) bool {
+			if !due[i].Due.Equal(*due[j].Due) {
+				return due[i].Due.Before(*due[j].Due)
+			}
+			return due[i].ID < due[j].ID
+		})
+		var committed time.Duration
+		for i, f := range due {
+			day := dueDay(*f.Due)
+			if i < WorkloadDueLimit {
+				w.Due = append(w.Due, DueFiber{ID: f.ID, Name: f.Name, Due: day.Format("2006-01-02")})
+			}
+			if d, ok := f.Estimate(); ok {
+				committed += d
+			}
+			if daily <= 0 || w.Overcommit != nil || (i+1 < len(due) && dueDay(*due[i+1].Due).Equal(day)) {
+				continue
+			}
+			days := int(day.Sub(today)/(24*time.Hour)) + 1
+			if days < 1 {
+				days = 1 // overdue work is due today at the latest
+			}
+			if capacity := daily * time.Duration(days); committed > capacity {
+				w.Overcommit = &Overcommit{By: day.Format("2006-01-02"), Estimated: committed, Capacity: capacity}
+			}
+		}
+		out = append(out, w)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if (out[i].Owner == "") != (out[j].Owner == "") {
+			return out[j].Owner == ""
+		}
+		return out[i].Owner < out[j].Owner
+	})
+	return out
+}
+
+// dueDay is the date a due: timestamp names, as felt show prints it.
+func dueDay(due time.Time) time.Time {
+	return civilDay(due, due.Location())
+}
+
+// civilDay returns the midnight UTC of t's calendar date in loc, so whole
+// days between dates subtract exactly.
+func civilDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
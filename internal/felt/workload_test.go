@@ -0,0 +1,76 @@
+package felt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkloadsFlagOverload(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	day := func(s string) *time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &d
+	}
+	fiber := func(id, owner, status, estimate string, due *time.Time) *Felt {
+		f := &Felt{ID: id, Name: id, Status: status, Due: due}
+		if owner != "" {
+			if err := f.SetOwner(owner); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if estimate != "" {
+			if err := f.SetExtraField(EstimateKey, estimate); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return f
+	}
+	felts := []*Felt{
+		// ana: 10h due by tomorrow against two 4h days.
+		fiber("fit", "ana", StatusActive, "6h", day("2026-10-15")),
+		fiber("plot", "ana", StatusOpen, "4h", day("2026-10-14")),
+		fiber("draft", "ana", StatusActive, "", nil),
+		fiber("done", "ana", StatusClosed, "8h", day("2026-10-01")),
+		// ben: comfortably within capacity, but one fiber is late.
+		fiber("late", "ben", StatusOpen, "1h", day("2026-10-10")),
+		fiber("later", "ben", StatusOpen, "2h", day("2026-10-30")),
+		fiber("loose", "", StatusOpen, "30m", nil),
+		fiber("note", "", "", "", nil),
+	}
+
+	got := Workloads(felts, 1, 4*time.Hour, now, time.UTC)
+	if len(got) != 3 || got[0].Owner != "ana" || got[1].Owner != "ben" || got[2].Owner != "" {
+		t.Fatalf("owners = %+v", got)
+	}
+
+	ana := got[0]
+	if ana.Active != 2 || ana.Open != 1 || ana.Estimated != 10*time.Hour || ana.Unestimated != 1 {
+		t.Fatalf("ana counts = %+v", ana)
+	}
+	if len(ana.Due) != 2 || ana.Due[0].ID != "plot" || ana.Due[1].ID != "fit" {
+		t.Fatalf("ana due = %+v", ana.Due)
+	}
+	if !ana.OverActive || ana.Overdue != 0 {
+		t.Fatalf("ana flags = %+v", ana)
+	}
+	if c := ana.Overcommit; c == nil || c.By != "2026-10-15" || c.Estimated != 10*time.Hour || c.Capacity != 8*time.Hour {
+		t.Fatalf("ana overcommit = %+v", ana.Overcommit)
+	}
+
+	ben := got[1]
+	if ben.Overdue != 1 || ben.OverActive || ben.Overcommit != nil || !ben.Overloaded() {
+		t.Fatalf("ben = %+v", ben)
+	}
+	if unowned := got[2]; unowned.Open != 1 || unowned.Overloaded() {
+		t.Fatalf("unowned = %+v", unowned)
+	}
+
+	// Without limits only lateness flags anyone.
+	got = Workloads(felts, 0, 0, now, time.UTC)
+	if got[0].Overloaded() || !got[1].Overloaded() {
+		t.Fatalf("no limits: ana %+v, ben %+v", got[0], got[1])
+	}
+}
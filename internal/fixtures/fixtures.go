@@ -0,0 +1,172 @@
+// Package fixtures builds deterministic felt stores from YAML specs and
+// compares command output against golden files, for tests that need a
+// representative repo rather than one or two hand-written fibers.
+package fixtures
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cailmdaley/felt/internal/felt"
+	"github.com/oklog/ulid/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// update rewrites golden files with the output under test instead of
+// comparing: go test ./cmd -run Golden -update
+var update = flag.Bool("update", false, "rewrite golden files with current output")
+
+// Epoch is the created-at of the first fiber in a spec that sets none; each
+// later fiber without one is an hour after the previous, so spec order is
+// also recency order.
+var Epoch = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+// Spec describes a store. Each fiber's frontmatter is written as given, in
+// the given key order, so extra fields round-trip exactly as spelled.
+//
+//	config: |
+//	  wip:
+//	    limit: 2
+//	fibers:
+//	  - id: bao/fit
+//	    frontmatter:
+//	      name: Fit the BAO scale
+//	      status: active
+//	      inputs:
+//	        - id: cov
+//	          from: bao/covariance
+//	    body: Chains running.
+type Spec struct {
+	Config string  `yaml:"config"`
+	Fibers []Fiber `yaml:"fibers"`
+}
+
+// Fiber is one fiber in a Spec.
+type Fiber struct {
+	ID          string    `yaml:"id"`
+	Frontmatter yaml.Node `yaml:"frontmatter"`
+	Body        string    `yaml:"body"`
+}
+
+// Build creates a store in a fresh temp dir from a YAML spec and returns the
+// project root.
+func Build(t testing.TB, spec string) string {
+	t.Helper()
+	var s Spec
+	if err := yaml.Unmarshal([]byte(spec), &s); err != nil {
+		t.Fatalf("fixtures: parsing spec: %v", err)
+	}
+	dir := t.TempDir()
+	if err := s.Write(dir); err != nil {
+		t.Fatalf("fixtures: %v", err)
+	}
+	return dir
+}
+
+// BuildFile is Build with the spec read from path (relative to the test's
+// package directory, e.g. "testdata/fixtures/project.yaml").
+func BuildFile(t testing.TB, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("fixtures: %v", err)
+	}
+	return Build(t, string(data))
+}
+
+// Write materializes s as a store under dir. Fibers without an `id:` get a
+// ULID derived from their slug and created-at, and every file's mtime is
+// set to the fiber's recency anchor, so repeated builds are identical.
+func (s *Spec) Write(dir string) error {
+	storage := felt.NewStorage(dir)
+	if err := storage.Init(); err != nil {
+		return err
+	}
+	if s.Config != "" {
+		if err := os.WriteFile(filepath.Join(dir, felt.DirName, felt.ConfigName), []byte(s.Config), 0644); err != nil {
+			return err
+		}
+	}
+	for i, fiber := range s.Fibers {
+		if fiber.ID == "" {
+			return fmt.Errorf("fiber %d has no id", i)
+		}
+		frontmatter := []byte("{}\n")
+		if fiber.Frontmatter.Kind != 0 {
+			var err error
+			if frontmatter, err = yaml.Marshal(&fiber.Frontmatter); err != nil {
+				return fmt.Errorf("fiber %s: %w", fiber.ID, err)
+			}
+		}
+		content := "---\n" + string(frontmatter) + "---\n\n" + fiber.Body
+		f, err := felt.Parse(fiber.ID, []byte(content))
+		if err != nil {
+			return fmt.Errorf("fiber %s: %w", fiber.ID, err)
+		}
+		if f.CreatedAt.IsZero() {
+			f.CreatedAt = Epoch.Add(time.Duration(i) * time.Hour)
+		}
+		if f.UID == "" {
+			f.UID = deterministicULID(f.ID, f.CreatedAt)
+		}
+		if err := storage.Write(f); err != nil {
+			return err
+		}
+		// Pin mtimes too: modified_at is in JSON output and feeds recency.
+		anchor := f.RecencyAnchor()
+		if err := os.Chtimes(storage.Path(f.ID), anchor, anchor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deterministicULID(id string, at time.Time) string {
+	sum := sha256.Sum256([]byte(id))
+	return ulid.MustNew(ulid.Timestamp(at), bytes.NewReader(sum[:])).String()
+}
+
+// Golden compares got against testdata/golden/<name>.golden, or rewrites
+// that file when the test binary runs with -update. On mismatch it reports
+// the first differing line.
+func Golden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	want := string(data)
+	if got == want {
+		return
+	}
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			t.Fatalf("%s: output differs from golden at line %d:\n got: %q\nwant: %q\n\nfull output:\n%s\n(run with -update to accept)", path, i+1, g, w, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package fixtures
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cailmdaley/felt/internal/felt"
+)
+
+const spec = `
+config: |
+  wip:
+    limit: 1
+fibers:
+  - id: parent
+    frontmatter:
+      name: Parent
+      status: open
+  - id: parent/child
+    frontmatter:
+      name: Child
+      zeta: 1
+      alpha:
+        nested: true
+    body: See [[parent]].
+`
+
+func TestBuildIsDeterministic(t *testing.T) {
+	a, b := Build(t, spec), Build(t, spec)
+	for _, id := range []string{"parent", "parent/child"} {
+		pa, pb := felt.NewStorage(a).Path(id), felt.NewStorage(b).Path(id)
+		da, err := os.ReadFile(pa)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db, err := os.ReadFile(pb)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(da) != string(db) {
+			t.Fatalf("%s differs between builds:\n%s\n---\n%s", id, da, db)
+		}
+		sa, _ := os.Stat(pa)
+		sb, _ := os.Stat(pb)
+		if !sa.ModTime().Equal(sb.ModTime()) {
+			t.Fatalf("%s mtime differs: %v vs %v", id, sa.ModTime(), sb.ModTime())
+		}
+	}
+
+	child, err := felt.NewStorage(a).Read("parent/child")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := Epoch.Add(3600e9); !child.CreatedAt.Equal(want) {
+		t.Fatalf("child created-at = %v, want %v", child.CreatedAt, want)
+	}
+	if got := strings.Join(child.ExtraFieldOrder, ","); got != "zeta,alpha" {
+		t.Fatalf("extra field order = %s, want spec order zeta,alpha", got)
+	}
+	if child.Body != "See [[parent]]." {
+		t.Fatalf("body = %q", child.Body)
+	}
+	cfg, err := os.ReadFile(filepath.Join(a, felt.DirName, felt.ConfigName))
+	if err != nil || !strings.Contains(string(cfg), "limit: 1") {
+		t.Fatalf("config = %q, %v", cfg, err)
+	}
+}